@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -31,6 +32,11 @@ type Config interface {
 	GetEthereumContextWaitTimeout() time.Duration
 	GetEthereumGasLimit(op config.ContractOp) uint64
 	GetCentChainAnchorLifespan() time.Duration
+	GetAnchorBatchEnabled() bool
+	GetAnchorBatchSize() int
+	GetAnchorBatchInterval() time.Duration
+	GetAnchorBackend() string
+	GetContractAddress(contractName config.ContractName) common.Address
 }
 
 // ToAnchorID convert the bytes into AnchorID type
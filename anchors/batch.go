@@ -0,0 +1,367 @@
+package anchors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("anchors")
+
+const (
+	// ErrBatchProofNotFound is a sentinel error when the batch proof of an anchor is not stored.
+	ErrBatchProofNotFound = errors.Error("batch proof not found")
+
+	// ErrBatchProofInvalid is a sentinel error when the batch proof doesn't lead to the batch root.
+	ErrBatchProofInvalid = errors.Error("invalid batch proof")
+
+	batchProofPrefix = "anchor_batch_proof_"
+
+	// batchLeafTag and batchNodeTag prefix the hashes of the leaves and the inner nodes of the batch tree
+	// so that an inner node can't be passed off as a leaf.
+	batchLeafTag byte = 0x00
+	batchNodeTag byte = 0x01
+)
+
+// BatchProof proves that an anchor is part of a batch anchored on chain.
+// The leaf of the anchor is blake2b(0x00 + AnchorID + DocumentRoot + SigningRootProof), the inner nodes are
+// blake2b(0x01 + left + right) and the batch root is anchored against BatchAnchorID.
+// Proofs are stored by the node that anchored the batch and sent along with the document to the collaborators,
+// who verify them against the batch anchor before storing them.
+type BatchProof struct {
+	AnchorID     AnchorID     `json:"anchor_id"`
+	DocumentRoot DocumentRoot `json:"document_root"`
+
+	// SigningRootProof is the proof of the signing root that a direct commit would have carried.
+	// Batch commits to it since the chain doesn't check it against the pre-commit of the anchor.
+	SigningRootProof [32]byte `json:"signing_root_proof"`
+
+	BatchAnchorID AnchorID     `json:"batch_anchor_id"`
+	BatchRoot     DocumentRoot `json:"batch_root"`
+	Proof         [][32]byte   `json:"proof"`
+}
+
+// JSON marshals BatchProof to json bytes.
+func (b *BatchProof) JSON() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// Type returns the type of BatchProof.
+func (b *BatchProof) Type() reflect.Type {
+	return reflect.TypeOf(b)
+}
+
+// FromJSON loads json bytes to BatchProof.
+func (b *BatchProof) FromJSON(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+// Verify returns true if the proof leads from the anchor leaf to the given batch root.
+func (b *BatchProof) Verify(batchRoot DocumentRoot) bool {
+	if batchRoot != b.BatchRoot {
+		return false
+	}
+
+	node, err := batchLeaf(b.AnchorID, b.DocumentRoot, b.SigningRootProof)
+	if err != nil {
+		return false
+	}
+
+	for _, sibling := range b.Proof {
+		node, err = hashPair(node, sibling)
+		if err != nil {
+			return false
+		}
+	}
+
+	return node == b.BatchRoot
+}
+
+func batchProofKey(anchorID AnchorID) []byte {
+	return append([]byte(batchProofPrefix), []byte(hexutil.Encode(anchorID[:]))...)
+}
+
+// batchLeaf returns the leaf of an anchor in the batch tree.
+func batchLeaf(anchorID AnchorID, docRoot DocumentRoot, proof [32]byte) (leaf [32]byte, err error) {
+	data := append([]byte{batchLeafTag}, anchorID[:]...)
+	data = append(data, docRoot[:]...)
+	h, err := crypto.Blake2bHash(append(data, proof[:]...))
+	if err != nil {
+		return leaf, err
+	}
+
+	copy(leaf[:], h)
+	return leaf, nil
+}
+
+// hashPair hashes the nodes in sorted order so that the proof doesn't need to carry the position of the siblings.
+func hashPair(a, b [32]byte) (node [32]byte, err error) {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+
+	data := append([]byte{batchNodeTag}, a[:]...)
+	h, err := crypto.Blake2bHash(append(data, b[:]...))
+	if err != nil {
+		return node, err
+	}
+
+	copy(node[:], h)
+	return node, nil
+}
+
+// buildBatchTree builds a merkle tree from the leaves and returns the root along with the proof of each leaf.
+// A node without a sibling is promoted to the next level as is.
+func buildBatchTree(leaves [][32]byte) (root [32]byte, proofs [][][32]byte, err error) {
+	if len(leaves) < 1 {
+		return root, nil, errors.New("no leaves to build the batch tree")
+	}
+
+	proofs = make([][][32]byte, len(leaves))
+	// positions[i] is the index of the node holding leaf i in the current level
+	positions := make([]int, len(leaves))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		var next [][32]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+
+			node, err := hashPair(level[i], level[i+1])
+			if err != nil {
+				return root, nil, err
+			}
+			next = append(next, node)
+		}
+
+		for i, pos := range positions {
+			sibling := pos ^ 1
+			if sibling < len(level) {
+				proofs[i] = append(proofs[i], level[sibling])
+			}
+			positions[i] = pos / 2
+		}
+
+		level = next
+	}
+
+	return level[0], proofs, nil
+}
+
+// batchItem is a commit waiting for its batch to be anchored.
+type batchItem struct {
+	ctx      context.Context
+	anchorID AnchorID
+	docRoot  DocumentRoot
+	proof    [32]byte
+	done     chan error
+}
+
+// batcher aggregates the commits of an account and anchors the merkle root of the document roots.
+// A batch is anchored once it reaches the batch size or once the batch interval has passed since its first commit.
+type batcher struct {
+	size     int
+	interval time.Duration
+	lifespan time.Duration
+	repo     Repository
+	db       storage.Repository
+
+	mu      sync.Mutex
+	pending map[identity.DID][]batchItem
+	timers  map[identity.DID]*time.Timer
+}
+
+func newBatcher(config Config, repo Repository, db storage.Repository) *batcher {
+	return &batcher{
+		size:     config.GetAnchorBatchSize(),
+		interval: config.GetAnchorBatchInterval(),
+		lifespan: config.GetCentChainAnchorLifespan(),
+		repo:     repo,
+		db:       db,
+		pending:  make(map[identity.DID][]batchItem),
+		timers:   make(map[identity.DID]*time.Timer),
+	}
+}
+
+// add queues the commit to the account's batch.
+// The returned channel receives the result once the batch is anchored and the proof of the commit is stored.
+func (b *batcher) add(ctx context.Context, anchorIDPreImage AnchorID, docRoot DocumentRoot, proof [32]byte) (chan error, error) {
+	did, err := getDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := crypto.Blake2bHash(anchorIDPreImage[:])
+	if err != nil {
+		return nil, err
+	}
+
+	anchorID, err := ToAnchorID(h)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan error, 1)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending[did] = append(b.pending[did], batchItem{
+		ctx:      contextutil.Copy(ctx),
+		anchorID: anchorID,
+		docRoot:  docRoot,
+		proof:    proof,
+		done:     done,
+	})
+
+	if len(b.pending[did]) >= b.size {
+		go b.flush(b.take(did))
+		return done, nil
+	}
+
+	if _, ok := b.timers[did]; !ok {
+		b.timers[did] = time.AfterFunc(b.interval, func() {
+			b.mu.Lock()
+			items := b.take(did)
+			b.mu.Unlock()
+			b.flush(items)
+		})
+	}
+
+	return done, nil
+}
+
+// take removes the pending batch of the account.
+// Caller must hold the lock.
+func (b *batcher) take(did identity.DID) []batchItem {
+	if t, ok := b.timers[did]; ok {
+		t.Stop()
+		delete(b.timers, did)
+	}
+
+	items := b.pending[did]
+	delete(b.pending, did)
+	return items
+}
+
+// flush anchors the batch and notifies each commit of the result.
+func (b *batcher) flush(items []batchItem) {
+	if len(items) < 1 {
+		return
+	}
+
+	err := b.anchor(items)
+	if err != nil {
+		log.Errorf("failed to anchor batch of %d documents: %v", len(items), err)
+	}
+
+	for _, item := range items {
+		item.done <- err
+	}
+}
+
+// anchor anchors the batch and stores the proof of each commit.
+func (b *batcher) anchor(items []batchItem) error {
+	anchorIDs := make([]AnchorID, len(items))
+	docRoots := make([]DocumentRoot, len(items))
+	signingRootProofs := make([][32]byte, len(items))
+	for i, item := range items {
+		anchorIDs[i], docRoots[i], signingRootProofs[i] = item.anchorID, item.docRoot, item.proof
+	}
+
+	// batch anchor is committed in its own job since it doesn't belong to any of the document jobs.
+	ctx := contextutil.WithJob(items[0].ctx, jobs.NilJobID())
+	proofs, err := commitBatch(ctx, b.repo, anchorIDs, docRoots, signingRootProofs, time.Now().UTC().Add(b.lifespan))
+	if err != nil {
+		return err
+	}
+
+	for _, proof := range proofs {
+		if err := b.db.Create(batchProofKey(proof.AnchorID), proof); err != nil {
+			return errors.New("failed to store batch proof for anchor %s: %v", proof.AnchorID.String(), err)
+		}
+	}
+
+	return nil
+}
+
+// commitBatch builds the batch tree of the document roots along with their signing root proofs
+// and anchors the root against a new batch anchor.
+// Returns the proof of each document root in the order of the anchors.
+func commitBatch(
+	ctx context.Context,
+	repo Repository,
+	anchorIDs []AnchorID,
+	docRoots []DocumentRoot,
+	signingRootProofs [][32]byte,
+	storedUntil time.Time) ([]*BatchProof, error) {
+	var leaves [][32]byte
+	for i, anchorID := range anchorIDs {
+		leaf, err := batchLeaf(anchorID, docRoots[i], signingRootProofs[i])
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+
+	root, siblings, err := buildBatchTree(leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	preImage, h, err := crypto.GenerateHashPair(AnchorIDLength)
+	if err != nil {
+		return nil, err
+	}
+
+	batchPreImage, err := ToAnchorID(preImage)
+	if err != nil {
+		return nil, err
+	}
+
+	batchAnchorID, err := ToAnchorID(h)
+	if err != nil {
+		return nil, err
+	}
+
+	// batch anchor is never pre-committed, so there is no proof for the chain to check.
+	// Signing root proofs of the documents are part of the leaves instead.
+	done, err := repo.Commit(ctx, batchPreImage, root, [32]byte{}, storedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := <-done; err != nil {
+		return nil, err
+	}
+
+	proofs := make([]*BatchProof, len(anchorIDs))
+	for i, anchorID := range anchorIDs {
+		proofs[i] = &BatchProof{
+			AnchorID:         anchorID,
+			DocumentRoot:     docRoots[i],
+			SigningRootProof: signingRootProofs[i],
+			BatchAnchorID:    batchAnchorID,
+			BatchRoot:        root,
+			Proof:            siblings[i],
+		}
+	}
+
+	return proofs, nil
+}
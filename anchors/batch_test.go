@@ -0,0 +1,350 @@
+// +build unit
+
+package anchors
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRepository struct {
+	mock.Mock
+	Repository
+}
+
+func (m *mockRepository) Commit(ctx context.Context, anchorIDPreImage AnchorID, documentRoot DocumentRoot, proof [32]byte, storedUntil time.Time) (chan error, error) {
+	args := m.Called(ctx, anchorIDPreImage, documentRoot, proof, storedUntil)
+	c, _ := args.Get(0).(chan error)
+	return c, args.Error(1)
+}
+
+func (m *mockRepository) GetAnchorByID(id *big.Int) (*AnchorData, error) {
+	args := m.Called(id)
+	ad, _ := args.Get(0).(*AnchorData)
+	return ad, args.Error(1)
+}
+
+func newTestDB(t *testing.T) storage.Repository {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	repo.Register(new(BatchProof))
+	repo.Register(new(AnchorRecord))
+	return repo
+}
+
+func randomLeaves(n int) [][32]byte {
+	var leaves [][32]byte
+	for i := 0; i < n; i++ {
+		leaves = append(leaves, utils.RandomByte32())
+	}
+	return leaves
+}
+
+func TestBuildBatchTree(t *testing.T) {
+	_, _, err := buildBatchTree(nil)
+	assert.Error(t, err)
+
+	for n := 1; n <= 9; n++ {
+		var anchorIDs []AnchorID
+		var docRoots []DocumentRoot
+		var signingRootProofs [][32]byte
+		var leaves [][32]byte
+		for i := 0; i < n; i++ {
+			anchorIDs = append(anchorIDs, utils.RandomByte32())
+			docRoots = append(docRoots, RandomDocumentRoot())
+			signingRootProofs = append(signingRootProofs, utils.RandomByte32())
+			leaf, err := batchLeaf(anchorIDs[i], docRoots[i], signingRootProofs[i])
+			assert.NoError(t, err)
+			leaves = append(leaves, leaf)
+		}
+
+		root, proofs, err := buildBatchTree(leaves)
+		assert.NoError(t, err)
+		assert.Len(t, proofs, n)
+		for i := 0; i < n; i++ {
+			proof := BatchProof{
+				AnchorID:         anchorIDs[i],
+				DocumentRoot:     docRoots[i],
+				SigningRootProof: signingRootProofs[i],
+				BatchRoot:        root,
+				Proof:            proofs[i],
+			}
+			assert.True(t, proof.Verify(root))
+
+			// wrong batch root
+			assert.False(t, proof.Verify(RandomDocumentRoot()))
+
+			// wrong signing root proof
+			wrongProof := proof
+			wrongProof.SigningRootProof = utils.RandomByte32()
+			assert.False(t, wrongProof.Verify(root))
+
+			// wrong document root
+			proof.DocumentRoot = RandomDocumentRoot()
+			assert.False(t, proof.Verify(root))
+		}
+	}
+
+	// single leaf is the root
+	leaves := randomLeaves(1)
+	root, proofs, err := buildBatchTree(leaves)
+	assert.NoError(t, err)
+	assert.Equal(t, leaves[0], root)
+	assert.Empty(t, proofs[0])
+
+	// inner node can't be proven as a leaf
+	leaves = randomLeaves(2)
+	root, _, err = buildBatchTree(leaves)
+	assert.NoError(t, err)
+	if bytes.Compare(leaves[0][:], leaves[1][:]) > 0 {
+		leaves[0], leaves[1] = leaves[1], leaves[0]
+	}
+	proof := BatchProof{AnchorID: leaves[0], DocumentRoot: leaves[1], BatchRoot: root}
+	assert.False(t, proof.Verify(root))
+}
+
+func TestBatchProof_JSON(t *testing.T) {
+	proof := &BatchProof{
+		AnchorID:         utils.RandomByte32(),
+		DocumentRoot:     RandomDocumentRoot(),
+		SigningRootProof: utils.RandomByte32(),
+		BatchAnchorID:    utils.RandomByte32(),
+		BatchRoot:        RandomDocumentRoot(),
+		Proof:            randomLeaves(3),
+	}
+
+	data, err := proof.JSON()
+	assert.NoError(t, err)
+	got := new(BatchProof)
+	assert.NoError(t, got.FromJSON(data))
+	assert.Equal(t, proof, got)
+	assert.Equal(t, proof.Type(), got.Type())
+}
+
+func newTestBatcher(t *testing.T, size int, interval time.Duration) (*batcher, *mockRepository) {
+	c := new(testingconfig.MockConfig)
+	c.On("GetAnchorBatchSize").Return(size).Once()
+	c.On("GetAnchorBatchInterval").Return(interval).Once()
+	c.On("GetCentChainAnchorLifespan").Return(time.Hour).Once()
+	repo := new(mockRepository)
+	b := newBatcher(c, repo, newTestDB(t))
+	c.AssertExpectations(t)
+	return b, repo
+}
+
+func TestBatcher_size(t *testing.T) {
+	b, repo := newTestBatcher(t, 2, time.Hour)
+
+	// missing account
+	_, err := b.add(context.Background(), utils.RandomByte32(), RandomDocumentRoot(), utils.RandomByte32())
+	assert.Error(t, err)
+
+	// failed commit is sent to every document
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(nil, errors.New("failed to commit")).Once()
+	d1, err := b.add(ctx, utils.RandomByte32(), RandomDocumentRoot(), utils.RandomByte32())
+	assert.NoError(t, err)
+	d2, err := b.add(ctx, utils.RandomByte32(), RandomDocumentRoot(), utils.RandomByte32())
+	assert.NoError(t, err)
+	assert.Error(t, <-d1)
+	assert.Error(t, <-d2)
+
+	// success stores the proof of each document
+	preImages := []AnchorID{utils.RandomByte32(), utils.RandomByte32()}
+	docRoots := []DocumentRoot{RandomDocumentRoot(), RandomDocumentRoot()}
+	signingRootProofs := [][32]byte{utils.RandomByte32(), utils.RandomByte32()}
+	done := make(chan error, 1)
+	done <- nil
+	var batchRoot DocumentRoot
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(done, nil).Once().Run(func(args mock.Arguments) {
+		batchRoot = args.Get(2).(DocumentRoot)
+	})
+	d1, err = b.add(ctx, preImages[0], docRoots[0], signingRootProofs[0])
+	assert.NoError(t, err)
+	d2, err = b.add(ctx, preImages[1], docRoots[1], signingRootProofs[1])
+	assert.NoError(t, err)
+	assert.NoError(t, <-d1)
+	assert.NoError(t, <-d2)
+	for i, preImage := range preImages {
+		h, err := crypto.Blake2bHash(preImage[:])
+		assert.NoError(t, err)
+		anchorID, err := ToAnchorID(h)
+		assert.NoError(t, err)
+		m, err := b.db.Get(batchProofKey(anchorID))
+		assert.NoError(t, err)
+		proof := m.(*BatchProof)
+		assert.Equal(t, docRoots[i], proof.DocumentRoot)
+		assert.Equal(t, signingRootProofs[i], proof.SigningRootProof)
+		assert.True(t, proof.Verify(batchRoot))
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestBatcher_interval(t *testing.T) {
+	b, repo := newTestBatcher(t, 10, 10*time.Millisecond)
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	done := make(chan error, 1)
+	done <- nil
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(done, nil).Once()
+	d, err := b.add(ctx, utils.RandomByte32(), RandomDocumentRoot(), utils.RandomByte32())
+	assert.NoError(t, err)
+	assert.NoError(t, <-d)
+	assert.Empty(t, b.pending)
+	assert.Empty(t, b.timers)
+	repo.AssertExpectations(t)
+}
+
+func TestService_GetAnchorData_batch(t *testing.T) {
+	repo := new(mockRepository)
+	db := newTestDB(t)
+	s := &service{anchorRepository: repo, db: db}
+
+	anchorID, batchAnchorID := AnchorID(utils.RandomByte32()), AnchorID(utils.RandomByte32())
+	docRoot := RandomDocumentRoot()
+	leaves := randomLeaves(2)
+	signingRootProof := utils.RandomByte32()
+	leaf, err := batchLeaf(anchorID, docRoot, signingRootProof)
+	assert.NoError(t, err)
+	root, proofs, err := buildBatchTree(append(leaves, leaf))
+	assert.NoError(t, err)
+
+	// not anchored and no proof
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(nil, errors.New("anchor not found")).Once()
+	_, _, _, err = s.GetAnchorData(anchorID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "anchor not found")
+	_, err = s.GetBatchProof(anchorID)
+	assert.True(t, errors.IsOfType(ErrBatchProofNotFound, err))
+
+	// batch not anchored
+	proof := &BatchProof{
+		AnchorID:         anchorID,
+		DocumentRoot:     docRoot,
+		SigningRootProof: signingRootProof,
+		BatchAnchorID:    batchAnchorID,
+		BatchRoot:        root,
+		Proof:            proofs[2],
+	}
+	assert.NoError(t, db.Create(batchProofKey(anchorID), proof))
+	repo.On("GetAnchorByID", batchAnchorID.BigInt()).Return(nil, errors.New("batch not found")).Once()
+	_, _, _, err = s.GetAnchorData(anchorID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "batch not found")
+
+	// different batch root anchored
+	repo.On("GetAnchorByID", batchAnchorID.BigInt()).Return(&AnchorData{DocumentRoot: types.NewHash(utils.RandomSlice(32))}, nil).Once()
+	_, _, _, err = s.GetAnchorData(anchorID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+
+	// anchor is committed directly with another document root
+	anchoredTime := time.Now().UTC()
+	batchAnchor := &AnchorData{DocumentRoot: types.NewHash(root[:]), BlockNumber: 7, AnchoredTime: anchoredTime}
+	repo.On("GetAnchorByID", batchAnchorID.BigInt()).Return(batchAnchor, nil)
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(&AnchorData{DocumentRoot: types.NewHash(utils.RandomSlice(32))}, nil).Once()
+	_, _, _, err = s.GetAnchorData(anchorID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+	assert.Contains(t, err.Error(), "committed with a document root other than in batch")
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(&AnchorData{DocumentRoot: types.NewHash(utils.RandomSlice(32))}, nil).Once()
+	err = s.StoreBatchProof(proof)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+
+	// anchor committed directly with the same document root
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(&AnchorData{DocumentRoot: types.NewHash(docRoot[:])}, nil).Once()
+	_, _, _, err = s.GetAnchorData(anchorID)
+	assert.NoError(t, err)
+
+	// success
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(nil, errors.New("anchor not found")).Once()
+	got, gotTime, bn, err := s.GetAnchorData(anchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, got)
+	assert.Equal(t, anchoredTime, gotTime)
+	assert.Equal(t, uint32(7), bn)
+	gotProof, err := s.GetBatchProof(anchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, proof, gotProof)
+	repo.AssertExpectations(t)
+}
+
+func TestService_StoreBatchProof_secondNode(t *testing.T) {
+	b, repo := newTestBatcher(t, 1, time.Hour)
+	sender := &service{anchorRepository: repo, db: b.db, batcher: b}
+	receiver := &service{anchorRepository: repo, db: newTestDB(t)}
+
+	// sender anchors the document in a batch
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	preImage, docRoot := AnchorID(utils.RandomByte32()), RandomDocumentRoot()
+	done := make(chan error, 1)
+	done <- nil
+	var batchRoot DocumentRoot
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(done, nil).Once().Run(func(args mock.Arguments) {
+		batchRoot = args.Get(2).(DocumentRoot)
+	})
+	d, err := b.add(ctx, preImage, docRoot, utils.RandomByte32())
+	assert.NoError(t, err)
+	assert.NoError(t, <-d)
+	h, err := crypto.Blake2bHash(preImage[:])
+	assert.NoError(t, err)
+	anchorID, err := ToAnchorID(h)
+	assert.NoError(t, err)
+	proof, err := sender.GetBatchProof(anchorID)
+	assert.NoError(t, err)
+
+	// receiver can't verify the anchor without the proof
+	repo.On("GetAnchorByID", anchorID.BigInt()).Return(nil, errors.New("anchor not found"))
+	_, _, _, err = receiver.GetAnchorData(anchorID)
+	assert.Error(t, err)
+
+	// nil proof
+	err = receiver.StoreBatchProof(nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+
+	// batch is not anchored
+	repo.On("GetAnchorByID", proof.BatchAnchorID.BigInt()).Return(nil, errors.New("batch not found")).Once()
+	err = receiver.StoreBatchProof(proof)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+
+	// proof of a different document root
+	batchAnchor := &AnchorData{DocumentRoot: types.NewHash(batchRoot[:]), BlockNumber: 3}
+	repo.On("GetAnchorByID", proof.BatchAnchorID.BigInt()).Return(batchAnchor, nil)
+	tampered := *proof
+	tampered.DocumentRoot = RandomDocumentRoot()
+	err = receiver.StoreBatchProof(&tampered)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBatchProofInvalid, err))
+	_, err = receiver.GetBatchProof(anchorID)
+	assert.True(t, errors.IsOfType(ErrBatchProofNotFound, err))
+
+	// verified proof is stored and the anchor is verified against the batch
+	assert.NoError(t, receiver.StoreBatchProof(proof))
+	got, _, bn, err := receiver.GetAnchorData(anchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, got)
+	assert.Equal(t, uint32(3), bn)
+
+	// storing the proof again is a no-op
+	assert.NoError(t, receiver.StoreBatchProof(proof))
+	repo.AssertExpectations(t)
+}
@@ -3,10 +3,16 @@ package anchors
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
@@ -15,6 +21,15 @@ const (
 
 	// ErrAnchorRepoNotInitialised is a sentinel error when repository is not initialised
 	ErrAnchorRepoNotInitialised = errors.Error("anchor repository not initialised")
+
+	// BackendCentChain anchors on the Centrifuge Chain. This is the default backend.
+	BackendCentChain = "centchain"
+
+	// BackendEthereum anchors on the anchor repository contract on Ethereum.
+	BackendEthereum = "ethereum"
+
+	// BackendLocal anchors on the in-process local ledger. Intended for development and testing.
+	BackendLocal = "local"
 )
 
 // Bootstrapper implements bootstrapper.Bootstrapper for package requirement initialisations.
@@ -28,11 +43,6 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return err
 	}
 
-	if _, ok := ctx[centchain.BootstrappedCentChainClient]; !ok {
-		return errors.New("centchain client hasn't been initialized")
-	}
-	client := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
-
 	jobsMan, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
 	if !ok {
 		return errors.New("jobs repository not initialised")
@@ -43,8 +53,68 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("queue hasn't been initialized")
 	}
 
-	repo := NewRepository(client, jobsMan)
-	srv := newService(cfg, repo, queueSrv, jobsMan)
+	db, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
+	}
+
+	db.Register(new(BatchProof))
+	db.Register(new(AnchorRecord))
+	repo, err := newRepository(ctx, cfg, jobsMan, db)
+	if err != nil {
+		return err
+	}
+
+	srv := newService(cfg, repo, queueSrv, jobsMan, db)
 	ctx[BootstrappedAnchorService] = srv
 	return nil
 }
+
+// LocalRepositoryAddress is the anchor repository address recorded in the documents anchored on the local ledger.
+var LocalRepositoryAddress = common.BytesToAddress([]byte(BackendLocal))
+
+// RepositoryAddress returns the anchor repository address recorded in the documents anchored on the configured backend.
+// Documents anchored on the centrifuge chain record the configured anchor repository address like they always did.
+func RepositoryAddress(cfg Config) common.Address {
+	if cfg.GetAnchorBackend() == BackendLocal {
+		return LocalRepositoryAddress
+	}
+
+	return cfg.GetContractAddress(config.AnchorRepo)
+}
+
+// newRepository returns the anchor repository of the backend configured for the network.
+func newRepository(ctx map[string]interface{}, cfg Config, jobsMan jobs.Manager, db storage.Repository) (Repository, error) {
+	backend := cfg.GetAnchorBackend()
+	switch backend {
+	case "", BackendCentChain:
+		client, ok := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
+		if !ok {
+			return nil, errors.New("centchain client hasn't been initialized")
+		}
+
+		return NewRepository(client, jobsMan), nil
+	case BackendEthereum:
+		client, ok := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
+		if !ok {
+			return nil, errors.New("ethereum client hasn't been initialized")
+		}
+
+		idSrv, ok := ctx[identity.BootstrappedDIDService].(identity.Service)
+		if !ok {
+			return nil, errors.New("%s not found in the bootstrapper", identity.BootstrappedDIDService)
+		}
+
+		address := cfg.GetContractAddress(config.AnchorRepo)
+		if utils.IsEmptyAddress(address) {
+			return nil, errors.New("anchor repository contract address is not set")
+		}
+
+		return NewEthereumRepository(address, client, idSrv), nil
+	case BackendLocal:
+		log.Warning("anchoring on the local ledger. Anchors are not visible to other nodes.")
+		return NewLocalRepository(db, jobsMan), nil
+	default:
+		return nil, errors.New("unknown anchor backend: %s", backend)
+	}
+}
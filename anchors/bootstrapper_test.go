@@ -0,0 +1,86 @@
+// +build unit
+
+package anchors
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRepository(t *testing.T) {
+	ctx := make(map[string]interface{})
+	jobMan := new(testingjobs.MockJobManager)
+	db := newTestDB(t)
+	newCfg := func(backend string) *testingconfig.MockConfig {
+		c := new(testingconfig.MockConfig)
+		c.On("GetAnchorBackend").Return(backend).Once()
+		return c
+	}
+
+	// unknown backend
+	_, err := newRepository(ctx, newCfg("unknown"), jobMan, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown anchor backend")
+
+	// centchain is the default
+	_, err = newRepository(ctx, newCfg(""), jobMan, db)
+	assert.Error(t, err)
+	ctx[centchain.BootstrappedCentChainClient] = new(centchain.MockAPI)
+	repo, err := newRepository(ctx, newCfg(""), jobMan, db)
+	assert.NoError(t, err)
+	assert.IsType(t, repository{}, repo)
+	repo, err = newRepository(ctx, newCfg(BackendCentChain), jobMan, db)
+	assert.NoError(t, err)
+	assert.IsType(t, repository{}, repo)
+
+	// ethereum
+	_, err = newRepository(ctx, newCfg(BackendEthereum), jobMan, db)
+	assert.Error(t, err)
+	ctx[ethereum.BootstrappedEthereumClient] = new(ethereum.MockEthClient)
+	_, err = newRepository(ctx, newCfg(BackendEthereum), jobMan, db)
+	assert.Error(t, err)
+	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
+	c := newCfg(BackendEthereum)
+	c.On("GetContractAddress", config.AnchorRepo).Return(common.Address{}).Once()
+	_, err = newRepository(ctx, c, jobMan, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "contract address is not set")
+	c = newCfg(BackendEthereum)
+	c.On("GetContractAddress", config.AnchorRepo).Return(common.BytesToAddress(utils.RandomSlice(20))).Once()
+	repo, err = newRepository(ctx, c, jobMan, db)
+	assert.NoError(t, err)
+	assert.IsType(t, ethRepository{}, repo)
+
+	// local
+	repo, err = newRepository(ctx, newCfg(BackendLocal), jobMan, db)
+	assert.NoError(t, err)
+	assert.IsType(t, &localRepository{}, repo)
+}
+
+func TestRepositoryAddress(t *testing.T) {
+	addr := common.BytesToAddress(utils.RandomSlice(20))
+	for _, backend := range []string{"", BackendCentChain, BackendEthereum} {
+		c := new(testingconfig.MockConfig)
+		c.On("GetAnchorBackend").Return(backend).Once()
+		c.On("GetContractAddress", config.AnchorRepo).Return(addr).Once()
+		assert.Equal(t, addr, RepositoryAddress(c))
+		c.AssertExpectations(t)
+	}
+
+	// documents anchored locally are told apart from the ones anchored on chain
+	c := new(testingconfig.MockConfig)
+	c.On("GetAnchorBackend").Return(BackendLocal).Once()
+	assert.Equal(t, LocalRepositoryAddress, RepositoryAddress(c))
+	assert.NotEqual(t, common.Address{}, LocalRepositoryAddress)
+	c.AssertExpectations(t)
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/centchain"
 	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
 )
 
@@ -140,6 +142,9 @@ type AnchorData struct {
 	AnchorID     types.Hash `json:"id"`
 	DocumentRoot types.Hash `json:"doc_root"`
 	BlockNumber  uint32     `json:"anchored_block"`
+
+	// AnchoredTime is the timestamp of the block the anchor was committed in.
+	AnchoredTime time.Time `json:"-"`
 }
 
 func (r repository) GetAnchorByID(id *big.Int) (*AnchorData, error) {
@@ -148,5 +153,16 @@ func (r repository) GetAnchorByID(id *big.Int) (*AnchorData, error) {
 	if err != nil {
 		return &ad, err
 	}
+
+	// anchor doesn't exist
+	if utils.IsEmptyByte32(ad.DocumentRoot) {
+		return &ad, nil
+	}
+
+	ad.AnchoredTime, err = r.api.GetBlockTimestamp(ad.BlockNumber)
+	if err != nil {
+		return &ad, errors.New("failed to get timestamp of block %d: %v", ad.BlockNumber, err)
+	}
+
 	return &ad, nil
 }
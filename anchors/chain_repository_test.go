@@ -101,3 +101,40 @@ func TestRepository_Commit(t *testing.T) {
 	assert.NoError(t, err)
 	api.AssertExpectations(t)
 }
+
+func TestRepository_GetAnchorByID(t *testing.T) {
+	api := new(centchain.MockAPI)
+	repo := NewRepository(api, nil)
+	anchorID := AnchorID(utils.RandomByte32())
+	args := []interface{}{types.NewHash(anchorID.BigInt().Bytes())}
+
+	// failed call
+	api.On("Call", GetByID, args).Return(nil, errors.New("failed to call")).Once()
+	_, err := repo.GetAnchorByID(anchorID.BigInt())
+	assert.Error(t, err)
+
+	// missing anchor
+	api.On("Call", GetByID, args).Return(AnchorData{}, nil).Once()
+	ad, err := repo.GetAnchorByID(anchorID.BigInt())
+	assert.NoError(t, err)
+	assert.True(t, ad.AnchoredTime.IsZero())
+
+	// failed block timestamp
+	data := AnchorData{AnchorID: types.NewHash(anchorID[:]), DocumentRoot: types.NewHash(utils.RandomSlice(32)), BlockNumber: 10}
+	api.On("Call", GetByID, args).Return(data, nil).Once()
+	api.On("GetBlockTimestamp", uint32(10)).Return(nil, errors.New("block not found")).Once()
+	_, err = repo.GetAnchorByID(anchorID.BigInt())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block not found")
+
+	// success
+	now := time.Now().UTC()
+	api.On("Call", GetByID, args).Return(data, nil).Once()
+	api.On("GetBlockTimestamp", uint32(10)).Return(now, nil).Once()
+	ad, err = repo.GetAnchorByID(anchorID.BigInt())
+	assert.NoError(t, err)
+	assert.Equal(t, data.DocumentRoot, ad.DocumentRoot)
+	assert.Equal(t, uint32(10), ad.BlockNumber)
+	assert.Equal(t, now, ad.AnchoredTime)
+	api.AssertExpectations(t)
+}
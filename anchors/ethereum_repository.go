@@ -0,0 +1,105 @@
+package anchors
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AnchorContractABI is the abi of the anchor repository contract on Ethereum.
+const AnchorContractABI = `[{"constant":true,"inputs":[{"name":"anchorId","type":"uint256"}],"name":"getAnchorById","outputs":[{"name":"anchorId","type":"uint256"},{"name":"documentRoot","type":"bytes32"},{"name":"blockNumber","type":"uint32"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"anchorIdPreImage","type":"uint256"},{"name":"documentRoot","type":"bytes32"},{"name":"proof","type":"bytes32"}],"name":"commit","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":false,"inputs":[{"name":"anchorId","type":"uint256"},{"name":"signingRoot","type":"bytes32"}],"name":"preCommit","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
+// anchorABI is the abi for caller functions on the anchor repository contract
+var anchorABI abi.ABI
+
+func init() {
+	var err error
+	anchorABI, err = abi.JSON(strings.NewReader(AnchorContractABI))
+	if err != nil {
+		log.Fatalf("failed to decode anchor repository ABI: %v", err)
+	}
+}
+
+// ethRepository anchors through the anchor repository contract on Ethereum.
+// Transactions are executed through the identity contract of the account.
+type ethRepository struct {
+	address      common.Address
+	client       ethereum.Client
+	idSrv        identity.Service
+	bindContract func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract
+}
+
+// NewEthereumRepository returns a new Anchor repository backed by the anchor repository contract at the address.
+func NewEthereumRepository(address common.Address, client ethereum.Client, idSrv identity.Service) Repository {
+	return ethRepository{
+		address:      address,
+		client:       client,
+		idSrv:        idSrv,
+		bindContract: ethereum.BindContract,
+	}
+}
+
+func (r ethRepository) PreCommit(ctx context.Context, anchorID AnchorID, signingRoot DocumentRoot) (confirmations chan error, err error) {
+	_, done, err := r.idSrv.Execute(ctx, r.address, AnchorContractABI, "preCommit", anchorID.BigInt(), [32]byte(signingRoot))
+	return done, err
+}
+
+// Commit commits the anchor on the contract. The contract doesn't expire anchors so storedUntil is ignored.
+func (r ethRepository) Commit(
+	ctx context.Context,
+	anchorIDPreImage AnchorID,
+	documentRoot DocumentRoot,
+	proof [32]byte, storedUntil time.Time) (confirmations chan error, err error) {
+	_, done, err := r.idSrv.Execute(
+		ctx, r.address, AnchorContractABI, "commit", anchorIDPreImage.BigInt(), [32]byte(documentRoot), proof)
+	return done, err
+}
+
+func (r ethRepository) GetAnchorByID(id *big.Int) (*AnchorData, error) {
+	var out struct {
+		AnchorId     *big.Int
+		DocumentRoot [32]byte
+		BlockNumber  uint32
+	}
+
+	c := r.bindContract(r.address, anchorABI, r.client)
+	opts, cancel := r.client.GetGethCallOpts(false)
+	defer cancel()
+	if err := c.Call(opts, &out, "getAnchorById", id); err != nil {
+		return nil, errors.New("failed to get anchor %s: %v", id.String(), err)
+	}
+
+	var anchorID types.Hash
+	if out.AnchorId != nil {
+		copy(anchorID[:], common.LeftPadBytes(out.AnchorId.Bytes(), 32))
+	}
+
+	ad := &AnchorData{
+		AnchorID:     anchorID,
+		DocumentRoot: types.NewHash(out.DocumentRoot[:]),
+		BlockNumber:  out.BlockNumber,
+	}
+
+	// anchor doesn't exist
+	if utils.IsEmptyByte32(out.DocumentRoot) {
+		return ad, nil
+	}
+
+	blk, err := r.client.GetBlockByNumber(context.Background(), big.NewInt(int64(out.BlockNumber)))
+	if err != nil {
+		return nil, errors.New("failed to get block %d: %v", out.BlockNumber, err)
+	}
+
+	ad.AnchoredTime = time.Unix(int64(blk.Time()), 0).UTC()
+	return ad, nil
+}
@@ -0,0 +1,106 @@
+// +build unit
+
+package anchors
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/utils"
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockContractCaller struct {
+	mock.Mock
+}
+
+func (m *mockContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (m *mockContractCaller) CallContract(ctx context.Context, call geth.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args := m.Called(call.To, call.Data)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func TestEthRepository_Commit(t *testing.T) {
+	address := common.BytesToAddress(utils.RandomSlice(20))
+	idSrv := new(testingcommons.MockIdentityService)
+	repo := NewEthereumRepository(address, nil, idSrv)
+	anchorID, signingRoot := AnchorID(utils.RandomByte32()), RandomDocumentRoot()
+
+	// pre commit
+	done := make(chan error)
+	idSrv.On("Execute", context.Background(), address, AnchorContractABI, "preCommit",
+		[]interface{}{anchorID.BigInt(), [32]byte(signingRoot)}).Return(jobs.NewJobID(), done, nil).Once()
+	c, err := repo.PreCommit(context.Background(), anchorID, signingRoot)
+	assert.NoError(t, err)
+	assert.Equal(t, done, c)
+
+	// failed commit
+	proof := utils.RandomByte32()
+	idSrv.On("Execute", context.Background(), address, AnchorContractABI, "commit",
+		[]interface{}{anchorID.BigInt(), [32]byte(signingRoot), proof}).Return(jobs.NilJobID(), make(chan error), errors.New("failed to execute")).Once()
+	_, err = repo.Commit(context.Background(), anchorID, signingRoot, proof, time.Now())
+	assert.Error(t, err)
+
+	// commit
+	idSrv.On("Execute", context.Background(), address, AnchorContractABI, "commit",
+		[]interface{}{anchorID.BigInt(), [32]byte(signingRoot), proof}).Return(jobs.NewJobID(), done, nil).Once()
+	c, err = repo.Commit(context.Background(), anchorID, signingRoot, proof, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, done, c)
+	idSrv.AssertExpectations(t)
+}
+
+func TestEthRepository_GetAnchorByID(t *testing.T) {
+	address := common.BytesToAddress(utils.RandomSlice(20))
+	client := new(ethereum.MockEthClient)
+	client.On("GetGethCallOpts").Return(&bind.CallOpts{})
+	caller := new(mockContractCaller)
+	repo := ethRepository{
+		address: address,
+		client:  client,
+		bindContract: func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract {
+			return bind.NewBoundContract(address, abi, caller, nil, nil)
+		},
+	}
+
+	anchorID, docRoot := AnchorID(utils.RandomByte32()), RandomDocumentRoot()
+	input, err := anchorABI.Pack("getAnchorById", anchorID.BigInt())
+	assert.NoError(t, err)
+
+	// failed call
+	caller.On("CallContract", &address, input).Return(nil, errors.New("failed to call")).Once()
+	_, err = repo.GetAnchorByID(anchorID.BigInt())
+	assert.Error(t, err)
+
+	// success
+	output, err := anchorABI.Methods["getAnchorById"].Outputs.Pack(anchorID.BigInt(), [32]byte(docRoot), uint32(10))
+	assert.NoError(t, err)
+	anchoredTime := time.Unix(time.Now().Unix(), 0).UTC()
+	client.On("GetBlockByNumber", mock.Anything, big.NewInt(10)).Return(
+		types.NewBlockWithHeader(&types.Header{Time: uint64(anchoredTime.Unix())}), nil).Once()
+	caller.On("CallContract", &address, input).Return(output, nil).Once()
+	ad, err := repo.GetAnchorByID(anchorID.BigInt())
+	assert.NoError(t, err)
+	assert.Equal(t, anchorID[:], ad.AnchorID[:])
+	assert.Equal(t, docRoot[:], ad.DocumentRoot[:])
+	assert.Equal(t, uint32(10), ad.BlockNumber)
+	assert.Equal(t, anchoredTime, ad.AnchoredTime)
+	caller.AssertExpectations(t)
+	client.AssertExpectations(t)
+}
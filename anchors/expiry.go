@@ -0,0 +1,217 @@
+package anchors
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrAnchorRecordNotFound is a sentinel error when the anchor is not in the registry.
+	ErrAnchorRecordNotFound = errors.Error("anchor record not found")
+
+	anchorRecordPrefix = "anchor_record_"
+)
+
+// AnchorRecord tracks the expiry of an anchor committed by the node.
+type AnchorRecord struct {
+	AccountID    identity.DID `json:"account_id"`
+	AnchorID     AnchorID     `json:"anchor_id"`
+	DocumentRoot DocumentRoot `json:"document_root"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+
+	// SigningRootProof is the proof of the signing root committed along with the document root.
+	SigningRootProof [32]byte `json:"signing_root_proof"`
+
+	// Renewals is the number of times the document root was re-committed before the expiry.
+	Renewals int `json:"renewals"`
+
+	// Warned is true once the account is warned about the expiry. Reset on renewal.
+	Warned bool `json:"warned"`
+}
+
+// JSON marshals AnchorRecord to json bytes.
+func (r *AnchorRecord) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Type returns the type of AnchorRecord.
+func (r *AnchorRecord) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// FromJSON loads json bytes to AnchorRecord.
+func (r *AnchorRecord) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func anchorRecordKey(anchorID AnchorID) []byte {
+	return append([]byte(anchorRecordPrefix), []byte(hexutil.Encode(anchorID[:]))...)
+}
+
+// recordCommit adds the anchor to the registry once the commit is confirmed.
+// Returned channel receives the result of the commit.
+func (s *service) recordCommit(
+	ctx context.Context,
+	anchorIDPreImage AnchorID,
+	docRoot DocumentRoot,
+	proof [32]byte,
+	expiresAt time.Time,
+	done chan error) (chan error, error) {
+	did, err := getDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := crypto.Blake2bHash(anchorIDPreImage[:])
+	if err != nil {
+		return nil, err
+	}
+
+	anchorID, err := ToAnchorID(h)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(chan error, 1)
+	go func() {
+		err := <-done
+		if err == nil {
+			rerr := s.db.Create(anchorRecordKey(anchorID), &AnchorRecord{
+				AccountID:        did,
+				AnchorID:         anchorID,
+				DocumentRoot:     docRoot,
+				ExpiresAt:        expiresAt,
+				SigningRootProof: proof,
+			})
+			if rerr != nil {
+				log.Errorf("failed to record anchor %s: %v", anchorID.String(), rerr)
+			}
+		}
+
+		recorded <- err
+	}()
+
+	return recorded, nil
+}
+
+func (s *service) getAnchorRecord(anchorID AnchorID) (*AnchorRecord, error) {
+	if s.db == nil {
+		return nil, ErrAnchorRecordNotFound
+	}
+
+	m, err := s.db.Get(anchorRecordKey(anchorID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrAnchorRecordNotFound, err)
+	}
+
+	return m.(*AnchorRecord), nil
+}
+
+// GetExpiringAnchors returns the anchors committed by the node that expire before the given time, soonest first.
+func (s *service) GetExpiringAnchors(before time.Time) ([]*AnchorRecord, error) {
+	if s.db == nil {
+		return nil, nil
+	}
+
+	iter := s.db.Iterate(storage.IterateOptions{Prefix: []byte(anchorRecordPrefix)})
+	defer iter.Release()
+	var records []*AnchorRecord
+	for iter.Next() {
+		r, ok := iter.Model().(*AnchorRecord)
+		if ok && r.ExpiresAt.Before(before) {
+			records = append(records, r)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ExpiresAt.Before(records[j].ExpiresAt)
+	})
+
+	return records, nil
+}
+
+// MarkAnchorWarned records that the account was warned about the expiry of the anchor.
+func (s *service) MarkAnchorWarned(anchorID AnchorID) error {
+	r, err := s.getAnchorRecord(anchorID)
+	if err != nil {
+		return err
+	}
+
+	r.Warned = true
+	return s.db.Update(anchorRecordKey(anchorID), r)
+}
+
+// RenewAnchors re-commits the document roots of the anchors in a single batch anchor and extends their expiry.
+// Batch proofs of the anchors are replaced so that the document roots remain verifiable once the anchors expire.
+// Collaborators verify the versions with the new proofs only once the versions are sent to them again.
+// Anchors must belong to the account in context.
+func (s *service) RenewAnchors(ctx context.Context, anchorIDs []AnchorID) error {
+	if len(anchorIDs) < 1 {
+		return nil
+	}
+
+	did, err := getDID(ctx)
+	if err != nil {
+		return err
+	}
+
+	records := make([]*AnchorRecord, len(anchorIDs))
+	docRoots := make([]DocumentRoot, len(anchorIDs))
+	signingRootProofs := make([][32]byte, len(anchorIDs))
+	for i, anchorID := range anchorIDs {
+		r, err := s.getAnchorRecord(anchorID)
+		if err != nil {
+			return err
+		}
+
+		if !r.AccountID.Equal(did) {
+			return errors.New("anchor %s doesn't belong to account %s", anchorID.String(), did.String())
+		}
+
+		records[i], docRoots[i], signingRootProofs[i] = r, r.DocumentRoot, r.SigningRootProof
+	}
+
+	// renewal is committed in its own job since it doesn't belong to any of the document jobs.
+	expiresAt := time.Now().UTC().Add(s.config.GetCentChainAnchorLifespan())
+	proofs, err := commitBatch(contextutil.WithJob(ctx, jobs.NilJobID()), s.anchorRepository, anchorIDs, docRoots, signingRootProofs, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	for i, proof := range proofs {
+		key := batchProofKey(proof.AnchorID)
+		if s.db.Exists(key) {
+			err = s.db.Update(key, proof)
+		} else {
+			err = s.db.Create(key, proof)
+		}
+		if err != nil {
+			return errors.New("failed to store batch proof for anchor %s: %v", proof.AnchorID.String(), err)
+		}
+
+		r := records[i]
+		r.ExpiresAt = expiresAt
+		r.Renewals++
+		r.Warned = false
+		err = s.db.Update(anchorRecordKey(r.AnchorID), r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// +build unit
+
+package anchors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (*service, *mockRepository) {
+	c := new(testingconfig.MockConfig)
+	c.On("GetAnchorBatchEnabled").Return(false).Once()
+	c.On("GetCentChainAnchorLifespan").Return(time.Hour)
+	repo := new(mockRepository)
+	return newService(c, repo, nil, nil, newTestDB(t)).(*service), repo
+}
+
+func TestAnchorRecord_JSON(t *testing.T) {
+	r := &AnchorRecord{
+		AccountID:    testingidentity.GenerateRandomDID(),
+		AnchorID:     utils.RandomByte32(),
+		DocumentRoot: RandomDocumentRoot(),
+		ExpiresAt:    time.Now().UTC(),
+		Renewals:     1,
+		Warned:       true,
+	}
+
+	data, err := r.JSON()
+	assert.NoError(t, err)
+	got := new(AnchorRecord)
+	assert.NoError(t, got.FromJSON(data))
+	assert.Equal(t, r.AnchorID, got.AnchorID)
+	assert.True(t, r.ExpiresAt.Equal(got.ExpiresAt))
+	assert.Equal(t, r.Type(), got.Type())
+}
+
+func TestService_CommitAnchor_record(t *testing.T) {
+	s, repo := newTestService(t)
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	preImage, docRoot := AnchorID(utils.RandomByte32()), RandomDocumentRoot()
+	h, err := crypto.Blake2bHash(preImage[:])
+	assert.NoError(t, err)
+	anchorID, err := ToAnchorID(h)
+	assert.NoError(t, err)
+
+	// failed commit is not recorded
+	failed := make(chan error, 1)
+	failed <- errors.New("failed to commit")
+	repo.On("Commit", ctx, preImage, docRoot, [32]byte{}, mock.Anything).Return(failed, nil).Once()
+	done, err := s.CommitAnchor(ctx, preImage, docRoot, [32]byte{})
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+	_, err = s.getAnchorRecord(anchorID)
+	assert.True(t, errors.IsOfType(ErrAnchorRecordNotFound, err))
+
+	// success
+	var storedUntil time.Time
+	confirmed := make(chan error, 1)
+	confirmed <- nil
+	repo.On("Commit", ctx, preImage, docRoot, [32]byte{}, mock.Anything).Return(confirmed, nil).Once().
+		Run(func(args mock.Arguments) {
+			storedUntil = args.Get(4).(time.Time)
+		})
+	done, err = s.CommitAnchor(ctx, preImage, docRoot, [32]byte{})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	r, err := s.getAnchorRecord(anchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, did, r.AccountID)
+	assert.Equal(t, docRoot, r.DocumentRoot)
+	assert.True(t, storedUntil.Equal(r.ExpiresAt))
+	assert.False(t, r.Warned)
+	repo.AssertExpectations(t)
+}
+
+func TestService_GetExpiringAnchors(t *testing.T) {
+	s, _ := newTestService(t)
+	did := testingidentity.GenerateRandomDID()
+	now := time.Now().UTC()
+	var ids []AnchorID
+	for _, d := range []time.Duration{3 * time.Hour, -time.Hour, time.Hour} {
+		r := &AnchorRecord{AccountID: did, AnchorID: utils.RandomByte32(), ExpiresAt: now.Add(d)}
+		assert.NoError(t, s.db.Create(anchorRecordKey(r.AnchorID), r))
+		ids = append(ids, r.AnchorID)
+	}
+
+	rs, err := s.GetExpiringAnchors(now.Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, rs, 2)
+	assert.Equal(t, ids[1], rs[0].AnchorID)
+	assert.Equal(t, ids[2], rs[1].AnchorID)
+
+	// warned
+	assert.NoError(t, s.MarkAnchorWarned(ids[1]))
+	r, err := s.getAnchorRecord(ids[1])
+	assert.NoError(t, err)
+	assert.True(t, r.Warned)
+	assert.Error(t, s.MarkAnchorWarned(utils.RandomByte32()))
+}
+
+func TestService_RenewAnchors(t *testing.T) {
+	s, repo := newTestService(t)
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	expiresAt := time.Now().UTC().Add(time.Minute)
+	var records []*AnchorRecord
+	for i := 0; i < 3; i++ {
+		r := &AnchorRecord{AccountID: did, AnchorID: utils.RandomByte32(), DocumentRoot: RandomDocumentRoot(), ExpiresAt: expiresAt, Warned: true}
+		assert.NoError(t, s.db.Create(anchorRecordKey(r.AnchorID), r))
+		records = append(records, r)
+	}
+
+	// existing proof of a batched anchor is replaced
+	assert.NoError(t, s.db.Create(batchProofKey(records[0].AnchorID), &BatchProof{AnchorID: records[0].AnchorID}))
+
+	// nothing to renew
+	assert.NoError(t, s.RenewAnchors(ctx, nil))
+
+	// missing record
+	err = s.RenewAnchors(ctx, []AnchorID{records[0].AnchorID, utils.RandomByte32()})
+	assert.True(t, errors.IsOfType(ErrAnchorRecordNotFound, err))
+
+	// different account
+	other := &AnchorRecord{AccountID: testingidentity.GenerateRandomDID(), AnchorID: utils.RandomByte32()}
+	assert.NoError(t, s.db.Create(anchorRecordKey(other.AnchorID), other))
+	err = s.RenewAnchors(ctx, []AnchorID{other.AnchorID})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't belong to account")
+
+	// failed commit
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(nil, errors.New("failed to commit")).Once()
+	anchorIDs := []AnchorID{records[0].AnchorID, records[1].AnchorID}
+	assert.Error(t, s.RenewAnchors(ctx, anchorIDs))
+
+	// success
+	done := make(chan error, 1)
+	done <- nil
+	var batchRoot DocumentRoot
+	repo.On("Commit", mock.Anything, mock.Anything, mock.Anything, [32]byte{}, mock.Anything).
+		Return(done, nil).Once().Run(func(args mock.Arguments) {
+		batchRoot = args.Get(2).(DocumentRoot)
+	})
+	assert.NoError(t, s.RenewAnchors(ctx, anchorIDs))
+	for i, anchorID := range anchorIDs {
+		r, err := s.getAnchorRecord(anchorID)
+		assert.NoError(t, err)
+		assert.True(t, r.ExpiresAt.After(expiresAt))
+		assert.Equal(t, 1, r.Renewals)
+		assert.False(t, r.Warned)
+		proof, err := s.GetBatchProof(anchorID)
+		assert.NoError(t, err)
+		assert.Equal(t, records[i].DocumentRoot, proof.DocumentRoot)
+		assert.True(t, proof.Verify(batchRoot))
+	}
+
+	// not renewed
+	r, err := s.getAnchorRecord(records[2].AnchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, r.Renewals)
+
+	// expired anchor is verified against the renewal batch
+	proof, err := s.GetBatchProof(records[1].AnchorID)
+	assert.NoError(t, err)
+	repo.On("GetAnchorByID", records[1].AnchorID.BigInt()).Return(&AnchorData{}, nil).Once()
+	repo.On("GetAnchorByID", proof.BatchAnchorID.BigInt()).Return(&AnchorData{DocumentRoot: types.NewHash(batchRoot[:])}, nil).Once()
+	docRoot, _, _, err := s.GetAnchorData(records[1].AnchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, records[1].DocumentRoot, docRoot)
+	repo.AssertExpectations(t)
+}
+
+func TestService_RenewAnchors_missingAccount(t *testing.T) {
+	s, _ := newTestService(t)
+	assert.Error(t, s.RenewAnchors(context.Background(), []AnchorID{utils.RandomByte32()}))
+}
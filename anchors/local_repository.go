@@ -0,0 +1,218 @@
+package anchors
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	localAnchorPrefix    = "local_anchor_"
+	localPreCommitPrefix = "local_pre_commit_"
+	localBlockKey        = "local_ledger_block"
+)
+
+// LocalAnchor is an anchor stored on the local ledger.
+type LocalAnchor struct {
+	AnchorID     AnchorID     `json:"anchor_id"`
+	DocumentRoot DocumentRoot `json:"document_root"`
+	BlockNumber  uint32       `json:"block_number"`
+	AnchoredTime time.Time    `json:"anchored_time"`
+	StoredUntil  time.Time    `json:"stored_until"`
+}
+
+// JSON marshals LocalAnchor to json bytes.
+func (l *LocalAnchor) JSON() ([]byte, error) {
+	return json.Marshal(l)
+}
+
+// Type returns the type of LocalAnchor.
+func (l *LocalAnchor) Type() reflect.Type {
+	return reflect.TypeOf(l)
+}
+
+// FromJSON loads json bytes to LocalAnchor.
+func (l *LocalAnchor) FromJSON(data []byte) error {
+	return json.Unmarshal(data, l)
+}
+
+// LocalBlock is the last block of the local ledger.
+type LocalBlock struct {
+	Number uint32 `json:"number"`
+}
+
+// JSON marshals LocalBlock to json bytes.
+func (l *LocalBlock) JSON() ([]byte, error) {
+	return json.Marshal(l)
+}
+
+// Type returns the type of LocalBlock.
+func (l *LocalBlock) Type() reflect.Type {
+	return reflect.TypeOf(l)
+}
+
+// FromJSON loads json bytes to LocalBlock.
+func (l *LocalBlock) FromJSON(data []byte) error {
+	return json.Unmarshal(data, l)
+}
+
+// localRepository is an in-process ledger that stores the anchors in the node's db.
+// Anchors are visible only to the node that committed them, so it is intended for development and testing.
+type localRepository struct {
+	db      storage.Repository
+	jobsMan jobs.Manager
+
+	// mu ensures anchors are committed one at a time.
+	mu sync.Mutex
+}
+
+// NewLocalRepository returns a new Anchor repository backed by the local ledger.
+func NewLocalRepository(db storage.Repository, jobsMan jobs.Manager) Repository {
+	db.Register(new(LocalAnchor))
+	db.Register(new(LocalBlock))
+	return &localRepository{db: db, jobsMan: jobsMan}
+}
+
+func localAnchorKey(prefix string, anchorID AnchorID) []byte {
+	return append([]byte(prefix), []byte(hexutil.Encode(anchorID[:]))...)
+}
+
+func (r *localRepository) PreCommit(ctx context.Context, anchorID AnchorID, signingRoot DocumentRoot) (confirmations chan error, err error) {
+	return r.execute(ctx, "Check Job for anchor pre-commit", func() error {
+		key := localAnchorKey(localPreCommitPrefix, anchorID)
+		if r.db.Exists(key) {
+			return errors.New("anchor %s is already pre-committed", anchorID.String())
+		}
+
+		return r.db.Create(key, &LocalAnchor{AnchorID: anchorID, DocumentRoot: signingRoot})
+	})
+}
+
+func (r *localRepository) Commit(
+	ctx context.Context,
+	anchorIDPreImage AnchorID,
+	documentRoot DocumentRoot,
+	proof [32]byte, storedUntil time.Time) (confirmations chan error, err error) {
+	h, err := crypto.Blake2bHash(anchorIDPreImage[:])
+	if err != nil {
+		return nil, err
+	}
+
+	anchorID, err := ToAnchorID(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.execute(ctx, "Check Job for anchor commit", func() error {
+		key := localAnchorKey(localAnchorPrefix, anchorID)
+		if r.db.Exists(key) {
+			return errors.New("anchor %s is already committed", anchorID.String())
+		}
+
+		block, err := r.lastBlock()
+		if err != nil {
+			return err
+		}
+
+		block.Number++
+		b := r.db.NewBatch()
+		err = b.Create(key, &LocalAnchor{
+			AnchorID:     anchorID,
+			DocumentRoot: documentRoot,
+			BlockNumber:  block.Number,
+			AnchoredTime: time.Now().UTC(),
+			StoredUntil:  storedUntil,
+		})
+		if err != nil {
+			return err
+		}
+
+		if b.Exists([]byte(localBlockKey)) {
+			err = b.Update([]byte(localBlockKey), block)
+		} else {
+			err = b.Create([]byte(localBlockKey), block)
+		}
+		if err != nil {
+			return err
+		}
+
+		return b.Commit()
+	})
+}
+
+// lastBlock returns the last block of the ledger.
+// Ledgers created before the block was tracked are counted once from the committed anchors.
+func (r *localRepository) lastBlock() (*LocalBlock, error) {
+	if !r.db.Exists([]byte(localBlockKey)) {
+		iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(localAnchorPrefix)})
+		defer iter.Release()
+		var n uint32
+		for iter.Next() {
+			n++
+		}
+
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+
+		return &LocalBlock{Number: n}, nil
+	}
+
+	m, err := r.db.Get([]byte(localBlockKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.(*LocalBlock), nil
+}
+
+// execute runs the ledger operation within a job similar to the transactions on chain.
+func (r *localRepository) execute(ctx context.Context, desc string, op func() error) (chan error, error) {
+	did, err := getDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID := contextutil.Job(ctx)
+	_, done, err := r.jobsMan.ExecuteWithinJob(contextutil.Copy(ctx), did, jobID, desc,
+		func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errOut chan<- error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			errOut <- op()
+		})
+
+	return done, err
+}
+
+func (r *localRepository) GetAnchorByID(id *big.Int) (*AnchorData, error) {
+	anchorID, err := ToAnchorID(common.LeftPadBytes(id.Bytes(), AnchorIDLength))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.db.Get(localAnchorKey(localAnchorPrefix, anchorID))
+	if err != nil {
+		return nil, errors.New("anchor %s not found: %v", anchorID.String(), err)
+	}
+
+	la := m.(*LocalAnchor)
+	return &AnchorData{
+		AnchorID:     types.NewHash(la.AnchorID[:]),
+		DocumentRoot: types.NewHash(la.DocumentRoot[:]),
+		BlockNumber:  la.BlockNumber,
+		AnchoredTime: la.AnchoredTime,
+	}, nil
+}
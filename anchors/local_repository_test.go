@@ -0,0 +1,122 @@
+// +build unit
+
+package anchors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// runningJobManager returns a job manager that runs the work of the job synchronously.
+// Result of the work must be read before the next job is executed.
+func runningJobManager() *testingjobs.MockJobManager {
+	jobMan := new(testingjobs.MockJobManager)
+	done := make(chan error, 1)
+	jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(jobs.NewJobID(), done, nil).Run(func(args mock.Arguments) {
+		work := args.Get(4).(func(identity.DID, jobs.JobID, jobs.Manager, chan<- error))
+		work(args.Get(1).(identity.DID), jobs.NewJobID(), nil, done)
+	})
+	return jobMan
+}
+
+func TestLocalRepository(t *testing.T) {
+	repo := NewLocalRepository(newTestDB(t), runningJobManager())
+	preImage, docRoot := AnchorID(utils.RandomByte32()), RandomDocumentRoot()
+	h, err := crypto.Blake2bHash(preImage[:])
+	assert.NoError(t, err)
+	anchorID, err := ToAnchorID(h)
+	assert.NoError(t, err)
+
+	// missing account
+	_, err = repo.Commit(context.Background(), preImage, docRoot, [32]byte{}, time.Now())
+	assert.Error(t, err)
+
+	// not anchored
+	_, err = repo.GetAnchorByID(anchorID.BigInt())
+	assert.Error(t, err)
+
+	// pre commit
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	done, err := repo.PreCommit(ctx, anchorID, RandomDocumentRoot())
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	done, err = repo.PreCommit(ctx, anchorID, RandomDocumentRoot())
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+
+	// commit
+	done, err = repo.Commit(ctx, preImage, docRoot, [32]byte{}, time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	ad, err := repo.GetAnchorByID(anchorID.BigInt())
+	assert.NoError(t, err)
+	assert.Equal(t, anchorID[:], ad.AnchorID[:])
+	assert.Equal(t, docRoot[:], ad.DocumentRoot[:])
+	assert.Equal(t, uint32(1), ad.BlockNumber)
+	assert.WithinDuration(t, time.Now(), ad.AnchoredTime, time.Minute)
+
+	// already committed
+	done, err = repo.Commit(ctx, preImage, RandomDocumentRoot(), [32]byte{}, time.Now())
+	assert.NoError(t, err)
+	assert.Error(t, <-done)
+
+	// next anchor is in the next block
+	preImage = utils.RandomByte32()
+	h, err = crypto.Blake2bHash(preImage[:])
+	assert.NoError(t, err)
+	anchorID, err = ToAnchorID(h)
+	assert.NoError(t, err)
+	done, err = repo.Commit(ctx, preImage, docRoot, [32]byte{}, time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	ad, err = repo.GetAnchorByID(anchorID.BigInt())
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), ad.BlockNumber)
+}
+
+func TestLocalRepository_blockNumber(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewLocalRepository(db, runningJobManager())
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+
+	// ledger created before the block was tracked
+	for i := 0; i < 2; i++ {
+		anchorID := AnchorID(utils.RandomByte32())
+		assert.NoError(t, db.Create(localAnchorKey(localAnchorPrefix, anchorID), &LocalAnchor{AnchorID: anchorID}))
+	}
+	assert.False(t, db.Exists([]byte(localBlockKey)))
+
+	commit := func() uint32 {
+		preImage := AnchorID(utils.RandomByte32())
+		h, err := crypto.Blake2bHash(preImage[:])
+		assert.NoError(t, err)
+		anchorID, err := ToAnchorID(h)
+		assert.NoError(t, err)
+		done, err := repo.Commit(ctx, preImage, RandomDocumentRoot(), [32]byte{}, time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, <-done)
+		ad, err := repo.GetAnchorByID(anchorID.BigInt())
+		assert.NoError(t, err)
+		return ad.BlockNumber
+	}
+
+	assert.Equal(t, uint32(3), commit())
+	m, err := db.Get([]byte(localBlockKey))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), m.(*LocalBlock).Number)
+
+	// block is read from the tracked block, not the anchors
+	assert.NoError(t, db.Update([]byte(localBlockKey), &LocalBlock{Number: 10}))
+	assert.Equal(t, uint32(11), commit())
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/utils"
-	"github.com/centrifuge/go-substrate-rpc-client/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -24,8 +24,31 @@ type Service interface {
 	// CommitAnchor will send a commit transaction to Ethereum.
 	CommitAnchor(ctx context.Context, anchorID AnchorID, documentRoot DocumentRoot, proof [32]byte) (chan error, error)
 
-	// GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain.
-	GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, err error)
+	// GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain along with
+	// the timestamp and number of the block it was anchored in.
+	// If the anchor was committed as part of a batch, the document root is verified against the batch anchor.
+	GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error)
+
+	// GetBatchProof returns the inclusion proof of the anchor in its batch.
+	GetBatchProof(anchorID AnchorID) (*BatchProof, error)
+
+	// StoreBatchProof verifies the inclusion proof received from a collaborator against the batch anchor on chain
+	// and stores it so that the anchor data of the document can be verified.
+	StoreBatchProof(proof *BatchProof) error
+
+	// GetExpiringAnchors returns the anchors committed by the node that expire before the given time, soonest first.
+	GetExpiringAnchors(before time.Time) ([]*AnchorRecord, error)
+
+	// MarkAnchorWarned records that the account was warned about the expiry of the anchor.
+	MarkAnchorWarned(anchorID AnchorID) error
+
+	// RenewAnchors re-commits the document roots of the anchors in a single batch anchor and extends their expiry.
+	// Anchors must belong to the account in context.
+	RenewAnchors(ctx context.Context, anchorIDs []AnchorID) error
+
+	// RepositoryAddress returns the anchor repository address recorded in the documents anchored on the backend
+	// of the node. Documents recording a different address cannot be verified by the node.
+	RepositoryAddress() common.Address
 }
 
 type service struct {
@@ -33,39 +56,120 @@ type service struct {
 	anchorRepository Repository
 	queue            *queue.Server
 	jobsMan          jobs.Manager
+	db               storage.Repository
+	batcher          *batcher
 }
 
-func newService(config Config, anchorRepository Repository, queue *queue.Server, jobsMan jobs.Manager) Service {
-	return &service{config: config, anchorRepository: anchorRepository, queue: queue, jobsMan: jobsMan}
+func newService(config Config, anchorRepository Repository, queue *queue.Server, jobsMan jobs.Manager, db storage.Repository) Service {
+	s := &service{config: config, anchorRepository: anchorRepository, queue: queue, jobsMan: jobsMan, db: db}
+	if config.GetAnchorBatchEnabled() {
+		s.batcher = newBatcher(config, anchorRepository, db)
+	}
+
+	return s
+}
+
+// RepositoryAddress returns the anchor repository address recorded in the documents anchored on the backend of the node.
+func (s *service) RepositoryAddress() common.Address {
+	return RepositoryAddress(s.config)
 }
 
-// GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain.
+// GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain along with
+// the timestamp and number of the block it was anchored in.
+// If the anchor is part of a batch, the document root is verified against the batch anchor.
 // Returns a nil error when the anchor data is found else returns a non nil error
-func (s *service) GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, err error) {
-	r, err := s.anchorRepository.GetAnchorByID(anchorID.BigInt())
+func (s *service) GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
+	proof, err := s.GetBatchProof(anchorID)
 	if err != nil {
-		return docRoot, anchoredTime, err
+		return s.getChainAnchorData(anchorID)
 	}
 
-	if utils.IsEmptyByte32(r.DocumentRoot) {
-		return docRoot, anchoredTime, errors.New("anchor data empty for id: %v", anchorID.String())
+	_, anchoredTime, blockNumber, err = s.verifyBatchProof(proof)
+	if err != nil {
+		return docRoot, anchoredTime, blockNumber, err
+	}
+
+	return proof.DocumentRoot, anchoredTime, blockNumber, nil
+}
+
+// verifyBatchProof checks the proof against the batch anchor on chain and returns the batch anchor data.
+// Anchor of a batched document root is never committed on its own, so a direct anchor of the same ID
+// with another document root conflicts with the batch.
+func (s *service) verifyBatchProof(proof *BatchProof) (batchRoot DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
+	batchRoot, anchoredTime, blockNumber, err = s.getChainAnchorData(proof.BatchAnchorID)
+	if err != nil {
+		return batchRoot, anchoredTime, blockNumber, errors.NewTypedError(ErrBatchProofInvalid, err)
+	}
+
+	if !proof.Verify(batchRoot) {
+		return batchRoot, anchoredTime, blockNumber, errors.NewTypedError(ErrBatchProofInvalid,
+			errors.New("anchor %s is not part of batch %s", proof.AnchorID.String(), proof.BatchAnchorID.String()))
+	}
+
+	docRoot, _, _, err := s.getChainAnchorData(proof.AnchorID)
+	if err == nil && docRoot != proof.DocumentRoot {
+		return batchRoot, anchoredTime, blockNumber, errors.NewTypedError(ErrBatchProofInvalid,
+			errors.New("anchor %s is committed with a document root other than in batch %s",
+				proof.AnchorID.String(), proof.BatchAnchorID.String()))
+	}
+
+	return batchRoot, anchoredTime, blockNumber, nil
+}
+
+// GetBatchProof returns the inclusion proof of the anchor in its batch.
+func (s *service) GetBatchProof(anchorID AnchorID) (*BatchProof, error) {
+	if s.db == nil {
+		return nil, ErrBatchProofNotFound
 	}
 
-	//TODO get block time
-	//blk, err := s.client.GetBlockByNumber(context.Background(), big.NewInt(int64(r.BlockNumber)))
-	//if err != nil {
-	//	return docRoot, anchoredTime, err
-	//}
-	bts, err := types.HexDecodeString(r.DocumentRoot.Hex())
+	m, err := s.db.Get(batchProofKey(anchorID))
 	if err != nil {
-		return docRoot, anchoredTime, err
+		return nil, errors.NewTypedError(ErrBatchProofNotFound, err)
+	}
+
+	return m.(*BatchProof), nil
+}
+
+// StoreBatchProof verifies the inclusion proof received from a collaborator against the batch anchor on chain
+// and stores it so that the anchor data of the document can be verified.
+func (s *service) StoreBatchProof(proof *BatchProof) error {
+	if proof == nil {
+		return errors.NewTypedError(ErrBatchProofInvalid, errors.New("nil batch proof"))
+	}
+
+	if s.db == nil {
+		return errors.New("batch proofs can't be stored without a db")
 	}
-	dr, err := ToDocumentRoot(bts)
+
+	if _, _, _, err := s.verifyBatchProof(proof); err != nil {
+		return err
+	}
+
+	key := batchProofKey(proof.AnchorID)
+	if s.db.Exists(key) {
+		return s.db.Update(key, proof)
+	}
+
+	return s.db.Create(key, proof)
+}
+
+// getChainAnchorData returns the document root anchored on chain against the anchorID along with the anchoring block details.
+func (s *service) getChainAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
+	r, err := s.anchorRepository.GetAnchorByID(anchorID.BigInt())
+	if err != nil {
+		return docRoot, anchoredTime, blockNumber, err
+	}
+
+	if utils.IsEmptyByte32(r.DocumentRoot) {
+		return docRoot, anchoredTime, blockNumber, errors.New("anchor data empty for id: %v", anchorID.String())
+	}
+
+	dr, err := ToDocumentRoot(r.DocumentRoot[:])
 	if err != nil {
-		return docRoot, anchoredTime, err
+		return docRoot, anchoredTime, blockNumber, err
 	}
 
-	return dr, time.Unix(0, 0), nil
+	return dr, r.AnchoredTime, r.BlockNumber, nil
 }
 
 // PreCommitAnchor will call the transaction PreCommit substrate module
@@ -86,6 +190,23 @@ func getDID(ctx context.Context) (identity.DID, error) {
 }
 
 // CommitAnchor will send a commit transaction to CentChain.
+// If batching is enabled, the document root is added to the account's batch and the returned channel receives the
+// result once the batch is anchored.
+// Committed anchors are added to the registry to track their expiry.
 func (s *service) CommitAnchor(ctx context.Context, anchorID AnchorID, documentRoot DocumentRoot, proof [32]byte) (chan error, error) {
-	return s.anchorRepository.Commit(ctx, anchorID, documentRoot, proof, time.Now().UTC().Add(s.config.GetCentChainAnchorLifespan()))
+	// batch anchor is committed later than the document root is added, so its expiry is never earlier than this.
+	expiresAt := time.Now().UTC().Add(s.config.GetCentChainAnchorLifespan())
+	var done chan error
+	var err error
+	if s.batcher != nil {
+		done, err = s.batcher.add(ctx, anchorID, documentRoot, proof)
+	} else {
+		done, err = s.anchorRepository.Commit(ctx, anchorID, documentRoot, proof, expiresAt)
+	}
+
+	if err != nil || s.db == nil {
+		return done, err
+	}
+
+	return s.recordCommit(ctx, anchorID, documentRoot, proof, expiresAt, done)
 }
@@ -0,0 +1,172 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// chunkSize is the size of the compressed stream sealed at once in the encrypted snapshots.
+const chunkSize = 64 * 1024
+
+// record tags of the snapshot body.
+const (
+	tagConfigDB byte = iota
+	tagDB
+	tagEnd = 0xff
+)
+
+// maxRecordSize limits the size of a key or a value read from the snapshot.
+const maxRecordSize = 1 << 30
+
+// sealWriter seals the stream in chunks. Each chunk is prefixed with its index before it is sealed
+// and is bound to the index so that reordered or dropped chunks are detected.
+type sealWriter struct {
+	w     io.Writer
+	c     storage.Cipher
+	buf   []byte
+	index uint64
+}
+
+func newSealWriter(w io.Writer, c storage.Cipher) *sealWriter {
+	return &sealWriter{w: w, c: c, buf: make([]byte, 8, 8+chunkSize)}
+}
+
+func (s *sealWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		m := copy(s.buf[len(s.buf):cap(s.buf)], p)
+		s.buf = s.buf[:len(s.buf)+m]
+		p, n = p[m:], n+m
+		if len(s.buf) == cap(s.buf) {
+			err := s.flush()
+			if err != nil {
+				return n, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+func (s *sealWriter) flush() error {
+	binary.BigEndian.PutUint64(s.buf[:8], s.index)
+	sealed, err := s.c.Encrypt(s.buf[:8], s.buf)
+	if err != nil {
+		return err
+	}
+
+	err = writeBytes(s.w, sealed)
+	if err != nil {
+		return err
+	}
+
+	s.index++
+	s.buf = s.buf[:8]
+	return nil
+}
+
+// Close seals the remaining stream.
+func (s *sealWriter) Close() error {
+	if len(s.buf) == 8 {
+		return nil
+	}
+
+	return s.flush()
+}
+
+// openReader opens the chunks sealed by the sealWriter.
+type openReader struct {
+	r     io.Reader
+	c     storage.Cipher
+	buf   []byte
+	index uint64
+}
+
+func (o *openReader) Read(p []byte) (int, error) {
+	for len(o.buf) == 0 {
+		sealed, err := readBytes(o.r)
+		if err != nil {
+			return 0, err
+		}
+
+		if !o.c.IsEncrypted(sealed) {
+			return 0, errors.New("chunk %d is not sealed", o.index)
+		}
+
+		var index [8]byte
+		binary.BigEndian.PutUint64(index[:], o.index)
+		chunk, err := o.c.Decrypt(index[:], sealed)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(chunk) < 8 || binary.BigEndian.Uint64(chunk[:8]) != o.index {
+			return 0, errors.New("chunk %d is out of order", o.index)
+		}
+
+		o.index++
+		o.buf = chunk[8:]
+	}
+
+	n := copy(p, o.buf)
+	o.buf = o.buf[n:]
+	return n, nil
+}
+
+// writeBytes writes the length prefixed bytes.
+func writeBytes(w io.Writer, b []byte) error {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(b)))
+	_, err := w.Write(l[:])
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// readBytes reads the length prefixed bytes. Returns io.EOF only if the reader ends before the length.
+func readBytes(r io.Reader) ([]byte, error) {
+	var l [4]byte
+	_, err := io.ReadFull(r, l[:])
+	if err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(l[:])
+	if n > maxRecordSize {
+		return nil, errors.New("record of %d bytes is too large", n)
+	}
+
+	b := make([]byte, n)
+	_, err = io.ReadFull(r, b)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return b, err
+}
+
+// writeRecords writes every key value of the DB as a record with the tag.
+func writeRecords(w *bufio.Writer, tag byte, db storage.KeyValueStore) (n int, err error) {
+	err = db.Iterate(nil, func(key, value []byte) error {
+		err := w.WriteByte(tag)
+		if err != nil {
+			return err
+		}
+
+		err = writeBytes(w, key)
+		if err != nil {
+			return err
+		}
+
+		n++
+		return writeBytes(w, value)
+	})
+	return n, err
+}
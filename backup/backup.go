@@ -0,0 +1,294 @@
+// Package backup takes snapshots of the config and document DBs of a node and restores them.
+// Snapshots are taken without stopping the node. A snapshot holds the key values of both DBs, so it can be
+// restored on any of the storage backends. Values encrypted at rest are kept sealed with the storage master key.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/migration"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/version"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("backup")
+
+const (
+	// ErrIncompatibleSnapshot is a sentinel error when the snapshot cannot be restored on this node.
+	ErrIncompatibleSnapshot = errors.Error("incompatible snapshot")
+
+	// ErrInvalidSnapshot is a sentinel error when the snapshot is malformed or corrupted.
+	ErrInvalidSnapshot = errors.Error("invalid snapshot")
+
+	// ErrMissingSecret is a sentinel error when the snapshot is encrypted and no key file or passphrase is provided.
+	ErrMissingSecret = errors.Error("snapshot is encrypted, key file or passphrase is required")
+
+	// ErrTargetExists is a sentinel error when the DB being restored already exists.
+	ErrTargetExists = errors.Error("restore target already exists")
+)
+
+// FormatVersion is the version of the snapshot format.
+const FormatVersion = 1
+
+// magic identifies the snapshot files.
+var magic = []byte("CENTSNAP")
+
+// Config holds the storage configuration of the node.
+type Config interface {
+	GetStorageBackend() string
+	GetStoragePath() string
+	GetConfigStoragePath() string
+	GetNetworkString() string
+}
+
+// Manifest describes the snapshot. Manifest is stored in plaintext ahead of the compressed DBs
+// so that the compatibility is checked before anything is decrypted or restored.
+// Snapshot body is bound to the manifest with its hash.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	NodeVersion   string    `json:"node_version"`
+	Network       string    `json:"network"`
+	Backend       string    `json:"backend"`
+	Migrations    []string  `json:"migrations"`
+	CreatedAt     time.Time `json:"created_at"`
+	Encrypted     bool      `json:"encrypted"`
+
+	// Salt the key is derived from the passphrase with. Empty if the snapshot is encrypted with a key file.
+	Salt byteutils.HexBytes `json:"salt,omitempty"`
+}
+
+// Secret holds the key file or the passphrase of the snapshot encryption.
+// Snapshots are not encrypted if both are empty. Key file takes precedence over the passphrase.
+type Secret struct {
+	KeyFile    string
+	Passphrase string
+}
+
+func (s Secret) isEmpty() bool {
+	return s.KeyFile == "" && s.Passphrase == ""
+}
+
+// cipher returns the cipher the snapshot of the manifest is encrypted with.
+// New salt is set on the manifest if the key is derived from the passphrase and the salt is missing.
+func (s Secret) cipher(m *Manifest) (storage.Cipher, error) {
+	var key []byte
+	var err error
+	switch {
+	case s.KeyFile != "":
+		if len(m.Salt) > 0 {
+			return nil, errors.New("snapshot is encrypted with a passphrase")
+		}
+
+		key, err = encryption.ReadKeyFile(s.KeyFile)
+	case len(m.Salt) == 0 && m.Encrypted:
+		return nil, errors.New("snapshot is encrypted with a key file")
+	default:
+		if len(m.Salt) == 0 {
+			m.Salt = make([]byte, encryption.KeySize)
+			_, err = rand.Read(m.Salt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		key, err = encryption.DeriveKey(s.Passphrase, m.Salt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.Encrypted = true
+	return encryption.NewCipher(key)
+}
+
+// compatible checks that the snapshot can be restored on this node of the network.
+// Snapshots taken by nodes of another major version, or with migrations unknown to this node, are refused.
+func (m Manifest) compatible(network string) error {
+	if m.FormatVersion != FormatVersion {
+		return errors.NewTypedError(ErrIncompatibleSnapshot, errors.New("unsupported format version %d", m.FormatVersion))
+	}
+
+	if m.Network != network {
+		return errors.NewTypedError(ErrIncompatibleSnapshot, errors.New("snapshot of network %s, node is on %s", m.Network, network))
+	}
+
+	if !version.CheckVersion(m.NodeVersion) {
+		return errors.NewTypedError(ErrIncompatibleSnapshot, version.IncompatibleVersionError(m.NodeVersion))
+	}
+
+	for _, id := range m.Migrations {
+		if !migration.IsKnown(id) {
+			return errors.NewTypedError(ErrIncompatibleSnapshot, errors.New("migration %s is unknown to this node", id))
+		}
+	}
+
+	return nil
+}
+
+// Create writes the snapshot of the config and document DBs of the node to w.
+// DBs are copied first, so the node keeps running while the snapshot is written.
+func Create(cfg Config, w io.Writer, secret Secret) (*Manifest, error) {
+	dir, err := ioutil.TempDir("", "centrifuge-backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	be := cfg.GetStorageBackend()
+	configDB, err := snapshot(be, cfg.GetConfigStoragePath(), filepath.Join(dir, "config.db"))
+	if err != nil {
+		return nil, errors.New("failed to copy the config db: %v", err)
+	}
+	defer configDB.Close()
+
+	db, err := snapshot(be, cfg.GetStoragePath(), filepath.Join(dir, "data.db"))
+	if err != nil {
+		return nil, errors.New("failed to copy the db: %v", err)
+	}
+	defer db.Close()
+
+	m := &Manifest{
+		FormatVersion: FormatVersion,
+		NodeVersion:   version.GetVersion().String(),
+		Network:       cfg.GetNetworkString(),
+		Backend:       be,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	err = db.Iterate([]byte(migration.DBPrefix), func(key, _ []byte) error {
+		m.Migrations = append(m.Migrations, strings.TrimPrefix(string(key), migration.DBPrefix))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var c storage.Cipher
+	if !secret.isEmpty() {
+		c, err = secret.cipher(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.Write(magic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeBytes(w, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeBody(w, c, raw, configDB, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// snapshot copies the DB at path to dst and opens the copy.
+func snapshot(be, path, dst string) (storage.KeyValueStore, error) {
+	err := backend.Snapshot(be, path, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.OpenKeyValueStore(be, dst)
+}
+
+// writeBody writes the compressed records of the DBs, sealed if the cipher is set.
+func writeBody(w io.Writer, c storage.Cipher, manifest []byte, configDB, db storage.KeyValueStore) error {
+	var sw *sealWriter
+	if c != nil {
+		sw = newSealWriter(w, c)
+		w = sw
+	}
+
+	gz := gzip.NewWriter(w)
+	bw := bufio.NewWriter(gz)
+	h := sha256.Sum256(manifest)
+	_, err := bw.Write(h[:])
+	if err != nil {
+		return err
+	}
+
+	n, err := writeRecords(bw, tagConfigDB, configDB)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("%d config db entries written", n)
+	n, err = writeRecords(bw, tagDB, db)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("%d db entries written", n)
+	err = bw.WriteByte(tagEnd)
+	if err != nil {
+		return err
+	}
+
+	err = bw.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = gz.Close()
+	if err != nil || sw == nil {
+		return err
+	}
+
+	return sw.Close()
+}
+
+// ReadManifest reads the manifest of the snapshot.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	m, _, err := readManifest(r)
+	return m, err
+}
+
+func readManifest(r io.Reader) (*Manifest, []byte, error) {
+	header := make([]byte, len(magic))
+	_, err := io.ReadFull(r, header)
+	if err != nil || !bytes.Equal(header, magic) {
+		return nil, nil, errors.NewTypedError(ErrInvalidSnapshot, errors.New("not a snapshot"))
+	}
+
+	raw, err := readBytes(r)
+	if err != nil {
+		return nil, nil, errors.NewTypedError(ErrInvalidSnapshot, err)
+	}
+
+	m := new(Manifest)
+	err = json.Unmarshal(raw, m)
+	if err != nil {
+		return nil, nil, errors.NewTypedError(ErrInvalidSnapshot, err)
+	}
+
+	return m, raw, nil
+}
@@ -0,0 +1,250 @@
+// +build unit
+
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/migration"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/version"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConfig struct {
+	backend, dir, network string
+}
+
+func (m mockConfig) GetStorageBackend() string {
+	return m.backend
+}
+
+func (m mockConfig) GetStoragePath() string {
+	return filepath.Join(m.dir, "data_"+m.backend)
+}
+
+func (m mockConfig) GetConfigStoragePath() string {
+	return filepath.Join(m.dir, "config_"+m.backend)
+}
+
+func (m mockConfig) GetNetworkString() string {
+	return m.network
+}
+
+// newNode returns the config of a node with DBs holding n entries each, along with the entries.
+func newNode(t *testing.T, be string, n int) (mockConfig, map[string][]byte) {
+	dir, err := ioutil.TempDir("", "backup-test")
+	assert.NoError(t, err)
+	cfg := mockConfig{backend: be, dir: dir, network: "testing"}
+	entries := make(map[string][]byte)
+	for _, path := range []string{cfg.GetConfigStoragePath(), cfg.GetStoragePath()} {
+		db, err := backend.OpenKeyValueStore(be, path)
+		assert.NoError(t, err)
+		for i := 0; i < n; i++ {
+			key, value := []byte(fmt.Sprintf("%s_%d", filepath.Base(path), i)), utils.RandomSlice(64)
+			assert.NoError(t, db.Put(key, value))
+			entries[string(key)] = value
+		}
+		assert.NoError(t, db.Close())
+	}
+
+	db, err := backend.OpenKeyValueStore(be, cfg.GetStoragePath())
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put([]byte(migration.DBPrefix+"00Initial"), []byte(`{"id":"00Initial"}`)))
+	assert.NoError(t, db.Close())
+	return cfg, entries
+}
+
+// assertRestored asserts the DBs of the node hold the entries.
+func assertRestored(t *testing.T, cfg mockConfig, entries map[string][]byte) {
+	var count int
+	for _, path := range []string{cfg.GetConfigStoragePath(), cfg.GetStoragePath()} {
+		db, err := backend.OpenKeyValueStore(cfg.backend, path)
+		assert.NoError(t, err)
+		assert.NoError(t, db.Iterate(nil, func(key, value []byte) error {
+			count++
+			if bytes.HasPrefix(key, []byte(migration.DBPrefix)) {
+				return nil
+			}
+
+			assert.Equal(t, entries[string(key)], value)
+			return nil
+		}))
+		assert.NoError(t, db.Close())
+	}
+	assert.Equal(t, len(entries)+1, count)
+}
+
+func TestCreate_Restore(t *testing.T) {
+	cfg, entries := newNode(t, storage.LevelDBBackend, 1000)
+	defer os.RemoveAll(cfg.dir)
+
+	// source DBs are open by the node
+	db, err := backend.OpenKeyValueStore(cfg.backend, cfg.GetStoragePath())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	m, err := Create(cfg, &buf, Secret{})
+	assert.NoError(t, err)
+	assert.Equal(t, version.GetVersion().String(), m.NodeVersion)
+	assert.Equal(t, "testing", m.Network)
+	assert.Equal(t, []string{"00Initial"}, m.Migrations)
+	assert.False(t, m.Encrypted)
+	snapshot := buf.Bytes()
+	rm, err := ReadManifest(bytes.NewReader(snapshot))
+	assert.NoError(t, err)
+	assert.Equal(t, m.Migrations, rm.Migrations)
+
+	// restored on another backend
+	for _, be := range []string{storage.LevelDBBackend, storage.SQLiteBackend} {
+		target := mockConfig{backend: be, dir: filepath.Join(cfg.dir, "restored"), network: "testing"}
+		_, err = Restore(target, bytes.NewReader(snapshot), Secret{})
+		assert.NoError(t, err)
+		assertRestored(t, target, entries)
+
+		// DBs exist
+		_, err = Restore(target, bytes.NewReader(snapshot), Secret{})
+		assert.True(t, errors.IsOfType(ErrTargetExists, err))
+	}
+
+	// another network
+	target := mockConfig{backend: storage.SQLiteBackend, dir: cfg.dir, network: "mainnet"}
+	_, err = Restore(target, bytes.NewReader(snapshot), Secret{})
+	assert.True(t, errors.IsOfType(ErrIncompatibleSnapshot, err))
+
+	// manifest altered
+	altered := bytes.Replace(snapshot, []byte(`"network":"testing"`), []byte(`"network":"mainnet"`), 1)
+	_, err = Restore(target, bytes.NewReader(altered), Secret{})
+	assert.True(t, errors.IsOfType(ErrInvalidSnapshot, err))
+	_, err = os.Stat(target.GetStoragePath() + restoreSuffix)
+	assert.True(t, os.IsNotExist(err))
+
+	// truncated
+	target.network = "testing"
+	_, err = Restore(target, bytes.NewReader(snapshot[:len(snapshot)-100]), Secret{})
+	assert.True(t, errors.IsOfType(ErrInvalidSnapshot, err))
+
+	// not a snapshot
+	_, err = Restore(target, bytes.NewReader(utils.RandomSlice(100)), Secret{})
+	assert.True(t, errors.IsOfType(ErrInvalidSnapshot, err))
+}
+
+func TestRestore_renameFailed(t *testing.T) {
+	cfg, entries := newNode(t, storage.LevelDBBackend, 100)
+	defer os.RemoveAll(cfg.dir)
+	var buf bytes.Buffer
+	_, err := Create(cfg, &buf, Secret{})
+	assert.NoError(t, err)
+
+	// second DB can't be moved in place
+	target := mockConfig{backend: storage.LevelDBBackend, dir: filepath.Join(cfg.dir, "restored"), network: "testing"}
+	defer func() { rename = os.Rename }()
+	rename = func(from, to string) error {
+		if to == target.GetStoragePath() {
+			return errors.New("disk full")
+		}
+
+		return os.Rename(from, to)
+	}
+
+	_, err = Restore(target, bytes.NewReader(buf.Bytes()), Secret{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+
+	// nothing is left in place
+	for _, path := range []string{target.GetConfigStoragePath(), target.GetStoragePath()} {
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(path + restoreSuffix)
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	// restore can be retried
+	rename = os.Rename
+	_, err = Restore(target, bytes.NewReader(buf.Bytes()), Secret{})
+	assert.NoError(t, err)
+	assertRestored(t, target, entries)
+}
+
+func TestCreate_Restore_Encrypted(t *testing.T) {
+	cfg, entries := newNode(t, storage.SQLiteBackend, 1000)
+	defer os.RemoveAll(cfg.dir)
+
+	keyFile := filepath.Join(cfg.dir, "key")
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte(hexutil.Encode(utils.RandomSlice(32))), 0600))
+	otherKeyFile := filepath.Join(cfg.dir, "other_key")
+	assert.NoError(t, ioutil.WriteFile(otherKeyFile, []byte(hexutil.Encode(utils.RandomSlice(32))), 0600))
+	tests := []struct {
+		secret Secret
+		wrong  []Secret
+	}{
+		{
+			secret: Secret{Passphrase: "secret"},
+			wrong:  []Secret{{Passphrase: "wrong"}, {KeyFile: keyFile}},
+		},
+		{
+			secret: Secret{KeyFile: keyFile},
+			wrong:  []Secret{{KeyFile: otherKeyFile}, {Passphrase: "secret"}},
+		},
+	}
+
+	for i, c := range tests {
+		var buf bytes.Buffer
+		m, err := Create(cfg, &buf, c.secret)
+		assert.NoError(t, err)
+		assert.True(t, m.Encrypted)
+		snapshot := buf.Bytes()
+
+		// values are not in plaintext, more than a chunk is sealed
+		assert.True(t, len(snapshot) > 2*chunkSize)
+		for _, value := range entries {
+			assert.False(t, bytes.Contains(snapshot, value))
+			break
+		}
+
+		target := mockConfig{backend: storage.LevelDBBackend, dir: filepath.Join(cfg.dir, fmt.Sprint(i)), network: "testing"}
+		_, err = Restore(target, bytes.NewReader(snapshot), Secret{})
+		assert.Equal(t, ErrMissingSecret, err)
+		for _, s := range c.wrong {
+			_, err = Restore(target, bytes.NewReader(snapshot), s)
+			assert.True(t, errors.IsOfType(ErrInvalidSnapshot, err))
+		}
+
+		_, err = Restore(target, bytes.NewReader(snapshot), c.secret)
+		assert.NoError(t, err)
+		assertRestored(t, target, entries)
+	}
+}
+
+func TestManifest_compatible(t *testing.T) {
+	m := Manifest{
+		FormatVersion: FormatVersion,
+		NodeVersion:   version.GetVersion().String(),
+		Network:       "testing",
+		Migrations:    []string{"00Initial", "05AddAttributeIndex"},
+	}
+	assert.NoError(t, m.compatible("testing"))
+
+	for _, f := range []func(m *Manifest){
+		func(m *Manifest) { m.FormatVersion = FormatVersion + 1 },
+		func(m *Manifest) { m.Network = "mainnet" },
+		func(m *Manifest) { m.NodeVersion = fmt.Sprintf("%d.0.0", version.GetVersion().Major()+1) },
+		func(m *Manifest) { m.NodeVersion = "invalid" },
+		func(m *Manifest) { m.Migrations = append(m.Migrations, "99Unknown") },
+	} {
+		im := m
+		im.Migrations = append([]string{}, m.Migrations...)
+		f(&im)
+		assert.True(t, errors.IsOfType(ErrIncompatibleSnapshot, im.compatible("testing")))
+	}
+}
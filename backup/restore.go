@@ -0,0 +1,195 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+)
+
+// restoreSuffix is the suffix of the DBs being restored. DBs are moved in place once fully restored.
+const restoreSuffix = ".restoring"
+
+// rename moves the restored DBs in place.
+var rename = os.Rename
+
+// Restore restores the config and document DBs of the node from the snapshot read from r.
+// Node must be stopped and its DBs must not exist. DBs are created on the configured backend.
+// Incompatible snapshots are refused before anything is written.
+func Restore(cfg Config, r io.Reader, secret Secret) (*Manifest, error) {
+	br := bufio.NewReader(r)
+	m, raw, err := readManifest(br)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.compatible(cfg.GetNetworkString())
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader = br
+	if m.Encrypted {
+		if secret.isEmpty() {
+			return nil, ErrMissingSecret
+		}
+
+		c, err := secret.cipher(m)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidSnapshot, err)
+		}
+
+		body = &openReader{r: br, c: c}
+	}
+
+	paths := []string{cfg.GetConfigStoragePath(), cfg.GetStoragePath()}
+	for _, path := range paths {
+		_, err = os.Stat(path)
+		if err == nil {
+			return nil, errors.NewTypedError(ErrTargetExists, errors.New("%s", path))
+		}
+	}
+
+	dbs := make([]storage.KeyValueStore, len(paths))
+	defer func() {
+		for i, db := range dbs {
+			if db != nil {
+				_ = db.Close()
+			}
+
+			if err != nil {
+				_ = os.RemoveAll(paths[i] + restoreSuffix)
+			}
+		}
+	}()
+
+	for i, path := range paths {
+		// leftovers of a failed restore
+		err = os.RemoveAll(path + restoreSuffix)
+		if err != nil {
+			return nil, err
+		}
+
+		dbs[i], err = backend.OpenKeyValueStore(cfg.GetStorageBackend(), path+restoreSuffix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = readBody(body, raw, dbs[tagConfigDB], dbs[tagDB])
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range dbs {
+		err = dbs[i].Close()
+		dbs[i] = nil
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = moveInPlace(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// moveInPlace moves the restored DBs to their paths.
+// If a DB can't be moved, the ones already moved are moved back so that the restore can be retried.
+func moveInPlace(paths []string) error {
+	for i, path := range paths {
+		err := rename(path+restoreSuffix, path)
+		if err == nil {
+			continue
+		}
+
+		for _, moved := range paths[:i] {
+			rerr := rename(moved, moved+restoreSuffix)
+			if rerr != nil {
+				err = errors.AppendError(err, errors.New("failed to move back %s: %v", moved, rerr))
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// readBody writes the records of the snapshot body to the DBs.
+func readBody(body io.Reader, manifest []byte, configDB, db storage.KeyValueStore) error {
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidSnapshot, err)
+	}
+
+	br := bufio.NewReader(gz)
+	h := make([]byte, sha256.Size)
+	_, err = io.ReadFull(br, h)
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidSnapshot, err)
+	}
+
+	mh := sha256.Sum256(manifest)
+	if !bytes.Equal(h, mh[:]) {
+		return errors.NewTypedError(ErrInvalidSnapshot, errors.New("manifest does not match the snapshot"))
+	}
+
+	counts := make(map[byte]int)
+	for {
+		tag, err := br.ReadByte()
+		if err != nil {
+			return errors.NewTypedError(ErrInvalidSnapshot, err)
+		}
+
+		if tag == tagEnd {
+			break
+		}
+
+		var dst storage.KeyValueStore
+		switch tag {
+		case tagConfigDB:
+			dst = configDB
+		case tagDB:
+			dst = db
+		default:
+			return errors.NewTypedError(ErrInvalidSnapshot, errors.New("unknown record tag %d", tag))
+		}
+
+		key, err := readBytes(br)
+		if err != nil {
+			return errors.NewTypedError(ErrInvalidSnapshot, err)
+		}
+
+		value, err := readBytes(br)
+		if err != nil {
+			return errors.NewTypedError(ErrInvalidSnapshot, err)
+		}
+
+		err = dst.Put(key, value)
+		if err != nil {
+			return err
+		}
+
+		counts[tag]++
+	}
+
+	// reading to the end verifies the checksum of the compressed stream
+	_, err = io.Copy(ioutil.Discard, br)
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidSnapshot, err)
+	}
+
+	log.Infof("%d config db entries and %d db entries restored", counts[tagConfigDB], counts[tagDB])
+	return nil
+}
@@ -11,6 +11,16 @@ const (
 	NodeObjRegistry         string = "NodeObjRegistry"
 	// BootstrappedNFTService is the key to NFT Service in bootstrap context.
 	BootstrappedNFTService = "BootstrappedNFTService"
+	// BootstrappedAnchorRecovery is the key to the server recovering the interrupted document anchoring.
+	BootstrappedAnchorRecovery = "BootstrappedAnchorRecovery"
+	// BootstrappedDeliveryQueue is the key to the server retrying the failed deliveries of anchored documents.
+	BootstrappedDeliveryQueue = "BootstrappedDeliveryQueue"
+	// BootstrappedAnchorExpiryMonitor is the key to the server warning about and renewing the expiring anchors.
+	BootstrappedAnchorExpiryMonitor = "BootstrappedAnchorExpiryMonitor"
+	// BootstrappedPendingRepository is the key to the repository of the pending documents.
+	BootstrappedPendingRepository = "BootstrappedPendingRepository"
+	// BootstrappedStorageReencryption is the key to the server re-encrypting the stored values with the current key.
+	BootstrappedStorageReencryption = "BootstrappedStorageReencryption"
 )
 
 // Bootstrapper must be implemented by all packages that needs bootstrapping at application start
@@ -7,6 +7,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/centchain"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/disclosure"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
@@ -24,7 +25,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/centrifuge/go-centrifuge/version"
 	log2 "github.com/ipfs/go-log"
 )
@@ -41,7 +42,7 @@ func (m *MainBootstrapper) PopulateBaseBootstrappers() {
 	m.Bootstrappers = []bootstrap.Bootstrapper{
 		&version.Bootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
 		jobsv1.Bootstrapper{},
 		&queue.Bootstrapper{},
 		centchain.Bootstrapper{},
@@ -55,8 +56,9 @@ func (m *MainBootstrapper) PopulateBaseBootstrappers() {
 		generic.Bootstrapper{},
 		&nft.Bootstrapper{},
 		p2p.Bootstrapper{},
-		documents.PostBootstrapper{},
 		pending.Bootstrapper{},
+		documents.PostBootstrapper{},
+		disclosure.Bootstrapper{},
 		coreapi.Bootstrapper{},
 		&entity.Bootstrapper{},
 		funding.Bootstrapper{},
@@ -71,7 +73,7 @@ func (m *MainBootstrapper) PopulateCommandBootstrappers() {
 	m.Bootstrappers = []bootstrap.Bootstrapper{
 		&version.Bootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
 		jobsv1.Bootstrapper{},
 		&queue.Bootstrapper{},
 		centchain.Bootstrapper{},
@@ -9,6 +9,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/centchain"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/disclosure"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
@@ -48,8 +49,9 @@ var bootstrappers = []bootstrap.TestBootstrapper{
 	generic.Bootstrapper{},
 	&nft.Bootstrapper{},
 	p2p.Bootstrapper{},
-	documents.PostBootstrapper{},
 	pending.Bootstrapper{},
+	documents.PostBootstrapper{},
+	disclosure.Bootstrapper{},
 	coreapi.Bootstrapper{},
 	&entity.Bootstrapper{},
 	funding.Bootstrapper{},
@@ -49,6 +49,9 @@ type API interface {
 
 	// SubmitAndWatch returns function that submits and watches an extrinsic, implements transaction.Submitter
 	SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error)
+
+	// GetBlockTimestamp returns the timestamp of the block with the given block number.
+	GetBlockTimestamp(blockNumber uint32) (time.Time, error)
 }
 
 // SubstrateAPI exposes Substrate API functions
@@ -142,6 +145,37 @@ func (a *api) GetMetadataLatest() (*types.Metadata, error) {
 	return a.sapi.GetMetadataLatest()
 }
 
+// GetBlockTimestamp returns the timestamp set in the block through Timestamp.Now.
+func (a *api) GetBlockTimestamp(blockNumber uint32) (time.Time, error) {
+	blockHash, err := a.sapi.GetBlockHash(uint64(blockNumber))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	meta, err := a.sapi.GetMetadataLatest()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "Timestamp", "Now", nil, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// decoded as milliseconds since types.Moment doesn't decode the sub second part correctly
+	var now types.U64
+	err = a.sapi.GetStorage(key, &now, blockHash)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if now == 0 {
+		return time.Time{}, errors.New("timestamp not found for block %d", blockNumber)
+	}
+
+	return time.Unix(0, int64(now)*int64(time.Millisecond)).UTC(), nil
+}
+
 func (a *api) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) (txHash types.Hash, bn types.BlockNumber, sig types.MultiSignature, err error) {
 	ext := types.NewExtrinsic(c)
 	era := types.ExtrinsicEra{IsMortalEra: false}
@@ -205,7 +239,8 @@ func (a *api) QueueCentChainEXTStatusTask(
 	return queuer.EnqueueJob(ExtrinsicStatusTaskName, params)
 }
 
-/**
+/*
+*
 SubmitWithRetries submits extrinsic to the centchain
 Blocking Function that sends Extrinsic wrapped in a retrial block. It is based on the ErrNonceTooLow error,
 meaning that a transaction is being attempted to run twice with the same nonce.
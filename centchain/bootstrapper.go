@@ -2,6 +2,7 @@ package centchain
 
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -32,11 +33,11 @@ func (Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	}
 	queueSrv := context[bootstrap.BootstrappedQueueServer].(*queue.Server)
 
-	sapi, err := gsrpc.NewSubstrateAPI(cfg.GetCentChainNodeURL())
+	centSAPI, err := newSubstrateAPI(cfg)
 	if err != nil {
 		return err
 	}
-	centSAPI := &defaultSubstrateAPI{sapi}
+
 	client := NewAPI(centSAPI, cfg, queueSrv)
 	extStatusTask := NewExtrinsicStatusTask(cfg.GetCentChainIntervalRetry(), cfg.GetCentChainMaxRetries(), txManager, centSAPI.GetBlockHash, centSAPI.GetBlock, centSAPI.GetMetadataLatest, centSAPI.GetStorage)
 	queueSrv.RegisterTaskType(extStatusTask.TaskTypeName(), extStatusTask)
@@ -44,3 +45,21 @@ func (Bootstrapper) Bootstrap(context map[string]interface{}) error {
 
 	return nil
 }
+
+// newSubstrateAPI returns the in-process chain when the node is configured to run the chain in-process.
+// Otherwise connects to the configured Centrifuge Chain node.
+// Only the Centrifuge Chain is replaced. Ethereum client, identities and NFTs still connect to ethNodeURL.
+func newSubstrateAPI(cfg config.Configuration) (SubstrateAPI, error) {
+	if cfg.GetCentChainInProcess() {
+		log.Warning("Centrifuge Chain is running in-process. Anchors are visible only to this node. " +
+			"Ethereum is still reached at the configured ethNodeURL.")
+		return NewLocalSubstrateAPI()
+	}
+
+	sapi, err := gsrpc.NewSubstrateAPI(cfg.GetCentChainNodeURL())
+	if err != nil {
+		return nil, err
+	}
+
+	return &defaultSubstrateAPI{sapi}, nil
+}
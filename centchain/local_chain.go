@@ -0,0 +1,504 @@
+package centchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// localChainSpecName is the runtime spec name reported by the local chain.
+	localChainSpecName = "centrifuge-local"
+
+	// submitExtrinsicMethod is the rpc method to submit an extrinsic.
+	submitExtrinsicMethod = "author_submitExtrinsic"
+
+	// getAnchorByIDMethod is the rpc method to fetch an anchor.
+	getAnchorByIDMethod = "anchor_getAnchorById"
+)
+
+// dispatch errors of the Anchor module on the local chain.
+const (
+	errAnchorExists uint8 = iota
+	errPreCommitExists
+)
+
+// localAnchor is an anchor committed on the local chain.
+// The json representation matches the response of anchor_getAnchorById.
+type localAnchor struct {
+	ID          types.Hash `json:"id"`
+	DocRoot     types.Hash `json:"doc_root"`
+	BlockNumber uint32     `json:"anchored_block"`
+	storedUntil time.Time
+}
+
+type localBlock struct {
+	hash      types.Hash
+	block     types.SignedBlock
+	events    types.EventRecordsRaw
+	timestamp types.Moment
+}
+
+// localChain is an in-process stand-in for a Centrifuge Chain node.
+// Every submitted extrinsic is applied right away in a new block along with the System events of the result.
+// Only the Anchor module is supported and extrinsic signatures are not verified.
+type localChain struct {
+	meta        *types.Metadata
+	preCommitID types.CallIndex
+	commitID    types.CallIndex
+	eventsKey   types.StorageKey
+	nowKey      types.StorageKey
+
+	mu         sync.RWMutex
+	blocks     []localBlock
+	accounts   map[string]types.AccountInfo
+	preCommits map[types.Hash]types.Hash
+	anchors    map[types.Hash]localAnchor
+}
+
+// NewLocalSubstrateAPI returns a SubstrateAPI backed by an in-process chain.
+// Intended for development and testing when a Centrifuge Chain node is not available.
+func NewLocalSubstrateAPI() (SubstrateAPI, error) {
+	meta := localChainMetadata()
+	preCommitID, err := meta.FindCallIndex("Anchor.pre_commit")
+	if err != nil {
+		return nil, err
+	}
+
+	commitID, err := meta.FindCallIndex("Anchor.commit")
+	if err != nil {
+		return nil, err
+	}
+
+	eventsKey, err := types.CreateStorageKey(meta, "System", "Events", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nowKey, err := types.CreateStorageKey(meta, "Timestamp", "Now", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lc := &localChain{
+		meta:        meta,
+		preCommitID: preCommitID,
+		commitID:    commitID,
+		eventsKey:   eventsKey,
+		nowKey:      nowKey,
+		accounts:    make(map[string]types.AccountInfo),
+		preCommits:  make(map[types.Hash]types.Hash),
+		anchors:     make(map[types.Hash]localAnchor),
+	}
+
+	// genesis block
+	err = lc.addBlock(nil, nil)
+	return lc, err
+}
+
+// newLocalAccountInfo returns the info of an account seen for the first time.
+func newLocalAccountInfo() types.AccountInfo {
+	var info types.AccountInfo
+	zero := types.NewU128(*big.NewInt(0))
+	info.Data.Free, info.Data.Reserved, info.Data.MiscFrozen, info.Data.FreeFrozen = zero, zero, zero, zero
+	return info
+}
+
+// localChainMetadata returns the metadata of the modules supported by the local chain.
+func localChainMetadata() *types.Metadata {
+	meta := types.NewMetadataV8()
+	meta.AsMetadataV8.Modules = []types.ModuleMetadataV8{
+		{
+			Name:       "System",
+			HasStorage: true,
+			Storage: types.StorageMetadata{
+				Prefix: "System",
+				Items: []types.StorageFunctionMetadataV5{
+					{
+						Name: "Account",
+						Type: types.StorageFunctionTypeV5{
+							IsMap: true,
+							AsMap: types.MapTypeV4{
+								Hasher: types.StorageHasher{IsBlake2_256: true},
+							},
+						},
+					},
+					{
+						Name: "Events",
+						Type: types.StorageFunctionTypeV5{IsType: true},
+					},
+				},
+			},
+			HasEvents: true,
+			Events: []types.EventMetadataV4{
+				{Name: "ExtrinsicSuccess"},
+				{Name: "ExtrinsicFailed"},
+			},
+		},
+		{
+			Name:       "Timestamp",
+			HasStorage: true,
+			Storage: types.StorageMetadata{
+				Prefix: "Timestamp",
+				Items: []types.StorageFunctionMetadataV5{
+					{
+						Name: "Now",
+						Type: types.StorageFunctionTypeV5{IsType: true},
+					},
+				},
+			},
+		},
+		{
+			Name:     "Anchor",
+			HasCalls: true,
+			Calls: []types.FunctionMetadataV4{
+				{Name: "pre_commit"},
+				{Name: "commit"},
+			},
+		},
+	}
+	return meta
+}
+
+func (lc *localChain) GetMetadataLatest() (*types.Metadata, error) {
+	return lc.meta, nil
+}
+
+func (lc *localChain) Call(result interface{}, method string, args ...interface{}) error {
+	var res interface{}
+	var err error
+	switch method {
+	case submitExtrinsicMethod:
+		res, err = lc.submitExtrinsic(args...)
+	case getAnchorByIDMethod:
+		res, err = lc.getAnchorByID(args...)
+	default:
+		return errors.New("method %s is not supported by the local chain", method)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// roundtrip through json the same way an rpc response is loaded
+	d, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(d, result)
+}
+
+func (lc *localChain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	if blockNumber >= uint64(len(lc.blocks)) {
+		return types.Hash{}, ErrBlockNotReady
+	}
+
+	return lc.blocks[blockNumber].hash, nil
+}
+
+func (lc *localChain) GetBlockLatest() (*types.SignedBlock, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	b := lc.blocks[len(lc.blocks)-1].block
+	return &b, nil
+}
+
+func (lc *localChain) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	b, err := lc.findBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := b.block
+	return &sb, nil
+}
+
+func (lc *localChain) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	rv := types.NewRuntimeVersion()
+	rv.SpecName = localChainSpecName
+	rv.ImplName = localChainSpecName
+	rv.SpecVersion = 1
+	return rv, nil
+}
+
+func (lc *localChain) GetClient() client.Client {
+	return localClient{chain: lc}
+}
+
+// GetStorageLatest supports System.Account. Target is left untouched if the key is not found, same as on chain.
+func (lc *localChain) GetStorageLatest(key types.StorageKey, target interface{}) error {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	info, ok := lc.accounts[key.Hex()]
+	if !ok {
+		return nil
+	}
+
+	d, err := types.EncodeToBytes(info)
+	if err != nil {
+		return err
+	}
+
+	return types.DecodeFromBytes(d, target)
+}
+
+// GetStorage supports System.Events and Timestamp.Now of the block.
+func (lc *localChain) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	b, err := lc.findBlock(blockHash)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.Equal(key, lc.eventsKey):
+		return types.DecodeFromBytes(b.events, target)
+	case bytes.Equal(key, lc.nowKey):
+		d, err := types.EncodeToBytes(b.timestamp)
+		if err != nil {
+			return err
+		}
+
+		return types.DecodeFromBytes(d, target)
+	default:
+		return errors.New("storage key %s is not supported by the local chain", key.Hex())
+	}
+}
+
+func (lc *localChain) findBlock(blockHash types.Hash) (localBlock, error) {
+	for _, b := range lc.blocks {
+		if b.hash == blockHash {
+			return b, nil
+		}
+	}
+
+	return localBlock{}, errors.New("block %s not found", blockHash.Hex())
+}
+
+func (lc *localChain) getAnchorByID(args ...interface{}) (interface{}, error) {
+	anchorID, err := hashArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	a, ok := lc.anchors[anchorID]
+	if !ok || a.storedUntil.Before(time.Now()) {
+		return nil, nil
+	}
+
+	return a, nil
+}
+
+func hashArg(args []interface{}) (types.Hash, error) {
+	if len(args) != 1 {
+		return types.Hash{}, errors.New("expected 1 argument but got %d", len(args))
+	}
+
+	switch arg := args[0].(type) {
+	case types.Hash:
+		return arg, nil
+	case string:
+		return types.NewHashFromHexString(arg)
+	default:
+		return types.Hash{}, errors.New("unsupported argument type %T", arg)
+	}
+}
+
+// submitExtrinsic applies the extrinsic in a new block and returns the extrinsic hash.
+func (lc *localChain) submitExtrinsic(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, errors.New("expected 1 argument but got %d", len(args))
+	}
+
+	enc, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("expected hex encoded extrinsic")
+	}
+
+	data, err := types.HexDecodeString(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	var ext types.Extrinsic
+	err = types.DecodeFromBytes(data, &ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ext.IsSigned() {
+		return nil, errors.New("%s: extrinsic is not signed", ErrInvalidTransaction)
+	}
+
+	if ext.Method.CallIndex != lc.preCommitID && ext.Method.CallIndex != lc.commitID {
+		return nil, errors.New("%s: call %v is not supported", ErrInvalidTransaction, ext.Method.CallIndex)
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	accKey, err := types.CreateStorageKey(lc.meta, "System", "Account", ext.Signature.Signer.AsAccountID[:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := lc.accounts[accKey.Hex()]
+	if !ok {
+		info = newLocalAccountInfo()
+	}
+
+	if uint32(ext.Signature.Nonce) != uint32(info.Nonce) {
+		return nil, errors.New("%s: expected nonce %d but got %d", ErrInvalidTransaction, info.Nonce, ext.Signature.Nonce)
+	}
+
+	info.Nonce++
+	lc.accounts[accKey.Hex()] = info
+	bn := uint32(len(lc.blocks))
+	dispatchErr := lc.dispatch(ext.Method, bn)
+	err = lc.addBlock([]types.Extrinsic{ext}, []*types.DispatchError{dispatchErr})
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Hash(blake2b.Sum256(data)).Hex(), nil
+}
+
+// dispatch applies the call on the anchor state of block number bn.
+func (lc *localChain) dispatch(call types.Call, bn uint32) *types.DispatchError {
+	moduleErr := func(e uint8) *types.DispatchError {
+		return &types.DispatchError{HasModule: true, Module: call.CallIndex.SectionIndex, Error: e}
+	}
+
+	if call.CallIndex == lc.preCommitID {
+		var args struct {
+			AnchorID    types.Hash
+			SigningRoot types.Hash
+		}
+
+		if err := types.DecodeFromBytes(call.Args, &args); err != nil {
+			return &types.DispatchError{}
+		}
+
+		if _, ok := lc.anchors[args.AnchorID]; ok {
+			return moduleErr(errAnchorExists)
+		}
+
+		if _, ok := lc.preCommits[args.AnchorID]; ok {
+			return moduleErr(errPreCommitExists)
+		}
+
+		lc.preCommits[args.AnchorID] = args.SigningRoot
+		return nil
+	}
+
+	var args struct {
+		PreImage types.Hash
+		DocRoot  types.Hash
+		Proof    types.Hash
+		// milliseconds since types.Moment doesn't decode the sub second part correctly
+		StoredUntil types.U64
+	}
+
+	if err := types.DecodeFromBytes(call.Args, &args); err != nil {
+		return &types.DispatchError{}
+	}
+
+	anchorID := types.Hash(blake2b.Sum256(args.PreImage[:]))
+	if _, ok := lc.anchors[anchorID]; ok {
+		return moduleErr(errAnchorExists)
+	}
+
+	delete(lc.preCommits, anchorID)
+	lc.anchors[anchorID] = localAnchor{
+		ID:          anchorID,
+		DocRoot:     args.DocRoot,
+		BlockNumber: bn,
+		storedUntil: time.Unix(0, int64(args.StoredUntil)*int64(time.Millisecond)),
+	}
+	return nil
+}
+
+// addBlock adds a new block with the extrinsics and the System events for their results.
+func (lc *localChain) addBlock(exts []types.Extrinsic, results []*types.DispatchError) error {
+	var buf bytes.Buffer
+	enc := scale.NewEncoder(&buf)
+	err := enc.EncodeUintCompact(uint64(len(exts)))
+	if err != nil {
+		return err
+	}
+
+	for i, res := range results {
+		phase := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: uint32(i)}
+		fields := []interface{}{phase, types.EventID{0, 0}}
+		if res != nil {
+			fields = []interface{}{phase, types.EventID{0, 1}, *res}
+		}
+
+		fields = append(fields, types.DispatchInfo{Class: types.DispatchClass{IsNormal: true}, PaysFee: true}, []types.Hash{})
+		for _, f := range fields {
+			err = enc.Encode(f)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	header := types.Header{Number: types.BlockNumber(len(lc.blocks))}
+	if len(lc.blocks) > 0 {
+		header.ParentHash = lc.blocks[len(lc.blocks)-1].hash
+	}
+
+	if exts == nil {
+		exts = []types.Extrinsic{}
+	}
+
+	h, err := types.EncodeToBytes(header)
+	if err != nil {
+		return err
+	}
+
+	lc.blocks = append(lc.blocks, localBlock{
+		hash:      blake2b.Sum256(h),
+		block:     types.SignedBlock{Block: types.Block{Header: header, Extrinsics: exts}},
+		events:    buf.Bytes(),
+		timestamp: types.NewMoment(time.Now().UTC()),
+	})
+	return nil
+}
+
+// localClient routes the rpc calls to the local chain.
+type localClient struct {
+	chain *localChain
+}
+
+func (c localClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.chain.Call(result, method, args...)
+}
+
+func (c localClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string,
+	channel interface{}, args ...interface{}) (*gethrpc.ClientSubscription, error) {
+	return nil, errors.New("subscriptions are not supported by the local chain")
+}
+
+func (c localClient) URL() string {
+	return localChainSpecName
+}
@@ -0,0 +1,124 @@
+// +build unit
+
+package centchain
+
+import (
+	"testing"
+	"time"
+
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+// signedExtrinsic returns the extrinsic with a random signature since the local chain doesn't verify them.
+func signedExtrinsic(c types.Call, signer []byte, nonce uint32) types.Extrinsic {
+	ext := types.NewExtrinsic(c)
+	ext.Signature = types.ExtrinsicSignatureV4{
+		Signer:    types.NewAddressFromAccountID(signer),
+		Signature: types.MultiSignature{IsSr25519: true, AsSr25519: types.NewSignature(utils.RandomSlice(64))},
+		Era:       types.ExtrinsicEra{IsImmortalEra: true},
+		Nonce:     types.UCompact(nonce),
+	}
+	ext.Version |= types.ExtrinsicBitSigned
+	return ext
+}
+
+func TestLocalChain(t *testing.T) {
+	sapi, err := NewLocalSubstrateAPI()
+	assert.NoError(t, err)
+	meta, err := sapi.GetMetadataLatest()
+	assert.NoError(t, err)
+	auth := author.NewAuthor(sapi.GetClient())
+	api := NewAPI(sapi, nil, nil).(*api)
+	task := NewExtrinsicStatusTask(time.Millisecond, 3, nil, sapi.GetBlockHash, sapi.GetBlock, sapi.GetMetadataLatest, sapi.GetStorage)
+	signer := utils.RandomSlice(32)
+
+	// genesis only
+	_, err = sapi.GetBlockHash(0)
+	assert.NoError(t, err)
+	_, err = sapi.GetBlockHash(1)
+	assert.Equal(t, ErrBlockNotReady, err)
+	nonce, err := api.getNonceFromChain(meta, signer)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), nonce)
+
+	// pre commit
+	preImage, signingRoot, docRoot := utils.RandomByte32(), utils.RandomByte32(), utils.RandomByte32()
+	anchorID := types.Hash(blake2b.Sum256(preImage[:]))
+	c, err := types.NewCall(meta, "Anchor.pre_commit", anchorID, types.NewHash(signingRoot[:]))
+	assert.NoError(t, err)
+	ext := signedExtrinsic(c, signer, 0)
+	_, err = auth.SubmitExtrinsic(ext)
+	assert.NoError(t, err)
+	task.fromBlock, task.extSignature = 0, ext.Signature.Signature.AsSr25519
+	_, err = task.processRunTask()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), task.fromBlock)
+
+	// stale nonce
+	_, err = auth.SubmitExtrinsic(signedExtrinsic(c, signer, 0))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidTransaction.Error())
+
+	// commit
+	nonce, err = api.getNonceFromChain(meta, signer)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), nonce)
+	c, err = types.NewCall(meta, "Anchor.commit", types.NewHash(preImage[:]), types.NewHash(docRoot[:]),
+		types.NewHash(utils.RandomSlice(32)), types.NewMoment(time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	ext = signedExtrinsic(c, signer, nonce)
+	txHash, err := auth.SubmitExtrinsic(ext)
+	assert.NoError(t, err)
+	enc, err := types.EncodeToBytes(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash(blake2b.Sum256(enc)), txHash)
+	task.fromBlock, task.extSignature = 1, ext.Signature.Signature.AsSr25519
+	_, err = task.processRunTask()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), task.fromBlock)
+
+	var ad struct {
+		AnchorID     types.Hash `json:"id"`
+		DocumentRoot types.Hash `json:"doc_root"`
+		BlockNumber  uint32     `json:"anchored_block"`
+	}
+	assert.NoError(t, api.Call(&ad, "anchor_getAnchorById", anchorID))
+	assert.Equal(t, anchorID, ad.AnchorID)
+	assert.Equal(t, types.NewHash(docRoot[:]), ad.DocumentRoot)
+	assert.Equal(t, uint32(2), ad.BlockNumber)
+	ts, err := api.GetBlockTimestamp(ad.BlockNumber)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ts, time.Minute)
+	_, err = api.GetBlockTimestamp(10)
+	assert.Equal(t, ErrBlockNotReady, err)
+
+	// anchor already exists
+	ext = signedExtrinsic(c, signer, 2)
+	_, err = auth.SubmitExtrinsic(ext)
+	assert.NoError(t, err)
+	task.fromBlock, task.extSignature = 2, ext.Signature.Signature.AsSr25519
+	_, err = task.processRunTask()
+	assert.Error(t, err)
+
+	// missing anchor
+	ad.BlockNumber = 0
+	assert.NoError(t, api.Call(&ad, "anchor_getAnchorById", types.NewHash(utils.RandomSlice(32))))
+	assert.Equal(t, uint32(0), ad.BlockNumber)
+
+	// unsupported method
+	assert.Error(t, api.Call(&ad, "chain_getFinalizedHead"))
+}
+
+func TestNewSubstrateAPI_inProcess(t *testing.T) {
+	c := new(testingconfig.MockConfig)
+	c.On("GetCentChainInProcess").Return(true).Once()
+	sapi, err := newSubstrateAPI(c)
+	assert.NoError(t, err)
+	assert.IsType(t, &localChain{}, sapi)
+	c.AssertExpectations(t)
+}
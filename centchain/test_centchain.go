@@ -4,7 +4,9 @@ package centchain
 
 import (
 	"context"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -95,6 +97,20 @@ func (m *MockAPI) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c ty
 	return nil
 }
 
+func (m *MockAPI) Call(result interface{}, method string, args ...interface{}) error {
+	margs := m.Called(method, args)
+	if res := margs.Get(0); res != nil {
+		reflect.ValueOf(result).Elem().Set(reflect.ValueOf(res))
+	}
+	return margs.Error(1)
+}
+
+func (m *MockAPI) GetBlockTimestamp(blockNumber uint32) (time.Time, error) {
+	args := m.Called(blockNumber)
+	ts, _ := args.Get(0).(time.Time)
+	return ts, args.Error(1)
+}
+
 func MetaDataWithCall(call string) *types.Metadata {
 	data := strings.Split(call, ".")
 	meta := types.NewMetadataV8()
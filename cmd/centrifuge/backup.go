@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+
+	"github.com/centrifuge/go-centrifuge/backup"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/spf13/cobra"
+)
+
+// passphraseEnv is the env variable the snapshot passphrase is read from if the flag is not set.
+const passphraseEnv = "CENT_BACKUP_PASSPHRASE"
+
+func init() {
+
+	//specific param
+	var outputParam string
+	var keyFileParam string
+	var passphraseParam string
+
+	var backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "Takes a snapshot of the node storage",
+		Long: "writes a compressed snapshot of the document and config DBs of the node. " +
+			"Node can keep running while the snapshot is taken. " +
+			"Snapshot is encrypted if a key file or a passphrase is provided.",
+		Run: func(c *cobra.Command, args []string) {
+			cfg := config.LoadConfiguration(ensureConfigFile())
+			f, err := os.OpenFile(outputParam, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			m, err := backup.Create(cfg, f, secret(keyFileParam, passphraseParam))
+			if err == nil {
+				err = f.Close()
+			}
+			if err != nil {
+				_ = f.Close()
+				_ = os.Remove(outputParam)
+				log.Fatal(err)
+			}
+
+			log.Infof("Snapshot of node %s on %s written to %s", m.NodeVersion, m.Network, outputParam)
+		},
+	}
+
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.Flags().StringVarP(&outputParam, "output", "o", "", "path to write the snapshot to")
+	backupCmd.Flags().StringVar(&keyFileParam, "keyfile", "", "path to the hex encoded 32 byte key to encrypt the snapshot with")
+	backupCmd.Flags().StringVar(&passphraseParam, "passphrase", "", "passphrase to encrypt the snapshot with. Read from "+passphraseEnv+" if not set")
+	_ = backupCmd.MarkFlagRequired("output")
+}
+
+// secret returns the snapshot secret from the flags, or the passphrase from the env.
+func secret(keyFile, passphrase string) backup.Secret {
+	if keyFile == "" && passphrase == "" {
+		passphrase = os.Getenv(passphraseEnv)
+	}
+
+	return backup.Secret{KeyFile: keyFile, Passphrase: passphrase}
+}
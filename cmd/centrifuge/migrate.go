@@ -26,5 +26,5 @@ func init() {
 func doMigrate() error {
 	cfg := config.LoadConfiguration(cfgFile)
 	runner := migration.NewMigrationRunner()
-	return runner.RunMigrations(cfg.GetStoragePath())
+	return runner.RunMigrations(cfg.GetStorageBackend(), cfg.GetStoragePath(), cfg)
 }
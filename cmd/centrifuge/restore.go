@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+
+	"github.com/centrifuge/go-centrifuge/backup"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+
+	//specific param
+	var inputParam string
+	var keyFileParam string
+	var passphraseParam string
+
+	var restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Restores the node storage from a snapshot",
+		Long: "restores the document and config DBs of the node from a snapshot taken with the backup command. " +
+			"Node must be stopped and the DBs must not exist. " +
+			"Snapshots of another network, major version or with unknown migrations are refused.",
+		Run: func(c *cobra.Command, args []string) {
+			cfg := config.LoadConfiguration(ensureConfigFile())
+			f, err := os.Open(inputParam)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+
+			m, err := backup.Restore(cfg, f, secret(keyFileParam, passphraseParam))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Infof("Snapshot of node %s taken at %s restored", m.NodeVersion, m.CreatedAt)
+		},
+	}
+
+	rootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().StringVarP(&inputParam, "input", "i", "", "path to the snapshot")
+	restoreCmd.Flags().StringVar(&keyFileParam, "keyfile", "", "path to the key the snapshot is encrypted with")
+	restoreCmd.Flags().StringVar(&passphraseParam, "passphrase", "", "passphrase the snapshot is encrypted with. Read from "+passphraseEnv+" if not set")
+	_ = restoreCmd.MarkFlagRequired("input")
+}
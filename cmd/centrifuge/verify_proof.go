@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/proofwire"
+	"github.com/centrifuge/go-centrifuge/verifier"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+
+	//specific param
+	var proofFileParam string
+	var documentRootParam string
+	var bundleFileParam string
+	var checkAnchorParam bool
+	var chainURLParam string
+
+	var verifyProofCmd = &cobra.Command{
+		Use:   "verifyproof",
+		Short: "verify the field proofs of a document against the anchored document root",
+		Long: "verifies the proofs returned by the proofs endpoint of a node. " +
+			"Document root is fetched from the Centrifuge Chain if it is not provided. " +
+			"Proofs generated from the zk tree are verified along with their circuit proofs. " +
+			"Proof bundles are verified against the document root in the bundle unless the anchor check is requested. " +
+			"Signing keys of the bundle are reported as unverified since the signers' identities are not read.",
+		Run: func(cm *cobra.Command, args []string) {
+			if bundleFileParam != "" {
+				verifyBundle(bundleFileParam, checkAnchorParam, chainURLParam)
+				return
+			}
+
+			data, err := ioutil.ReadFile(proofFileParam)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var proof proofwire.ProofsResponse
+			err = json.Unmarshal(data, &proof)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if documentRootParam != "" {
+				docRoot, derr := hexutil.Decode(documentRootParam)
+				if derr != nil {
+					log.Fatal(derr)
+				}
+
+				err = verifier.Verify(proof, docRoot)
+			} else {
+				err = verifier.VerifyAnchored(proof, newChainReader(chainURLParam))
+			}
+
+			if err != nil {
+				fmt.Printf("proofs of version %s are invalid: %v\n", proof.Header.VersionID.String(), err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("proofs of version %s are valid\n", proof.Header.VersionID.String())
+		},
+	}
+
+	rootCmd.AddCommand(verifyProofCmd)
+	verifyProofCmd.Flags().StringVarP(&proofFileParam, "proof", "p", "", "path to the proofs JSON")
+	verifyProofCmd.Flags().StringVarP(&documentRootParam, "root", "r", "", "anchored document root. Fetched from the Centrifuge Chain if not provided")
+	verifyProofCmd.Flags().StringVarP(&bundleFileParam, "bundle", "b", "", "path to the proof bundle JSON")
+	verifyProofCmd.Flags().BoolVar(&checkAnchorParam, "check-anchor", false, "check the document root of the bundle against the Centrifuge Chain")
+	verifyProofCmd.Flags().StringVar(&chainURLParam, "chain-url", "", "url of the Centrifuge Chain node. Read from the config if not provided")
+}
+
+// newChainReader connects to the Centrifuge Chain node at the url or, if empty, at the node url of the config.
+// Only the chain is reached. Node is not bootstrapped.
+func newChainReader(url string) verifier.AnchorReader {
+	if url == "" {
+		url = config.LoadConfiguration(ensureConfigFile()).GetCentChainNodeURL()
+	}
+
+	reader, err := verifier.NewChainReader(url)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return reader
+}
+
+func verifyBundle(path string, checkAnchor bool, chainURL string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var bundle proofwire.ProofBundle
+	err = json.Unmarshal(data, &bundle)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var reader verifier.AnchorReader
+	if checkAnchor {
+		reader = newChainReader(chainURL)
+	}
+
+	// signing keys are not checked since that requires the identities on Ethereum
+	res, err := verifier.VerifyBundle(bundle, reader, nil)
+	if err != nil {
+		fmt.Printf("proof bundle of version %s is invalid: %v\n", bundle.Proofs.Header.VersionID.String(), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("proof bundle of version %s is valid\n", bundle.Proofs.Header.VersionID.String())
+	if !res.Anchored {
+		fmt.Println("UNANCHORED: document root of the bundle was not checked against the chain. Use --check-anchor to check it")
+	}
+
+	if !res.KeysChecked {
+		fmt.Println("UNVERIFIED KEYS: signing keys were not checked against the signers' identities")
+	}
+
+	if !res.EndToEnd() {
+		fmt.Println("proof bundle is not verified end to end")
+	}
+}
@@ -54,11 +54,19 @@ type NodeConfig struct {
 	BootstrapPeers                 []string
 	NetworkID                      uint32
 	SmartContractAddresses         map[config.ContractName]common.Address
+	AnchorBackend                  string
 	SmartContractBytecode          map[config.ContractName]string
 	PprofEnabled                   bool
 	LowEntropyNFTTokenEnabled      bool
+	SignaturePolicies              map[string]string
+	AnchorBatchEnabled             bool
+	AnchorBatchSize                int
+	AnchorBatchInterval            time.Duration
+	AnchorExpiryWarning            time.Duration
+	AnchorRenewalEnabled           bool
 	DebugLogEnabled                bool
 	CentChainNodeURL               string
+	CentChainInProcess             bool
 	CentChainIntervalRetry         time.Duration
 	CentChainMaxRetries            int
 	CentChainAnchorLifespan        time.Duration
@@ -119,6 +127,36 @@ func (nc *NodeConfig) GetConfigStoragePath() string {
 	panic("irrelevant, NodeConfig#GetConfigStoragePath must not be used")
 }
 
+// GetStorageBackend refer the interface
+func (nc *NodeConfig) GetStorageBackend() string {
+	panic("irrelevant, NodeConfig#GetStorageBackend must not be used")
+}
+
+// IsStorageEncryptionEnabled refer the interface
+func (nc *NodeConfig) IsStorageEncryptionEnabled() bool {
+	panic("irrelevant, NodeConfig#IsStorageEncryptionEnabled must not be used")
+}
+
+// GetStorageEncryptionKeyFile refer the interface
+func (nc *NodeConfig) GetStorageEncryptionKeyFile() string {
+	panic("irrelevant, NodeConfig#GetStorageEncryptionKeyFile must not be used")
+}
+
+// GetStorageEncryptionPassphrase refer the interface
+func (nc *NodeConfig) GetStorageEncryptionPassphrase() string {
+	panic("irrelevant, NodeConfig#GetStorageEncryptionPassphrase must not be used")
+}
+
+// GetStorageEncryptionPreviousKeyFile refer the interface
+func (nc *NodeConfig) GetStorageEncryptionPreviousKeyFile() string {
+	panic("irrelevant, NodeConfig#GetStorageEncryptionPreviousKeyFile must not be used")
+}
+
+// GetStorageEncryptionPreviousPassphrase refer the interface
+func (nc *NodeConfig) GetStorageEncryptionPreviousPassphrase() string {
+	panic("irrelevant, NodeConfig#GetStorageEncryptionPreviousPassphrase must not be used")
+}
+
 // GetAccountsKeystore returns the accounts keystore path.
 func (nc *NodeConfig) GetAccountsKeystore() string {
 	return nc.AccountsKeystore
@@ -229,6 +267,11 @@ func (nc *NodeConfig) GetBootstrapPeers() []string {
 	return nc.BootstrapPeers
 }
 
+// GetAnchorBackend refer the interface
+func (nc *NodeConfig) GetAnchorBackend() string {
+	return nc.AnchorBackend
+}
+
 // GetNetworkID refer the interface
 func (nc *NodeConfig) GetNetworkID() uint32 {
 	return nc.NetworkID
@@ -249,6 +292,12 @@ func (nc *NodeConfig) GetCentChainNodeURL() string {
 	return nc.CentChainNodeURL
 }
 
+// GetCentChainInProcess returns true if the node should run against an in-process Centrifuge Chain.
+// Ethereum is not affected and is still reached at the configured node url.
+func (nc *NodeConfig) GetCentChainInProcess() bool {
+	return nc.CentChainInProcess
+}
+
 // GetCentChainIntervalRetry returns duration to wait between retries.
 func (nc *NodeConfig) GetCentChainIntervalRetry() time.Duration {
 	return nc.CentChainIntervalRetry
@@ -294,6 +343,36 @@ func (nc *NodeConfig) GetPrecommitEnabled() bool {
 	return nc.MainIdentity.PrecommitEnabled
 }
 
+// GetSignaturePolicies refer the interface
+func (nc *NodeConfig) GetSignaturePolicies() map[string]string {
+	return nc.SignaturePolicies
+}
+
+// GetAnchorBatchEnabled refer the interface
+func (nc *NodeConfig) GetAnchorBatchEnabled() bool {
+	return nc.AnchorBatchEnabled
+}
+
+// GetAnchorBatchSize refer the interface
+func (nc *NodeConfig) GetAnchorBatchSize() int {
+	return nc.AnchorBatchSize
+}
+
+// GetAnchorBatchInterval refer the interface
+func (nc *NodeConfig) GetAnchorBatchInterval() time.Duration {
+	return nc.AnchorBatchInterval
+}
+
+// GetAnchorExpiryWarning refer the interface
+func (nc *NodeConfig) GetAnchorExpiryWarning() time.Duration {
+	return nc.AnchorExpiryWarning
+}
+
+// GetAnchorRenewalEnabled refer the interface
+func (nc *NodeConfig) GetAnchorRenewalEnabled() bool {
+	return nc.AnchorRenewalEnabled
+}
+
 // GetLowEntropyNFTTokenEnabled refer the interface
 func (nc *NodeConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return nc.LowEntropyNFTTokenEnabled
@@ -379,13 +458,21 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		BootstrapPeers:                 c.GetBootstrapPeers(),
 		NetworkID:                      c.GetNetworkID(),
 		SmartContractAddresses:         extractSmartContractAddresses(c),
+		AnchorBackend:                  c.GetAnchorBackend(),
 		PprofEnabled:                   c.IsPProfEnabled(),
 		DebugLogEnabled:                c.IsDebugLogEnabled(),
 		LowEntropyNFTTokenEnabled:      c.GetLowEntropyNFTTokenEnabled(),
+		SignaturePolicies:              c.GetSignaturePolicies(),
+		AnchorBatchEnabled:             c.GetAnchorBatchEnabled(),
+		AnchorBatchSize:                c.GetAnchorBatchSize(),
+		AnchorBatchInterval:            c.GetAnchorBatchInterval(),
+		AnchorExpiryWarning:            c.GetAnchorExpiryWarning(),
+		AnchorRenewalEnabled:           c.GetAnchorRenewalEnabled(),
 		CentChainMaxRetries:            c.GetCentChainMaxRetries(),
 		CentChainIntervalRetry:         c.GetCentChainIntervalRetry(),
 		CentChainAnchorLifespan:        c.GetCentChainAnchorLifespan(),
 		CentChainNodeURL:               c.GetCentChainNodeURL(),
+		CentChainInProcess:             c.GetCentChainInProcess(),
 	}
 }
 
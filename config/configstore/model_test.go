@@ -31,6 +31,36 @@ func (m *mockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *mockConfig) GetSignaturePolicies() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *mockConfig) GetAnchorBatchEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *mockConfig) GetAnchorBatchSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetAnchorBatchInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetAnchorExpiryWarning() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetAnchorRenewalEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func (m *mockConfig) GetPrecommitEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
@@ -228,6 +258,11 @@ func (m *mockConfig) GetBootstrapPeers() []string {
 	return args.Get(0).([]string)
 }
 
+func (m *mockConfig) GetAnchorBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *mockConfig) GetNetworkID() uint32 {
 	args := m.Called()
 	return args.Get(0).(uint32)
@@ -273,6 +308,11 @@ func (m *mockConfig) GetCentChainNodeURL() string {
 	return args.Get(0).(string)
 }
 
+func (m *mockConfig) GetCentChainInProcess() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func (m *mockConfig) GetTaskValidDuration() time.Duration {
 	args := m.Called()
 	return args.Get(0).(time.Duration)
@@ -329,15 +369,23 @@ func createMockConfig() *mockConfig {
 	c.On("GetNetworkString").Return("somehill").Once()
 	c.On("GetBootstrapPeers").Return([]string{"p1", "p2"}).Once()
 	c.On("GetNetworkID").Return(uint32(1)).Once()
+	c.On("GetAnchorBackend").Return("centchain").Once()
 	c.On("GetContractAddress", mock.Anything).Return(common.Address{})
 	c.On("IsPProfEnabled", mock.Anything).Return(true)
 	c.On("IsDebugLogEnabled", mock.Anything).Return(true)
 	c.On("GetLowEntropyNFTTokenEnabled", mock.Anything).Return(true)
+	c.On("GetSignaturePolicies").Return(map[string]string{"generic": "threshold:2"}).Once()
+	c.On("GetAnchorBatchEnabled").Return(true).Once()
+	c.On("GetAnchorBatchSize").Return(10).Once()
+	c.On("GetAnchorBatchInterval").Return(time.Second).Once()
+	c.On("GetAnchorExpiryWarning").Return(time.Hour).Once()
+	c.On("GetAnchorRenewalEnabled").Return(true).Once()
 	c.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
 	c.On("GetCentChainIntervalRetry").Return(time.Second).Once()
 	c.On("GetCentChainAnchorLifespan").Return(time.Second).Once()
 	c.On("GetCentChainMaxRetries").Return(1).Once()
 	c.On("GetCentChainNodeURL").Return("dummyNode").Once()
+	c.On("GetCentChainInProcess").Return(false).Once()
 	c.On("GetTaskValidDuration").Return(time.Minute).Once()
 	return c
 }
@@ -103,14 +103,16 @@ func (r *repo) GetConfig() (config.Configuration, error) {
 // If an error occur reading a account, throws a warning and continue
 func (r *repo) GetAllAccounts() ([]config.Account, error) {
 	var accountConfigs []config.Account
-	models, err := r.db.GetAllByPrefix(accountPrefix)
-	if err != nil {
-		return nil, err
-	}
-	for _, acc := range models {
-		accountConfigs = append(accountConfigs, acc.(*Account))
+	iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(accountPrefix)})
+	defer iter.Release()
+	for iter.Next() {
+		acc, ok := iter.Model().(*Account)
+		if !ok {
+			continue
+		}
+		accountConfigs = append(accountConfigs, acc)
 	}
-	return accountConfigs, nil
+	return accountConfigs, iter.Error()
 }
 
 // Create creates the account model if not present in the DB.
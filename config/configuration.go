@@ -115,8 +115,14 @@ type Configuration interface {
 	GetInt(key string) int
 	GetDuration(key string) time.Duration
 
+	GetStorageBackend() string
 	GetStoragePath() string
 	GetConfigStoragePath() string
+	IsStorageEncryptionEnabled() bool
+	GetStorageEncryptionKeyFile() string
+	GetStorageEncryptionPassphrase() string
+	GetStorageEncryptionPreviousKeyFile() string
+	GetStorageEncryptionPreviousPassphrase() string
 	GetAccountsKeystore() string
 	GetP2PPort() int
 	GetP2PExternalIP() string
@@ -140,6 +146,7 @@ type Configuration interface {
 	GetContractAddress(contractName ContractName) common.Address
 	GetBootstrapPeers() []string
 	GetNetworkID() uint32
+	GetAnchorBackend() string
 
 	// CentID specific configs (eg: for multi tenancy)
 	GetEthereumAccount(accountName string) (account *AccountConfig, err error)
@@ -150,6 +157,24 @@ type Configuration interface {
 	GetSigningKeyPair() (pub, priv string)
 	GetPrecommitEnabled() bool
 
+	// GetSignaturePolicies returns the default signature policies of the documents keyed by the document scheme.
+	GetSignaturePolicies() map[string]string
+
+	// GetAnchorBatchEnabled returns true if the document roots are anchored in batches.
+	GetAnchorBatchEnabled() bool
+
+	// GetAnchorBatchSize returns the maximum number of document roots anchored in a single batch.
+	GetAnchorBatchSize() int
+
+	// GetAnchorBatchInterval returns the maximum time a document root waits for its batch to be anchored.
+	GetAnchorBatchInterval() time.Duration
+
+	// GetAnchorExpiryWarning returns the time before the expiry of an anchor at which the account is warned.
+	GetAnchorExpiryWarning() time.Duration
+
+	// GetAnchorRenewalEnabled returns true if the expiring anchors of the latest document versions are renewed.
+	GetAnchorRenewalEnabled() bool
+
 	// GetLowEntropyNFTTokenEnabled enables low entropy token IDs.
 	// The Dharma NFT Collateralizer and other contracts require tokenIds that are shorter than
 	// the ERC721 standard bytes32. This option reduces the maximum value of the tokenId.
@@ -167,6 +192,7 @@ type Configuration interface {
 	GetCentChainIntervalRetry() time.Duration
 	GetCentChainMaxRetries() int
 	GetCentChainNodeURL() string
+	GetCentChainInProcess() bool
 	GetCentChainAnchorLifespan() time.Duration
 }
 
@@ -300,6 +326,11 @@ func (c *configuration) get(key string) interface{} {
 	return c.v.Get(key)
 }
 
+// GetStorageBackend returns the backend of the data and config storage.
+func (c *configuration) GetStorageBackend() string {
+	return c.GetString("storage.backend")
+}
+
 // GetStoragePath returns the data storage backend.
 func (c *configuration) GetStoragePath() string {
 	return c.GetString("storage.path")
@@ -310,6 +341,31 @@ func (c *configuration) GetConfigStoragePath() string {
 	return c.GetString("configStorage.path")
 }
 
+// IsStorageEncryptionEnabled returns true if the values of the data and config storage are encrypted at rest.
+func (c *configuration) IsStorageEncryptionEnabled() bool {
+	return c.GetBool("storage.encryption.enabled")
+}
+
+// GetStorageEncryptionKeyFile returns the path to the file holding the hex encoded master key of the storage.
+func (c *configuration) GetStorageEncryptionKeyFile() string {
+	return c.GetString("storage.encryption.keyFile")
+}
+
+// GetStorageEncryptionPassphrase returns the passphrase the master key of the storage is derived from.
+func (c *configuration) GetStorageEncryptionPassphrase() string {
+	return c.GetString("storage.encryption.passphrase")
+}
+
+// GetStorageEncryptionPreviousKeyFile returns the path to the file holding the master key being rotated out.
+func (c *configuration) GetStorageEncryptionPreviousKeyFile() string {
+	return c.GetString("storage.encryption.previous.keyFile")
+}
+
+// GetStorageEncryptionPreviousPassphrase returns the passphrase of the master key being rotated out.
+func (c *configuration) GetStorageEncryptionPreviousPassphrase() string {
+	return c.GetString("storage.encryption.previous.passphrase")
+}
+
 // GetAccountsKeystore returns the accounts keystore location.
 func (c *configuration) GetAccountsKeystore() string {
 	return c.GetString("accounts.keystore")
@@ -448,6 +504,12 @@ func (c *configuration) GetCentChainNodeURL() string {
 	return c.GetString("centChain.nodeURL")
 }
 
+// GetCentChainInProcess returns true if the node should run against an in-process Centrifuge Chain.
+// Ethereum is not affected and is still reached at the configured node url.
+func (c *configuration) GetCentChainInProcess() bool {
+	return c.GetBool("centChain.inProcess")
+}
+
 // GetCentChainIntervalRetry returns duration to wait between retries.
 func (c *configuration) GetCentChainIntervalRetry() time.Duration {
 	return c.GetDuration("centChain.intervalRetry")
@@ -493,6 +555,11 @@ func (c *configuration) GetNetworkID() uint32 {
 	return uint32(c.GetInt(c.GetNetworkKey("id")))
 }
 
+// GetAnchorBackend returns the anchoring backend used by the network.
+func (c *configuration) GetAnchorBackend() string {
+	return c.GetString(c.GetNetworkKey("anchorBackend"))
+}
+
 // GetIdentityID returns the self centID in bytes.
 func (c *configuration) GetIdentityID() ([]byte, error) {
 	id, err := hexutil.Decode(c.GetString("identityId"))
@@ -527,6 +594,38 @@ func (c *configuration) GetPrecommitEnabled() bool {
 	return c.GetBool("anchoring.precommit")
 }
 
+// GetSignaturePolicies returns the default signature policies of the documents keyed by the document scheme.
+func (c *configuration) GetSignaturePolicies() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.v.GetStringMapString("anchoring.signaturePolicies")
+}
+
+// GetAnchorBatchEnabled returns true if the document roots are anchored in batches.
+func (c *configuration) GetAnchorBatchEnabled() bool {
+	return c.GetBool("anchoring.batch.enabled")
+}
+
+// GetAnchorBatchSize returns the maximum number of document roots anchored in a single batch.
+func (c *configuration) GetAnchorBatchSize() int {
+	return c.GetInt("anchoring.batch.size")
+}
+
+// GetAnchorBatchInterval returns the maximum time a document root waits for its batch to be anchored.
+func (c *configuration) GetAnchorBatchInterval() time.Duration {
+	return c.GetDuration("anchoring.batch.interval")
+}
+
+// GetAnchorExpiryWarning returns the time before the expiry of an anchor at which the account is warned.
+func (c *configuration) GetAnchorExpiryWarning() time.Duration {
+	return c.GetDuration("anchoring.expiry.warning")
+}
+
+// GetAnchorRenewalEnabled returns true if the expiring anchors of the latest document versions are renewed.
+func (c *configuration) GetAnchorRenewalEnabled() bool {
+	return c.GetBool("anchoring.expiry.renew")
+}
+
 // GetLowEntropyNFTTokenEnabled returns true if low entropy nft token IDs are not enabled
 func (c *configuration) GetLowEntropyNFTTokenEnabled() bool {
 	return c.GetBool("nft.lowEntropyTokenIDEnabled")
@@ -0,0 +1,42 @@
+package disclosure
+
+import (
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// BootstrappedDisclosureService is the key to the disclosure service in the bootstrap context.
+const BootstrappedDisclosureService = "BootstrappedDisclosureService"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap registers the redacted documents with the DB and adds the disclosure service to the context.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	docSrv, ok := ctx[documents.BootstrappedDocumentService].(documents.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", documents.BootstrappedDocumentService)
+	}
+
+	anchorSrv, ok := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", anchors.BootstrappedAnchorService)
+	}
+
+	idSrv, ok := ctx[identity.BootstrappedDIDService].(identity.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", identity.BootstrappedDIDService)
+	}
+
+	db, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
+	}
+
+	db.Register(new(RedactedDocument))
+	ctx[BootstrappedDisclosureService] = NewService(docSrv, anchorSrv, idSrv, db)
+	return nil
+}
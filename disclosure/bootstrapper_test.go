@@ -0,0 +1,52 @@
+// +build unit
+
+package disclosure
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	ctx := make(map[string]interface{})
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	b := Bootstrapper{}
+
+	// missing document service
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.BootstrappedDocumentService)
+
+	// missing anchor service
+	ctx[documents.BootstrappedDocumentService] = new(testingdocuments.MockService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), anchors.BootstrappedAnchorService)
+
+	// missing identity service
+	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), identity.BootstrappedDIDService)
+
+	// missing db
+	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), storage.BootstrappedDB)
+
+	// success
+	ctx[storage.BootstrappedDB] = leveldb.NewLevelDBRepository(db)
+	assert.NoError(t, b.Bootstrap(ctx))
+	assert.NotNil(t, ctx[BootstrappedDisclosureService])
+}
@@ -0,0 +1,103 @@
+// Package disclosure exports selected fields of an anchored document as a redacted document and imports
+// redacted documents disclosed by other identities.
+// Each disclosed value carries its proof against the anchored document root so that the importing node
+// can verify the disclosure without read access to the full document.
+package disclosure
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+)
+
+const (
+	// ErrNoFields is a sentinel error when no fields are disclosed.
+	ErrNoFields = errors.Error("no fields to disclose")
+
+	// ErrInvalidDisclosure is a sentinel error when the redacted document fails verification.
+	ErrInvalidDisclosure = errors.Error("invalid disclosure")
+
+	// ErrDisclosureNotFound is a sentinel error when the imported redacted document is not found.
+	ErrDisclosureNotFound = errors.Error("disclosure not found")
+)
+
+// Field is a disclosed field of the document.
+// Property is the compact property of the field in the document trees.
+type Field struct {
+	Name     string             `json:"name"`
+	Property byteutils.HexBytes `json:"property" swaggertype:"primitive,string"`
+	Value    byteutils.HexBytes `json:"value" swaggertype:"primitive,string"`
+}
+
+// RedactedDocument discloses a subset of the fields of an anchored document version.
+// Bundle holds the proofs of the fields along with the document signatures and anchor details.
+// Discloser signs the field names against their properties since the proofs only commit to the properties.
+type RedactedDocument struct {
+	Scheme      string              `json:"scheme"`
+	Fields      []Field             `json:"fields"`
+	Bundle      coreapi.ProofBundle `json:"bundle"`
+	Discloser   identity.DID        `json:"discloser" swaggertype:"primitive,string"`
+	PublicKey   byteutils.HexBytes  `json:"public_key" swaggertype:"primitive,string"`
+	Signature   byteutils.HexBytes  `json:"signature" swaggertype:"primitive,string"`
+	DisclosedAt time.Time           `json:"disclosed_at" swaggertype:"primitive,string"`
+}
+
+// JSON marshals RedactedDocument to json bytes.
+func (r *RedactedDocument) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Type returns the type of RedactedDocument.
+func (r *RedactedDocument) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// FromJSON loads json bytes to RedactedDocument.
+func (r *RedactedDocument) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// signingPayload returns the payload signed by the discloser.
+func (r *RedactedDocument) signingPayload() ([]byte, error) {
+	return json.Marshal(struct {
+		VersionID   byteutils.HexBytes `json:"version_id"`
+		Scheme      string             `json:"scheme"`
+		Fields      []Field            `json:"fields"`
+		DisclosedAt int64              `json:"disclosed_at"`
+	}{
+		VersionID:   r.Bundle.Proofs.Header.VersionID,
+		Scheme:      r.Scheme,
+		Fields:      r.Fields,
+		DisclosedAt: r.DisclosedAt.Unix(),
+	})
+}
+
+// verifyFields checks that every disclosed field is proven in the bundle.
+// The proofs themselves are verified with the bundle.
+func (r *RedactedDocument) verifyFields() error {
+	if len(r.Fields) < 1 {
+		return ErrNoFields
+	}
+
+	for _, f := range r.Fields {
+		found := false
+		for _, fp := range r.Bundle.Proofs.FieldProofs {
+			if bytes.Equal(fp.Property, f.Property) && bytes.Equal(fp.Value, f.Value) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return errors.New("field %s is not proven", f.Name)
+		}
+	}
+
+	return nil
+}
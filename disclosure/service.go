@@ -0,0 +1,197 @@
+package disclosure
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/verifier"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// prefix is the DB key prefix of the imported redacted documents.
+const prefix = "disclosure_"
+
+// Service exports and imports redacted documents.
+type Service interface {
+	// Export creates the redacted document of the version disclosing the fields.
+	// Latest version is used if the version is empty.
+	Export(ctx context.Context, docID, versionID []byte, fields []string) (*RedactedDocument, error)
+
+	// Import verifies the redacted document against the anchors and identities and stores it for the account.
+	Import(ctx context.Context, doc RedactedDocument) (*RedactedDocument, error)
+
+	// Get returns the redacted document version imported by the account.
+	Get(ctx context.Context, versionID []byte) (*RedactedDocument, error)
+}
+
+// NewService returns the default implementation of the disclosure Service.
+func NewService(docSrv documents.Service, anchorSrv anchors.Service, idSrv identity.Service, db storage.Repository) Service {
+	return service{
+		docSrv:    docSrv,
+		anchorSrv: anchorSrv,
+		idSrv:     idSrv,
+		db:        db,
+	}
+}
+
+type service struct {
+	docSrv    documents.Service
+	anchorSrv anchors.Service
+	idSrv     identity.Service
+	db        storage.Repository
+}
+
+// getKey returns disclosure_+accountID+versionID
+func getKey(accountID, versionID []byte) []byte {
+	return append([]byte(prefix), []byte(hexutil.Encode(append(accountID, versionID...)))...)
+}
+
+func (s service) Export(ctx context.Context, docID, versionID []byte, fields []string) (*RedactedDocument, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, documents.ErrDocumentConfigAccountID
+	}
+
+	if len(fields) < 1 {
+		return nil, ErrNoFields
+	}
+
+	var model documents.Model
+	if utils.IsEmptyByteSlice(versionID) {
+		model, err = s.docSrv.GetCurrentVersion(ctx, docID)
+	} else {
+		model, err = s.docSrv.GetVersion(ctx, docID, versionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.docSrv.CreateProofBundle(ctx, model.ID(), model.CurrentVersion(), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// proofs are in the order of the fields followed by the signature proofs
+	doc := &RedactedDocument{
+		Scheme:      model.Scheme(),
+		Bundle:      coreapi.ToProofBundle(bundle),
+		DisclosedAt: time.Now().UTC(),
+	}
+
+	for i, f := range fields {
+		fp := bundle.Proof.FieldProofs[i]
+		doc.Fields = append(doc.Fields, Field{
+			Name:     f,
+			Property: fp.GetCompactName(),
+			Value:    fp.Value,
+		})
+	}
+
+	payload, err := doc.signingPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := acc.SignMsg(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Discloser, err = identity.NewDIDFromBytes(sig.SignerId)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.PublicKey = sig.PublicKey
+	doc.Signature = sig.Signature
+	return doc, nil
+}
+
+func (s service) Import(ctx context.Context, doc RedactedDocument) (*RedactedDocument, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, documents.ErrDocumentConfigAccountID
+	}
+
+	err = s.verify(ctx, &doc)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidDisclosure, err)
+	}
+
+	key := getKey(acc.GetIdentityID(), doc.Bundle.Proofs.Header.VersionID)
+	if s.db.Exists(key) {
+		err = s.db.Update(key, &doc)
+	} else {
+		err = s.db.Create(key, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// verify verifies the discloser's signature, the validity of the document signers' keys at the anchoring time
+// and the proof bundle against the anchored document root.
+func (s service) verify(ctx context.Context, doc *RedactedDocument) error {
+	err := doc.verifyFields()
+	if err != nil {
+		return err
+	}
+
+	payload, err := doc.signingPayload()
+	if err != nil {
+		return err
+	}
+
+	err = s.idSrv.ValidateSignature(doc.Discloser, doc.PublicKey, doc.Signature, payload, doc.DisclosedAt)
+	if err != nil {
+		return errors.New("failed to validate the discloser's signature: %v", err)
+	}
+
+	checker := verifier.KeyCheckerFunc(func(signerID, publicKey []byte, anchoredAt time.Time) error {
+		did, err := identity.NewDIDFromBytes(signerID)
+		if err != nil {
+			return err
+		}
+
+		return s.idSrv.ValidateKey(ctx, did, publicKey, &(identity.KeyPurposeSigning.Value), &anchoredAt)
+	})
+
+	// key validity is checked against the signers' identities instead of relying on the discloser
+	_, err = verifier.VerifyBundle(doc.Bundle, verifier.AnchorReaderFunc(s.getAnchoredRoot), checker)
+	return err
+}
+
+func (s service) Get(ctx context.Context, versionID []byte) (*RedactedDocument, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, documents.ErrDocumentConfigAccountID
+	}
+
+	m, err := s.db.Get(getKey(acc.GetIdentityID(), versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDisclosureNotFound, err)
+	}
+
+	doc, ok := m.(*RedactedDocument)
+	if !ok {
+		return nil, errors.NewTypedError(ErrDisclosureNotFound, errors.New("disclosure %s is of invalid type", hexutil.Encode(versionID)))
+	}
+
+	return doc, nil
+}
+
+// getAnchoredRoot returns the document root anchored against the anchor ID.
+func (s service) getAnchoredRoot(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error) {
+	docRoot, _, blockNumber, err = s.anchorSrv.GetAnchorData(anchorID)
+	return docRoot, blockNumber, err
+}
@@ -0,0 +1,196 @@
+// +build unit
+
+package disclosure
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	cfg config.Configuration
+	did = testingidentity.GenerateRandomDID()
+)
+
+func TestMain(m *testing.M) {
+	ctx := make(map[string]interface{})
+	ibootstappers := []bootstrap.TestBootstrapper{
+		&testlogging.TestLoggingBootstrapper{},
+		&config.Bootstrapper{},
+	}
+	bootstrap.RunTestBootstrappers(ibootstappers, ctx)
+	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	cfg.Set("identityId", did.String())
+	cfg.Set("keys.p2p.publicKey", "../build/resources/p2pKey.pub.pem")
+	cfg.Set("keys.p2p.privateKey", "../build/resources/p2pKey.key.pem")
+	cfg.Set("keys.signing.publicKey", "../build/resources/signingKey.pub.pem")
+	cfg.Set("keys.signing.privateKey", "../build/resources/signingKey.key.pem")
+	result := m.Run()
+	bootstrap.RunTestTeardown(ibootstappers)
+	os.Exit(result)
+}
+
+// createSignedDocument returns a generic document signed by the account along with its anchor details.
+func createSignedDocument(t *testing.T, ctx context.Context) (documents.Model, *documents.AnchorInfo) {
+	g := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	sr, err := g.CalculateSigningRoot()
+	assert.NoError(t, err)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	sig, err := acc.SignMsg(documents.ConsensusSignaturePayload(sr, false))
+	assert.NoError(t, err)
+	g.AppendSignatures(sig)
+	dr, err := g.CalculateDocumentRoot()
+	assert.NoError(t, err)
+	anchorID, err := anchors.ToAnchorID(g.CurrentVersion())
+	assert.NoError(t, err)
+	docRoot, err := anchors.ToDocumentRoot(dr)
+	assert.NoError(t, err)
+	return g, &documents.AnchorInfo{AnchorID: anchorID, DocumentRoot: docRoot, AnchoredAt: time.Now().UTC(), BlockNumber: 7}
+}
+
+func newBundle(t *testing.T, model documents.Model, anchor *documents.AnchorInfo, fields []string) *documents.ProofBundle {
+	sig := model.Signatures()[0]
+	proof, err := model.CreateProofs(append(fields, documents.SignatureField(sig.SignatureId)))
+	assert.NoError(t, err)
+	proof.DocumentID = model.ID()
+	proof.VersionID = model.CurrentVersion()
+	proof.Anchor = anchor
+	signer, err := identity.NewDIDFromBytes(sig.SignerId)
+	assert.NoError(t, err)
+	return &documents.ProofBundle{
+		Version: documents.ProofBundleVersion,
+		Proof:   proof,
+		Signatures: []documents.SignatureInfo{
+			{
+				SignatureID: sig.SignatureId,
+				SignerID:    signer,
+				PublicKey:   sig.PublicKey,
+				Signature:   sig.Signature,
+				KeyValid:    true,
+			},
+		},
+	}
+}
+
+func TestService_Export_Import(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	model, anchor := createSignedDocument(t, ctx)
+	fields := []string{documents.CDTreePrefix + ".document_type"}
+	docSrv := new(testingdocuments.MockService)
+	anchorSrv := new(testinganchors.MockAnchorService)
+	idSrv := new(testingcommons.MockIdentityService)
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	repo.Register(new(RedactedDocument))
+	srv := NewService(docSrv, anchorSrv, idSrv, repo)
+
+	// missing account
+	_, err = srv.Export(context.Background(), model.ID(), model.CurrentVersion(), fields)
+	assert.Equal(t, documents.ErrDocumentConfigAccountID, err)
+
+	// no fields
+	_, err = srv.Export(ctx, model.ID(), model.CurrentVersion(), nil)
+	assert.Equal(t, ErrNoFields, err)
+
+	// missing version
+	docSrv.On("GetVersion", model.ID(), model.CurrentVersion()).Return(nil, documents.ErrDocumentVersionNotFound).Once()
+	_, err = srv.Export(ctx, model.ID(), model.CurrentVersion(), fields)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentVersionNotFound, err))
+
+	// success
+	docSrv.On("GetVersion", model.ID(), model.CurrentVersion()).Return(model, nil).Once()
+	docSrv.On("CreateProofBundle", mock.Anything, model.ID(), model.CurrentVersion(), fields).Return(
+		newBundle(t, model, anchor, fields), nil).Once()
+	doc, err := srv.Export(ctx, model.ID(), model.CurrentVersion(), fields)
+	assert.NoError(t, err)
+	docSrv.AssertExpectations(t)
+	assert.Equal(t, "generic", doc.Scheme)
+	assert.Equal(t, did, doc.Discloser)
+	assert.Len(t, doc.Fields, 1)
+	assert.Equal(t, fields[0], doc.Fields[0].Name)
+	assert.Equal(t, documents.CompactProperties(documents.CDTreePrefix), doc.Fields[0].Property.Bytes()[:4])
+	payload, err := doc.signingPayload()
+	assert.NoError(t, err)
+	assert.True(t, crypto.VerifyMessage(doc.PublicKey, payload, doc.Signature, crypto.CurveSecp256K1))
+
+	// redacted documents are shared as JSON
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	var rd RedactedDocument
+	assert.NoError(t, json.Unmarshal(data, &rd))
+
+	// discloser's signature is validated against the payload
+	discloserSigned := mock.MatchedBy(func(payload []byte) bool {
+		return crypto.VerifyMessage(rd.PublicKey, payload, rd.Signature, crypto.CurveSecp256K1)
+	})
+	idSrv.On("ValidateSignature", did, rd.PublicKey.Bytes(), rd.Signature.Bytes(), discloserSigned, mock.Anything).Return(nil)
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid signature"))
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	assert.NoError(t, err)
+	anchorSrv.On("GetAnchorData", anchorID).Return(anchor.DocumentRoot, nil)
+
+	// signing key is not valid as per the signer's identity
+	idSrv.On("ValidateKey", mock.Anything, did, rd.Bundle.Signatures[0].PublicKey.Bytes(), mock.Anything).Return(errors.New("revoked")).Once()
+	_, err = srv.Import(ctx, rd)
+	assert.True(t, errors.IsOfType(ErrInvalidDisclosure, err))
+	assert.Contains(t, err.Error(), "not a valid signing key")
+
+	// success
+	idSrv.On("ValidateKey", mock.Anything, did, rd.Bundle.Signatures[0].PublicKey.Bytes(), mock.Anything).Return(nil)
+	imported, err := srv.Import(ctx, rd)
+	assert.NoError(t, err)
+	assert.Equal(t, rd.Fields, imported.Fields)
+	stored, err := srv.Get(ctx, model.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, rd.Fields, stored.Fields)
+	assert.Equal(t, rd.Bundle.Proofs.Header.DocumentRoot, stored.Bundle.Proofs.Header.DocumentRoot)
+
+	// imported again
+	_, err = srv.Import(ctx, rd)
+	assert.NoError(t, err)
+
+	// renamed field
+	renamed := rd
+	renamed.Fields = []Field{rd.Fields[0]}
+	renamed.Fields[0].Name = "cd_tree.author"
+	_, err = srv.Import(ctx, renamed)
+	assert.True(t, errors.IsOfType(ErrInvalidDisclosure, err))
+	assert.Contains(t, err.Error(), "discloser's signature")
+
+	// field not proven
+	tampered := rd
+	tampered.Fields = []Field{rd.Fields[0]}
+	tampered.Fields[0].Value = utils.RandomSlice(32)
+	_, err = srv.Import(ctx, tampered)
+	assert.True(t, errors.IsOfType(ErrInvalidDisclosure, err))
+	assert.Contains(t, err.Error(), "is not proven")
+
+	// not imported
+	_, err = srv.Get(ctx, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(ErrDisclosureNotFound, err))
+}
@@ -0,0 +1,40 @@
+// +build integration unit
+
+package disclosure
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+type MockService struct {
+	mock.Mock
+	Service
+}
+
+func (m *MockService) Export(ctx context.Context, docID, versionID []byte, fields []string) (*RedactedDocument, error) {
+	args := m.Called(ctx, docID, versionID, fields)
+	doc, _ := args.Get(0).(*RedactedDocument)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) Import(ctx context.Context, doc RedactedDocument) (*RedactedDocument, error) {
+	args := m.Called(ctx, doc)
+	d, _ := args.Get(0).(*RedactedDocument)
+	return d, args.Error(1)
+}
+
+func (m *MockService) Get(ctx context.Context, versionID []byte) (*RedactedDocument, error) {
+	args := m.Called(ctx, versionID)
+	doc, _ := args.Get(0).(*RedactedDocument)
+	return doc, args.Error(1)
+}
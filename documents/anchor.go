@@ -23,64 +23,123 @@ type AnchorProcessor interface {
 // updaterFunc is a wrapper that will be called to save the state of the model between processor steps
 type updaterFunc func(id []byte, model Model) error
 
+// checkpointFunc is called with the step once the step is completed and the state of the model is saved.
+type checkpointFunc func(step AnchorStep) error
+
 // AnchorDocument add signature, requests signatures, anchors document, and sends the anchored document
 // to collaborators
 func AnchorDocument(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, preAnchor bool) (Model, error) {
+	return anchorDocument(ctx, model, proc, updater, preAnchor, AnchorStepNone, nil)
+}
+
+// anchorDocument anchors the document starting from the step after the completed step.
+// checkpoint, if provided, is called after each step so that anchoring can be resumed from the last completed step.
+func anchorDocument(
+	ctx context.Context,
+	model Model,
+	proc AnchorProcessor,
+	updater updaterFunc,
+	preAnchor bool,
+	completed AnchorStep,
+	checkpoint checkpointFunc) (Model, error) {
 	id := model.CurrentVersion()
-	err := proc.PrepareForSignatureRequests(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
-	}
+	stepDone := func(step AnchorStep) error {
+		if checkpoint == nil {
+			return nil
+		}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, err
+		err := checkpoint(step)
+		if err != nil {
+			return errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to save checkpoint %s: %v", step, err))
+		}
+
+		return nil
 	}
 
-	if preAnchor {
-		err = proc.PreAnchorDocument(ctx, model)
+	if completed < AnchorStepPreparedForSignatures {
+		err := proc.PrepareForSignatureRequests(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
+		}
+
+		err = updater(id, model)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	err = proc.RequestSignatures(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+		if err = stepDone(AnchorStepPreparedForSignatures); err != nil {
+			return nil, err
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
-	}
+	if completed < AnchorStepPreAnchored {
+		if preAnchor {
+			err := proc.PreAnchorDocument(ctx, model)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	err = proc.PrepareForAnchoring(model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
+		if err := stepDone(AnchorStepPreAnchored); err != nil {
+			return nil, err
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, err
-	}
+	if completed < AnchorStepSignaturesCollected {
+		err := proc.RequestSignatures(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+		}
 
-	// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
-	err = proc.AnchorDocument(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		if err = stepDone(AnchorStepSignaturesCollected); err != nil {
+			return nil, err
+		}
 	}
 
-	// set the status to committed
-	if err = model.SetStatus(Committed); err != nil {
-		return nil, err
+	if completed < AnchorStepPreparedForAnchoring {
+		err := proc.PrepareForAnchoring(model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = stepDone(AnchorStepPreparedForAnchoring); err != nil {
+			return nil, err
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+	if completed < AnchorStepAnchored {
+		// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
+		err := proc.AnchorDocument(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
+		}
+
+		// set the status to committed
+		if err = model.SetStatus(Committed); err != nil {
+			return nil, err
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		if err = stepDone(AnchorStepAnchored); err != nil {
+			return nil, err
+		}
 	}
 
-	err = proc.SendDocument(ctx, model)
+	err := proc.SendDocument(ctx, model)
 	if err != nil {
 		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to send anchored document: %v", err))
 	}
@@ -0,0 +1,109 @@
+package documents
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// AnchorStep is a step of the anchoring pipeline.
+// Steps are ordered in the order of execution.
+type AnchorStep int
+
+const (
+	// AnchorStepNone is used when none of the steps are completed.
+	AnchorStepNone AnchorStep = iota
+
+	// AnchorStepPreparedForSignatures is completed once the document is signed by the author.
+	AnchorStepPreparedForSignatures
+
+	// AnchorStepPreAnchored is completed once the document is pre-anchored.
+	// This step is a no-op when pre-anchoring is disabled.
+	AnchorStepPreAnchored
+
+	// AnchorStepSignaturesCollected is completed once the signatures are collected from the collaborators.
+	AnchorStepSignaturesCollected
+
+	// AnchorStepPreparedForAnchoring is completed once the signatures are validated and the document root is calculated.
+	AnchorStepPreparedForAnchoring
+
+	// AnchorStepAnchored is completed once the document is anchored and marked as committed.
+	AnchorStepAnchored
+)
+
+var anchorStepNames = map[AnchorStep]string{
+	AnchorStepNone:                  "none",
+	AnchorStepPreparedForSignatures: "prepared for signatures",
+	AnchorStepPreAnchored:           "pre-anchored",
+	AnchorStepSignaturesCollected:   "signatures collected",
+	AnchorStepPreparedForAnchoring:  "prepared for anchoring",
+	AnchorStepAnchored:              "anchored",
+}
+
+// String returns the readable name of the step.
+func (s AnchorStep) String() string {
+	name, ok := anchorStepNames[s]
+	if !ok {
+		return "unknown"
+	}
+
+	return name
+}
+
+// AnchorCheckpoint holds the last completed anchoring step of a document version.
+// Checkpoint is removed once the anchored document is sent to the collaborators.
+type AnchorCheckpoint struct {
+	AccountID  identity.DID `json:"account_id"`
+	DocumentID []byte       `json:"document_id"`
+	VersionID  []byte       `json:"version_id"`
+
+	// JobID is the job anchoring the document version.
+	JobID     jobs.JobID `json:"job_id"`
+	Step      AnchorStep `json:"step"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// JSON marshals AnchorCheckpoint to json bytes.
+func (c *AnchorCheckpoint) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Type returns the type of AnchorCheckpoint.
+func (c *AnchorCheckpoint) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+// FromJSON loads json bytes to AnchorCheckpoint.
+func (c *AnchorCheckpoint) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// CommittingVersion indexes a document version being committed.
+// Versions are indexed when the commit starts, before the anchoring is checkpointed,
+// so that the commits interrupted before the first checkpoint are rolled back.
+type CommittingVersion struct {
+	AccountID  []byte `json:"account_id"`
+	DocumentID []byte `json:"document_id"`
+	VersionID  []byte `json:"version_id"`
+
+	// Timestamp is the time when the commit started.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// JSON marshals CommittingVersion to json bytes.
+func (c *CommittingVersion) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Type returns the type of CommittingVersion.
+func (c *CommittingVersion) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+// FromJSON loads json bytes to CommittingVersion.
+func (c *CommittingVersion) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
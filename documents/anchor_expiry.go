@@ -0,0 +1,202 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// anchorExpiryInterval is the interval at which the expiring anchors are checked.
+	anchorExpiryInterval = time.Hour
+
+	// anchorExpiring is the notification status of an anchor about to expire.
+	anchorExpiring = "expiring"
+
+	// anchorExpired is the notification status of an anchor past its expiry.
+	anchorExpired = "expired"
+)
+
+// anchorExpiryMonitor warns the accounts about their expiring anchors through the webhook.
+// If renewal is enabled, anchors of the latest document versions are renewed instead and
+// the renewed versions are sent to the collaborators along with their new batch proofs.
+// anchorExpiryMonitor implements node.Server.
+type anchorExpiryMonitor struct {
+	repo      Repository
+	anchorSrv anchors.Service
+	processor AnchorProcessor
+	config    config.Service
+	notifier  notification.Sender
+	warning   time.Duration
+	renew     bool
+	interval  time.Duration
+}
+
+// Name returns the name of the server.
+func (*anchorExpiryMonitor) Name() string {
+	return "AnchorExpiryMonitor"
+}
+
+// Start checks the expiring anchors at every interval until the context is done.
+func (m *anchorExpiryMonitor) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check renews or warns about the anchors expiring within the warning period.
+func (m *anchorExpiryMonitor) check(ctx context.Context) {
+	records, err := m.anchorSrv.GetExpiringAnchors(time.Now().UTC().Add(m.warning))
+	if err != nil {
+		log.Errorf("failed to get expiring anchors: %v", err)
+		return
+	}
+
+	renewals := make(map[identity.DID][]*anchors.AnchorRecord)
+	for _, r := range records {
+		model, err := m.repo.Get(r.AccountID[:], r.AnchorID[:])
+		if err != nil {
+			// version is not stored anymore
+			model = nil
+		}
+
+		if m.renew && model != nil && m.isLatest(r.AccountID, model) {
+			renewals[r.AccountID] = append(renewals[r.AccountID], r)
+			continue
+		}
+
+		m.warn(ctx, r, model)
+	}
+
+	for did, rs := range renewals {
+		err := m.renewAnchors(ctx, did, rs)
+		if err == nil {
+			continue
+		}
+
+		log.Errorf("failed to renew %d anchors of account %s: %v", len(rs), did.String(), err)
+		for _, r := range rs {
+			model, _ := m.repo.Get(r.AccountID[:], r.AnchorID[:])
+			m.warn(ctx, r, model)
+		}
+	}
+}
+
+// isLatest returns true if the model is the latest version of the document.
+func (m *anchorExpiryMonitor) isLatest(accountID identity.DID, model Model) bool {
+	latest, err := m.repo.GetLatest(accountID[:], model.ID())
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(latest.CurrentVersion(), model.CurrentVersion())
+}
+
+func (m *anchorExpiryMonitor) renewAnchors(ctx context.Context, did identity.DID, records []*anchors.AnchorRecord) error {
+	ctx, err := m.accountContext(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	anchorIDs := make([]anchors.AnchorID, len(records))
+	for i, r := range records {
+		anchorIDs[i] = r.AnchorID
+	}
+
+	err = m.anchorSrv.RenewAnchors(ctx, anchorIDs)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("renewed %d anchors of account %s", len(anchorIDs), did.String())
+	m.sendRenewed(ctx, did, records)
+	return nil
+}
+
+// sendRenewed sends the renewed versions to their collaborators so that they receive the new batch proofs.
+// Otherwise, collaborators can't verify the versions once the original anchors expire.
+// Failed deliveries are retried by the delivery queue.
+func (m *anchorExpiryMonitor) sendRenewed(ctx context.Context, did identity.DID, records []*anchors.AnchorRecord) {
+	for _, r := range records {
+		model, err := m.repo.Get(did[:], r.AnchorID[:])
+		if err != nil {
+			log.Errorf("failed to get renewed version %s: %v", hexutil.Encode(r.AnchorID[:]), err)
+			continue
+		}
+
+		err = m.processor.SendDocument(ctx, model)
+		if err != nil {
+			log.Errorf("failed to send renewed version %s: %v", hexutil.Encode(r.AnchorID[:]), err)
+		}
+	}
+}
+
+// warn sends the expiry warning of the anchor to the account's webhook.
+// Account is warned only once per expiry.
+func (m *anchorExpiryMonitor) warn(ctx context.Context, record *anchors.AnchorRecord, model Model) {
+	if record.Warned {
+		return
+	}
+
+	ctx, err := m.accountContext(ctx, record.AccountID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	status, verb := anchorExpiring, "expires"
+	if record.ExpiresAt.Before(time.Now().UTC()) {
+		status, verb = anchorExpired, "expired"
+	}
+
+	msg := notification.Message{
+		EventType: notification.AnchorExpiring,
+		AccountID: record.AccountID.String(),
+		Recorded:  time.Now().UTC(),
+		Status:    status,
+		Message: fmt.Sprintf("anchor of version %s %s at %s", hexutil.Encode(record.AnchorID[:]), verb,
+			record.ExpiresAt.UTC().Format(time.RFC3339)),
+	}
+
+	if model != nil {
+		msg.DocumentType = model.DocumentType()
+		msg.DocumentID = hexutil.Encode(model.ID())
+	}
+
+	_, err = m.notifier.Send(ctx, msg)
+	if err != nil {
+		log.Errorf("failed to send expiry warning of anchor %s: %v", record.AnchorID.String(), err)
+		return
+	}
+
+	err = m.anchorSrv.MarkAnchorWarned(record.AnchorID)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (m *anchorExpiryMonitor) accountContext(ctx context.Context, did identity.DID) (context.Context, error) {
+	acc, err := m.config.GetAccount(did[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return contextutil.New(ctx, acc)
+}
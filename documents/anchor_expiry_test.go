@@ -0,0 +1,125 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m mockAnchorService) GetExpiringAnchors(before time.Time) ([]*anchors.AnchorRecord, error) {
+	args := m.Called(before)
+	rs, _ := args.Get(0).([]*anchors.AnchorRecord)
+	return rs, args.Error(1)
+}
+
+func (m mockAnchorService) MarkAnchorWarned(anchorID anchors.AnchorID) error {
+	args := m.Called(anchorID)
+	return args.Error(0)
+}
+
+func (m mockAnchorService) RenewAnchors(ctx context.Context, anchorIDs []anchors.AnchorID) error {
+	args := m.Called(anchorIDs)
+	return args.Error(0)
+}
+
+func TestAnchorExpiryMonitor_check(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	newRecord := func(expiresIn time.Duration) *anchors.AnchorRecord {
+		return &anchors.AnchorRecord{AccountID: did, AnchorID: utils.RandomByte32(), ExpiresAt: time.Now().UTC().Add(expiresIn)}
+	}
+
+	// latest version, older version, warned version, missing version
+	latest, older, warned, missing := newRecord(time.Hour), newRecord(-time.Hour), newRecord(time.Hour), newRecord(time.Hour)
+	warned.Warned = true
+	docID := utils.RandomSlice(32)
+	latestModel, olderModel := new(MockModel), new(MockModel)
+	latestModel.On("ID").Return(docID)
+	latestModel.On("CurrentVersion").Return(latest.AnchorID[:])
+	latestModel.On("DocumentType").Return("generic")
+	olderModel.On("ID").Return(docID)
+	olderModel.On("CurrentVersion").Return(older.AnchorID[:])
+	olderModel.On("DocumentType").Return("generic")
+	repo := new(MockRepository)
+	repo.On("Get", did[:], latest.AnchorID[:]).Return(latestModel, nil)
+	repo.On("Get", did[:], older.AnchorID[:]).Return(olderModel, nil)
+	repo.On("Get", did[:], warned.AnchorID[:]).Return(olderModel, nil)
+	repo.On("Get", did[:], missing.AnchorID[:]).Return(nil, errors.New("not found"))
+	repo.On("GetLatest", did[:], docID).Return(latestModel, nil)
+	records := []*anchors.AnchorRecord{older, latest, warned, missing}
+	anchorSrv := new(mockAnchorService)
+	anchorSrv.On("GetExpiringAnchors", mock.Anything).Return(records, nil)
+	cfgSrv := new(configstore.MockService)
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil)
+	notifier := mockNotifier{msgs: make(chan notification.Message, 5)}
+	proc := new(mockAnchorProcessor)
+	m := &anchorExpiryMonitor{
+		repo:      repo,
+		anchorSrv: anchorSrv,
+		processor: proc,
+		config:    cfgSrv,
+		notifier:  notifier,
+		warning:   24 * time.Hour,
+	}
+
+	// renewal disabled
+	anchorSrv.On("MarkAnchorWarned", mock.Anything).Return(nil).Times(3)
+	m.check(context.Background())
+	assert.Len(t, notifier.msgs, 3)
+	msg := <-notifier.msgs
+	assert.Equal(t, notification.AnchorExpiring, msg.EventType)
+	assert.Equal(t, anchorExpired, msg.Status)
+	assert.Equal(t, hexutil.Encode(docID), msg.DocumentID)
+	assert.Equal(t, did.String(), msg.AccountID)
+	msg = <-notifier.msgs
+	assert.Equal(t, anchorExpiring, msg.Status)
+	assert.Contains(t, msg.Message, hexutil.Encode(latest.AnchorID[:]))
+	msg = <-notifier.msgs
+	assert.Contains(t, msg.Message, hexutil.Encode(missing.AnchorID[:]))
+	assert.Empty(t, msg.DocumentID)
+
+	// latest version is renewed and sent to the collaborators with the new batch proof
+	m.renew = true
+	anchorSrv.On("RenewAnchors", []anchors.AnchorID{latest.AnchorID}).Return(nil).Once()
+	proc.On("SendDocument", latestModel).Return(nil).Once()
+	anchorSrv.On("MarkAnchorWarned", mock.Anything).Return(nil).Twice()
+	m.check(context.Background())
+	assert.Len(t, notifier.msgs, 2)
+	assert.Contains(t, (<-notifier.msgs).Message, hexutil.Encode(older.AnchorID[:]))
+	assert.Contains(t, (<-notifier.msgs).Message, hexutil.Encode(missing.AnchorID[:]))
+
+	// failed renewal is warned
+	anchorSrv.On("RenewAnchors", []anchors.AnchorID{latest.AnchorID}).Return(errors.New("failed to renew")).Once()
+	anchorSrv.On("MarkAnchorWarned", mock.Anything).Return(nil).Times(3)
+	m.check(context.Background())
+	assert.Len(t, notifier.msgs, 3)
+	for len(notifier.msgs) > 0 {
+		<-notifier.msgs
+	}
+
+	// failed send of a renewed version is left to the delivery queue
+	anchorSrv.On("RenewAnchors", []anchors.AnchorID{latest.AnchorID}).Return(nil).Once()
+	proc.On("SendDocument", latestModel).Return(errors.New("failed to send")).Once()
+	anchorSrv.On("MarkAnchorWarned", mock.Anything).Return(nil).Twice()
+	m.check(context.Background())
+	assert.Len(t, notifier.msgs, 2)
+	anchorSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	proc.AssertExpectations(t)
+}
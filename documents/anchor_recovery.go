@@ -0,0 +1,293 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// anchorRecoveryDelay is the time to wait for the queue and p2p servers to start before resuming the anchoring.
+const anchorRecoveryDelay = 10 * time.Second
+
+// PendingRepository stores the documents that are not committed yet.
+// Rolled back commits are restored to it so that the documents can be committed again.
+type PendingRepository interface {
+	// Get returns the pending document associated with ID, owned by accountID.
+	Get(accountID, id []byte) (Model, error)
+
+	// Create stores the pending document. Should error out if the document exists.
+	Create(accountID, id []byte, model Model) error
+}
+
+// anchorRecovery resumes or rolls back the document anchoring interrupted by a node restart.
+// anchorRecovery implements node.Server.
+type anchorRecovery struct {
+	repo        Repository
+	pendingRepo PendingRepository
+	anchorSrv   anchors.Service
+	jobManager  jobs.Manager
+	queueSrv    queue.TaskQueuer
+	delay       time.Duration
+
+	// startedAt is the start time of the server.
+	// Commits started after are left to the jobs of this run.
+	startedAt time.Time
+}
+
+// Name returns the name of the server.
+func (*anchorRecovery) Name() string {
+	return "AnchorRecovery"
+}
+
+// Start recovers the interrupted anchoring once the other servers are started.
+func (a *anchorRecovery) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	a.startedAt = time.Now().UTC()
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(a.delay):
+	}
+
+	err := a.recover(ctx)
+	if err != nil {
+		log.Errorf("failed to recover document anchoring: %v", err)
+	}
+}
+
+// recover goes through the anchor checkpoints and the versions being committed left by the previous run of the node.
+// Anchoring is resumed from the last completed step if the job is still pending.
+// Otherwise, document is rolled back to Pending if the anchoring didn't go through.
+// Versions being committed without a checkpoint never started anchoring and are rolled back.
+func (a *anchorRecovery) recover(ctx context.Context) error {
+	checkpoints, err := a.repo.GetAnchorCheckpoints()
+	if err != nil {
+		return err
+	}
+
+	checkpointed := make(map[string]struct{})
+	for _, c := range checkpoints {
+		checkpointed[hexutil.Encode(append(c.AccountID[:], c.VersionID...))] = struct{}{}
+		if c.Timestamp.After(a.startedAt) {
+			continue
+		}
+
+		err := a.recoverCheckpoint(ctx, c)
+		if err != nil {
+			log.Errorf("failed to recover anchoring of version %s: %v", hexutil.Encode(c.VersionID), err)
+		}
+	}
+
+	cvs, err := a.repo.GetCommittingVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, cv := range cvs {
+		if _, ok := checkpointed[hexutil.Encode(append(cv.AccountID, cv.VersionID...))]; ok || cv.Timestamp.After(a.startedAt) {
+			continue
+		}
+
+		err := a.recoverCommitting(cv)
+		if err != nil {
+			log.Errorf("failed to recover commit of version %s: %v", hexutil.Encode(cv.VersionID), err)
+		}
+	}
+
+	return nil
+}
+
+// recoverCommitting rolls back the version whose commit was interrupted before the anchoring was checkpointed.
+func (a *anchorRecovery) recoverCommitting(cv *CommittingVersion) error {
+	model, err := a.repo.Get(cv.AccountID, cv.VersionID)
+	if err != nil {
+		return err
+	}
+
+	return rollbackCommit(a.repo, a.pendingRepo, cv.AccountID, model)
+}
+
+func (a *anchorRecovery) recoverCheckpoint(ctx context.Context, checkpoint *AnchorCheckpoint) error {
+	model, err := a.repo.Get(checkpoint.AccountID[:], checkpoint.VersionID)
+	if err != nil {
+		return errors.AppendError(err, a.deleteCheckpoint(checkpoint))
+	}
+
+	status := model.GetStatus()
+	if status != Committing && status != Committed {
+		return a.deleteCheckpoint(checkpoint)
+	}
+
+	job, err := a.jobManager.GetJob(checkpoint.AccountID, checkpoint.JobID)
+	if err != nil {
+		log.Warningf("job %s of version %s not found: %v", checkpoint.JobID, hexutil.Encode(checkpoint.VersionID), err)
+		return a.rollback(ctx, checkpoint, model, false)
+	}
+
+	switch job.Status {
+	case jobs.Success:
+		return a.deleteCheckpoint(checkpoint)
+	case jobs.Failed:
+		return a.rollback(ctx, checkpoint, model, true)
+	default:
+		return a.resume(ctx, checkpoint)
+	}
+}
+
+// resume enqueues the anchor task with the existing job.
+// Anchor task continues from the last completed step of the checkpoint.
+func (a *anchorRecovery) resume(ctx context.Context, checkpoint *AnchorCheckpoint) error {
+	log.Infof("resuming anchoring of version %s from step %s", hexutil.Encode(checkpoint.VersionID), checkpoint.Step)
+	_, done, err := CreateAnchorJob(ctx, a.jobManager, a.queueSrv, checkpoint.AccountID, checkpoint.JobID, checkpoint.VersionID)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := <-done
+		if ctx.Err() != nil {
+			// node is shutting down. checkpoint is recovered on next start
+			return
+		}
+
+		if err != nil {
+			// job is marked as failed by the job manager and the version is rolled back by the anchor task
+			log.Errorf("resumed anchoring of version %s failed: %v", hexutil.Encode(checkpoint.VersionID), err)
+			return
+		}
+
+		// existing jobs are not marked as successful by the job manager
+		err = a.jobManager.UpdateJobStatus(checkpoint.AccountID, checkpoint.JobID, jobs.Success, "resumed anchoring completed")
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+
+	return nil
+}
+
+// rollback moves the document back to Pending if the version never reached the chain.
+// If the document root of the version is anchored, the commit is completed instead.
+// Otherwise, the version may be pre-anchored, so the anchoring is resumed if the job is known.
+// Checkpoint is deleted along with the rolled back version, or on its own if the document is anchored.
+func (a *anchorRecovery) rollback(ctx context.Context, checkpoint *AnchorCheckpoint, model Model, jobFound bool) error {
+	if model.GetStatus() != Committing {
+		return a.deleteCheckpoint(checkpoint)
+	}
+
+	switch getCommitState(a.anchorSrv, model, checkpoint.Step) {
+	case commitNotAnchored:
+		return rollbackCommit(a.repo, a.pendingRepo, checkpoint.AccountID[:], model)
+	case commitAnchored:
+		err := completeCommit(model, func(id []byte, model Model) error {
+			return a.repo.Update(checkpoint.AccountID[:], id, model)
+		})
+		if err != nil {
+			return err
+		}
+
+		return a.deleteCheckpoint(checkpoint)
+	}
+
+	if !jobFound {
+		return errors.New("version %s may be pre-anchored and cannot be rolled back", hexutil.Encode(checkpoint.VersionID))
+	}
+
+	return a.resume(ctx, checkpoint)
+}
+
+func (a *anchorRecovery) deleteCheckpoint(checkpoint *AnchorCheckpoint) error {
+	return a.repo.DeleteAnchorCheckpoint(checkpoint.AccountID[:], checkpoint.VersionID)
+}
+
+// commitState is the state on chain of a version whose commit failed.
+type commitState int
+
+const (
+	// commitNotAnchored is used when the version never reached the chain. Version can be rolled back.
+	commitNotAnchored commitState = iota
+
+	// commitAnchored is used when the document root of the version is anchored. Commit can be completed.
+	commitAnchored
+
+	// commitUnknown is used when the version may be pre-anchored or is anchored with a different root.
+	// Version ID is used up on chain, so the version cannot be rolled back.
+	commitUnknown
+)
+
+// getCommitState checks the anchor of the version being committed on chain.
+// Versions whose anchoring went past the pre-anchoring are never considered as not anchored
+// since the pre-commit is not visible in the anchor data.
+func getCommitState(anchorSrv anchors.Service, model Model, completed AnchorStep) commitState {
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	if err != nil {
+		return commitUnknown
+	}
+
+	root, _, _, err := anchorSrv.GetAnchorData(anchorID)
+	if err == nil {
+		dr, err := model.CalculateDocumentRoot()
+		if err == nil && bytes.Equal(root[:], dr) {
+			return commitAnchored
+		}
+
+		return commitUnknown
+	}
+
+	if completed >= AnchorStepPreAnchored {
+		return commitUnknown
+	}
+
+	return commitNotAnchored
+}
+
+// completeCommit marks the version, whose document root is anchored, as committed.
+func completeCommit(model Model, updater updaterFunc) error {
+	log.Infof("document root of version %s is anchored, completing the commit", hexutil.Encode(model.CurrentVersion()))
+	err := model.SetStatus(Committed)
+	if err != nil {
+		return err
+	}
+
+	return updater(model.CurrentVersion(), model)
+}
+
+// rollbackCommit moves the version being committed, owned by accountID, back to the pending documents.
+// Version is restored as it was when the commit started. If the document has a new pending version,
+// the pending version is kept and the rolled back version is discarded.
+func rollbackCommit(repo Repository, pendingRepo PendingRepository, accountID []byte, model Model) error {
+	versionID := model.CurrentVersion()
+	log.Infof("rolling back version %s to pending", hexutil.Encode(versionID))
+	snapshot, err := repo.GetCommitSnapshot(accountID, versionID)
+	if err != nil {
+		// versions that started committing before the snapshots were taken
+		snapshot = model
+	}
+
+	pending, err := pendingRepo.Get(accountID, snapshot.ID())
+	switch {
+	case err != nil:
+		if err := snapshot.SetStatus(Pending); err != nil {
+			return err
+		}
+
+		if err := pendingRepo.Create(accountID, snapshot.ID(), snapshot); err != nil {
+			return err
+		}
+	case bytes.Equal(pending.CurrentVersion(), versionID):
+		// commit failed before the pending version was removed. rolled back on the next start
+		return errors.New("pending version %s is not removed yet", hexutil.Encode(versionID))
+	default:
+		log.Warningf("discarding version %s: document has a new pending version %s",
+			hexutil.Encode(versionID), hexutil.Encode(pending.CurrentVersion()))
+	}
+
+	return repo.Rollback(accountID, model)
+}
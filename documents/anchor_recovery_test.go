@@ -0,0 +1,454 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAnchorProcessor struct {
+	mock.Mock
+	AnchorProcessor
+}
+
+func (m *mockAnchorProcessor) Send(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) error {
+	args := m.Called(ctx, cd, recipient)
+	return args.Error(0)
+}
+
+func (m *mockAnchorProcessor) PrepareForAnchoring(model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *mockAnchorProcessor) AnchorDocument(ctx context.Context, model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *mockAnchorProcessor) SendDocument(ctx context.Context, model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+type mockPendingRepository struct {
+	mock.Mock
+}
+
+func (m *mockPendingRepository) Get(accountID, id []byte) (Model, error) {
+	args := m.Called(accountID, id)
+	doc, _ := args.Get(0).(Model)
+	return doc, args.Error(1)
+}
+
+func (m *mockPendingRepository) Create(accountID, id []byte, model Model) error {
+	args := m.Called(accountID, id, model)
+	return args.Error(0)
+}
+
+func TestAnchorDocument_resume(t *testing.T) {
+	updater := func(id []byte, model Model) error {
+		return nil
+	}
+
+	// checkpoint fails
+	m := new(MockModel)
+	m.On("CurrentVersion").Return(utils.RandomSlice(32))
+	proc := new(mockAnchorProcessor)
+	proc.On("PrepareForAnchoring", m).Return(nil).Once()
+	_, err := anchorDocument(context.Background(), m, proc, updater, false, AnchorStepSignaturesCollected, func(step AnchorStep) error {
+		return errors.New("failed")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to save checkpoint prepared for anchoring")
+	proc.AssertExpectations(t)
+
+	// resumed after the signatures are collected
+	m.On("SetStatus", Committed).Return(nil).Once()
+	proc.On("PrepareForAnchoring", m).Return(nil).Once()
+	proc.On("AnchorDocument", m).Return(nil).Once()
+	proc.On("SendDocument", m).Return(nil).Once()
+	var steps []AnchorStep
+	_, err = anchorDocument(context.Background(), m, proc, updater, true, AnchorStepSignaturesCollected, func(step AnchorStep) error {
+		steps = append(steps, step)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []AnchorStep{AnchorStepPreparedForAnchoring, AnchorStepAnchored}, steps)
+
+	// resumed after anchoring
+	proc.On("SendDocument", m).Return(nil).Once()
+	steps = nil
+	_, err = anchorDocument(context.Background(), m, proc, updater, false, AnchorStepAnchored, func(step AnchorStep) error {
+		steps = append(steps, step)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, steps)
+	proc.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
+
+func TestAnchorRecovery_recover(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	newCheckpoint := func() *AnchorCheckpoint {
+		return &AnchorCheckpoint{
+			AccountID:  did,
+			DocumentID: utils.RandomSlice(32),
+			VersionID:  utils.RandomSlice(32),
+			JobID:      jobs.NewJobID(),
+			Step:       AnchorStepSignaturesCollected,
+		}
+	}
+
+	// failed to get checkpoints
+	repo := new(MockRepository)
+	repo.On("GetAnchorCheckpoints").Return(nil, errors.New("failed")).Once()
+	jm := new(testingjobs.MockJobManager)
+	pendingRepo := new(mockPendingRepository)
+	anchorSrv := new(mockAnchorService)
+	ar := &anchorRecovery{repo: repo, pendingRepo: pendingRepo, anchorSrv: anchorSrv, jobManager: jm, startedAt: time.Now().UTC()}
+	assert.Error(t, ar.recover(context.Background()))
+
+	// missing model
+	c := newCheckpoint()
+	repo.On("GetAnchorCheckpoints").Return([]*AnchorCheckpoint{c}, nil).Once()
+	repo.On("Get", did[:], c.VersionID).Return(nil, errors.New("missing")).Once()
+	repo.On("DeleteAnchorCheckpoint", did[:], c.VersionID).Return(nil).Once()
+	repo.On("GetCommittingVersions").Return(nil, nil).Once()
+	assert.NoError(t, ar.recover(context.Background()))
+
+	// failed to get committing versions
+	repo.On("GetAnchorCheckpoints").Return(nil, nil).Once()
+	repo.On("GetCommittingVersions").Return(nil, errors.New("failed")).Once()
+	assert.Error(t, ar.recover(context.Background()))
+
+	// only the versions without checkpoints committed before the start are rolled back
+	later := newCheckpoint()
+	later.Timestamp = ar.startedAt.Add(time.Second)
+	cv := &CommittingVersion{AccountID: did[:], DocumentID: utils.RandomSlice(32), VersionID: utils.RandomSlice(32)}
+	repo.On("GetAnchorCheckpoints").Return([]*AnchorCheckpoint{later}, nil).Once()
+	repo.On("GetCommittingVersions").Return([]*CommittingVersion{
+		cv,
+		{AccountID: did[:], VersionID: later.VersionID},
+		{AccountID: did[:], VersionID: utils.RandomSlice(32), Timestamp: ar.startedAt.Add(time.Second)},
+	}, nil).Once()
+	cm := new(MockModel)
+	cm.On("CurrentVersion").Return(cv.VersionID)
+	cm.On("ID").Return(cv.DocumentID)
+	cm.On("SetStatus", Pending).Return(nil).Once()
+	repo.On("Get", did[:], cv.VersionID).Return(cm, nil).Once()
+	repo.On("GetCommitSnapshot", did[:], cv.VersionID).Return(nil, errors.New("missing")).Once()
+	pendingRepo.On("Get", did[:], cv.DocumentID).Return(nil, errors.New("missing")).Once()
+	pendingRepo.On("Create", did[:], cv.DocumentID, cm).Return(nil).Once()
+	repo.On("Rollback", did[:], cm).Return(nil).Once()
+	assert.NoError(t, ar.recover(context.Background()))
+	cm.AssertExpectations(t)
+
+	// model is not being committed
+	m := new(MockModel)
+	m.On("GetStatus").Return(Pending).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	repo.On("DeleteAnchorCheckpoint", did[:], c.VersionID).Return(nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+
+	// job succeeded
+	m.On("GetStatus").Return(Committed).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(&jobs.Job{Status: jobs.Success}, nil).Once()
+	repo.On("DeleteAnchorCheckpoint", did[:], c.VersionID).Return(nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+
+	// job failed while committing before pre-anchoring, restored from the snapshot
+	aid, err := anchors.ToAnchorID(c.VersionID)
+	assert.NoError(t, err)
+	c.Step = AnchorStepPreparedForSignatures
+	m.On("GetStatus").Return(Committing).Twice()
+	m.On("CurrentVersion").Return(c.VersionID).Twice()
+	snapshot := new(MockModel)
+	snapshot.On("ID").Return(c.DocumentID)
+	snapshot.On("SetStatus", Pending).Return(nil).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(&jobs.Job{Status: jobs.Failed}, nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(nil, nil, errors.New("missing")).Once()
+	repo.On("GetCommitSnapshot", did[:], c.VersionID).Return(snapshot, nil).Once()
+	pendingRepo.On("Get", did[:], c.DocumentID).Return(nil, errors.New("missing")).Once()
+	pendingRepo.On("Create", did[:], c.DocumentID, snapshot).Return(nil).Once()
+	repo.On("Rollback", did[:], m).Return(nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+	snapshot.AssertExpectations(t)
+
+	// job failed while committing but the document root is anchored, commit is completed
+	root := utils.RandomSlice(32)
+	dr, err := anchors.ToDocumentRoot(root)
+	assert.NoError(t, err)
+	m.On("GetStatus").Return(Committing).Twice()
+	m.On("CurrentVersion").Return(c.VersionID).Times(3)
+	m.On("CalculateDocumentRoot").Return(root, nil).Once()
+	m.On("SetStatus", Committed).Return(nil).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(&jobs.Job{Status: jobs.Failed}, nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil).Once()
+	repo.On("Update", did[:], c.VersionID).Return(nil).Once()
+	repo.On("DeleteAnchorCheckpoint", did[:], c.VersionID).Return(nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+
+	// job is missing after pre-anchoring, version is kept since its ID may be used up
+	c.Step = AnchorStepSignaturesCollected
+	m.On("GetStatus").Return(Committing).Twice()
+	m.On("CurrentVersion").Return(c.VersionID).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(nil, errors.New("missing")).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(nil, nil, errors.New("missing")).Once()
+	err = ar.recoverCheckpoint(context.Background(), c)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be rolled back")
+
+	// job failed after pre-anchoring, anchoring is resumed
+	m.On("GetStatus").Return(Committing).Twice()
+	m.On("CurrentVersion").Return(c.VersionID).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(&jobs.Job{Status: jobs.Failed}, nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(nil, nil, errors.New("missing")).Once()
+	done := make(chan error, 1)
+	done <- errors.New("failed")
+	jm.On("ExecuteWithinJob", mock.Anything, did, c.JobID, "anchor document", mock.Anything).Return(c.JobID, done, nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+
+	// missing job after anchoring
+	m.On("GetStatus").Return(Committed).Twice()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(nil, errors.New("missing")).Once()
+	repo.On("DeleteAnchorCheckpoint", did[:], c.VersionID).Return(nil).Once()
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+
+	// pending job is resumed
+	m.On("GetStatus").Return(Committing).Once()
+	repo.On("Get", did[:], c.VersionID).Return(m, nil).Once()
+	jm.On("GetJob", did, c.JobID).Return(&jobs.Job{Status: jobs.Pending}, nil).Once()
+	done = make(chan error, 1)
+	done <- nil
+	updated := make(chan struct{})
+	jm.On("ExecuteWithinJob", mock.Anything, did, c.JobID, "anchor document", mock.Anything).Return(c.JobID, done, nil).Once()
+	jm.On("UpdateJobStatus", did, c.JobID, jobs.Success, mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		close(updated)
+	})
+	assert.NoError(t, ar.recoverCheckpoint(context.Background(), c))
+	<-updated
+	m.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	jm.AssertExpectations(t)
+	pendingRepo.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
+}
+
+// pendingRepository is an in memory PendingRepository.
+type pendingRepository map[string]Model
+
+func (p pendingRepository) Get(accountID, id []byte) (Model, error) {
+	m, ok := p[hexutil.Encode(append(accountID, id...))]
+	if !ok {
+		return nil, errors.New("pending document not found")
+	}
+
+	return m, nil
+}
+
+func (p pendingRepository) Create(accountID, id []byte, model Model) error {
+	p[hexutil.Encode(append(accountID, id...))] = model
+	return nil
+}
+
+func TestAnchorRecovery_crashPoints(t *testing.T) {
+	path := leveldb.GetRandomTestStoragePath()
+	ldb, err := leveldb.NewLevelDBStorage(path)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, ldb.Close())
+		assert.NoError(t, os.RemoveAll(path))
+	}()
+
+	repo := NewDBRepository(leveldb.NewLevelDBRepository(ldb))
+	repo.Register(new(doc))
+	did := testingidentity.GenerateRandomDID()
+	acc := did[:]
+	tm := time.Now().UTC()
+	newDoc := func(status Status, prev *doc) *doc {
+		d := &doc{Current: utils.RandomSlice(32), Next: utils.RandomSlice(32), Time: tm, DocStatus: status}
+		d.DocID = d.Current
+		if prev != nil {
+			d.DocID, d.Current, d.Previous, d.Time = prev.DocID, prev.Next, prev.Current, prev.Time.Add(time.Minute)
+		}
+
+		assert.NoError(t, repo.Create(acc, d.Current, d))
+		return d
+	}
+	checkpoint := func(d *doc) *AnchorCheckpoint {
+		c := &AnchorCheckpoint{
+			AccountID:  did,
+			DocumentID: d.DocID,
+			VersionID:  d.Current,
+			JobID:      jobs.NewJobID(),
+			Step:       AnchorStepPreparedForSignatures,
+			Timestamp:  time.Now().UTC(),
+		}
+		assert.NoError(t, repo.StoreAnchorCheckpoint(c))
+		return c
+	}
+	pendingRepo := make(pendingRepository)
+	jm := new(testingjobs.MockJobManager)
+	anchorSrv := new(mockAnchorService)
+
+	// crashed after the version is stored as Committing, before anchoring started
+	notAnchored := newDoc(Committing, nil)
+
+	// anchoring of the second version failed
+	first := newDoc(Committed, nil)
+	failed := newDoc(Committing, first)
+	failedCheckpoint := checkpoint(failed)
+	jm.On("GetJob", did, failedCheckpoint.JobID).Return(&jobs.Job{Status: jobs.Failed}, nil).Once()
+
+	// crashed after anchoring, before the checkpoint is removed
+	anchored := newDoc(Committing, nil)
+	anchoredCheckpoint := checkpoint(anchored)
+	anchored.DocStatus = Committed
+	assert.NoError(t, repo.Update(acc, anchored.Current, anchored))
+	jm.On("GetJob", did, anchoredCheckpoint.JobID).Return(&jobs.Job{Status: jobs.Success}, nil).Once()
+
+	// anchoring failed after the document root is anchored
+	onChain := newDoc(Committing, nil)
+	onChain.Root = utils.RandomSlice(32)
+	assert.NoError(t, repo.Update(acc, onChain.Current, onChain))
+	onChainCheckpoint := checkpoint(onChain)
+	jm.On("GetJob", did, onChainCheckpoint.JobID).Return(&jobs.Job{Status: jobs.Failed}, nil).Once()
+	aid, err := anchors.ToAnchorID(onChain.Current)
+	assert.NoError(t, err)
+	dr, err := anchors.ToDocumentRoot(onChain.Root)
+	assert.NoError(t, err)
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil).Once()
+
+	// crashed after pre-anchoring, version ID may be used up
+	preAnchored := newDoc(Committing, nil)
+	preAnchoredCheckpoint := checkpoint(preAnchored)
+	preAnchoredCheckpoint.Step = AnchorStepPreAnchored
+	assert.NoError(t, repo.StoreAnchorCheckpoint(preAnchoredCheckpoint))
+	jm.On("GetJob", did, preAnchoredCheckpoint.JobID).Return(nil, errors.New("missing")).Once()
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("missing"))
+
+	// crashed before the pending version is removed
+	stillPending := newDoc(Committing, nil)
+	assert.NoError(t, pendingRepo.Create(acc, stillPending.DocID, &doc{DocID: stillPending.DocID, Current: stillPending.Current}))
+
+	// document has a new pending version
+	outdated := newDoc(Committing, nil)
+	newer := &doc{DocID: outdated.DocID, Current: outdated.Next}
+	assert.NoError(t, pendingRepo.Create(acc, outdated.DocID, newer))
+
+	// started committing after the start of the node
+	ar := &anchorRecovery{repo: repo, pendingRepo: pendingRepo, anchorSrv: anchorSrv, jobManager: jm, startedAt: time.Now().UTC()}
+	time.Sleep(time.Millisecond)
+	current := newDoc(Committing, nil)
+	assert.NoError(t, ar.recover(context.Background()))
+	jm.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
+
+	assertRolledBack := func(d *doc, latest []byte) {
+		assert.False(t, repo.Exists(acc, d.Current))
+		_, err := repo.GetCommitSnapshot(acc, d.Current)
+		assert.Error(t, err)
+		_, err = repo.GetAnchorCheckpoint(acc, d.Current)
+		assert.Error(t, err)
+		m, err := repo.GetLatest(acc, d.DocID)
+		if latest == nil {
+			assert.Error(t, err)
+			return
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, latest, m.CurrentVersion())
+	}
+	assertRestored := func(d *doc) {
+		m, err := pendingRepo.Get(acc, d.DocID)
+		assert.NoError(t, err)
+		assert.Equal(t, d.Current, m.CurrentVersion())
+		assert.Equal(t, Pending, m.GetStatus())
+	}
+
+	assertRolledBack(notAnchored, nil)
+	assertRestored(notAnchored)
+	assertRolledBack(failed, first.Current)
+	assertRestored(failed)
+
+	m, err := repo.GetLatest(acc, anchored.DocID)
+	assert.NoError(t, err)
+	assert.Equal(t, Committed, m.GetStatus())
+	_, err = repo.GetAnchorCheckpoint(acc, anchored.Current)
+	assert.Error(t, err)
+
+	m, err = repo.GetLatest(acc, onChain.DocID)
+	assert.NoError(t, err)
+	assert.Equal(t, Committed, m.GetStatus())
+	_, err = repo.GetAnchorCheckpoint(acc, onChain.Current)
+	assert.Error(t, err)
+	_, err = repo.GetCommitSnapshot(acc, onChain.Current)
+	assert.Error(t, err)
+	_, err = pendingRepo.Get(acc, onChain.DocID)
+	assert.Error(t, err)
+
+	// kept committing with its checkpoint
+	m, err = repo.Get(acc, preAnchored.Current)
+	assert.NoError(t, err)
+	assert.Equal(t, Committing, m.GetStatus())
+	_, err = repo.GetAnchorCheckpoint(acc, preAnchored.Current)
+	assert.NoError(t, err)
+
+	// left to the next start
+	assert.True(t, repo.Exists(acc, stillPending.Current))
+	_, err = repo.GetCommitSnapshot(acc, stillPending.Current)
+	assert.NoError(t, err)
+
+	assertRolledBack(outdated, nil)
+	m, err = pendingRepo.Get(acc, outdated.DocID)
+	assert.NoError(t, err)
+	assert.Equal(t, newer, m)
+
+	assert.True(t, repo.Exists(acc, current.Current))
+	_, err = repo.GetCommitSnapshot(acc, current.Current)
+	assert.NoError(t, err)
+	cvs, err := repo.GetCommittingVersions()
+	assert.NoError(t, err)
+	assert.Len(t, cvs, 3)
+}
+
+func TestAnchorCheckpoint_JSON(t *testing.T) {
+	c := &AnchorCheckpoint{
+		AccountID:  testingidentity.GenerateRandomDID(),
+		DocumentID: utils.RandomSlice(32),
+		VersionID:  utils.RandomSlice(32),
+		JobID:      jobs.NewJobID(),
+		Step:       AnchorStepAnchored,
+	}
+	data, err := c.JSON()
+	assert.NoError(t, err)
+	got := new(AnchorCheckpoint)
+	assert.NoError(t, got.FromJSON(data))
+	assert.Equal(t, c, got)
+	assert.Equal(t, "anchored", got.Step.String())
+	assert.Equal(t, "unknown", AnchorStep(100).String())
+}
@@ -2,7 +2,10 @@ package documents
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -23,6 +26,9 @@ const (
 	AccountIDParam = "accountID"
 
 	documentAnchorTaskName = "Document Anchoring"
+
+	// signatureCollectionTaskName is the task name used to log the signature collection errors of a collaborator.
+	signatureCollectionTaskName = "Signature Collection"
 )
 
 var log = logging.Logger("anchor_task")
@@ -38,6 +44,15 @@ type documentAnchorTask struct {
 	processor     AnchorProcessor
 	modelGetFunc  func(tenantID, id []byte) (Model, error)
 	modelSaveFunc func(tenantID, id []byte, model Model) error
+
+	// checkpointRepo stores the anchoring checkpoints
+	checkpointRepo Repository
+
+	// pendingRepo receives the versions rolled back on failure
+	pendingRepo PendingRepository
+
+	// anchorSrv tells if a failed commit reached the chain
+	anchorSrv anchors.Service
 }
 
 // TaskTypeName returns the name of the task.
@@ -77,11 +92,14 @@ func (d *documentAnchorTask) ParseKwargs(kwargs map[string]interface{}) error {
 // Copy returns a new task with state.
 func (d *documentAnchorTask) Copy() (gocelery.CeleryTask, error) {
 	return &documentAnchorTask{
-		BaseTask:      jobsv1.BaseTask{JobManager: d.JobManager},
-		config:        d.config,
-		processor:     d.processor,
-		modelGetFunc:  d.modelGetFunc,
-		modelSaveFunc: d.modelSaveFunc,
+		BaseTask:       jobsv1.BaseTask{JobManager: d.JobManager},
+		config:         d.config,
+		processor:      d.processor,
+		modelGetFunc:   d.modelGetFunc,
+		modelSaveFunc:  d.modelSaveFunc,
+		checkpointRepo: d.checkpointRepo,
+		pendingRepo:    d.pendingRepo,
+		anchorSrv:      d.anchorSrv,
 	}, nil
 }
 
@@ -108,15 +126,97 @@ func (d *documentAnchorTask) RunTask() (res interface{}, err error) {
 		return false, errors.New("failed to get model: %v", err)
 	}
 
-	if _, err = AnchorDocument(ctxh, model, d.processor, func(id []byte, model Model) error {
+	// resume from the last completed step if the anchoring was interrupted
+	checkpoint, err := d.checkpointRepo.GetAnchorCheckpoint(d.accountID[:], d.id)
+	if err != nil || !jobs.JobIDEqual(checkpoint.JobID, d.JobID) {
+		checkpoint = &AnchorCheckpoint{
+			AccountID:  d.accountID,
+			DocumentID: model.ID(),
+			VersionID:  d.id,
+			JobID:      d.JobID,
+			Step:       AnchorStepNone,
+		}
+	}
+
+	if err = d.saveCheckpoint(checkpoint, checkpoint.Step); err != nil {
+		return false, errors.New("failed to save anchor checkpoint: %v", err)
+	}
+
+	updater := func(id []byte, model Model) error {
 		return d.modelSaveFunc(d.accountID[:], id, model)
-	}, tc.GetPrecommitEnabled()); err != nil {
-		return false, errors.New("failed to anchor document: %v", err)
+	}
+	stepDone := func(step AnchorStep) error {
+		return d.saveCheckpoint(checkpoint, step)
+	}
+	if _, err = anchorDocument(ctxh, model, d.processor, updater, tc.GetPrecommitEnabled(), checkpoint.Step, stepDone); err != nil {
+		// version is not marked as committed if it is still being committed
+		if model.GetStatus() != Committing {
+			return false, errors.New("failed to anchor document: %v", err)
+		}
+
+		if rerr := d.recoverCommit(ctxh, checkpoint, model, updater, stepDone); rerr != nil {
+			return false, errors.New("failed to anchor document: %v", errors.AppendError(err, rerr))
+		}
+	}
+
+	if err = d.checkpointRepo.DeleteAnchorCheckpoint(d.accountID[:], d.id); err != nil {
+		log.Warningf("failed to delete anchor checkpoint of version %s: %v", hexutil.Encode(d.id), err)
 	}
 
 	return true, nil
 }
 
+// recoverCommit completes the commit of the version if its document root is anchored despite the failure.
+// Version is rolled back only if it never reached the chain. Otherwise, the checkpoint is kept
+// so that the anchoring is retried on node restart.
+func (d *documentAnchorTask) recoverCommit(
+	ctx context.Context,
+	checkpoint *AnchorCheckpoint,
+	model Model,
+	updater updaterFunc,
+	stepDone checkpointFunc) error {
+	switch getCommitState(d.anchorSrv, model, checkpoint.Step) {
+	case commitAnchored:
+		if err := completeCommit(model, updater); err != nil {
+			return err
+		}
+
+		if err := stepDone(AnchorStepAnchored); err != nil {
+			return err
+		}
+
+		_, err := anchorDocument(ctx, model, d.processor, updater, false, AnchorStepAnchored, stepDone)
+		return err
+	case commitNotAnchored:
+		err := rollbackCommit(d.checkpointRepo, d.pendingRepo, d.accountID[:], model)
+		if err != nil {
+			// checkpoint is kept so that the document is rolled back on node restart
+			log.Errorf("failed to roll back version %s: %v", hexutil.Encode(d.id), err)
+		}
+	default:
+		log.Warningf("version %s may be pre-anchored, anchoring is retried on node restart", hexutil.Encode(d.id))
+	}
+
+	return errors.New("version %s is not anchored", hexutil.Encode(d.id))
+}
+
+// saveCheckpoint stores the checkpoint with the completed step and logs the step on the job.
+func (d *documentAnchorTask) saveCheckpoint(checkpoint *AnchorCheckpoint, step AnchorStep) error {
+	checkpoint.Step = step
+	checkpoint.Timestamp = time.Now().UTC()
+	err := d.checkpointRepo.StoreAnchorCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	if step == AnchorStepNone {
+		return nil
+	}
+
+	return d.JobManager.UpdateTaskStatus(
+		d.accountID, d.JobID, jobs.Pending, d.TaskTypeName(), fmt.Sprintf("completed step: %s", step))
+}
+
 // initDocumentAnchorTask enqueues a new document anchor task for a given combination of accountID/modelID/txID.
 func initDocumentAnchorTask(jobMan jobs.Manager, tq queue.TaskQueuer, accountID identity.DID, modelID []byte, jobID jobs.JobID) (queue.TaskResult, error) {
 	params := map[string]interface{}{
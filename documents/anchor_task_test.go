@@ -3,13 +3,20 @@
 package documents
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDocumentAnchorTask_ParseKwargs(t *testing.T) {
@@ -84,3 +91,77 @@ func TestDocumentAnchorTask_ParseKwargs(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentAnchorTask_recoverCommit(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	id, docID := utils.RandomSlice(32), utils.RandomSlice(32)
+	aid, err := anchors.ToAnchorID(id)
+	assert.NoError(t, err)
+	repo := new(MockRepository)
+	pendingRepo := new(mockPendingRepository)
+	anchorSrv := new(mockAnchorService)
+	proc := new(mockAnchorProcessor)
+	jm := new(testingjobs.MockJobManager)
+	task := &documentAnchorTask{
+		BaseTask:       jobsv1.BaseTask{JobManager: jm, JobID: jobs.NewJobID()},
+		id:             id,
+		accountID:      did,
+		processor:      proc,
+		checkpointRepo: repo,
+		pendingRepo:    pendingRepo,
+		anchorSrv:      anchorSrv,
+	}
+	var updated []Model
+	updater := func(id []byte, model Model) error {
+		updated = append(updated, model)
+		return nil
+	}
+	stepDone := func(step AnchorStep) error {
+		return task.saveCheckpoint(&AnchorCheckpoint{AccountID: did, VersionID: id, JobID: task.JobID}, step)
+	}
+
+	// document root is anchored, commit is completed
+	root := utils.RandomSlice(32)
+	dr, err := anchors.ToDocumentRoot(root)
+	assert.NoError(t, err)
+	m := new(MockModel)
+	m.On("CurrentVersion").Return(id)
+	m.On("CalculateDocumentRoot").Return(root, nil).Once()
+	m.On("SetStatus", Committed).Return(nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil).Once()
+	repo.On("StoreAnchorCheckpoint", mock.Anything).Return(nil).Once()
+	jm.On("UpdateTaskStatus", did, task.JobID, jobs.Pending, documentAnchorTaskName, "completed step: anchored").Return(nil).Once()
+	proc.On("SendDocument", m).Return(nil).Once()
+	checkpoint := &AnchorCheckpoint{Step: AnchorStepPreparedForAnchoring}
+	assert.NoError(t, task.recoverCommit(context.Background(), checkpoint, m, updater, stepDone))
+	assert.Equal(t, []Model{m, m}, updated)
+
+	// anchored with a different root, version is kept committing
+	m.On("CalculateDocumentRoot").Return(utils.RandomSlice(32), nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil).Once()
+	err = task.recoverCommit(context.Background(), checkpoint, m, updater, stepDone)
+	assert.Error(t, err)
+
+	// pre-anchored, version is kept committing
+	anchorSrv.On("GetAnchorData", aid).Return(nil, nil, errors.New("missing")).Once()
+	err = task.recoverCommit(context.Background(), &AnchorCheckpoint{Step: AnchorStepPreAnchored}, m, updater, stepDone)
+	assert.Error(t, err)
+
+	// not anchored before pre-anchoring, version is rolled back
+	m.On("ID").Return(docID)
+	m.On("SetStatus", Pending).Return(nil).Once()
+	anchorSrv.On("GetAnchorData", aid).Return(nil, nil, errors.New("missing")).Once()
+	repo.On("GetCommitSnapshot", did[:], id).Return(nil, errors.New("missing")).Once()
+	pendingRepo.On("Get", did[:], docID).Return(nil, errors.New("missing")).Once()
+	pendingRepo.On("Create", did[:], docID, m).Return(nil).Once()
+	repo.On("Rollback", did[:], m).Return(nil).Once()
+	err = task.recoverCommit(context.Background(), &AnchorCheckpoint{Step: AnchorStepPreparedForSignatures}, m, updater, stepDone)
+	assert.Error(t, err)
+	assert.Len(t, updated, 2)
+	m.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	pendingRepo.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	jm.AssertExpectations(t)
+}
@@ -67,6 +67,26 @@ func isAttrTypeAllowed(attr AttributeType) bool {
 	}
 }
 
+// ReservedAttrLabelPrefix is the namespace of the attribute labels reserved for the node.
+// Payloads cannot set attributes in this namespace.
+const ReservedAttrLabelPrefix = "centrifuge:"
+
+// ValidatePayloadAttributes returns an error if any of the attributes are reserved for the node.
+func ValidatePayloadAttributes(attrs map[AttrKey]Attribute) error {
+	policyKey, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	if err != nil {
+		return err
+	}
+
+	for k, attr := range attrs {
+		if k == policyKey || attr.Key == policyKey || strings.HasPrefix(attr.KeyLabel, ReservedAttrLabelPrefix) {
+			return errors.NewTypedError(ErrReservedAttr, errors.New("%s", attr.KeyLabel))
+		}
+	}
+
+	return nil
+}
+
 // AttrKey represents a sha256 hash of a attribute label given by a user.
 type AttrKey [32]byte
 
@@ -0,0 +1,473 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const (
+	// AttributeIndexPrefix is used to index the attribute values of the latest document versions.
+	AttributeIndexPrefix string = "attribute_index_"
+
+	// AttributeKeysPrefix is used to store the attribute keys indexed for a document.
+	AttributeKeysPrefix string = "attribute_keys_"
+)
+
+// AttributeFilterOp is the comparison operator of an AttributeFilter.
+type AttributeFilterOp string
+
+const (
+	// FilterEq matches the attribute values equal to the filter value.
+	FilterEq AttributeFilterOp = "eq"
+
+	// FilterLt matches the attribute values less than the filter value.
+	FilterLt AttributeFilterOp = "lt"
+
+	// FilterLte matches the attribute values less than or equal to the filter value.
+	FilterLte AttributeFilterOp = "lte"
+
+	// FilterGt matches the attribute values greater than the filter value.
+	FilterGt AttributeFilterOp = "gt"
+
+	// FilterGte matches the attribute values greater than or equal to the filter value.
+	FilterGte AttributeFilterOp = "gte"
+)
+
+// AttributeFilterOpFromString returns the AttributeFilterOp for the string.
+func AttributeFilterOpFromString(op string) (AttributeFilterOp, error) {
+	fop := AttributeFilterOp(op)
+	switch fop {
+	case FilterEq, FilterLt, FilterLte, FilterGt, FilterGte:
+		return fop, nil
+	default:
+		return fop, errors.New("unknown attribute filter operator %s", op)
+	}
+}
+
+// AttributeFilter filters documents on the value of a custom attribute.
+// Value is parsed according to the type of the indexed attribute.
+// Monetary attributes are compared on their decimal value.
+type AttributeFilter struct {
+	Key   AttrKey
+	Op    AttributeFilterOp
+	Value string
+}
+
+// keyRange returns the range of the index keys, after the prefix, of the values of the type satisfying the filter.
+// Returns false if the filter value is not a value of the type.
+func (f AttributeFilter) keyRange(tag byte) (start, end []byte, ok bool) {
+	v, err := encodeFilterValue(tag, f.Value)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	// encoded values are self delimiting, so the keys of the value are the keys with the value as prefix
+	typeStart, typeEnd := []byte{tag}, []byte{tag + 1}
+	v = append([]byte{tag}, v...)
+	switch f.Op {
+	case FilterEq:
+		return v, prefixEnd(v), true
+	case FilterLt:
+		return typeStart, v, true
+	case FilterLte:
+		return typeStart, prefixEnd(v), true
+	case FilterGt:
+		return prefixEnd(v), typeEnd, true
+	case FilterGte:
+		return v, typeEnd, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// type tags of the encoded attribute values. Monetary values are indexed as decimals.
+const (
+	tagInt256    byte = 'i'
+	tagDecimal   byte = 'd'
+	tagString    byte = 's'
+	tagBytes     byte = 'b'
+	tagTimestamp byte = 't'
+)
+
+var attrValueTags = []byte{tagInt256, tagDecimal, tagString, tagBytes, tagTimestamp}
+
+// encodeAttrVal returns the type tag followed by the order preserving encoding of the attribute value.
+func encodeAttrVal(val AttrVal) ([]byte, error) {
+	var tag byte
+	var enc []byte
+	switch val.Type {
+	case AttrInt256:
+		tag, enc = tagInt256, encodeInt256(val.Int256)
+	case AttrDecimal, AttrMonetary:
+		x := val.Decimal
+		if val.Type == AttrMonetary {
+			x = val.Monetary.Value
+		}
+
+		tag, enc = tagDecimal, encodeDecimal(x)
+	case AttrString:
+		tag, enc = tagString, encodeBytes([]byte(val.Str))
+	case AttrBytes:
+		tag, enc = tagBytes, encodeBytes(val.Bytes)
+	case AttrTimestamp:
+		tm, err := utils.FromTimestamp(val.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, enc = tagTimestamp, encodeTime(tm)
+	default:
+		return nil, ErrNotValidAttrType
+	}
+
+	return append([]byte{tag}, enc...), nil
+}
+
+// encodeFilterValue parses the filter value as a value of the type and returns its order preserving encoding.
+func encodeFilterValue(tag byte, s string) ([]byte, error) {
+	switch tag {
+	case tagInt256:
+		i, err := NewInt256(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeInt256(i), nil
+	case tagDecimal:
+		d, err := NewDecimal(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeDecimal(d), nil
+	case tagString:
+		return encodeBytes([]byte(s)), nil
+	case tagBytes:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeBytes(b), nil
+	case tagTimestamp:
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeTime(tm), nil
+	default:
+		return nil, ErrNotValidAttrType
+	}
+}
+
+// encodeBigInt returns the 32 byte two's complement of the integer with the sign bit flipped,
+// so that the byte order is the numeric order.
+func encodeBigInt(i *big.Int) []byte {
+	b := math.PaddedBigBytes(math.U256(new(big.Int).Set(i)), 32)
+	b[0] ^= 0x80
+	return b
+}
+
+func encodeInt256(i *Int256) []byte {
+	return encodeBigInt(&i.v)
+}
+
+// encodeDecimal encodes the decimal as the integer of its value in the smallest unit of the max precision.
+func encodeDecimal(d *Decimal) []byte {
+	return encodeBigInt(d.dec.Shift(decimalPrecision).BigInt())
+}
+
+// encodeTime encodes the seconds of the time with the sign bit flipped followed by the nanoseconds.
+func encodeTime(tm time.Time) []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, uint64(tm.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(b[8:], uint32(tm.Nanosecond()))
+	return b
+}
+
+// encodeBytes escapes the zero bytes and terminates the bytes with 0x00 0x01,
+// so that the encoding is self delimiting and preserves the byte order.
+func encodeBytes(b []byte) []byte {
+	enc := make([]byte, 0, len(b)+2)
+	for _, c := range b {
+		enc = append(enc, c)
+		if c == 0 {
+			enc = append(enc, 0xff)
+		}
+	}
+
+	return append(enc, 0, 1)
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			end := append([]byte{}, prefix[:i+1]...)
+			end[i]++
+			return end
+		}
+	}
+
+	return nil
+}
+
+// isAttrTypeIndexed returns true if the attributes of the type are indexed.
+func isAttrTypeIndexed(attrType AttributeType) bool {
+	switch attrType {
+	case AttrInt256, AttrDecimal, AttrString, AttrBytes, AttrTimestamp, AttrMonetary:
+		return true
+	default:
+		return false
+	}
+}
+
+// attributeIndexEntry holds the indexed attribute value of a document.
+type attributeIndexEntry struct {
+	DocumentID []byte  `json:"document_id"`
+	VersionID  []byte  `json:"version_id"`
+	Value      AttrVal `json:"value"`
+}
+
+// JSON marshals attributeIndexEntry to json bytes.
+func (a *attributeIndexEntry) JSON() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// Type returns the type of attributeIndexEntry.
+func (a *attributeIndexEntry) Type() reflect.Type {
+	return reflect.TypeOf(a)
+}
+
+// FromJSON loads json bytes to attributeIndexEntry.
+func (a *attributeIndexEntry) FromJSON(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// indexedAttributes holds the keys of the index entries of a document.
+// This is used to remove the stale entries when an attribute is removed or changed in a newer version.
+type indexedAttributes struct {
+	EntryKeys [][]byte `json:"entry_keys"`
+}
+
+// JSON marshals indexedAttributes to json bytes.
+func (i *indexedAttributes) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// Type returns the type of indexedAttributes.
+func (i *indexedAttributes) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+// FromJSON loads json bytes to indexedAttributes.
+func (i *indexedAttributes) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
+}
+
+// AttributeIndex maintains a secondary index of the custom attributes of the latest document versions.
+type AttributeIndex interface {
+	// Index replaces the index entries of the document with the attributes of the model.
+	Index(accountID []byte, model Model) error
+
+	// IndexBatch adds the writes replacing the index entries of the document to the batch.
+	IndexBatch(b storage.Batch, accountID []byte, model Model) error
+
+	// RemoveBatch adds the deletes of the index entries of the document to the batch.
+	RemoveBatch(b storage.Batch, accountID, docID []byte) error
+
+	// Find returns the IDs of the documents, owned by accountID, whose attributes satisfy all the filters.
+	Find(accountID []byte, filters ...AttributeFilter) ([][]byte, error)
+}
+
+// NewAttributeIndex returns the default implementation of AttributeIndex.
+func NewAttributeIndex(db storage.Repository) AttributeIndex {
+	db.Register(new(attributeIndexEntry))
+	db.Register(new(indexedAttributes))
+	return attributeIndex{db: db}
+}
+
+type attributeIndex struct {
+	db storage.Repository
+}
+
+// getPrefix returns attribute_index_+accountID+attrKey
+func (a attributeIndex) getPrefix(accountID []byte, key AttrKey) []byte {
+	hexKey := hexutil.Encode(append(accountID, key[:]...))
+	return []byte(AttributeIndexPrefix + hexKey)
+}
+
+// getEntryKey returns attribute_index_+accountID+attrKey+encodedValue+docID.
+// Entries of an attribute are ordered by the type and the value, so that the filters are key ranges.
+func (a attributeIndex) getEntryKey(accountID []byte, key AttrKey, value []byte, docID []byte) []byte {
+	return append(append(a.getPrefix(accountID, key), value...), docID...)
+}
+
+// getKeysKey returns attribute_keys_+accountID+docID
+func (a attributeIndex) getKeysKey(accountID, docID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, docID...))
+	return append([]byte(AttributeKeysPrefix), []byte(hexKey)...)
+}
+
+// put creates or overwrites the model at key in the batch.
+func (a attributeIndex) put(b storage.Batch, key []byte, model storage.Model) error {
+	if b.Exists(key) {
+		return b.Update(key, model)
+	}
+
+	return b.Create(key, model)
+}
+
+// Index replaces the index entries of the document with the attributes of the model.
+func (a attributeIndex) Index(accountID []byte, model Model) error {
+	b := a.db.NewBatch()
+	if err := a.IndexBatch(b, accountID, model); err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+// IndexBatch adds the writes replacing the index entries of the document to the batch.
+func (a attributeIndex) IndexBatch(b storage.Batch, accountID []byte, model Model) error {
+	docID := model.ID()
+	keysKey := a.getKeysKey(accountID, docID)
+	var old [][]byte
+	if m, err := a.db.Get(keysKey); err == nil {
+		if ia, ok := m.(*indexedAttributes); ok {
+			old = ia.EntryKeys
+		}
+	}
+
+	current := make(map[string]struct{})
+	ia := new(indexedAttributes)
+	for _, attr := range model.GetAttributes() {
+		if !isAttrTypeIndexed(attr.Value.Type) {
+			continue
+		}
+
+		value, err := encodeAttrVal(attr.Value)
+		if err != nil {
+			return errors.NewTypedError(ErrWrongAttrFormat, err)
+		}
+
+		entry := &attributeIndexEntry{
+			DocumentID: docID,
+			VersionID:  model.CurrentVersion(),
+			Value:      attr.Value,
+		}
+
+		key := a.getEntryKey(accountID, attr.Key, value, docID)
+		if err := a.put(b, key, entry); err != nil {
+			return err
+		}
+
+		current[string(key)] = struct{}{}
+		ia.EntryKeys = append(ia.EntryKeys, key)
+	}
+
+	for _, key := range old {
+		if _, ok := current[string(key)]; ok {
+			continue
+		}
+
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return a.put(b, keysKey, ia)
+}
+
+// RemoveBatch adds the deletes of the index entries of the document to the batch.
+func (a attributeIndex) RemoveBatch(b storage.Batch, accountID, docID []byte) error {
+	keysKey := a.getKeysKey(accountID, docID)
+	m, err := a.db.Get(keysKey)
+	if err != nil {
+		// document is not indexed
+		return nil
+	}
+
+	if ia, ok := m.(*indexedAttributes); ok {
+		for _, key := range ia.EntryKeys {
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return b.Delete(keysKey)
+}
+
+// Find returns the IDs of the documents, owned by accountID, whose attributes satisfy all the filters.
+// Filter value is parsed as each of the indexed types and only the entries of the types it parses as are compared.
+func (a attributeIndex) Find(accountID []byte, filters ...AttributeFilter) ([][]byte, error) {
+	var matched map[string][]byte
+	for _, f := range filters {
+		prefix := a.getPrefix(accountID, f.Key)
+		fm := make(map[string][]byte)
+		for _, tag := range attrValueTags {
+			start, end, ok := f.keyRange(tag)
+			if !ok {
+				continue
+			}
+
+			err := a.find(prefix, start, end, func(entry *attributeIndexEntry) {
+				id := hexutil.Encode(entry.DocumentID)
+				if matched == nil || matched[id] != nil {
+					fm[id] = entry.DocumentID
+				}
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		matched = fm
+	}
+
+	var ids [][]byte
+	for _, id := range matched {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// find calls fn with the entries with the keys in [prefix+start, prefix+end).
+// Entries whose value does not match their key are skipped.
+func (a attributeIndex) find(prefix, start, end []byte, fn func(entry *attributeIndexEntry)) error {
+	iter := a.db.Iterate(storage.IterateOptions{
+		Prefix: prefix,
+		Start:  append(append([]byte{}, prefix...), start...),
+		End:    append(append([]byte{}, prefix...), end...),
+	})
+	defer iter.Release()
+	for iter.Next() {
+		entry, ok := iter.Model().(*attributeIndexEntry)
+		if !ok {
+			continue
+		}
+
+		value, err := encodeAttrVal(entry.Value)
+		if err != nil || !bytes.HasPrefix(iter.Key()[len(prefix):], value) {
+			srvLog.Warningf("skipping attribute index entry %x: value does not match the key", iter.Key())
+			continue
+		}
+
+		fn(entry)
+	}
+
+	return iter.Error()
+}
@@ -0,0 +1,190 @@
+// +build unit
+
+package documents
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeFilterOpFromString(t *testing.T) {
+	for _, op := range []AttributeFilterOp{FilterEq, FilterLt, FilterLte, FilterGt, FilterGte} {
+		fop, err := AttributeFilterOpFromString(string(op))
+		assert.NoError(t, err)
+		assert.Equal(t, op, fop)
+	}
+
+	_, err := AttributeFilterOpFromString("ne")
+	assert.Error(t, err)
+}
+
+func TestEncodeAttrVal_Order(t *testing.T) {
+	tm := time.Now().UTC()
+	newAttr := func(attrType AttributeType, val string) AttrVal {
+		attr, err := NewStringAttribute("test", attrType, val)
+		assert.NoError(t, err)
+		return attr.Value
+	}
+
+	// values in ascending order
+	tests := [][]AttrVal{
+		{newAttr(AttrInt256, "-1000"), newAttr(AttrInt256, "-1"), newAttr(AttrInt256, "0"), newAttr(AttrInt256, "1"), newAttr(AttrInt256, "256")},
+		{newAttr(AttrDecimal, "-10.5"), newAttr(AttrDecimal, "-0.000000000000000001"), newAttr(AttrDecimal, "0"), newAttr(AttrDecimal, "10.25"), newAttr(AttrDecimal, "10.5")},
+		{newAttr(AttrString, ""), newAttr(AttrString, "a"), newAttr(AttrString, "a\x00"), newAttr(AttrString, "ab"), newAttr(AttrString, "b")},
+		{newAttr(AttrBytes, "0x"), newAttr(AttrBytes, "0x00"), newAttr(AttrBytes, "0x0000"), newAttr(AttrBytes, "0x01"), newAttr(AttrBytes, "0xff")},
+		{
+			newAttr(AttrTimestamp, time.Unix(-100, 0).UTC().Format(time.RFC3339Nano)),
+			newAttr(AttrTimestamp, tm.Format(time.RFC3339Nano)),
+			newAttr(AttrTimestamp, tm.Add(time.Nanosecond).Format(time.RFC3339Nano)),
+			newAttr(AttrTimestamp, tm.Add(time.Hour).Format(time.RFC3339Nano)),
+		},
+	}
+
+	for _, vals := range tests {
+		var prev []byte
+		for _, val := range vals {
+			enc, err := encodeAttrVal(val)
+			assert.NoError(t, err)
+			if prev != nil {
+				// values are followed by the document ID in the keys
+				assert.True(t, bytes.Compare(append(prev, 0xff), enc) < 0, val)
+			}
+			prev = enc
+		}
+	}
+
+	dec, err := NewDecimal("100.25")
+	assert.NoError(t, err)
+	monetary, err := NewMonetaryAttribute("test", dec, nil, "USD")
+	assert.NoError(t, err)
+	enc, err := encodeAttrVal(monetary.Value)
+	assert.NoError(t, err)
+	decEnc, err := encodeAttrVal(newAttr(AttrDecimal, "100.25"))
+	assert.NoError(t, err)
+	assert.Equal(t, decEnc, enc)
+
+	_, err = encodeAttrVal(AttrVal{Type: AttrSigned})
+	assert.Error(t, err)
+}
+
+func TestAttributeFilter_keyRange(t *testing.T) {
+	_, _, ok := AttributeFilter{Op: FilterEq, Value: "abc"}.keyRange(tagDecimal)
+	assert.False(t, ok)
+	_, _, ok = AttributeFilter{Op: FilterEq, Value: "0102"}.keyRange(tagBytes)
+	assert.False(t, ok)
+	_, _, ok = AttributeFilter{Op: FilterEq, Value: "abc"}.keyRange(tagString)
+	assert.True(t, ok)
+}
+
+func TestAttributeIndex_Index_Find(t *testing.T) {
+	idx := NewAttributeIndex(ctx[storage.BootstrappedDB].(storage.Repository))
+	acc := utils.RandomSlice(20)
+	docID := utils.RandomSlice(32)
+	amount, err := NewStringAttribute("amount", AttrDecimal, "100")
+	assert.NoError(t, err)
+	name, err := NewStringAttribute("name", AttrString, "alice")
+	assert.NoError(t, err)
+	signedKey, err := AttrKeyFromLabel("signed")
+	assert.NoError(t, err)
+	signed := Attribute{KeyLabel: "signed", Key: signedKey, Value: AttrVal{
+		Type:   AttrSigned,
+		Signed: Signed{Identity: testingidentity.GenerateRandomDID(), Value: []byte("value")},
+	}}
+
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	m.On("CurrentVersion").Return(docID)
+	m.On("GetAttributes").Return([]Attribute{amount, name, signed}).Once()
+	assert.NoError(t, idx.Index(acc, m))
+
+	ids, err := idx.Find(acc, AttributeFilter{Key: amount.Key, Op: FilterEq, Value: "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{docID}, ids)
+
+	ids, err = idx.Find(acc, AttributeFilter{Key: amount.Key, Op: FilterEq, Value: "100"}, AttributeFilter{Key: name.Key, Op: FilterEq, Value: "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+
+	// signed attributes are not indexed
+	ids, err = idx.Find(acc, AttributeFilter{Key: signed.Key, Op: FilterEq, Value: "value"})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+
+	// other accounts are not affected
+	ids, err = idx.Find(utils.RandomSlice(20), AttributeFilter{Key: amount.Key, Op: FilterEq, Value: "100"})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+
+	// new version removes name and updates amount
+	amount, err = NewStringAttribute("amount", AttrDecimal, "200")
+	assert.NoError(t, err)
+	m.On("GetAttributes").Return([]Attribute{amount}).Once()
+	assert.NoError(t, idx.Index(acc, m))
+	ids, err = idx.Find(acc, AttributeFilter{Key: amount.Key, Op: FilterGt, Value: "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{docID}, ids)
+	ids, err = idx.Find(acc, AttributeFilter{Key: name.Key, Op: FilterEq, Value: "alice"})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+	m.AssertExpectations(t)
+}
+
+func TestAttributeIndex_Find_Range(t *testing.T) {
+	db := ctx[storage.BootstrappedDB].(storage.Repository)
+	idx := NewAttributeIndex(db)
+	acc := utils.RandomSlice(20)
+	var ids [][]byte
+	for _, v := range []struct {
+		attrType AttributeType
+		value    string
+	}{
+		{AttrDecimal, "-5"}, {AttrDecimal, "10"}, {AttrDecimal, "20.5"}, {AttrDecimal, "300"},
+		{AttrString, "10"}, {AttrInt256, "15"},
+	} {
+		attr, err := NewStringAttribute("amount", v.attrType, v.value)
+		assert.NoError(t, err)
+		id := utils.RandomSlice(32)
+		m := new(MockModel)
+		m.On("ID").Return(id)
+		m.On("CurrentVersion").Return(id)
+		m.On("GetAttributes").Return([]Attribute{attr})
+		assert.NoError(t, idx.Index(acc, m))
+		ids = append(ids, id)
+	}
+
+	key, err := AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	tests := []struct {
+		op      AttributeFilterOp
+		value   string
+		matched [][]byte
+	}{
+		{FilterEq, "10", [][]byte{ids[1], ids[4]}},
+		{FilterLt, "10", [][]byte{ids[0]}},
+		{FilterLte, "10", [][]byte{ids[0], ids[1], ids[4]}},
+		{FilterGt, "10", [][]byte{ids[2], ids[3], ids[5]}},
+		{FilterGte, "20.5", [][]byte{ids[3], ids[2]}},
+		{FilterGt, "abc", nil},
+	}
+
+	for _, c := range tests {
+		matched, err := idx.Find(acc, AttributeFilter{Key: key, Op: c.op, Value: c.value})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, c.matched, matched, c)
+	}
+
+	// entry with a value of another type is skipped
+	value, err := encodeAttrVal(AttrVal{Type: AttrString, Str: "10"})
+	assert.NoError(t, err)
+	entryKey := idx.(attributeIndex).getEntryKey(acc, key, append(value, 0xff), utils.RandomSlice(32))
+	assert.NoError(t, db.Create(entryKey, &attributeIndexEntry{DocumentID: utils.RandomSlice(32), Value: AttrVal{Type: AttrSigned}}))
+	matched, err := idx.Find(acc, AttributeFilter{Key: key, Op: FilterGte, Value: "10"})
+	assert.NoError(t, err)
+	assert.Len(t, matched, 5)
+}
@@ -0,0 +1,123 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// batchProofField is the field number of the batch proof in the p2p messages carrying an anchored document.
+// The field is not part of the message definitions, so nodes that don't know about batch anchoring skip it.
+const batchProofField protowire.Number = 1000
+
+// AttachBatchProof adds the batch proof of the anchored version to the message carrying the document.
+// Nothing is added if the version was not anchored as part of a batch.
+func AttachBatchProof(anchorSrv anchors.Service, msg proto.Message, versionID []byte) error {
+	anchorID, err := anchors.ToAnchorID(versionID)
+	if err != nil {
+		return err
+	}
+
+	proof, err := anchorSrv.GetBatchProof(anchorID)
+	if err != nil {
+		if errors.IsOfType(anchors.ErrBatchProofNotFound, err) {
+			return nil
+		}
+
+		return err
+	}
+
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+
+	m := proto.MessageReflect(msg)
+	unknown := removeField(m.GetUnknown(), batchProofField)
+	unknown = protowire.AppendTag(unknown, batchProofField, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, data)
+	m.SetUnknown(unknown)
+	return nil
+}
+
+// ReceiveBatchProof verifies the batch proof of the model carried by the message against the batch anchor on chain
+// and stores it. Proof must carry the signatures root of the model as the signing root proof.
+// Nothing is done if the message carries no batch proof.
+func ReceiveBatchProof(anchorSrv anchors.Service, msg proto.Message, model Model) error {
+	data, ok := getField(proto.MessageReflect(msg).GetUnknown(), batchProofField)
+	if !ok {
+		return nil
+	}
+
+	proof := new(anchors.BatchProof)
+	if err := json.Unmarshal(data, proof); err != nil {
+		return errors.NewTypedError(anchors.ErrBatchProofInvalid, err)
+	}
+
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	if err != nil {
+		return err
+	}
+
+	if proof.AnchorID != anchorID {
+		return errors.NewTypedError(anchors.ErrBatchProofInvalid,
+			errors.New("batch proof is for anchor %s instead of %s", proof.AnchorID.String(), anchorID.String()))
+	}
+
+	signaturesRoot, err := model.CalculateSignaturesRoot()
+	if err != nil {
+		return errors.New("failed to get signatures root: %v", err)
+	}
+
+	if !bytes.Equal(proof.SigningRootProof[:], signaturesRoot) {
+		return errors.NewTypedError(anchors.ErrBatchProofInvalid,
+			errors.New("batch proof of anchor %s carries a different signing root proof", anchorID.String()))
+	}
+
+	return anchorSrv.StoreBatchProof(proof)
+}
+
+// getField returns the value of the first length delimited field with the given number.
+func getField(b []byte, num protowire.Number) ([]byte, bool) {
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return nil, false
+		}
+
+		if n == num && typ == protowire.BytesType {
+			v, vl := protowire.ConsumeBytes(b[l:])
+			return v, vl >= 0
+		}
+
+		fl := protowire.ConsumeFieldValue(n, typ, b[l:])
+		if fl < 0 {
+			return nil, false
+		}
+		b = b[l+fl:]
+	}
+
+	return nil, false
+}
+
+// removeField returns the fields other than the ones with the given number.
+func removeField(b []byte, num protowire.Number) []byte {
+	var res []byte
+	for len(b) > 0 {
+		n, _, l := protowire.ConsumeField(b)
+		if l < 0 {
+			return res
+		}
+
+		if n != num {
+			res = append(res, b[:l]...)
+		}
+		b = b[l:]
+	}
+
+	return res
+}
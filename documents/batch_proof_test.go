@@ -0,0 +1,99 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestBatchProof_AttachReceive(t *testing.T) {
+	versionID := utils.RandomSlice(32)
+	anchorID, err := anchors.ToAnchorID(versionID)
+	assert.NoError(t, err)
+	signaturesRoot := utils.RandomSlice(32)
+	proof := &anchors.BatchProof{
+		AnchorID:      anchorID,
+		DocumentRoot:  anchors.RandomDocumentRoot(),
+		BatchAnchorID: utils.RandomByte32(),
+		BatchRoot:     anchors.RandomDocumentRoot(),
+		Proof:         [][32]byte{utils.RandomByte32(), utils.RandomByte32()},
+	}
+	copy(proof.SigningRootProof[:], signaturesRoot)
+	model := new(MockModel)
+	model.On("CurrentVersion").Return(versionID)
+	model.On("CalculateSignaturesRoot").Return(signaturesRoot, nil)
+
+	// sends the message to the receiver over the wire
+	transfer := func(msg proto.Message) *p2ppb.GetDocumentResponse {
+		data, err := proto.Marshal(msg)
+		assert.NoError(t, err)
+		resp := new(p2ppb.GetDocumentResponse)
+		assert.NoError(t, proto.Unmarshal(data, resp))
+		return resp
+	}
+
+	// invalid version
+	sender := mockAnchorService{}
+	msg := &p2ppb.GetDocumentResponse{Document: &coredocumentpb.CoreDocument{CurrentVersion: versionID}}
+	assert.Error(t, AttachBatchProof(sender, msg, utils.RandomSlice(3)))
+
+	// version not anchored in a batch
+	sender.On("GetBatchProof", anchorID).Return(nil, anchors.ErrBatchProofNotFound).Once()
+	assert.NoError(t, AttachBatchProof(sender, msg, versionID))
+	receiver := mockAnchorService{}
+	assert.NoError(t, ReceiveBatchProof(receiver, transfer(msg), model))
+
+	// failed to get the proof
+	sender.On("GetBatchProof", anchorID).Return(nil, errors.New("db failed")).Once()
+	assert.Error(t, AttachBatchProof(sender, msg, versionID))
+
+	// attaching again replaces the proof
+	sender.On("GetBatchProof", anchorID).Return(proof, nil).Twice()
+	assert.NoError(t, AttachBatchProof(sender, msg, versionID))
+	assert.NoError(t, AttachBatchProof(sender, msg, versionID))
+	received := transfer(msg)
+	assert.Equal(t, versionID, received.Document.CurrentVersion)
+
+	// proof of another version
+	other := new(MockModel)
+	other.On("CurrentVersion").Return(utils.RandomSlice(32))
+	err = ReceiveBatchProof(receiver, received, other)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(anchors.ErrBatchProofInvalid, err))
+
+	// proof of another signing root
+	other = new(MockModel)
+	other.On("CurrentVersion").Return(versionID)
+	other.On("CalculateSignaturesRoot").Return(utils.RandomSlice(32), nil)
+	err = ReceiveBatchProof(receiver, received, other)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(anchors.ErrBatchProofInvalid, err))
+
+	// receiver fails to verify the proof
+	receiver.On("StoreBatchProof", proof).Return(anchors.ErrBatchProofInvalid).Once()
+	err = ReceiveBatchProof(receiver, received, model)
+	assert.Error(t, err)
+
+	// success
+	receiver.On("StoreBatchProof", proof).Return(nil).Once()
+	assert.NoError(t, ReceiveBatchProof(receiver, received, model))
+
+	// malformed proof
+	malformed := protowire.AppendTag(nil, batchProofField, protowire.BytesType)
+	malformed = protowire.AppendBytes(malformed, []byte("not a proof"))
+	msg.XXX_unrecognized = malformed
+	err = ReceiveBatchProof(receiver, transfer(msg), model)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(anchors.ErrBatchProofInvalid, err))
+	sender.AssertExpectations(t)
+	receiver.AssertExpectations(t)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
@@ -24,6 +25,9 @@ const (
 
 	// BootstrappedAnchorProcessor is the key to bootstrapped anchor processor
 	BootstrappedAnchorProcessor = "BootstrappedAnchorProcessor"
+
+	// BootstrappedDocumentSyncer is the key to bootstrapped document syncer
+	BootstrappedDocumentSyncer = "BootstrappedDocumentSyncer"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -110,20 +114,58 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("identity service not initialized")
 	}
 
-	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg)
-	ctx[BootstrappedAnchorProcessor] = dp
+	docSrv, ok := ctx[BootstrappedDocumentService].(Service)
+	if !ok {
+		return errors.New("document service not initialised")
+	}
+
+	pendingRepo, ok := ctx[bootstrap.BootstrappedPendingRepository].(PendingRepository)
+	if !ok {
+		return errors.New("pending document repository not initialised")
+	}
 
 	jobManager := ctx[jobs.BootstrappedService].(jobs.Manager)
+	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg, jobManager, repo)
+	ctx[BootstrappedAnchorProcessor] = dp
+	ctx[BootstrappedDocumentSyncer] = DefaultSyncer(docSrv, repo, dp.(DocumentRequestProcessor), anchorSrv, didService)
+
 	anchorTask := &documentAnchorTask{
 		BaseTask: jobsv1.BaseTask{
 			JobManager: jobManager,
 		},
-		config:        cfgService,
-		processor:     dp,
-		modelGetFunc:  repo.Get,
-		modelSaveFunc: repo.Update,
+		config:         cfgService,
+		processor:      dp,
+		modelGetFunc:   repo.Get,
+		modelSaveFunc:  repo.Update,
+		checkpointRepo: repo,
+		pendingRepo:    pendingRepo,
+		anchorSrv:      anchorSrv,
 	}
 
 	queueSrv.RegisterTaskType(documentAnchorTaskName, anchorTask)
+	ctx[bootstrap.BootstrappedAnchorRecovery] = &anchorRecovery{
+		repo:        repo,
+		pendingRepo: pendingRepo,
+		anchorSrv:   anchorSrv,
+		jobManager:  jobManager,
+		queueSrv:    queueSrv,
+		delay:       anchorRecoveryDelay,
+	}
+	ctx[bootstrap.BootstrappedDeliveryQueue] = &deliveryQueue{
+		repo:      repo,
+		config:    cfgService,
+		processor: dp,
+		interval:  deliveryQueueInterval,
+	}
+	ctx[bootstrap.BootstrappedAnchorExpiryMonitor] = &anchorExpiryMonitor{
+		repo:      repo,
+		anchorSrv: anchorSrv,
+		processor: dp,
+		config:    cfgService,
+		notifier:  notification.NewWebhookSender(),
+		warning:   cfg.GetAnchorExpiryWarning(),
+		renew:     cfg.GetAnchorRenewalEnabled(),
+		interval:  anchorExpiryInterval,
+	}
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/crypto"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/proofwire"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/precise-proofs/proofs"
@@ -81,6 +82,9 @@ const (
 
 	// Committed status represents document is committed/anchored.
 	Committed Status = "committed"
+
+	// Rejected status represents document version received for signing is rejected.
+	Rejected Status = "rejected"
 )
 
 // Status represents the document status.
@@ -108,9 +112,9 @@ func CompactProperties(key string) []byte {
 	case SigningTreePrefix:
 		return []byte{2, 0, 0, 0}
 	case SignaturesTreePrefix:
-		return []byte{3, 0, 0, 0}
+		return proofwire.SignaturesTreeProperty()
 	case DRTreePrefix:
-		return []byte{4, 0, 0, 0}
+		return proofwire.DRTreeProperty()
 	case BasicDataRootPrefix:
 		return []byte{5, 0, 0, 0}
 	case ZKDataRootPrefix:
@@ -424,9 +428,9 @@ func (cd *CoreDocument) createProofs(fromZKTree bool, docType string, dataLeaves
 		return nil, err
 	}
 
-	targetTree := basicDataTree
+	targetTree, tree := basicDataTree, BasicTree
 	if fromZKTree {
-		targetTree = zkDataTree
+		targetTree, tree = zkDataTree, ZKTree
 	}
 
 	treeProofs[dataPrefix] = targetTree
@@ -439,12 +443,22 @@ func (cd *CoreDocument) createProofs(fromZKTree bool, docType string, dataLeaves
 		return nil, err
 	}
 
+	var circuitProofs []CircuitProof
+	if fromZKTree {
+		circuitProofs, err = ConvertCircuitProofs(rawProofs)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrCDTree, errors.New("failed to generate circuit proofs: %v", err))
+		}
+	}
+
 	return &DocumentProof{
 		FieldProofs:    rawProofs,
 		LeftDataRooot:  basicDataTree.RootHash(),
 		RightDataRoot:  zkDataTree.RootHash(),
 		SigningRoot:    sdr,
 		SignaturesRoot: signatureTree.RootHash(),
+		Tree:           tree,
+		CircuitProofs:  circuitProofs,
 	}, nil
 }
 
@@ -1136,6 +1150,12 @@ func (cd *CoreDocument) UpdateRole(rk []byte, collabs []identity.DID) (*coredocu
 	return r, nil
 }
 
+// RoleKeyFromString returns the 32 byte role key of the key used to add the role.
+// key can either be plain text or 32 byte hex string.
+func RoleKeyFromString(key string) ([]byte, error) {
+	return get32ByteKey(key)
+}
+
 func get32ByteKey(key string) ([]byte, error) {
 	key = strings.TrimSpace(key)
 	if key == "" {
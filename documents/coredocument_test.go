@@ -672,11 +672,34 @@ func TestCreateProofs_fromZKTree(t *testing.T) {
 	assert.NoError(t, err)
 	// Sibling hash for proofs from basic tree should be the ZK tree roothash
 	assert.Equal(t, trees[1].RootHash(), pfs.RightDataRoot)
+	assert.Equal(t, BasicTree, pfs.Tree)
+	assert.Empty(t, pfs.CircuitProofs)
 
 	pfs, err = cd.CreateProofsFromZKTree(documenttypes.InvoiceDataTypeUrl, testTree.GetLeaves(), []string{"prefix.sample_field"})
 	assert.NoError(t, err)
 	// Sibling hash for proofs from ZK tree should be the basicTree roothash
 	assert.Equal(t, trees[1].RootHash(), pfs.RightDataRoot)
+	assert.Equal(t, ZKTree, pfs.Tree)
+
+	// circuit proof leads to the ZK tree root
+	assert.Len(t, pfs.CircuitProofs, 1)
+	cp := pfs.CircuitProofs[0]
+	assert.Equal(t, pfs.FieldProofs[0].GetCompactName(), cp.Property.Bytes())
+	assert.Equal(t, pfs.FieldProofs[0].Hash, cp.Leaf.Bytes())
+	assert.Len(t, cp.Siblings, ZKTreeDepth)
+	assert.Len(t, cp.PathIndices, ZKTreeDepth)
+	h, err := blake2b.New256(nil)
+	assert.NoError(t, err)
+	node := cp.Leaf.Bytes()
+	for i, s := range cp.Siblings {
+		assert.Equal(t, int(cp.LeafIndex>>uint(i)&1), cp.PathIndices[i])
+		if cp.PathIndices[i] == 0 {
+			node = proofs.HashTwoValues(node, s, h)
+		} else {
+			node = proofs.HashTwoValues(s, node, h)
+		}
+	}
+	assert.Equal(t, trees[1].RootHash(), node)
 }
 
 func TestGetDataTreePrefix(t *testing.T) {
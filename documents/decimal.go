@@ -75,6 +75,11 @@ func (d *Decimal) String() string {
 	return d.dec.String()
 }
 
+// Cmp compares d and y and returns -1, 0, or 1 if d is less than, equal to, or greater than y.
+func (d *Decimal) Cmp(y *Decimal) int {
+	return d.dec.Cmp(y.dec)
+}
+
 // Bytes return the decimal in bytes.
 // sign byte + upto 23 integer bytes + 8 decimal bytes
 func (d *Decimal) Bytes() (decimal []byte, err error) {
@@ -301,3 +301,13 @@ func TestDecimalsToStrings(t *testing.T) {
 		assert.Equal(t, res, c.res)
 	}
 }
+
+func TestDecimal_Cmp(t *testing.T) {
+	x, err := NewDecimal("100.5")
+	assert.NoError(t, err)
+	y, err := NewDecimal("-100.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, x.Cmp(y))
+	assert.Equal(t, -1, y.Cmp(x))
+	assert.Equal(t, 0, x.Cmp(x))
+}
@@ -0,0 +1,278 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// deliveryMaxAttempts is the number of attempts after which the delivery is marked as failed.
+	deliveryMaxAttempts = 10
+
+	// deliveryBaseBackoff is the wait before the first retry. Wait is doubled for each retry.
+	deliveryBaseBackoff = 30 * time.Second
+
+	// deliveryMaxBackoff is the maximum wait between the retries.
+	deliveryMaxBackoff = 6 * time.Hour
+
+	// deliveryQueueInterval is the interval at which the pending deliveries are retried.
+	deliveryQueueInterval = 10 * time.Second
+)
+
+// DeliveryStatus is the status of the delivery of an anchored document version to a collaborator.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is the status of the delivery waiting to be retried.
+	DeliveryPending DeliveryStatus = "pending"
+
+	// DeliveryDelivered is the status of the delivery accepted by the collaborator.
+	DeliveryDelivered DeliveryStatus = "delivered"
+
+	// DeliveryFailed is the status of the delivery once all the attempts have failed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery holds the delivery status of an anchored document version to a collaborator.
+type Delivery struct {
+	AccountID    identity.DID   `json:"account_id"`
+	DocumentID   []byte         `json:"document_id"`
+	VersionID    []byte         `json:"version_id"`
+	Collaborator identity.DID   `json:"collaborator"`
+	Status       DeliveryStatus `json:"status"`
+	Attempts     int            `json:"attempts"`
+
+	// LastError is the error of the last failed attempt.
+	LastError string `json:"last_error"`
+
+	// NextAttempt is the time after which the pending delivery is retried.
+	NextAttempt time.Time `json:"next_attempt"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// JSON marshals Delivery to json bytes.
+func (d *Delivery) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Type returns the type of Delivery.
+func (d *Delivery) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+// FromJSON loads json bytes to Delivery.
+func (d *Delivery) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// deliveryBackoff returns the wait before the next attempt once the delivery has failed the attempts.
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := deliveryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliveryMaxBackoff {
+			return deliveryMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// recordAttempt updates the delivery with the result of an attempt.
+func (d *Delivery) recordAttempt(err error) {
+	d.Attempts++
+	d.UpdatedAt = time.Now().UTC()
+	if err == nil {
+		d.Status = DeliveryDelivered
+		d.LastError = ""
+		return
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= deliveryMaxAttempts {
+		d.Status = DeliveryFailed
+		return
+	}
+
+	d.Status = DeliveryPending
+	d.NextAttempt = d.UpdatedAt.Add(deliveryBackoff(d.Attempts))
+}
+
+// reset moves the delivery to pending so that it is attempted again by the delivery queue.
+func (d *Delivery) reset() {
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.LastError = ""
+	d.UpdatedAt = time.Now().UTC()
+	d.NextAttempt = d.UpdatedAt
+}
+
+// recordDelivery stores the result of sending the anchored document version to the collaborator.
+func recordDelivery(repo Repository, accountID identity.DID, model Model, collaborator identity.DID, err error) error {
+	d, gerr := repo.GetDelivery(accountID[:], model.CurrentVersion(), collaborator)
+	if gerr != nil {
+		d = &Delivery{
+			AccountID:    accountID,
+			DocumentID:   model.ID(),
+			VersionID:    model.CurrentVersion(),
+			Collaborator: collaborator,
+		}
+	}
+
+	d.recordAttempt(err)
+	return repo.StoreDelivery(d)
+}
+
+// GetDeliveries returns the delivery status of the anchored document version to each collaborator.
+func (s service) GetDeliveries(ctx context.Context, documentID, versionID []byte) ([]*Delivery, error) {
+	if _, err := s.getVersion(ctx, documentID, versionID); err != nil {
+		return nil, err
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.GetDeliveries(did[:], versionID)
+}
+
+// ResendDocument queues the anchored document version to be sent to the collaborator again.
+func (s service) ResendDocument(ctx context.Context, documentID, versionID []byte, collaborator identity.DID) (*Delivery, error) {
+	model, err := s.getVersion(ctx, documentID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.GetStatus() != Committed {
+		return nil, errors.NewTypedError(ErrDocumentNotInAllowedState, errors.New("document version is not anchored"))
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	cs, err := model.GetSignerCollaborators(did)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDIDIn(cs, collaborator) {
+		return nil, errors.NewTypedError(ErrDeliveryInvalidCollaborator, errors.New("%s is not a collaborator", collaborator))
+	}
+
+	d, err := s.repo.GetDelivery(did[:], versionID, collaborator)
+	if err != nil {
+		d = &Delivery{
+			AccountID:    did,
+			DocumentID:   documentID,
+			VersionID:    versionID,
+			Collaborator: collaborator,
+		}
+	}
+
+	d.reset()
+	return d, s.repo.StoreDelivery(d)
+}
+
+func isDIDIn(dids []identity.DID, did identity.DID) bool {
+	for _, d := range dids {
+		if d.Equal(did) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deliveryQueue retries the pending deliveries of the anchored documents.
+// deliveryQueue implements node.Server.
+type deliveryQueue struct {
+	repo      Repository
+	config    config.Service
+	processor AnchorProcessor
+	interval  time.Duration
+}
+
+// Name returns the name of the server.
+func (*deliveryQueue) Name() string {
+	return "DeliveryQueue"
+}
+
+// Start retries the pending deliveries at every interval until the context is done.
+func (q *deliveryQueue) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.deliverPending(ctx)
+		}
+	}
+}
+
+// deliverPending attempts the pending deliveries that are due.
+func (q *deliveryQueue) deliverPending(ctx context.Context) {
+	ds, err := q.repo.GetPendingDeliveries()
+	if err != nil {
+		log.Errorf("failed to get pending deliveries: %v", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, d := range ds {
+		if d.NextAttempt.After(now) {
+			continue
+		}
+
+		err := q.deliver(ctx, d)
+		if err != nil {
+			log.Errorf("failed to deliver version %s to %s: %v", hexutil.Encode(d.VersionID), d.Collaborator, err)
+		}
+	}
+}
+
+// deliver sends the document version to the collaborator and stores the result.
+func (q *deliveryQueue) deliver(ctx context.Context, d *Delivery) error {
+	acc, err := q.config.GetAccount(d.AccountID[:])
+	if err != nil {
+		return err
+	}
+
+	ctx, err = contextutil.New(ctx, acc)
+	if err != nil {
+		return err
+	}
+
+	model, err := q.repo.Get(d.AccountID[:], d.VersionID)
+	if err != nil {
+		return err
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return err
+	}
+
+	serr := q.processor.Send(ctx, cd, d.Collaborator)
+	d.recordAttempt(serr)
+	err = q.repo.StoreDelivery(d)
+	if err != nil {
+		return err
+	}
+
+	return serr
+}
@@ -0,0 +1,158 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	assert.Equal(t, deliveryBaseBackoff, deliveryBackoff(1))
+	assert.Equal(t, 4*deliveryBaseBackoff, deliveryBackoff(3))
+	assert.Equal(t, deliveryMaxBackoff, deliveryBackoff(20))
+}
+
+func TestDelivery_recordAttempt(t *testing.T) {
+	d := new(Delivery)
+
+	// failed attempt is retried
+	d.recordAttempt(errors.New("offline"))
+	assert.Equal(t, DeliveryPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, "offline", d.LastError)
+	assert.Equal(t, d.UpdatedAt.Add(deliveryBaseBackoff), d.NextAttempt)
+
+	// delivered
+	d.recordAttempt(nil)
+	assert.Equal(t, DeliveryDelivered, d.Status)
+	assert.Equal(t, 2, d.Attempts)
+	assert.Empty(t, d.LastError)
+
+	// all attempts failed
+	d.Attempts = deliveryMaxAttempts - 1
+	d.recordAttempt(errors.New("offline"))
+	assert.Equal(t, DeliveryFailed, d.Status)
+
+	// reset
+	d.reset()
+	assert.Equal(t, DeliveryPending, d.Status)
+	assert.Equal(t, 0, d.Attempts)
+	assert.Equal(t, d.UpdatedAt, d.NextAttempt)
+}
+
+func TestService_GetDeliveries(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	repo := new(MockRepository)
+	s := service{repo: repo}
+
+	// missing version
+	repo.On("Get", did[:], versionID).Return(nil, errors.New("missing")).Once()
+	_, err = s.GetDeliveries(ctx, docID, versionID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentVersionNotFound, err))
+
+	// success
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	repo.On("Get", did[:], versionID).Return(m, nil).Once()
+	ds := []*Delivery{{VersionID: versionID, Status: DeliveryDelivered}}
+	repo.On("GetDeliveries", did[:], versionID).Return(ds, nil).Once()
+	got, err := s.GetDeliveries(ctx, docID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, ds, got)
+	repo.AssertExpectations(t)
+}
+
+func TestService_ResendDocument(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	collab := testingidentity.GenerateRandomDID()
+	repo := new(MockRepository)
+	s := service{repo: repo}
+
+	// not anchored
+	m := new(mockModel)
+	m.On("ID").Return(docID)
+	m.On("GetStatus").Return(Committing).Once()
+	repo.On("Get", did[:], versionID).Return(m, nil)
+	_, err = s.ResendDocument(ctx, docID, versionID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotInAllowedState, err))
+
+	// not a collaborator
+	m.On("GetStatus").Return(Committed)
+	m.On("GetSignerCollaborators", []identity.DID{did}).Return([]identity.DID{testingidentity.GenerateRandomDID()}, nil).Once()
+	_, err = s.ResendDocument(ctx, docID, versionID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDeliveryInvalidCollaborator, err))
+
+	// failed delivery is reset
+	m.On("GetSignerCollaborators", []identity.DID{did}).Return([]identity.DID{collab}, nil).Once()
+	repo.On("GetDelivery", did[:], versionID, collab).Return(&Delivery{
+		AccountID:    did,
+		VersionID:    versionID,
+		Collaborator: collab,
+		Status:       DeliveryFailed,
+		Attempts:     deliveryMaxAttempts,
+		LastError:    "offline",
+	}, nil).Once()
+	repo.On("StoreDelivery", mock.Anything).Return(nil).Once()
+	d, err := s.ResendDocument(ctx, docID, versionID, collab)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveryPending, d.Status)
+	assert.Equal(t, 0, d.Attempts)
+	assert.Empty(t, d.LastError)
+	repo.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
+
+func TestDeliveryQueue_deliverPending(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+	versionID := utils.RandomSlice(32)
+	due := &Delivery{AccountID: did, VersionID: versionID, Collaborator: collab, Status: DeliveryPending, Attempts: 1}
+	later := &Delivery{AccountID: did, Status: DeliveryPending, NextAttempt: time.Now().Add(time.Hour)}
+
+	repo := new(MockRepository)
+	repo.On("GetPendingDeliveries").Return([]*Delivery{due, later}, nil).Once()
+	cfgSrv := new(configstore.MockService)
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil).Once()
+	m := new(MockModel)
+	cd := coredocumentpb.CoreDocument{DocumentIdentifier: utils.RandomSlice(32)}
+	m.On("PackCoreDocument").Return(cd, nil).Once()
+	repo.On("Get", did[:], versionID).Return(m, nil).Once()
+	repo.On("StoreDelivery", due).Return(nil).Once()
+	proc := new(mockAnchorProcessor)
+	proc.On("Send", mock.Anything, cd, collab).Return(nil).Once()
+	q := &deliveryQueue{repo: repo, config: cfgSrv, processor: proc}
+	q.deliverPending(context.Background())
+	assert.Equal(t, DeliveryDelivered, due.Status)
+	assert.Equal(t, 2, due.Attempts)
+	assert.Equal(t, DeliveryPending, later.Status)
+	repo.AssertExpectations(t)
+	cfgSrv.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,243 @@
+package documents
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// collaboratorRead is the access of the collaborators with read access.
+	collaboratorRead = "read"
+
+	// collaboratorReadWrite is the access of the collaborators with read and write access.
+	collaboratorReadWrite = "read_write"
+)
+
+// FieldChange holds the human readable name, old and new value of a changed field.
+// Old is empty for added fields and New is empty for removed fields.
+type FieldChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// ChangeSet holds the fields added, removed, and modified in a document version.
+type ChangeSet struct {
+	Added    []FieldChange
+	Removed  []FieldChange
+	Modified []FieldChange
+}
+
+// Diff holds the changes between two versions of a document.
+type Diff struct {
+	FromVersion []byte
+	ToVersion   []byte
+
+	// Attributes are keyed by the attribute label.
+	Attributes ChangeSet
+
+	// Roles are keyed by the hex encoded role key and the values are the comma separated collaborators of the role.
+	Roles ChangeSet
+
+	// Collaborators are keyed by the DID and the values are the access of the collaborator.
+	Collaborators ChangeSet
+
+	// NFTs are keyed by the registry address and the values are the hex encoded token IDs.
+	NFTs ChangeSet
+
+	// Data are keyed by the readable property name of the field and the values are the hex encoded leaf values.
+	Data ChangeSet
+}
+
+// diffValues returns the ChangeSet between old and new values keyed by name.
+// Changes are sorted by name.
+func diffValues(old, new map[string]string) (cs ChangeSet) {
+	for name, ov := range old {
+		nv, ok := new[name]
+		if !ok {
+			cs.Removed = append(cs.Removed, FieldChange{Name: name, Old: ov})
+			continue
+		}
+
+		if ov != nv {
+			cs.Modified = append(cs.Modified, FieldChange{Name: name, Old: ov, New: nv})
+		}
+	}
+
+	for name, nv := range new {
+		if _, ok := old[name]; !ok {
+			cs.Added = append(cs.Added, FieldChange{Name: name, New: nv})
+		}
+	}
+
+	sortFieldChanges(cs.Added)
+	sortFieldChanges(cs.Removed)
+	sortFieldChanges(cs.Modified)
+	return cs
+}
+
+func sortFieldChanges(fcs []FieldChange) {
+	sort.Slice(fcs, func(i, j int) bool {
+		return fcs[i].Name < fcs[j].Name
+	})
+}
+
+func attributeValues(m Model) (map[string]string, error) {
+	vals := make(map[string]string)
+	for _, attr := range m.GetAttributes() {
+		v, err := attr.Value.String()
+		if err != nil {
+			return nil, err
+		}
+
+		vals[attr.KeyLabel] = v
+	}
+
+	return vals, nil
+}
+
+func roleValues(m Model) (map[string]string, error) {
+	cd, err := m.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make(map[string]string)
+	for _, role := range cd.Roles {
+		var collabs []string
+		for _, c := range role.Collaborators {
+			did, err := identity.NewDIDFromBytes(c)
+			if err != nil {
+				return nil, err
+			}
+
+			collabs = append(collabs, did.String())
+		}
+
+		vals[hexutil.Encode(role.RoleKey)] = strings.Join(collabs, ",")
+	}
+
+	return vals, nil
+}
+
+func collaboratorValues(m Model) (map[string]string, error) {
+	ca, err := m.GetCollaborators()
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make(map[string]string)
+	for _, did := range ca.ReadCollaborators {
+		vals[did.String()] = collaboratorRead
+	}
+
+	for _, did := range ca.ReadWriteCollaborators {
+		vals[did.String()] = collaboratorReadWrite
+	}
+
+	return vals, nil
+}
+
+func nftValues(m Model) map[string]string {
+	vals := make(map[string]string)
+	for _, nft := range m.NFTs() {
+		// registry ID is the registry address padded with 12 empty bytes
+		registry := common.BytesToAddress(nft.RegistryId[:common.AddressLength])
+		vals[registry.Hex()] = hexutil.Encode(nft.TokenId)
+	}
+
+	return vals
+}
+
+// leafValue returns the hex encoded value of the leaf.
+// Hash is returned for hashed leaves since the value is not available.
+func leafValue(leaf *proofs.LeafNode) string {
+	if leaf.Hashed {
+		return hexutil.Encode(leaf.Hash)
+	}
+
+	return hexutil.Encode(leaf.Value)
+}
+
+func dataChanges(from, to Model) (cs ChangeSet, err error) {
+	oldTree, err := from.DocumentDataTree()
+	if err != nil {
+		return cs, err
+	}
+
+	newTree, err := to.DocumentDataTree()
+	if err != nil {
+		return cs, err
+	}
+
+	for _, cf := range GetChangedFields(oldTree, newTree) {
+		_, ol := oldTree.GetLeafByProperty(cf.Name)
+		_, nl := newTree.GetLeafByProperty(cf.Name)
+		switch {
+		case ol == nil:
+			cs.Added = append(cs.Added, FieldChange{Name: cf.Name, New: leafValue(nl)})
+		case nl == nil:
+			cs.Removed = append(cs.Removed, FieldChange{Name: cf.Name, Old: leafValue(ol)})
+		default:
+			cs.Modified = append(cs.Modified, FieldChange{Name: cf.Name, Old: leafValue(ol), New: leafValue(nl)})
+		}
+	}
+
+	return cs, nil
+}
+
+// GetDiff returns the changes made in the document version `to` compared to the version `from`.
+// Both the versions are expected to be of the same document.
+func GetDiff(from, to Model) (diff Diff, err error) {
+	diff.FromVersion = from.CurrentVersion()
+	diff.ToVersion = to.CurrentVersion()
+	diffs := []struct {
+		cs   *ChangeSet
+		vals func(m Model) (map[string]string, error)
+	}{
+		{cs: &diff.Attributes, vals: attributeValues},
+		{cs: &diff.Roles, vals: roleValues},
+		{cs: &diff.Collaborators, vals: collaboratorValues},
+		{cs: &diff.NFTs, vals: func(m Model) (map[string]string, error) {
+			return nftValues(m), nil
+		}},
+	}
+
+	for _, d := range diffs {
+		ov, err := d.vals(from)
+		if err != nil {
+			return diff, err
+		}
+
+		nv, err := d.vals(to)
+		if err != nil {
+			return diff, err
+		}
+
+		*d.cs = diffValues(ov, nv)
+	}
+
+	diff.Data, err = dataChanges(from, to)
+	return diff, err
+}
+
+// GetVersionsDiff returns the changes made in the document version `to` compared to the version `from`.
+func (s service) GetVersionsDiff(ctx context.Context, documentID, from, to []byte) (Diff, error) {
+	fm, err := s.getVersion(ctx, documentID, from)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	tm, err := s.getVersion(ctx, documentID, to)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	return GetDiff(fm, tm)
+}
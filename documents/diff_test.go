@@ -0,0 +1,70 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiffValues(t *testing.T) {
+	old := map[string]string{
+		"a": "1",
+		"b": "2",
+		"c": "3",
+	}
+
+	new := map[string]string{
+		"a": "1",
+		"c": "4",
+		"e": "5",
+		"d": "6",
+	}
+
+	cs := diffValues(old, new)
+	assert.Equal(t, []FieldChange{{Name: "d", New: "6"}, {Name: "e", New: "5"}}, cs.Added)
+	assert.Equal(t, []FieldChange{{Name: "b", Old: "2"}}, cs.Removed)
+	assert.Equal(t, []FieldChange{{Name: "c", Old: "3", New: "4"}}, cs.Modified)
+
+	cs = diffValues(old, old)
+	assert.Empty(t, cs.Added)
+	assert.Empty(t, cs.Removed)
+	assert.Empty(t, cs.Modified)
+}
+
+func TestService_GetVersionsDiff(t *testing.T) {
+	s := service{}
+	docID := utils.RandomSlice(32)
+	from, to := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing account
+	_, err := s.GetVersionsDiff(context.Background(), docID, from, to)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// missing version
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(MockRepository)
+	repo.On("Get", mock.Anything, from).Return(nil, errors.New("not found")).Once()
+	s.repo = repo
+	_, err = s.GetVersionsDiff(ctx, docID, from, to)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentVersionNotFound, err))
+
+	// version of a different document
+	fm, tm := new(MockModel), new(MockModel)
+	fm.On("ID").Return(docID)
+	tm.On("ID").Return(utils.RandomSlice(32))
+	repo.On("Get", mock.Anything, from).Return(fm, nil)
+	repo.On("Get", mock.Anything, to).Return(tm, nil)
+	_, err = s.GetVersionsDiff(ctx, docID, from, to)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentVersionNotFound, err))
+	repo.AssertExpectations(t)
+}
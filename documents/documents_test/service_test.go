@@ -27,6 +27,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -78,6 +79,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	idSrv := new(testingcommons.MockIdentityService)
 	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	ar := new(mockAnchorRepo)
+	ar.On("RepositoryAddress").Return(common.Address{})
 	docRoot, err := doc.CalculateDocumentRoot()
 	assert.NoError(t, err)
 	dr, err := anchors.ToDocumentRoot(docRoot)
@@ -86,8 +88,8 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	zeroRoot, err := anchors.ToDocumentRoot(zeros[:])
 	assert.NoError(t, err)
 	nextAid, err := anchors.ToAnchorID(doc.NextVersion())
-	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
-	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
+	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), uint32(0), errors.New("missing"))
+	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), uint32(0), nil)
 	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.Error(t, err)
@@ -100,14 +102,15 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	idSrv = new(testingcommons.MockIdentityService)
 	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	ar = new(mockAnchorRepo)
+	ar.On("RepositoryAddress").Return(common.Address{})
 	docRoot, err = doc.CalculateDocumentRoot()
 	assert.NoError(t, err)
 	dr, err = anchors.ToDocumentRoot(docRoot)
 	assert.NoError(t, err)
 	nextAid, err = anchors.ToAnchorID(doc.NextVersion())
 	assert.NoError(t, err)
-	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
-	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
+	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), uint32(0), errors.New("missing"))
+	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), uint32(0), nil)
 	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.NoError(t, err)
@@ -140,12 +143,13 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	// valid transition for id2
 	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	ar = new(mockAnchorRepo)
+	ar.On("RepositoryAddress").Return(common.Address{})
 	dr, err = anchors.ToDocumentRoot(ndr)
 	assert.NoError(t, err)
 	nextAid, err = anchors.ToAnchorID(doc.NextVersion())
 	assert.NoError(t, err)
-	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
-	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
+	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), uint32(0), errors.New("missing"))
+	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), uint32(0), nil)
 
 	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, id2)
@@ -159,6 +163,7 @@ func getServiceWithMockedLayers() (documents.Service, testingcommons.MockIdentit
 	idService := testingcommons.MockIdentityService{}
 	idService.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	mockAnchor = &mockAnchorRepo{}
+	mockAnchor.On("RepositoryAddress").Return(common.Address{})
 	return documents.DefaultService(cfg, repo, mockAnchor, documents.NewServiceRegistry(), &idService, nil, nil), idService
 }
 
@@ -169,11 +174,17 @@ type mockAnchorRepo struct {
 
 var mockAnchor *mockAnchorRepo
 
-func (r *mockAnchorRepo) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, err error) {
+func (r *mockAnchorRepo) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
 	args := r.Called(anchorID)
 	docRoot, _ = args.Get(0).(anchors.DocumentRoot)
 	anchoredTime, _ = args.Get(1).(time.Time)
-	return docRoot, anchoredTime, args.Error(2)
+	blockNumber, _ = args.Get(2).(uint32)
+	return docRoot, anchoredTime, blockNumber, args.Error(3)
+}
+
+func (r *mockAnchorRepo) RepositoryAddress() common.Address {
+	args := r.Called()
+	return args.Get(0).(common.Address)
 }
 
 // Functions returns service mocks
@@ -183,12 +194,12 @@ func mockSignatureCheck(t *testing.T, i *generic.Generic, idService testingcommo
 	assert.NoError(t, err)
 	docRoot, err := anchors.ToDocumentRoot(dr)
 	assert.NoError(t, err)
-	mockAnchor.On("GetAnchorData", anchorID).Return(docRoot, time.Now(), nil)
+	mockAnchor.On("GetAnchorData", anchorID).Return(docRoot, time.Now(), uint32(0), nil)
 	nextAid, err := anchors.ToAnchorID(i.NextVersion())
 	assert.NoError(t, err)
 	zeros := [32]byte{}
 	zeroRoot, err := anchors.ToDocumentRoot(zeros[:])
-	mockAnchor.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
+	mockAnchor.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), uint32(0), errors.New("missing"))
 	return idService
 }
 
@@ -236,10 +247,83 @@ func TestService_CreateProofsForVersion(t *testing.T) {
 	assert.Equal(t, proof.FieldProofs[0].GetCompactName(), []byte{0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x64})
 }
 
+func TestService_CreateProofsFromZKTree(t *testing.T) {
+	// signatures are validated for each of the proofs created below
+	idService := new(testingcommons.MockIdentityService)
+	idService.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
+	mockAnchor = &mockAnchorRepo{}
+	mockAnchor.On("RepositoryAddress").Return(common.Address{})
+	service := documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idService, nil, nil)
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	g, _ := createCDWithEmbeddedDocument(t, ctxh, nil, false)
+	mockSignatureCheck(t, g.(*generic.Generic), testingcommons.MockIdentityService{})
+	proof, err := service.CreateProofsFromZKTree(ctxh, g.ID(), nil, []string{"cd_tree.document_type"})
+	assert.NoError(t, err)
+	assert.Equal(t, g.CurrentVersion(), proof.VersionID)
+	assert.Equal(t, documents.ZKTree, proof.Tree)
+	assert.Len(t, proof.FieldProofs, 1)
+	assert.Len(t, proof.FieldProofs[0].Hashes, documents.ZKTreeDepth)
+	assert.Len(t, proof.CircuitProofs, 1)
+
+	proof, err = service.CreateProofsFromZKTree(ctxh, g.ID(), g.CurrentVersion(), []string{"cd_tree.document_type"})
+	assert.NoError(t, err)
+	assert.Equal(t, documents.ZKTree, proof.Tree)
+
+	// missing version
+	_, err = service.CreateProofsFromZKTree(ctxh, g.ID(), utils.RandomSlice(32), []string{"cd_tree.document_type"})
+	assert.Error(t, err)
+	idService.AssertExpectations(t)
+}
+
+func TestService_CreateProofBundle(t *testing.T) {
+	idService := &testingcommons.MockIdentityService{}
+	mockAnchor = &mockAnchorRepo{}
+	mockAnchor.On("RepositoryAddress").Return(common.Address{})
+	service := documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idService, nil, nil)
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	g, _ := createCDWithEmbeddedDocument(t, ctxh, nil, false)
+	mockSignatureCheck(t, g.(*generic.Generic), *idService)
+	sig := g.Signatures()[0]
+	key, err := utils.SliceToByte32(sig.PublicKey)
+	assert.NoError(t, err)
+	idService.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	idService.On("GetKey", did, key).Return(&identity.KeyResponse{Key: key, RevokedAt: 10}, nil)
+
+	// missing document
+	_, err = service.CreateProofBundle(ctxh, utils.RandomSlice(32), nil, []string{"cd_tree.document_type"})
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// latest version with an invalid signing key
+	idService.On("ValidateKey", mock.Anything, did, sig.PublicKey, mock.Anything).Return(errors.New("revoked")).Once()
+	bundle, err := service.CreateProofBundle(ctxh, g.ID(), nil, []string{"cd_tree.document_type"})
+	assert.NoError(t, err)
+	assert.Equal(t, documents.ProofBundleVersion, bundle.Version)
+	assert.Equal(t, g.CurrentVersion(), bundle.Proof.VersionID)
+	assert.NotNil(t, bundle.Proof.Anchor)
+	assert.Len(t, bundle.Proof.FieldProofs, 2)
+	assert.Equal(t, append(documents.CompactProperties(documents.SignaturesTreePrefix), []byte{0, 0, 0, 1}...),
+		bundle.Proof.FieldProofs[1].GetCompactName()[:8])
+	assert.Equal(t, documents.SignatureLeafValue(sig.Signature, sig.TransitionValidated), bundle.Proof.FieldProofs[1].Value)
+	assert.Len(t, bundle.Signatures, 1)
+	assert.Equal(t, sig.SignatureId, bundle.Signatures[0].SignatureID)
+	assert.Equal(t, did, bundle.Signatures[0].SignerID)
+	assert.Equal(t, sig.Signature, bundle.Signatures[0].Signature)
+	assert.False(t, bundle.Signatures[0].KeyValid)
+	assert.Equal(t, uint32(10), bundle.Signatures[0].KeyRevokedAt)
+
+	// specific version with signature field requested
+	idService.On("ValidateKey", mock.Anything, did, sig.PublicKey, mock.Anything).Return(nil).Once()
+	bundle, err = service.CreateProofBundle(ctxh, g.ID(), g.CurrentVersion(), []string{documents.SignatureField(sig.SignatureId)})
+	assert.NoError(t, err)
+	assert.Len(t, bundle.Proof.FieldProofs, 1)
+	assert.True(t, bundle.Signatures[0].KeyValid)
+	idService.AssertExpectations(t)
+}
+
 func TestService_RequestDocumentSignature(t *testing.T) {
 	srv, _ := getServiceWithMockedLayers()
 
-	mockAnchor.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("missing"))
+	mockAnchor.On("GetAnchorData", mock.Anything).Return(nil, nil, uint32(0), errors.New("missing"))
 	// self failed
 	_, err := srv.RequestDocumentSignature(context.Background(), nil, did)
 	assert.Error(t, err)
@@ -288,12 +372,40 @@ func TestService_RequestDocumentSignature(t *testing.T) {
 	_, err = srv.RequestDocumentSignature(ctxh, doc, id2)
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+	assert.True(t, errors.IsOfType(documents.ErrDocumentRejected, err))
 	assert.Contains(t, err.Error(), "invalid document state transition")
+	rerr, ok := err.(*documents.RejectionError)
+	assert.True(t, ok)
+	assert.Contains(t, rerr.Codes, documents.RejectionCodeUnauthorizedTransition)
+
+	// rejected version and the rejection are stored
+	m, err := testRepo().Get(did[:], doc.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, documents.Rejected, m.GetStatus())
+	rejection, err := testRepo().GetRejection(did[:], doc.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, doc.ID(), rejection.DocumentID)
+	assert.Equal(t, id2, rejection.Requester)
+	assert.Equal(t, rerr.Codes, rejection.Codes)
+	assert.Contains(t, rejection.Reason, "invalid document state transition")
+
+	// rejected version of an unknown document is not stored
+	doc2, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{id}, true)
+	_, err = srv.RequestDocumentSignature(ctxh, doc2, id2)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentRejected, err))
+	assert.False(t, testRepo().Exists(did[:], doc2.CurrentVersion()))
+	_, err = testRepo().GetRejection(did[:], doc2.CurrentVersion())
+	assert.True(t, errors.IsOfType(documents.ErrRejectionNotFound, err))
 
 	// valid transition
 	sigs, err = srv.RequestDocumentSignature(ctxh, doc, did)
 	assert.NoError(t, err)
 	assert.True(t, sigs[0].TransitionValidated)
+
+	// rejected version is replaced
+	m, err = testRepo().Get(did[:], doc.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, documents.Committing, m.GetStatus())
 }
 
 func TestService_CreateProofsForVersionDocumentDoesntExist(t *testing.T) {
@@ -0,0 +1,114 @@
+// +build unit
+
+package documents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// nextVersion adds a new signed version to the document and returns its core document and document root.
+func nextVersion(t *testing.T, ctx context.Context, doc documents.Model) (coredocumentpb.CoreDocument, []byte) {
+	g := doc.(*generic.Generic)
+	err := g.AddNFT(true, testingidentity.GenerateRandomDID().ToAddress(), utils.RandomSlice(32))
+	assert.NoError(t, err)
+	err = g.AddUpdateLog(did)
+	assert.NoError(t, err)
+	sr, err := g.CalculateSigningRoot()
+	assert.NoError(t, err)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	sig, err := acc.SignMsg(sr)
+	assert.NoError(t, err)
+	g.AppendSignatures(sig)
+	dr, err := g.CalculateDocumentRoot()
+	assert.NoError(t, err)
+	cd, err := g.PackCoreDocument()
+	assert.NoError(t, err)
+	return cd, dr
+}
+
+func TestSyncer_SyncDocument_Stored(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	collab := testingidentity.GenerateRandomDID()
+	repo := testRepo()
+
+	// v1 is known, v2 and v3 are missed
+	doc, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{collab}, false)
+	docID := doc.ID()
+	cd2, dr2 := nextVersion(t, ctxh, doc)
+	cd3, dr3 := nextVersion(t, ctxh, doc)
+
+	ar := new(mockAnchorRepo)
+	ar.On("RepositoryAddress").Return(common.Address{})
+	for _, c := range []struct {
+		version, root []byte
+	}{{cd2.CurrentVersion, dr2}, {cd3.CurrentVersion, dr3}} {
+		aid, err := anchors.ToAnchorID(c.version)
+		assert.NoError(t, err)
+		dr, err := anchors.ToDocumentRoot(c.root)
+		assert.NoError(t, err)
+		ar.On("GetAnchorData", aid).Return(dr, time.Now(), uint32(0), nil)
+	}
+
+	idSrv := new(testingcommons.MockIdentityService)
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	registry := documents.NewServiceRegistry()
+	assert.NoError(t, registry.Register(documenttypes.GenericDataTypeUrl, generic.DefaultService(nil, nil, nil, nil, nil)))
+	docSrv := documents.DefaultService(cfg, repo, ar, registry, idSrv, nil, nil)
+	requester := new(testingcommons.MockRequestProcessor)
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd3}, nil).Twice()
+	requester.On("RequestDocumentVersion", collab, cd2.CurrentVersion).Return(&p2ppb.GetDocumentResponse{Document: &cd2}, nil)
+	s := documents.DefaultSyncer(docSrv, repo, requester, ar, idSrv)
+
+	vis, err := s.SyncDocument(ctxh, docID, collab)
+	assert.NoError(t, err)
+	assert.Len(t, vis, 2)
+	for i, cd := range []coredocumentpb.CoreDocument{cd2, cd3} {
+		assert.Equal(t, cd.CurrentVersion, vis[i].VersionID)
+		m, err := repo.Get(accountID, cd.CurrentVersion)
+		assert.NoError(t, err)
+		assert.Equal(t, documents.Committed, m.GetStatus())
+	}
+
+	m, err := repo.GetLatest(accountID, docID)
+	assert.NoError(t, err)
+	assert.Equal(t, cd3.CurrentVersion, m.CurrentVersion())
+
+	// collaborator has nothing newer
+	vis, err = s.SyncDocument(ctxh, docID, collab)
+	assert.NoError(t, err)
+	assert.Empty(t, vis)
+
+	// version not anchored is not stored
+	cd4, _ := nextVersion(t, ctxh, m)
+	aid, err := anchors.ToAnchorID(cd4.CurrentVersion)
+	assert.NoError(t, err)
+	ar.On("GetAnchorData", aid).Return(anchors.DocumentRoot{}, time.Time{}, uint32(0), errors.New("missing"))
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd4}, nil).Once()
+	vis, err = s.SyncDocument(ctxh, docID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+	assert.Empty(t, vis)
+	assert.False(t, repo.Exists(accountID, cd4.CurrentVersion))
+	requester.AssertExpectations(t)
+	ar.AssertExpectations(t)
+}
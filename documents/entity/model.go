@@ -224,11 +224,11 @@ func (e *Entity) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
-// getDocumentDataTree creates precise-proofs data tree for the model
-func (e *Entity) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
+// DocumentDataTree creates precise-proofs data tree for the model.
+func (e *Entity) DocumentDataTree() (tree *proofs.DocumentTree, err error) {
 	eProto := e.createP2PProtobuf()
 	if e.CoreDocument == nil {
-		return nil, errors.New("getDocumentDataTree error CoreDocument not set")
+		return nil, errors.New("DocumentDataTree error CoreDocument not set")
 	}
 	t, err := e.CoreDocument.DefaultTreeWithPrefix(prefix, compactPrefix())
 	if err != nil {
@@ -237,11 +237,11 @@ func (e *Entity) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
 
 	err = t.AddLeavesFromDocument(eProto)
 	if err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 	err = t.Generate()
 	if err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 
 	return t, nil
@@ -275,6 +275,16 @@ func (e *Entity) CreateProofs(fields []string) (prf *documents.DocumentProof, er
 	return e.CoreDocument.CreateProofs(e.DocumentType(), dataLeaves, fields)
 }
 
+// CreateProofsFromZKTree generates proofs for given fields from the ZK data tree.
+func (e *Entity) CreateProofsFromZKTree(fields []string) (prf *documents.DocumentProof, err error) {
+	dataLeaves, err := e.getDataLeaves()
+	if err != nil {
+		return nil, errors.New("createProofs error %v", err)
+	}
+
+	return e.CoreDocument.CreateProofsFromZKTree(e.DocumentType(), dataLeaves, fields)
+}
+
 // DocumentType returns the entity document type.
 func (*Entity) DocumentType() string {
 	return documenttypes.EntityDataTypeUrl
@@ -323,12 +333,12 @@ func (e *Entity) CollaboratorCanUpdate(updated documents.Model, collaborator ide
 	}
 
 	// check entity specific changes
-	oldTree, err := e.getDocumentDataTree()
+	oldTree, err := e.DocumentDataTree()
 	if err != nil {
 		return err
 	}
 
-	newTree, err := newEntity.getDocumentDataTree()
+	newTree, err := newEntity.DocumentDataTree()
 	if err != nil {
 		return err
 	}
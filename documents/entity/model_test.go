@@ -26,6 +26,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/p2p"
+	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -33,6 +34,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/assert"
@@ -62,11 +64,16 @@ func (m *mockAnchorSrv) GetDocumentRootOf(anchorID anchors.AnchorID) (anchors.Do
 	return docRoot, args.Error(1)
 }
 
-func (m *mockAnchorSrv) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, err error) {
+func (m *mockAnchorSrv) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
 	args := m.Called(anchorID)
 	docRoot, _ = args.Get(0).(anchors.DocumentRoot)
 	anchoredTime, _ = args.Get(1).(time.Time)
-	return docRoot, anchoredTime, args.Error(2)
+	return docRoot, anchoredTime, blockNumber, args.Error(2)
+}
+
+func (m *mockAnchorSrv) RepositoryAddress() common.Address {
+	args := m.Called()
+	return args.Get(0).(common.Address)
 }
 
 func TestMain(m *testing.M) {
@@ -90,6 +97,7 @@ func TestMain(m *testing.M) {
 		anchors.Bootstrapper{},
 		documents.Bootstrapper{},
 		p2p.Bootstrapper{},
+		pending.Bootstrapper{},
 		documents.PostBootstrapper{},
 		&queue.Starter{},
 	}
@@ -214,10 +222,10 @@ func TestEntityModel_GetDocumentID(t *testing.T) {
 	assert.Equal(t, e.CoreDocument.ID(), e.ID())
 }
 
-func TestEntityModel_getDocumentDataTree(t *testing.T) {
+func TestEntityModel_DocumentDataTree(t *testing.T) {
 	ctx := testingconfig.CreateAccountContext(t, cfg)
 	e, _ := CreateEntityWithEmbedCD(t, ctx, did, nil)
-	tree, err := e.getDocumentDataTree()
+	tree, err := e.DocumentDataTree()
 	assert.Nil(t, err, "tree should be generated without error")
 	_, leaf := tree.GetLeafByProperty("entity.legal_name")
 	assert.NotNil(t, leaf)
@@ -631,3 +639,55 @@ func calculateBasicDataRoot(t *testing.T, e *Entity) []byte {
 	assert.NoError(t, err)
 	return trees[0].RootHash()
 }
+
+func TestEntity_GetDiff(t *testing.T) {
+	old, _ := CreateEntityWithEmbedCD(t, testingconfig.CreateAccountContext(t, cfg), did, nil)
+	data := old.Data
+	data.LegalName = "new legal name"
+	d, err := json.Marshal(data)
+	assert.NoError(t, err)
+	attr, err := documents.NewStringAttribute("test", documents.AttrString, "value")
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+	payload := documents.UpdatePayload{
+		DocumentID: old.ID(),
+		CreatePayload: documents.CreatePayload{
+			Data:       d,
+			Attributes: map[documents.AttrKey]documents.Attribute{attr.Key: attr},
+			Collaborators: documents.CollaboratorsAccess{
+				ReadWriteCollaborators: []identity.DID{collab},
+			},
+		},
+	}
+	m, err := old.DeriveFromUpdatePayload(context.Background(), payload)
+	assert.NoError(t, err)
+
+	// no changes
+	diff, err := documents.GetDiff(old, old)
+	assert.NoError(t, err)
+	assert.Equal(t, old.CurrentVersion(), diff.FromVersion)
+	assert.Empty(t, diff.Attributes.Added)
+	assert.Empty(t, diff.Collaborators.Added)
+	assert.Empty(t, diff.Data.Modified)
+
+	diff, err = documents.GetDiff(old, m)
+	assert.NoError(t, err)
+	assert.Equal(t, old.CurrentVersion(), diff.FromVersion)
+	assert.Equal(t, m.CurrentVersion(), diff.ToVersion)
+	assert.Equal(t, []documents.FieldChange{{Name: "test", New: "value"}}, diff.Attributes.Added)
+	assert.Empty(t, diff.Attributes.Removed)
+	assert.Equal(t, []documents.FieldChange{{Name: collab.String(), New: "read_write"}}, diff.Collaborators.Added)
+	assert.NotEmpty(t, diff.Roles.Added)
+	assert.Empty(t, diff.NFTs.Added)
+	assert.Equal(t, []documents.FieldChange{{
+		Name: "entity.legal_name",
+		Old:  hexutil.Encode([]byte(old.Data.LegalName)),
+		New:  hexutil.Encode([]byte("new legal name")),
+	}}, diff.Data.Modified)
+
+	// reverse diff
+	diff, err = documents.GetDiff(m, old)
+	assert.NoError(t, err)
+	assert.Equal(t, []documents.FieldChange{{Name: "test", Old: "value"}}, diff.Attributes.Removed)
+	assert.Equal(t, []documents.FieldChange{{Name: collab.String(), Old: "read_write"}}, diff.Collaborators.Removed)
+}
@@ -154,11 +154,11 @@ func (e *EntityRelationship) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
-// getDocumentDataTree creates precise-proofs data tree for the model
-func (e *EntityRelationship) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
+// DocumentDataTree creates precise-proofs data tree for the model.
+func (e *EntityRelationship) DocumentDataTree() (tree *proofs.DocumentTree, err error) {
 	eProto := e.createP2PProtobuf()
 	if e.CoreDocument == nil {
-		return nil, errors.New("getDocumentDataTree error CoreDocument not set")
+		return nil, errors.New("DocumentDataTree error CoreDocument not set")
 	}
 	t, err := e.CoreDocument.DefaultTreeWithPrefix(prefix, compactPrefix())
 	if err != nil {
@@ -166,10 +166,10 @@ func (e *EntityRelationship) getDocumentDataTree() (tree *proofs.DocumentTree, e
 	}
 
 	if err := t.AddLeavesFromDocument(eProto); err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 	if err := t.Generate(); err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 
 	return t, nil
@@ -194,6 +194,16 @@ func (e *EntityRelationship) CreateProofs(fields []string) (prf *documents.Docum
 	return e.CoreDocument.CreateProofs(e.DocumentType(), dataLeaves, fields)
 }
 
+// CreateProofsFromZKTree generates proofs for given fields from the ZK data tree.
+func (e *EntityRelationship) CreateProofsFromZKTree(fields []string) (prf *documents.DocumentProof, err error) {
+	dataLeaves, err := e.getDataLeaves()
+	if err != nil {
+		return nil, errors.New("createProofs error %v", err)
+	}
+
+	return e.CoreDocument.CreateProofsFromZKTree(e.DocumentType(), dataLeaves, fields)
+}
+
 // DocumentType returns the entity relationship document type.
 func (*EntityRelationship) DocumentType() string {
 	return documenttypes.EntityRelationshipDataTypeUrl
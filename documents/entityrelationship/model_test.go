@@ -24,6 +24,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/p2p"
+	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -69,6 +70,7 @@ func TestMain(m *testing.M) {
 		anchors.Bootstrapper{},
 		documents.Bootstrapper{},
 		p2p.Bootstrapper{},
+		pending.Bootstrapper{},
 		documents.PostBootstrapper{},
 		&queue.Starter{},
 	}
@@ -210,10 +212,10 @@ func TestEntityRelationship_GetDocumentType(t *testing.T) {
 	assert.Equal(t, documenttypes.EntityRelationshipDataTypeUrl, er.DocumentType())
 }
 
-func TestEntityRelationship_getDocumentDataTree(t *testing.T) {
+func TestEntityRelationship_DocumentDataTree(t *testing.T) {
 	er, _ := CreateCDWithEmbeddedEntityRelationship(t, testingconfig.CreateAccountContext(t, cfg))
 	e := er.(*EntityRelationship)
-	tree, err := e.getDocumentDataTree()
+	tree, err := e.DocumentDataTree()
 	assert.Nil(t, err, "tree should be generated without error")
 	_, leaf := tree.GetLeafByProperty("entity_relationship.owner_identity")
 	assert.NotNil(t, leaf)
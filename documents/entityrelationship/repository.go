@@ -34,17 +34,10 @@ func newDBRepository(db storage.Repository, docRepo documents.Repository) reposi
 
 // FindEntityRelationshipIdentifier returns the identifier of an EntityRelationship based on a entity id and a targetDID
 func (r *repo) FindEntityRelationshipIdentifier(entityIdentifier []byte, ownerDID, targetDID identity.DID) ([]byte, error) {
-	relationships, err := r.db.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(ownerDID[:]))
-	if err != nil {
-		return nil, err
-	}
-
-	if relationships == nil {
-		return nil, documents.ErrDocumentNotFound
-	}
-
-	for _, r := range relationships {
-		e, ok := r.(*EntityRelationship)
+	iter := r.iterateDocuments(ownerDID)
+	defer iter.Release()
+	for iter.Next() {
+		e, ok := iter.Model().(*EntityRelationship)
 		if !ok {
 			continue
 		}
@@ -52,23 +45,21 @@ func (r *repo) FindEntityRelationshipIdentifier(entityIdentifier []byte, ownerDI
 			return e.ID(), nil
 		}
 	}
-	return nil, documents.ErrDocumentNotFound
-}
 
-// ListAllRelationships returns a list of all entity relationship identifiers in which a given entity is involved
-func (r *repo) ListAllRelationships(entityIdentifier []byte, ownerDID identity.DID) (map[string][]byte, error) {
-	allDocuments, err := r.db.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(ownerDID[:]))
-	if err != nil {
+	if err := iter.Error(); err != nil {
 		return nil, err
 	}
 
-	if allDocuments == nil {
-		return map[string][]byte{}, nil
-	}
+	return nil, documents.ErrDocumentNotFound
+}
 
+// ListAllRelationships returns a list of all entity relationship identifiers in which a given entity is involved
+func (r *repo) ListAllRelationships(entityIdentifier []byte, ownerDID identity.DID) (map[string][]byte, error) {
+	iter := r.iterateDocuments(ownerDID)
+	defer iter.Release()
 	relationships := make(map[string][]byte)
-	for _, r := range allDocuments {
-		e, ok := r.(*EntityRelationship)
+	for iter.Next() {
+		e, ok := iter.Model().(*EntityRelationship)
 		if !ok {
 			continue
 		}
@@ -78,9 +69,14 @@ func (r *repo) ListAllRelationships(entityIdentifier []byte, ownerDID identity.D
 		}
 	}
 
-	if len(relationships) == 0 {
-		return map[string][]byte{}, nil
+	if err := iter.Error(); err != nil {
+		return nil, err
 	}
 
 	return relationships, nil
 }
+
+// iterateDocuments returns an iterator over all the document versions owned by ownerDID.
+func (r *repo) iterateDocuments(ownerDID identity.DID) storage.Iterator {
+	return r.db.Iterate(storage.IterateOptions{Prefix: []byte(documents.DocPrefix + hexutil.Encode(ownerDID[:]))})
+}
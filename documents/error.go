@@ -128,8 +128,8 @@ const (
 	// ErrDocumentConfigNotInitialised is a sentinel error when document config is missing
 	ErrDocumentConfigNotInitialised = errors.Error("document config not initialised")
 
-	// ErrDifferentAnchoredAddress is a sentinel error when anchor address is different from the configured one.
-	ErrDifferentAnchoredAddress = errors.Error("anchor address is not the node configured address")
+	// ErrUnsupportedAnchorBackend is a sentinel error when the document is anchored on a backend the node does not anchor on.
+	ErrUnsupportedAnchorBackend = errors.Error("unsupported anchor backend")
 
 	// ErrDocumentIDReused is a sentinel error when identifier is re-used
 	ErrDocumentIDReused = errors.Error("document identifier is already used")
@@ -140,6 +140,9 @@ const (
 	// ErrEmptyAttrLabel is a sentinel error when the attribute label is empty
 	ErrEmptyAttrLabel = errors.Error("empty attribute label")
 
+	// ErrReservedAttr is a sentinel error when the payload sets an attribute reserved for the node
+	ErrReservedAttr = errors.Error("attribute is reserved")
+
 	// ErrWrongAttrFormat is a sentinel error when the attribute format is wrong
 	ErrWrongAttrFormat = errors.Error("wrong attribute format")
 
@@ -163,6 +166,56 @@ const (
 
 	// ErrTransitionRuleMissing is a sentinel error used when transition rule is missing from the document.
 	ErrTransitionRuleMissing = errors.Error("transition rule missing")
+
+	// ErrInvalidReadAction must be used when the action of the read rule is not a read action.
+	ErrInvalidReadAction = errors.Error("invalid read rule action")
+
+	// ErrInvalidSignaturePolicy must be used when the signature policy of the document is invalid
+	ErrInvalidSignaturePolicy = errors.Error("invalid signature policy")
+
+	// ErrSignaturePolicyNotMet must be used when the signatures on the document do not meet the signature policy
+	ErrSignaturePolicyNotMet = errors.Error("signature policy not met")
+
+	// ErrAnchorCheckpointNotFound must be used when the anchor checkpoint of a document version is not found
+	ErrAnchorCheckpointNotFound = errors.Error("anchor checkpoint not found")
+
+	// ErrDeliveryNotFound must be used when the delivery status of a document version is not found
+	ErrDeliveryNotFound = errors.Error("document delivery not found")
+
+	// ErrDeliveryInvalidCollaborator must be used when the document version is resent to a non collaborator
+	ErrDeliveryInvalidCollaborator = errors.Error("invalid delivery collaborator")
+
+	// ErrDocumentSync must be used when the missed versions of a document cannot be synced from a collaborator
+	ErrDocumentSync = errors.Error("failed to sync document")
+
+	// Rejection errors
+
+	// ErrDocumentRejected must be used when a collaborator rejects the document version sent for signing
+	ErrDocumentRejected = errors.Error("document rejected")
+
+	// ErrRejectionNotFound must be used when the rejection of a document version is not found
+	ErrRejectionNotFound = errors.Error("document rejection not found")
+
+	// ErrRejectionInvalidDocument is the reason of rejection when the cause cannot be classified
+	ErrRejectionInvalidDocument = errors.Error("document is not valid for signing")
+
+	// ErrRejectionInvalidTimestamp is the reason of rejection when the document timestamp is not valid for signing
+	ErrRejectionInvalidTimestamp = errors.Error("document timestamp is not valid for signing")
+
+	// ErrRejectionInvalidAuthor is the reason of rejection when the document author is not the sender
+	ErrRejectionInvalidAuthor = errors.Error("document author is not the sender")
+
+	// ErrRejectionVersionAnchored is the reason of rejection when the document version or the next version is already anchored
+	ErrRejectionVersionAnchored = errors.Error("document version is already anchored")
+
+	// ErrRejectionInvalidAnchorRepository is the reason of rejection when the document anchor repository is not the one used by the collaborator
+	ErrRejectionInvalidAnchorRepository = errors.Error("document anchor repository is not supported")
+
+	// ErrRejectionUnauthorizedTransition is the reason of rejection when the sender is not allowed to make the changes in the document
+	ErrRejectionUnauthorizedTransition = errors.Error("document changes are not allowed for the sender")
+
+	// ErrRejectionInvalidSignatures is the reason of rejection when the document signing root or signatures are invalid
+	ErrRejectionInvalidSignatures = errors.Error("document signatures are not valid")
 )
 
 // Error wraps an error with specific key
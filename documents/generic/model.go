@@ -109,10 +109,10 @@ func (g *Generic) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
-// getDocumentDataTree creates precise-proofs data tree for the model
-func (g *Generic) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
+// DocumentDataTree creates precise-proofs data tree for the model.
+func (g *Generic) DocumentDataTree() (tree *proofs.DocumentTree, err error) {
 	if g.CoreDocument == nil {
-		return nil, errors.New("getDocumentDataTree error CoreDocument not set")
+		return nil, errors.New("DocumentDataTree error CoreDocument not set")
 	}
 
 	t, err := g.CoreDocument.DefaultTreeWithPrefix(prefix, compactPrefix())
@@ -122,12 +122,12 @@ func (g *Generic) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
 
 	err = t.AddLeavesFromDocument(getProtoGenericData())
 	if err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 
 	err = t.Generate()
 	if err != nil {
-		return nil, errors.New("getDocumentDataTree error %v", err)
+		return nil, errors.New("DocumentDataTree error %v", err)
 	}
 
 	return t, nil
@@ -143,6 +143,16 @@ func (g *Generic) CreateProofs(fields []string) (prf *documents.DocumentProof, e
 	return g.CoreDocument.CreateProofs(g.DocumentType(), dataLeaves, fields)
 }
 
+// CreateProofsFromZKTree generates proofs for given fields from the ZK data tree.
+func (g *Generic) CreateProofsFromZKTree(fields []string) (prf *documents.DocumentProof, err error) {
+	dataLeaves, err := g.getDataLeaves()
+	if err != nil {
+		return nil, errors.New("createProofs error %v", err)
+	}
+
+	return g.CoreDocument.CreateProofsFromZKTree(g.DocumentType(), dataLeaves, fields)
+}
+
 // DocumentType returns the generic document type.
 func (*Generic) DocumentType() string {
 	return documenttypes.GenericDataTypeUrl
@@ -220,12 +230,12 @@ func (g *Generic) CollaboratorCanUpdate(updated documents.Model, collaborator id
 	}
 
 	// check generic doc specific changes
-	oldTree, err := g.getDocumentDataTree()
+	oldTree, err := g.DocumentDataTree()
 	if err != nil {
 		return err
 	}
 
-	newTree, err := newGeneric.getDocumentDataTree()
+	newTree, err := newGeneric.DocumentDataTree()
 	if err != nil {
 		return err
 	}
@@ -24,6 +24,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/p2p"
+	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
@@ -64,6 +65,7 @@ func TestMain(m *testing.M) {
 		anchors.Bootstrapper{},
 		documents.Bootstrapper{},
 		p2p.Bootstrapper{},
+		pending.Bootstrapper{},
 		documents.PostBootstrapper{},
 		&Bootstrapper{},
 		&queue.Starter{},
@@ -284,9 +286,9 @@ func TestGeneric_CreateNFTProofs(t *testing.T) {
 	assert.True(t, valid)
 }
 
-func TestGeneric_getDocumentDataTree(t *testing.T) {
+func TestGeneric_DocumentDataTree(t *testing.T) {
 	g, _ := createCDWithEmbeddedGeneric(t)
-	tree, err := g.(*Generic).getDocumentDataTree()
+	tree, err := g.(*Generic).DocumentDataTree()
 	assert.Nil(t, err, "tree should be generated without error")
 	_, leaf := tree.GetLeafByProperty("generic.scheme")
 	assert.NotNil(t, leaf)
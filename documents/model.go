@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/precise-proofs/proofs"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -55,6 +56,9 @@ type Model interface {
 	// CalculateSignaturesRoot returns signatures root of the model.
 	CalculateSignaturesRoot() ([]byte, error)
 
+	// DocumentDataTree returns the precise-proofs tree of the document data.
+	DocumentDataTree() (*proofs.DocumentTree, error)
+
 	// AppendSignatures appends the signatures to the model.
 	AppendSignatures(signatures ...*coredocumentpb.Signature)
 
@@ -64,6 +68,9 @@ type Model interface {
 	// CreateProofs creates precise-proofs for given fields
 	CreateProofs(fields []string) (prf *DocumentProof, err error)
 
+	// CreateProofsFromZKTree creates precise-proofs for given fields from the ZK data tree
+	CreateProofsFromZKTree(fields []string) (prf *DocumentProof, err error)
+
 	// CreateNFTProofs creates NFT proofs for minting.
 	CreateNFTProofs(
 		account identity.DID,
@@ -162,6 +169,10 @@ type Model interface {
 	// The access is only given to the roleKey which is expected to be present already.
 	AddTransitionRuleForAttribute(roleID []byte, key AttrKey) (*coredocumentpb.TransitionRule, error)
 
+	// AddReadRule gives the collaborators of the role read access to the document.
+	// The role is expected to be present already.
+	AddReadRule(roleID []byte, action coredocumentpb.Action) (*coredocumentpb.ReadRule, error)
+
 	// GetTransitionRule returns the transition rule associated with ruleID in the document.
 	GetTransitionRule(ruleID []byte) (*coredocumentpb.TransitionRule, error)
 
@@ -179,11 +190,13 @@ type TokenRegistry interface {
 }
 
 // CreatePayload holds the scheme, CollaboratorsAccess, Attributes, and Data of the document.
+// SignaturePolicy, if set, replaces the signature policy of the document. See ParseSignaturePolicy for the format.
 type CreatePayload struct {
-	Scheme        string
-	Collaborators CollaboratorsAccess
-	Attributes    map[AttrKey]Attribute
-	Data          []byte
+	Scheme          string
+	Collaborators   CollaboratorsAccess
+	Attributes      map[AttrKey]Attribute
+	Data            []byte
+	SignaturePolicy string
 }
 
 // UpdatePayload holds the scheme, CollaboratorsAccess, Attributes, Data and document identifier.
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
@@ -11,6 +12,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/proofwire"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,18 +26,30 @@ type Config interface {
 	GetIdentityID() ([]byte, error)
 	GetP2PConnectionTimeout() time.Duration
 	GetContractAddress(contractName config.ContractName) common.Address
+	GetSignaturePolicies() map[string]string
+	GetAnchorExpiryWarning() time.Duration
+	GetAnchorRenewalEnabled() bool
 }
 
 // DocumentRequestProcessor offers methods to interact with the p2p layer to request documents.
 type DocumentRequestProcessor interface {
 	RequestDocumentWithAccessToken(ctx context.Context, granterDID identity.DID, tokenIdentifier, documentIdentifier, delegatingDocumentIdentifier []byte) (*p2ppb.GetDocumentResponse, error)
+
+	// RequestDocumentVersion requests a version of the document from the collaborator.
+	// Latest version of the document is returned if the identifier is the document identifier.
+	RequestDocumentVersion(ctx context.Context, collaborator identity.DID, identifier []byte) (*p2ppb.GetDocumentResponse, error)
 }
 
 // Client defines methods that can be implemented by any type handling p2p communications.
 type Client interface {
 
-	// GetSignaturesForDocument gets the signatures for document
-	GetSignaturesForDocument(ctx context.Context, model Model) ([]*coredocumentpb.Signature, []error, error)
+	// GetSignaturesForDocument gets the signatures for document.
+	// Signatures of each collaborator are passed to collect as they arrive, collection stops once collect returns true.
+	// Collect can be nil to wait for all the collaborators. Signature collection errors are of type CollaboratorError.
+	GetSignaturesForDocument(
+		ctx context.Context,
+		model Model,
+		collect func(signatures []*coredocumentpb.Signature) bool) ([]*coredocumentpb.Signature, []error, error)
 
 	// after all signatures are collected the sender sends the document including the signatures
 	SendAnchoredDocument(ctx context.Context, receiverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error)
@@ -48,15 +64,21 @@ type defaultProcessor struct {
 	p2pClient       Client
 	anchorSrv       anchors.Service
 	config          Config
+	jobManager      jobs.Manager
+	notifier        notification.Sender
+	repo            Repository
 }
 
 // DefaultProcessor returns the default implementation of CoreDocument AnchorProcessor
-func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config) AnchorProcessor {
+func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config, jobManager jobs.Manager, repo Repository) AnchorProcessor {
 	return defaultProcessor{
 		identityService: idService,
 		p2pClient:       p2pClient,
 		anchorSrv:       anchorSrv,
 		config:          config,
+		jobManager:      jobManager,
+		notifier:        notification.NewWebhookSender(),
+		repo:            repo,
 	}
 }
 
@@ -66,7 +88,12 @@ func (dp defaultProcessor) Send(ctx context.Context, cd coredocumentpb.CoreDocum
 	ctx, cancel := context.WithTimeout(ctx, dp.config.GetP2PConnectionTimeout())
 	defer cancel()
 
-	resp, err := dp.p2pClient.SendAnchoredDocument(ctx, id, &p2ppb.AnchorDocumentRequest{Document: &cd})
+	req := &p2ppb.AnchorDocumentRequest{Document: &cd}
+	if err := AttachBatchProof(dp.anchorSrv, req, cd.CurrentVersion); err != nil {
+		return errors.New("failed to attach batch proof: %v", err)
+	}
+
+	resp, err := dp.p2pClient.SendAnchoredDocument(ctx, id, req)
 	if err != nil || !resp.Accepted {
 		return errors.New("failed to send document to the node: %v", err)
 	}
@@ -93,8 +120,7 @@ func (dp defaultProcessor) PrepareForSignatureRequests(ctx context.Context, mode
 		return err
 	}
 
-	addr := dp.config.GetContractAddress(config.AnchorRepo)
-	model.SetUsedAnchorRepoAddress(addr)
+	model.SetUsedAnchorRepoAddress(dp.anchorSrv.RepositoryAddress())
 
 	// calculate the signing root
 	sr, err := model.CalculateSigningRoot()
@@ -120,19 +146,84 @@ func (dp defaultProcessor) RequestSignatures(ctx context.Context, model Model) e
 		return errors.New("failed to validate model for signature request: %v", err)
 	}
 
-	// we ignore signature collection errors and anchor anyways
-	signs, _, err := dp.p2pClient.GetSignaturesForDocument(ctx, model)
+	policy, err := GetSignaturePolicy(model)
+	if err != nil {
+		return errors.New("failed to get the signature policy: %v", err)
+	}
+
+	// signatures are added as they arrive, the pending collaborators are not waited for once the policy is met.
+	// Without a policy, all the collaborators are waited for.
+	policyValidator := SignaturePolicyValidator(dp.identityService, dp.anchorSrv)
+	_, errs, err := dp.p2pClient.GetSignaturesForDocument(ctx, model, func(signs []*coredocumentpb.Signature) bool {
+		model.AppendSignatures(signs...)
+		return policy != nil && policyValidator.Validate(nil, model) == nil
+	})
 	if err != nil {
 		return errors.New("failed to collect signatures from the collaborators: %v", err)
 	}
 
-	model.AppendSignatures(signs...)
+	// we anchor anyways and only record the signature collection errors
+	for _, err := range errs {
+		cerr, ok := err.(CollaboratorError)
+		if !ok {
+			log.Error(err)
+			continue
+		}
+
+		dp.recordCollaboratorError(ctx, model, cerr)
+	}
+
 	return nil
 }
 
-// PrepareForAnchoring validates the signatures and generates the document root
+// recordCollaboratorError logs the signature collection error against the job in the context
+// and notifies the account if the collaborator rejected the document version.
+func (dp defaultProcessor) recordCollaboratorError(ctx context.Context, model Model, cerr CollaboratorError) {
+	log.Error(cerr)
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	jobID := contextutil.Job(ctx)
+	if dp.jobManager != nil && !jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		taskName := fmt.Sprintf("%s[%s]", signatureCollectionTaskName, cerr.Collaborator.String())
+		err = dp.jobManager.UpdateTaskStatus(did, jobID, jobs.Failed, taskName, cerr.Err.Error())
+		if err != nil {
+			log.Error(err)
+		}
+	}
+
+	rerr, ok := cerr.Rejection()
+	if !ok {
+		return
+	}
+
+	msg := notification.Message{
+		EventType:    notification.DocumentRejected,
+		AccountID:    did.String(),
+		FromID:       cerr.Collaborator.String(),
+		ToID:         did.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: model.DocumentType(),
+		DocumentID:   hexutil.Encode(model.ID()),
+		Status:       string(Rejected),
+		Message:      fmt.Sprintf("version %s rejected: %s", hexutil.Encode(model.CurrentVersion()), rerr.Reason()),
+	}
+
+	// async so that signature collection is not blocked by the webhook
+	go func() {
+		_, err := dp.notifier.Send(ctx, msg)
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// PrepareForAnchoring validates the signatures against the signature policy and generates the document root
 func (dp defaultProcessor) PrepareForAnchoring(model Model) error {
-	psv := SignatureValidator(dp.identityService, dp.anchorSrv)
+	psv := SignaturePolicyValidator(dp.identityService, dp.anchorSrv)
 	err := psv.Validate(nil, model)
 	if err != nil {
 		return errors.New("failed to validate signatures: %v", err)
@@ -238,6 +329,17 @@ func (dp defaultProcessor) RequestDocumentWithAccessToken(ctx context.Context, g
 	return response, nil
 }
 
+// RequestDocumentVersion requests a version of the document from the collaborator.
+// Collaborator verifies that the requester can read the version.
+func (dp defaultProcessor) RequestDocumentVersion(ctx context.Context, collaborator identity.DID, identifier []byte) (*p2ppb.GetDocumentResponse, error) {
+	request := &p2ppb.GetDocumentRequest{
+		DocumentIdentifier: identifier,
+		AccessType:         p2ppb.AccessType_ACCESS_TYPE_REQUESTER_VERIFICATION,
+	}
+
+	return dp.p2pClient.GetDocumentRequest(ctx, collaborator, request)
+}
+
 // SendDocument does post anchor validations and sends the document to collaborators
 func (dp defaultProcessor) SendDocument(ctx context.Context, model Model) error {
 	av := PostAnchoredValidator(dp.identityService, dp.anchorSrv)
@@ -261,11 +363,25 @@ func (dp defaultProcessor) SendDocument(ctx context.Context, model Model) error
 		return errors.New("failed to pack core document: %v", err)
 	}
 
+	// failed deliveries are retried by the delivery queue.
+	// delivery fails the anchoring only if it couldn't be recorded for a retry.
 	for _, c := range cs {
 		erri := dp.Send(ctx, cd, c)
-		if erri != nil {
+		derr := recordDelivery(dp.repo, selfDID, model, c, erri)
+		if derr != nil {
+			log.Errorf("failed to record delivery to %s: %v", c, derr)
+		}
+
+		if erri == nil {
+			continue
+		}
+
+		if derr != nil {
 			err = errors.AppendError(err, erri)
+			continue
 		}
+
+		log.Warningf("failed to send document to %s, delivery is retried: %v", c, erri)
 	}
 
 	return err
@@ -273,9 +389,5 @@ func (dp defaultProcessor) SendDocument(ctx context.Context, model Model) error
 
 // ConsensusSignaturePayload forms the payload needed to be signed during the document consensus flow
 func ConsensusSignaturePayload(dataRoot []byte, validated bool) []byte {
-	tFlag := byte(0)
-	if validated {
-		tFlag = byte(1)
-	}
-	return append(dataRoot, tFlag)
+	return proofwire.SigningPayload(dataRoot, validated)
 }
@@ -15,11 +15,15 @@ import (
 	"github.com/centrifuge/go-centrifuge/crypto"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,11 +34,21 @@ type mockModel struct {
 	sigs []*coredocumentpb.Signature
 }
 
+func (m *mockModel) DocumentType() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 func (m *mockModel) Scheme() string {
 	args := m.Called()
 	return args.String(0)
 }
 
+func (m *mockModel) GetStatus() Status {
+	args := m.Called()
+	return args.Get(0).(Status)
+}
+
 func (m *mockModel) SetStatus(st Status) error {
 	args := m.Called(st)
 	return args.Error(0)
@@ -72,7 +86,7 @@ func (m *mockModel) PreviousDocumentRoot() []byte {
 
 func (m *mockModel) AppendSignatures(sigs ...*coredocumentpb.Signature) {
 	m.Called(sigs)
-	m.sigs = sigs
+	m.sigs = append(m.sigs, sigs...)
 }
 
 func (m *mockModel) ID() []byte {
@@ -163,6 +177,23 @@ func (m *mockModel) AnchorRepoAddress() common.Address {
 	return args.Get(0).(common.Address)
 }
 
+func (m *mockModel) AttributeExists(key AttrKey) bool {
+	args := m.Called(key)
+	return args.Bool(0)
+}
+
+func (m *mockModel) GetAttribute(key AttrKey) (Attribute, error) {
+	args := m.Called(key)
+	attr, _ := args.Get(0).(Attribute)
+	return attr, args.Error(1)
+}
+
+func (m *mockModel) GetRole(key []byte) (*coredocumentpb.Role, error) {
+	args := m.Called(key)
+	role, _ := args.Get(0).(*coredocumentpb.Role)
+	return role, args.Error(1)
+}
+
 func (m *mockModel) GetAttributes() []Attribute {
 	args := m.Called()
 	attrs, _ := args.Get(0).([]Attribute)
@@ -171,7 +202,9 @@ func (m *mockModel) GetAttributes() []Attribute {
 
 func TestDefaultProcessor_PrepareForSignatureRequests(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	anchorSrv := &mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(cfg.GetContractAddress(config.AnchorRepo))
+	dp := DefaultProcessor(srv, nil, anchorSrv, cfg, nil, nil).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
@@ -215,10 +248,36 @@ type p2pClient struct {
 	Client
 }
 
-func (p *p2pClient) GetSignaturesForDocument(ctx context.Context, model Model) ([]*coredocumentpb.Signature, []error, error) {
+// GetSignaturesForDocument passes the signatures to collect one at a time as if each came from a collaborator.
+func (p *p2pClient) GetSignaturesForDocument(
+	ctx context.Context,
+	model Model,
+	collect func(signatures []*coredocumentpb.Signature) bool) ([]*coredocumentpb.Signature, []error, error) {
 	args := p.Called(ctx, model)
 	sigs, _ := args.Get(0).([]*coredocumentpb.Signature)
-	return sigs, nil, args.Error(1)
+	var errs []error
+	if len(args) > 2 {
+		errs, _ = args.Get(2).([]error)
+	}
+
+	var collected []*coredocumentpb.Signature
+	for _, sig := range sigs {
+		collected = append(collected, sig)
+		if collect != nil && collect([]*coredocumentpb.Signature{sig}) {
+			break
+		}
+	}
+
+	return collected, errs, args.Error(1)
+}
+
+type mockNotifier struct {
+	msgs chan notification.Message
+}
+
+func (m mockNotifier) Send(ctx context.Context, msg notification.Message) (notification.Status, error) {
+	m.msgs <- msg
+	return notification.Success, nil
 }
 
 func (p *p2pClient) SendAnchoredDocument(ctx context.Context, receiverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error) {
@@ -229,7 +288,7 @@ func (p *p2pClient) SendAnchoredDocument(ctx context.Context, receiverID identit
 
 func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
 	self, err := contextutil.Account(ctxh)
@@ -286,6 +345,7 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.On("Author").Return(did1, nil)
 	model.On("Timestamp").Return(time.Now(), nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
 	model.sigs = append(model.sigs, sig)
 	c = new(p2pClient)
@@ -310,6 +370,7 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
 	model.On("Timestamp").Return(time.Now(), nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	c = new(p2pClient)
 	c.On("GetSignaturesForDocument", ctxh, model).Return([]*coredocumentpb.Signature{sig}, nil).Once()
@@ -318,11 +379,77 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.AssertExpectations(t)
 	c.AssertExpectations(t)
 	assert.Nil(t, err)
-}
 
+	// collaborator rejected the document
+	model = new(mockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(id)
+	model.On("NextVersion").Return(next)
+	model.On("CalculateSigningRoot").Return(sr, nil)
+	model.On("Signatures").Return()
+	model.On("AppendSignatures", []*coredocumentpb.Signature{sig}).Return().Once()
+	model.On("Author").Return(did1, nil)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
+	model.On("Timestamp").Return(time.Now(), nil)
+	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
+	model.On("DocumentType").Return("generic")
+	model.sigs = append(model.sigs, sig)
+	collab := testingidentity.GenerateRandomDID()
+	rerr := &RejectionError{Codes: []RejectionCode{RejectionCodeUnauthorizedTransition}}
+	c = new(p2pClient)
+	c.On("GetSignaturesForDocument", mock.Anything, model).Return(
+		[]*coredocumentpb.Signature{sig}, nil, []error{CollaboratorError{Collaborator: collab, Err: rerr}}).Once()
+	dp.p2pClient = c
+	jobID := jobs.NewJobID()
+	jm := new(testingjobs.MockJobManager)
+	jm.On("UpdateTaskStatus", did1, jobID, jobs.Failed, "Signature Collection["+collab.String()+"]", rerr.Error()).Return(nil).Once()
+	dp.jobManager = jm
+	notifier := mockNotifier{msgs: make(chan notification.Message, 1)}
+	dp.notifier = notifier
+	err = dp.RequestSignatures(contextutil.WithJob(ctxh, jobID), model)
+	assert.NoError(t, err)
+	model.AssertExpectations(t)
+	c.AssertExpectations(t)
+	jm.AssertExpectations(t)
+	msg := <-notifier.msgs
+	assert.Equal(t, notification.DocumentRejected, msg.EventType)
+	assert.Equal(t, collab.String(), msg.FromID)
+	assert.Equal(t, string(Rejected), msg.Status)
+	assert.Contains(t, msg.Message, rerr.Reason())
+
+	// signature policy is met before the other collaborators answer
+	c1, c2 := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	sig1 := &coredocumentpb.Signature{SignerId: c1[:], PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(32)}
+	sig2 := &coredocumentpb.Signature{SignerId: c2[:], PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(32)}
+	policy, err := SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 2}.Attribute()
+	assert.NoError(t, err)
+	model = new(mockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(id)
+	model.On("NextVersion").Return(next)
+	model.On("CalculateSigningRoot").Return(sr, nil)
+	model.On("Signatures").Return()
+	model.On("AppendSignatures", []*coredocumentpb.Signature{sig1}).Return().Once()
+	model.On("Author").Return(did1, nil)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, c1, c2}, nil)
+	model.On("Timestamp").Return(time.Now(), nil)
+	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", policy.Key).Return(true)
+	model.On("GetAttribute", policy.Key).Return(policy, nil)
+	model.sigs = append(model.sigs, sig)
+	c = new(p2pClient)
+	c.On("GetSignaturesForDocument", ctxh, model).Return([]*coredocumentpb.Signature{sig1, sig2}, nil).Once()
+	dp.p2pClient = c
+	err = dp.RequestSignatures(ctxh, model)
+	assert.NoError(t, err)
+	model.AssertExpectations(t)
+	c.AssertExpectations(t)
+	assert.Equal(t, []*coredocumentpb.Signature{sig, sig1}, model.sigs)
+}
 func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, nil).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
@@ -349,6 +476,7 @@ func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	tm := time.Now()
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	cid, _ := identity.NewDIDFromBytes(did)
@@ -370,6 +498,7 @@ func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
@@ -378,6 +507,31 @@ func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	model.AssertExpectations(t)
 	srv.AssertExpectations(t)
 	assert.NoError(t, err)
+
+	// signature policy not met
+	policy, err := SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 2}.Attribute()
+	assert.NoError(t, err)
+	model = new(mockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(id)
+	model.On("NextVersion").Return(next)
+	model.On("CalculateSigningRoot").Return(sr, nil)
+	model.On("Signatures").Return()
+	model.On("Author").Return(did1, nil)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
+	model.On("Timestamp").Return(tm, nil)
+	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", policy.Key).Return(true)
+	model.On("GetAttribute", policy.Key).Return(policy, nil)
+	model.sigs = append(model.sigs, sig)
+	srv = &testingcommons.MockIdentityService{}
+	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
+	dp.identityService = srv
+	err = dp.PrepareForAnchoring(model)
+	model.AssertExpectations(t)
+	srv.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrSignaturePolicyNotMet.Error())
 }
 
 type mockAnchorService struct {
@@ -391,16 +545,32 @@ func (m mockAnchorService) CommitAnchor(ctx context.Context, anchorID anchors.An
 	return c, args.Error(1)
 }
 
-func (m mockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, err error) {
+func (m mockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
 	args := m.Called(anchorID)
 	docRoot, _ = args.Get(0).(anchors.DocumentRoot)
 	anchoredTime, _ = args.Get(1).(time.Time)
-	return docRoot, anchoredTime, args.Error(2)
+	return docRoot, anchoredTime, blockNumber, args.Error(2)
+}
+
+func (m mockAnchorService) RepositoryAddress() common.Address {
+	args := m.Called()
+	return args.Get(0).(common.Address)
+}
+
+func (m mockAnchorService) GetBatchProof(anchorID anchors.AnchorID) (*anchors.BatchProof, error) {
+	args := m.Called(anchorID)
+	proof, _ := args.Get(0).(*anchors.BatchProof)
+	return proof, args.Error(1)
+}
+
+func (m mockAnchorService) StoreBatchProof(proof *anchors.BatchProof) error {
+	args := m.Called(proof)
+	return args.Error(0)
 }
 
 func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -428,6 +598,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	tm := time.Now()
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	cid, err := identity.NewDIDFromBytes(did)
@@ -455,6 +626,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	model.On("Timestamp").Return(tm, nil)
 	model.On("CalculateSignaturesRoot").Return(nil, errors.New("error"))
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", did1, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
@@ -479,6 +651,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false)
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
@@ -498,7 +671,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 func TestDefaultProcessor_SendDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", mock.Anything, mock.Anything).Return(nil).Once()
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -527,11 +700,13 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	model.On("CalculateSigningRoot").Return(sr, nil)
 	model.On("Signatures").Return()
 	model.On("CalculateDocumentRoot").Return(utils.RandomSlice(32), nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
 	model.On("Author").Return(did1, nil)
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
 	tm := time.Now()
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	cid, err := identity.NewDIDFromBytes(didb)
@@ -539,6 +714,7 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
 	dp.identityService = srv
 	anchorSrv := mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
 
 	anchorSrv.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), nil)
 	anchorSrv.On("GetAnchorData", aid).Return(nil, time.Now(), errors.New("error"))
@@ -562,14 +738,17 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	model.On("CalculateSigningRoot").Return(sr, nil)
 	model.On("Signatures").Return()
 	model.On("CalculateDocumentRoot").Return(dr[:], nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
 	model.On("GetSignerCollaborators", mock.Anything).Return(nil, errors.New("error")).Once()
 	model.On("Author").Return(did1, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	dp.identityService = srv
 	anchorSrv = mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
 	anchorSrv.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), nil)
 	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
 	dp.anchorSrv = anchorSrv
@@ -587,16 +766,19 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	model.On("CalculateSigningRoot").Return(sr, nil)
 	model.On("Signatures").Return()
 	model.On("CalculateDocumentRoot").Return(dr[:], nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{testingidentity.GenerateRandomDID()}, nil)
 	model.On("PackCoreDocument").Return(nil, errors.New("error")).Once()
 	model.On("Author").Return(did1, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
 	dp.identityService = srv
 	anchorSrv = mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
 	anchorSrv.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
 	dp.anchorSrv = anchorSrv
@@ -606,8 +788,8 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	anchorSrv.AssertExpectations(t)
 	assert.Error(t, err)
 
-	// send failed
-	cd := coredocumentpb.CoreDocument{}
+	// send failed, delivery is recorded for a retry
+	cd := coredocumentpb.CoreDocument{CurrentVersion: id}
 	did := testingidentity.GenerateRandomDID()
 	model = new(mockModel)
 	model.On("ID").Return(id)
@@ -616,57 +798,139 @@ func TestDefaultProcessor_SendDocument(t *testing.T) {
 	model.On("CalculateSigningRoot").Return(sr, nil)
 	model.On("Signatures").Return()
 	model.On("CalculateDocumentRoot").Return(dr[:], nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did}, nil)
 	model.On("PackCoreDocument").Return(cd, nil).Once()
 	model.On("Author").Return(did1, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
 	dp.identityService = srv
 	anchorSrv = mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
 	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
 	anchorSrv.On("GetAnchorData", nextAid).Return([32]byte{}, time.Now(), errors.New("missing"))
+	anchorSrv.On("GetBatchProof", aid).Return(nil, anchors.ErrBatchProofNotFound).Once()
 	client := new(p2pClient)
 	client.On("SendAnchoredDocument", mock.Anything, did, mock.Anything).Return(nil, errors.New("error")).Once()
 	dp.anchorSrv = anchorSrv
 	dp.p2pClient = client
+	repo := new(MockRepository)
+	repo.On("GetDelivery", didb, id, did).Return(nil, ErrDeliveryNotFound).Once()
+	repo.On("StoreDelivery", mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		d := args.Get(0).(*Delivery)
+		assert.Equal(t, DeliveryPending, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		assert.True(t, d.NextAttempt.After(d.UpdatedAt))
+	})
+	dp.repo = repo
 	err = dp.SendDocument(ctxh, model)
 	model.AssertExpectations(t)
 	srv.AssertExpectations(t)
 	anchorSrv.AssertExpectations(t)
 	client.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	assert.NoError(t, err)
+
+	// send failed, delivery is not recorded
+	model = new(mockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(id)
+	model.On("NextVersion").Return(next)
+	model.On("CalculateSigningRoot").Return(sr, nil)
+	model.On("Signatures").Return()
+	model.On("CalculateDocumentRoot").Return(dr[:], nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did}, nil)
+	model.On("PackCoreDocument").Return(cd, nil).Once()
+	model.On("Author").Return(did1, nil)
+	model.On("Timestamp").Return(tm, nil)
+	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
+	model.sigs = append(model.sigs, sig)
+	srv = &testingcommons.MockIdentityService{}
+	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
+	dp.identityService = srv
+	anchorSrv = mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
+	anchorSrv.On("GetAnchorData", nextAid).Return([32]byte{}, time.Now(), errors.New("missing"))
+	anchorSrv.On("GetBatchProof", aid).Return(nil, anchors.ErrBatchProofNotFound).Once()
+	client = new(p2pClient)
+	client.On("SendAnchoredDocument", mock.Anything, did, mock.Anything).Return(nil, errors.New("error")).Once()
+	dp.anchorSrv = anchorSrv
+	dp.p2pClient = client
+	repo = new(MockRepository)
+	repo.On("GetDelivery", didb, id, did).Return(nil, ErrDeliveryNotFound).Once()
+	repo.On("StoreDelivery", mock.Anything).Return(errors.New("failed to store")).Once()
+	dp.repo = repo
+	err = dp.SendDocument(ctxh, model)
+	model.AssertExpectations(t)
+	srv.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
+	client.AssertExpectations(t)
+	repo.AssertExpectations(t)
 	assert.Error(t, err)
 
-	// successful
+	// successful, batch proof is sent along with the document
+	proof := &anchors.BatchProof{
+		AnchorID:         aid,
+		DocumentRoot:     dr,
+		SigningRootProof: utils.RandomByte32(),
+		BatchAnchorID:    utils.RandomByte32(),
+		Proof:            [][32]byte{utils.RandomByte32()},
+	}
 	model = new(mockModel)
+	model.On("CalculateSignaturesRoot").Return(proof.SigningRootProof[:], nil).Once()
 	model.On("ID").Return(id)
 	model.On("CurrentVersion").Return(id)
 	model.On("NextVersion").Return(next)
 	model.On("CalculateSigningRoot").Return(sr, nil)
 	model.On("Signatures").Return()
 	model.On("CalculateDocumentRoot").Return(dr[:], nil)
+	model.On("AnchorRepoAddress").Return(common.Address{})
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did}, nil)
 	model.On("PackCoreDocument").Return(cd, nil).Once()
 	model.On("Author").Return(did1, nil)
 	model.On("Timestamp").Return(tm, nil)
 	model.On("GetAttributes").Return(nil)
+	model.On("AttributeExists", mock.Anything).Return(false).Maybe()
 	model.sigs = append(model.sigs, sig)
 	srv = &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", cid, sig.PublicKey, sig.Signature, payload, tm).Return(nil).Once()
 	dp.identityService = srv
 	anchorSrv = mockAnchorService{}
+	anchorSrv.On("RepositoryAddress").Return(common.Address{})
 	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
 	anchorSrv.On("GetAnchorData", nextAid).Return([32]byte{}, time.Now(), errors.New("missing"))
+	anchorSrv.On("GetBatchProof", aid).Return(proof, nil).Once()
 	client = new(p2pClient)
-	client.On("SendAnchoredDocument", mock.Anything, did, mock.Anything).Return(&p2ppb.AnchorDocumentResponse{Accepted: true}, nil).Once()
+	client.On("SendAnchoredDocument", mock.Anything, did, mock.Anything).Return(&p2ppb.AnchorDocumentResponse{Accepted: true}, nil).Once().Run(func(args mock.Arguments) {
+		data, err := proto.Marshal(args.Get(2).(*p2ppb.AnchorDocumentRequest))
+		assert.NoError(t, err)
+		req := new(p2ppb.AnchorDocumentRequest)
+		assert.NoError(t, proto.Unmarshal(data, req))
+		receiver := mockAnchorService{}
+		receiver.On("StoreBatchProof", proof).Return(nil).Once()
+		assert.NoError(t, ReceiveBatchProof(receiver, req, model))
+		receiver.AssertExpectations(t)
+	})
 	dp.anchorSrv = anchorSrv
 	dp.p2pClient = client
+	repo.On("GetDelivery", didb, id, did).Return(&Delivery{Attempts: 1, Status: DeliveryPending}, nil).Once()
+	repo.On("StoreDelivery", mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		d := args.Get(0).(*Delivery)
+		assert.Equal(t, DeliveryDelivered, d.Status)
+		assert.Equal(t, 2, d.Attempts)
+	})
 	err = dp.SendDocument(ctxh, model)
 	model.AssertExpectations(t)
 	srv.AssertExpectations(t)
 	anchorSrv.AssertExpectations(t)
 	client.AssertExpectations(t)
+	repo.AssertExpectations(t)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,126 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/proofwire"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ProofBundleVersion is the version of the proof bundle format.
+const ProofBundleVersion = proofwire.BundleVersion
+
+// SignatureInfo holds a collaborator signature on the document version along with the validity of the signing key.
+type SignatureInfo struct {
+	SignatureID         []byte
+	SignerID            identity.DID
+	PublicKey           []byte
+	Signature           []byte
+	TransitionValidated bool
+
+	// KeyValid is true if the public key was a signing key of the signer when the version was anchored.
+	KeyValid bool
+
+	// KeyRevokedAt is the block number the key was revoked at. Zero if the key is not revoked.
+	KeyRevokedAt uint32
+}
+
+// ProofBundle holds everything required to verify the field proofs of a document version end to end.
+// Proof contains a field proof for each of the signatures so that the signatures are bound to the document root.
+type ProofBundle struct {
+	Version    int
+	Proof      *DocumentProof
+	Signatures []SignatureInfo
+}
+
+// SignatureField returns the signatures tree field of the signature.
+func SignatureField(signatureID []byte) string {
+	return fmt.Sprintf("%s.signatures[%s]", SignaturesTreePrefix, hexutil.Encode(signatureID))
+}
+
+// SignatureLeafValue returns the value of the signatures tree leaf of the signature.
+func SignatureLeafValue(signature []byte, transitionValidated bool) []byte {
+	return proofwire.SignatureLeafValue(signature, transitionValidated)
+}
+
+// CreateProofBundle creates the proof bundle of the fields for the document version.
+// Latest version is used if the version is empty.
+func (s service) CreateProofBundle(ctx context.Context, documentID, version []byte, fields []string) (*ProofBundle, error) {
+	var model Model
+	var err error
+	if utils.IsEmptyByteSlice(version) {
+		model, err = s.GetCurrentVersion(ctx, documentID)
+	} else {
+		model, err = s.getVersion(ctx, documentID, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sigs := model.Signatures()
+	requested := make(map[string]struct{})
+	for _, f := range fields {
+		requested[f] = struct{}{}
+	}
+
+	for _, sig := range sigs {
+		f := SignatureField(sig.SignatureId)
+		if _, ok := requested[f]; !ok {
+			fields = append(fields, f)
+		}
+	}
+
+	proof, err := s.createProofs(false, model, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &ProofBundle{Version: ProofBundleVersion, Proof: proof}
+	for _, sig := range sigs {
+		info, err := s.signatureInfo(ctx, sig.SignerId, sig.PublicKey, proof.Anchor)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentProof, err)
+		}
+
+		info.SignatureID = sig.SignatureId
+		info.Signature = sig.Signature
+		info.TransitionValidated = sig.TransitionValidated
+		bundle.Signatures = append(bundle.Signatures, info)
+	}
+
+	return bundle, nil
+}
+
+// signatureInfo returns the validity of the signing key at the anchoring time.
+func (s service) signatureInfo(ctx context.Context, signerID, publicKey []byte, anchor *AnchorInfo) (SignatureInfo, error) {
+	did, err := identity.NewDIDFromBytes(signerID)
+	if err != nil {
+		return SignatureInfo{}, err
+	}
+
+	key, err := utils.SliceToByte32(publicKey)
+	if err != nil {
+		return SignatureInfo{}, err
+	}
+
+	resp, err := s.idService.GetKey(did, key)
+	if err != nil {
+		return SignatureInfo{}, errors.New("failed to get key %s of signer %s: %v", hexutil.Encode(publicKey), did.String(), err)
+	}
+
+	err = s.idService.ValidateKey(ctx, did, publicKey, &(identity.KeyPurposeSigning.Value), &anchor.AnchoredAt)
+	if err != nil {
+		srvLog.Warningf("signing key %s of signer %s is not valid: %v", hexutil.Encode(publicKey), did.String(), err)
+	}
+
+	return SignatureInfo{
+		SignerID:     did,
+		PublicKey:    publicKey,
+		KeyValid:     err == nil,
+		KeyRevokedAt: resp.RevokedAt,
+	}, nil
+}
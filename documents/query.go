@@ -0,0 +1,91 @@
+package documents
+
+import (
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+)
+
+// Query holds the filters and pagination options used to list the latest versions of documents.
+// Documents are listed in the order of their IDs. Zero values are ignored while filtering.
+type Query struct {
+	// Scheme filters the documents by their scheme. Ex: generic, entity
+	Scheme string
+
+	// Status filters the documents by their status.
+	Status Status
+
+	// Author filters the documents by the author of the latest version.
+	Author *identity.DID
+
+	// Collaborator filters the documents where the DID is a read or write collaborator.
+	Collaborator *identity.DID
+
+	// From filters the documents with latest version timestamp at or after From.
+	From time.Time
+
+	// To filters the documents with latest version timestamp at or before To.
+	To time.Time
+
+	// AttributeFilters filters the documents using the attribute index.
+	// All the filters must be satisfied by a document.
+	AttributeFilters []AttributeFilter
+
+	// Cursor resumes the listing after the document with the ID, as returned by QueryResult.Next.
+	// Nil starts from the first document.
+	Cursor []byte
+
+	// Limit is the maximum number of documents returned. Zero means no limit.
+	Limit int
+}
+
+// QueryResult holds the documents matched by the Query.
+type QueryResult struct {
+	// Documents are the latest versions of the matched documents for the requested page.
+	Documents []Model
+
+	// Next is the cursor of the next page. Nil when there are no more documents.
+	Next []byte
+}
+
+// Match returns true if the model satisfies all the filters in the query.
+func (q Query) Match(m Model) bool {
+	if q.Scheme != "" && m.Scheme() != q.Scheme {
+		return false
+	}
+
+	if q.Status != "" && m.GetStatus() != q.Status {
+		return false
+	}
+
+	if q.Author != nil {
+		author, err := m.Author()
+		if err != nil || author != *q.Author {
+			return false
+		}
+	}
+
+	if q.Collaborator != nil {
+		ok, err := m.IsDIDCollaborator(*q.Collaborator)
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	if !q.From.IsZero() || !q.To.IsZero() {
+		ts, err := m.Timestamp()
+		if err != nil {
+			return false
+		}
+
+		if !q.From.IsZero() && ts.Before(q.From) {
+			return false
+		}
+
+		if !q.To.IsZero() && ts.After(q.To) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,45 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+	"time"
+
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_Match(t *testing.T) {
+	author := testingidentity.GenerateRandomDID()
+	collab := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+
+	m := new(MockModel)
+	m.On("Scheme").Return("generic")
+	m.On("GetStatus").Return(Committed)
+	m.On("Author").Return(author, nil)
+	m.On("IsDIDCollaborator", collab).Return(true, nil)
+	m.On("IsDIDCollaborator", author).Return(false, nil)
+	m.On("Timestamp").Return(tm, nil)
+
+	tests := []struct {
+		query Query
+		match bool
+	}{
+		{query: Query{}, match: true},
+		{query: Query{Scheme: "generic", Status: Committed}, match: true},
+		{query: Query{Scheme: "entity"}, match: false},
+		{query: Query{Status: Committing}, match: false},
+		{query: Query{Author: &author, Collaborator: &collab}, match: true},
+		{query: Query{Author: &collab}, match: false},
+		{query: Query{Collaborator: &author}, match: false},
+		{query: Query{From: tm.Add(-time.Hour), To: tm.Add(time.Hour)}, match: true},
+		{query: Query{From: tm.Add(time.Hour)}, match: false},
+		{query: Query{To: tm.Add(-time.Hour)}, match: false},
+	}
+
+	for _, c := range tests {
+		assert.Equal(t, c.match, c.query.Match(m), c.query)
+	}
+}
@@ -55,6 +55,22 @@ func (cd *CoreDocument) addNewReadRule(roleKey []byte, action coredocumentpb.Act
 	cd.Modified = true
 }
 
+// AddReadRule gives the collaborators of the role read access to the document.
+// Action must either be Action_ACTION_READ or Action_ACTION_READ_SIGN.
+// Role must be present to create a rule.
+func (cd *CoreDocument) AddReadRule(roleID []byte, action coredocumentpb.Action) (*coredocumentpb.ReadRule, error) {
+	if action != coredocumentpb.Action_ACTION_READ && action != coredocumentpb.Action_ACTION_READ_SIGN {
+		return nil, ErrInvalidReadAction
+	}
+
+	if _, err := cd.GetRole(roleID); err != nil {
+		return nil, err
+	}
+
+	cd.addNewReadRule(roleID, action)
+	return cd.Document.ReadRules[len(cd.Document.ReadRules)-1], nil
+}
+
 // findRole calls OnRole for every role that matches the actions passed in
 func findReadRole(cd coredocumentpb.CoreDocument, onRole func(rridx, ridx int, role *coredocumentpb.Role) bool, actions ...coredocumentpb.Action) bool {
 	am := make(map[int32]struct{})
@@ -102,6 +102,30 @@ func TestCoreDocument_addNFTToReadRules(t *testing.T) {
 	assert.Equal(t, enft, cd.Document.Roles[0].Nfts[0])
 }
 
+func TestCoreDocument_AddReadRule(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+	role, err := cd.AddRole("role", []identity.DID{collab})
+	assert.NoError(t, err)
+
+	// invalid action
+	_, err = cd.AddReadRule(role.RoleKey, coredocumentpb.Action_ACTION_INVALID)
+	assert.True(t, errors.IsOfType(ErrInvalidReadAction, err))
+
+	// missing role
+	_, err = cd.AddReadRule(utils.RandomSlice(32), coredocumentpb.Action_ACTION_READ)
+	assert.True(t, errors.IsOfType(ErrRoleNotExist, err))
+	assert.Nil(t, cd.Document.ReadRules)
+
+	// success
+	rule, err := cd.AddReadRule(role.RoleKey, coredocumentpb.Action_ACTION_READ)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{role.RoleKey}, rule.Roles)
+	assert.Equal(t, []*coredocumentpb.ReadRule{rule}, cd.Document.ReadRules)
+	assert.True(t, cd.AccountCanRead(collab))
+}
+
 func TestCoreDocument_NFTOwnerCanRead(t *testing.T) {
 	account := testingidentity.GenerateRandomDID()
 	cd, err := NewCoreDocument(nil, CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{account}}, nil)
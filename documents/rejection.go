@@ -0,0 +1,194 @@
+package documents
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+)
+
+// RejectionCode is the structured reason sent to the requester when a document version sent for signing is rejected.
+// Codes are sent over the p2p layer. Existing codes must not be changed.
+type RejectionCode int32
+
+const (
+	// RejectionCodeInvalidDocument is used when the cause of rejection cannot be classified.
+	RejectionCodeInvalidDocument RejectionCode = 1
+
+	// RejectionCodeInvalidTimestamp is used when the document timestamp is not valid for signing.
+	RejectionCodeInvalidTimestamp RejectionCode = 2
+
+	// RejectionCodeInvalidAuthor is used when the document author is not the sender.
+	RejectionCodeInvalidAuthor RejectionCode = 3
+
+	// RejectionCodeVersionAnchored is used when the document version or the next version is already anchored.
+	RejectionCodeVersionAnchored RejectionCode = 4
+
+	// RejectionCodeInvalidAnchorRepository is used when the document anchor repository is not supported.
+	RejectionCodeInvalidAnchorRepository RejectionCode = 5
+
+	// RejectionCodeUnauthorizedTransition is used when the sender is not allowed to make the changes in the document.
+	RejectionCodeUnauthorizedTransition RejectionCode = 6
+
+	// RejectionCodeInvalidSignatures is used when the document signing root or signatures are invalid.
+	RejectionCodeInvalidSignatures RejectionCode = 7
+)
+
+var rejectionReasons = map[RejectionCode]error{
+	RejectionCodeInvalidDocument:         ErrRejectionInvalidDocument,
+	RejectionCodeInvalidTimestamp:        ErrRejectionInvalidTimestamp,
+	RejectionCodeInvalidAuthor:           ErrRejectionInvalidAuthor,
+	RejectionCodeVersionAnchored:         ErrRejectionVersionAnchored,
+	RejectionCodeInvalidAnchorRepository: ErrRejectionInvalidAnchorRepository,
+	RejectionCodeUnauthorizedTransition:  ErrRejectionUnauthorizedTransition,
+	RejectionCodeInvalidSignatures:       ErrRejectionInvalidSignatures,
+}
+
+// String returns the reason of the rejection code.
+func (c RejectionCode) String() string {
+	reason, ok := rejectionReasons[c]
+	if !ok {
+		return fmt.Sprintf("unknown rejection code %d", c)
+	}
+
+	return reason.Error()
+}
+
+// RejectionError is returned when a document version sent for signing is rejected.
+type RejectionError struct {
+	// Codes are the reasons for the rejection.
+	Codes []RejectionCode
+
+	// err is the validation error.
+	// This is only available on the rejecting node and is never sent to the requester.
+	err error
+}
+
+// NewRejectionError returns a RejectionError with the codes of the failed validations in err.
+func NewRejectionError(err error) *RejectionError {
+	rerr := &RejectionError{err: err}
+	seen := make(map[RejectionCode]struct{})
+	for _, e := range errors.GetErrs(err) {
+		code := RejectionCodeInvalidDocument
+		for c, reason := range rejectionReasons {
+			if c != RejectionCodeInvalidDocument && errors.IsOfType(reason, e) {
+				code = c
+				break
+			}
+		}
+
+		if _, ok := seen[code]; ok {
+			continue
+		}
+
+		seen[code] = struct{}{}
+		rerr.Codes = append(rerr.Codes, code)
+	}
+
+	return rerr
+}
+
+// Reason returns the reasons of the rejection codes.
+func (e *RejectionError) Reason() string {
+	var reasons []string
+	for _, c := range e.Codes {
+		reasons = append(reasons, c.String())
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+// Error returns the rejection reasons along with the validation error if available.
+func (e *RejectionError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("%v: %s", ErrDocumentRejected, e.Reason())
+	}
+
+	return fmt.Sprintf("%v: %s: %v", ErrDocumentRejected, e.Reason(), e.err)
+}
+
+// IsOfType returns true if terr is ErrDocumentRejected, ErrDocumentInvalid, or the reason of any of the codes.
+func (e *RejectionError) IsOfType(terr error) bool {
+	switch terr.Error() {
+	case ErrDocumentRejected.Error(), ErrDocumentInvalid.Error():
+		return true
+	}
+
+	for _, c := range e.Codes {
+		if c.String() == terr.Error() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mask hides the validation error and returns only the rejection reasons.
+func (e *RejectionError) Mask() error {
+	return &RejectionError{Codes: e.Codes}
+}
+
+// rejectionValidator returns a validator that types the errors from v with the reason of the code.
+func rejectionValidator(code RejectionCode, v Validator) Validator {
+	return ValidatorFunc(func(old, new Model) error {
+		err := v.Validate(old, new)
+		if err != nil {
+			return errors.NewTypedError(rejectionReasons[code], err)
+		}
+
+		return nil
+	})
+}
+
+// Rejection holds the details of a document version rejected by the node.
+type Rejection struct {
+	DocumentID []byte `json:"document_id"`
+	VersionID  []byte `json:"version_id"`
+
+	// Requester is the collaborator who requested the signature.
+	Requester identity.DID `json:"requester"`
+
+	Codes []RejectionCode `json:"codes"`
+
+	// Reason is the validation error of the version.
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// JSON marshals Rejection to json bytes.
+func (r *Rejection) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Type returns the type of Rejection.
+func (r *Rejection) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// FromJSON loads json bytes to Rejection.
+func (r *Rejection) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// CollaboratorError holds the error received from a collaborator while collecting the signatures.
+type CollaboratorError struct {
+	Collaborator identity.DID
+
+	// Err is a *RejectionError if the collaborator rejected the document version.
+	Err error
+}
+
+// Error returns the collaborator and the error.
+func (e CollaboratorError) Error() string {
+	return fmt.Sprintf("collaborator %s: %v", e.Collaborator, e.Err)
+}
+
+// Rejection returns the RejectionError and true if the collaborator rejected the document version.
+func (e CollaboratorError) Rejection() (*RejectionError, bool) {
+	rerr, ok := e.Err.(*RejectionError)
+	return rerr, ok
+}
@@ -0,0 +1,58 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectionCode_String(t *testing.T) {
+	assert.Equal(t, ErrRejectionInvalidTimestamp.Error(), RejectionCodeInvalidTimestamp.String())
+	assert.Equal(t, "unknown rejection code 100", RejectionCode(100).String())
+}
+
+func TestNewRejectionError(t *testing.T) {
+	// unclassified error
+	rerr := NewRejectionError(errors.New("some error"))
+	assert.Equal(t, []RejectionCode{RejectionCodeInvalidDocument}, rerr.Codes)
+
+	// classified errors
+	err := errors.AppendError(nil, errors.NewTypedError(ErrRejectionInvalidTimestamp, errors.New("time")))
+	err = errors.AppendError(err, errors.NewTypedError(ErrRejectionUnauthorizedTransition, errors.New("transition")))
+	err = errors.AppendError(err, errors.NewTypedError(ErrRejectionInvalidTimestamp, errors.New("time again")))
+	rerr = NewRejectionError(err)
+	assert.Equal(t, []RejectionCode{RejectionCodeInvalidTimestamp, RejectionCodeUnauthorizedTransition}, rerr.Codes)
+	assert.Equal(t, ErrRejectionInvalidTimestamp.Error()+", "+ErrRejectionUnauthorizedTransition.Error(), rerr.Reason())
+	assert.Contains(t, rerr.Error(), "transition")
+}
+
+func TestRejectionError_IsOfType(t *testing.T) {
+	rerr := NewRejectionError(errors.NewTypedError(ErrRejectionInvalidAuthor, errors.New("author")))
+	assert.True(t, errors.IsOfType(ErrDocumentRejected, rerr))
+	assert.True(t, errors.IsOfType(ErrDocumentInvalid, rerr))
+	assert.True(t, errors.IsOfType(ErrRejectionInvalidAuthor, rerr))
+	assert.False(t, errors.IsOfType(ErrRejectionInvalidSignatures, rerr))
+}
+
+func TestRejectionError_Mask(t *testing.T) {
+	rerr := NewRejectionError(errors.NewTypedError(ErrRejectionInvalidAuthor, errors.New("secret details")))
+	merr := rerr.Mask()
+	assert.NotContains(t, merr.Error(), "secret details")
+	assert.Equal(t, rerr.Codes, merr.(*RejectionError).Codes)
+}
+
+func TestCollaboratorError_Rejection(t *testing.T) {
+	cerr := CollaboratorError{Err: errors.New("failed to connect")}
+	_, ok := cerr.Rejection()
+	assert.False(t, ok)
+
+	rerr := &RejectionError{Codes: []RejectionCode{RejectionCodeVersionAnchored}}
+	cerr = CollaboratorError{Err: rerr}
+	got, ok := cerr.Rejection()
+	assert.True(t, ok)
+	assert.Equal(t, rerr, got)
+	assert.Contains(t, cerr.Error(), rerr.Reason())
+}
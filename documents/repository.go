@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -17,6 +19,24 @@ const (
 
 	// LatestPrefix is used to index latest version of the document.
 	LatestPrefix string = "latest_document_"
+
+	// RejectionPrefix is used to store the rejections of the document versions.
+	RejectionPrefix string = "rejection_"
+
+	// AnchorCheckpointPrefix is used to store the anchoring checkpoints of the document versions.
+	AnchorCheckpointPrefix string = "anchor_checkpoint_"
+
+	// DeliveryPrefix is used to store the delivery status of the anchored document versions to the collaborators.
+	DeliveryPrefix string = "delivery_"
+
+	// PendingDeliveryPrefix is used to index the deliveries waiting to be retried.
+	PendingDeliveryPrefix string = "pending_delivery_"
+
+	// CommittingPrefix is used to index the document versions being committed.
+	CommittingPrefix string = "committing_document_"
+
+	// CommitSnapshotPrefix is used to store the document versions as they were when the commit started.
+	CommitSnapshotPrefix string = "commit_snapshot_"
 )
 
 type latestVersion struct {
@@ -68,16 +88,65 @@ type Repository interface {
 
 	// GetLatest returns the latest version of the document.
 	GetLatest(accountID, docID []byte) (Model, error)
+
+	// Query returns a page of the latest versions of the documents, owned by accountID, that match the query.
+	// Documents are listed in the order of their IDs.
+	Query(accountID []byte, query Query) (QueryResult, error)
+
+	// StoreRejection stores the rejection of a document version, owned by accountID.
+	StoreRejection(accountID []byte, rejection *Rejection) error
+
+	// GetRejection returns the rejection of the document version, owned by accountID.
+	GetRejection(accountID, versionID []byte) (*Rejection, error)
+
+	// StoreAnchorCheckpoint stores the anchoring checkpoint of a document version.
+	StoreAnchorCheckpoint(checkpoint *AnchorCheckpoint) error
+
+	// GetAnchorCheckpoint returns the anchoring checkpoint of the document version, owned by accountID.
+	GetAnchorCheckpoint(accountID, versionID []byte) (*AnchorCheckpoint, error)
+
+	// DeleteAnchorCheckpoint deletes the anchoring checkpoint of the document version, owned by accountID.
+	DeleteAnchorCheckpoint(accountID, versionID []byte) error
+
+	// GetAnchorCheckpoints returns the anchoring checkpoints of all the accounts.
+	GetAnchorCheckpoints() ([]*AnchorCheckpoint, error)
+
+	// StoreDelivery stores the delivery status of a document version to a collaborator.
+	StoreDelivery(delivery *Delivery) error
+
+	// GetDelivery returns the delivery status of the document version, owned by accountID, to the collaborator.
+	GetDelivery(accountID, versionID []byte, collaborator identity.DID) (*Delivery, error)
+
+	// GetDeliveries returns the delivery status of the document version, owned by accountID, to each collaborator.
+	GetDeliveries(accountID, versionID []byte) ([]*Delivery, error)
+
+	// GetPendingDeliveries returns the pending deliveries of all the accounts.
+	GetPendingDeliveries() ([]*Delivery, error)
+
+	// GetCommittingVersions returns the versions being committed by all the accounts.
+	GetCommittingVersions() ([]*CommittingVersion, error)
+
+	// GetCommitSnapshot returns the version, owned by accountID, as it was when the commit started.
+	GetCommitSnapshot(accountID, versionID []byte) (Model, error)
+
+	// Rollback deletes the version being committed, owned by accountID, along with its commit records
+	// and points the latest version index back to the previous version.
+	Rollback(accountID []byte, model Model) error
 }
 
 // NewDBRepository creates an instance of the documents Repository
 func NewDBRepository(db storage.Repository) Repository {
 	db.Register(new(latestVersion))
-	return &repo{db: db}
+	db.Register(new(Rejection))
+	db.Register(new(AnchorCheckpoint))
+	db.Register(new(Delivery))
+	db.Register(new(CommittingVersion))
+	return &repo{db: db, attrIndex: NewAttributeIndex(db)}
 }
 
 type repo struct {
-	db storage.Repository
+	db        storage.Repository
+	attrIndex AttributeIndex
 }
 
 // getKey returns document_+accountID+id
@@ -86,6 +155,45 @@ func (r *repo) getKey(accountID, id []byte) []byte {
 	return append([]byte(DocPrefix), []byte(hexKey)...)
 }
 
+// getRejectionKey returns rejection_+accountID+versionID
+func (r *repo) getRejectionKey(accountID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, versionID...))
+	return append([]byte(RejectionPrefix), []byte(hexKey)...)
+}
+
+// getAnchorCheckpointKey returns anchor_checkpoint_+accountID+versionID
+func (r *repo) getAnchorCheckpointKey(accountID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, versionID...))
+	return append([]byte(AnchorCheckpointPrefix), []byte(hexKey)...)
+}
+
+// getDeliveriesPrefix returns delivery_+accountID+versionID
+func (r *repo) getDeliveriesPrefix(accountID, versionID []byte) string {
+	return DeliveryPrefix + hexutil.Encode(append(accountID, versionID...))
+}
+
+// getDeliveryKey returns delivery_+accountID+versionID+collaborator
+func (r *repo) getDeliveryKey(accountID, versionID []byte, collaborator identity.DID) []byte {
+	return []byte(r.getDeliveriesPrefix(accountID, versionID) + hexutil.Encode(collaborator[:])[2:])
+}
+
+// getPendingDeliveryKey returns pending_delivery_+accountID+versionID+collaborator
+func (r *repo) getPendingDeliveryKey(accountID, versionID []byte, collaborator identity.DID) []byte {
+	return []byte(PendingDeliveryPrefix + hexutil.Encode(append(accountID, versionID...)) + hexutil.Encode(collaborator[:])[2:])
+}
+
+// getCommittingKey returns committing_document_+accountID+versionID
+func (r *repo) getCommittingKey(accountID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, versionID...))
+	return append([]byte(CommittingPrefix), []byte(hexKey)...)
+}
+
+// getCommitSnapshotKey returns commit_snapshot_+accountID+versionID
+func (r *repo) getCommitSnapshotKey(accountID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, versionID...))
+	return append([]byte(CommitSnapshotPrefix), []byte(hexKey)...)
+}
+
 // Register registers the model so that the DB can return the document without knowing the type
 func (r *repo) Register(model Model) {
 	r.db.Register(model)
@@ -113,24 +221,308 @@ func (r *repo) Get(accountID, id []byte) (Model, error) {
 
 // Create creates the model if not present in the DB.
 // should error out if the document exists.
+// The model, its latest version indexes and its commit records are written atomically.
 func (r *repo) Create(accountID, id []byte, model Model) error {
+	b := r.db.NewBatch()
 	key := r.getKey(accountID, id)
-	if err := r.db.Create(key, model); err != nil {
+	if err := b.Create(key, model); err != nil {
 		return err
 	}
 
-	return r.updateLatestIndex(accountID, model)
+	if err := r.updateLatestIndex(b, accountID, model); err != nil {
+		return err
+	}
+
+	if err := r.updateCommitRecords(b, accountID, id, model, true); err != nil {
+		return err
+	}
+
+	return b.Commit()
 }
 
 // Update strictly updates the model.
 // Will error out when the model doesn't exist in the DB.
+// The model, its latest version indexes and its commit records are written atomically.
 func (r *repo) Update(accountID, id []byte, model Model) error {
+	b := r.db.NewBatch()
 	key := r.getKey(accountID, id)
-	if err := r.db.Update(key, model); err != nil {
+	if err := b.Update(key, model); err != nil {
 		return err
 	}
 
-	return r.updateLatestIndex(accountID, model)
+	if err := r.updateLatestIndex(b, accountID, model); err != nil {
+		return err
+	}
+
+	if err := r.updateCommitRecords(b, accountID, id, model, false); err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+// updateCommitRecords adds the commit records of the version to the batch.
+// A version stored as Committing is indexed along with a snapshot of the version so that
+// the commit can be rolled back. Records are deleted once the version moves out of Committing.
+func (r *repo) updateCommitRecords(b storage.Batch, accountID, id []byte, model Model, create bool) error {
+	key := r.getCommittingKey(accountID, id)
+	snapshotKey := r.getCommitSnapshotKey(accountID, id)
+	if model.GetStatus() != Committing {
+		if !b.Exists(key) {
+			return nil
+		}
+
+		return errors.AppendError(b.Delete(key), b.Delete(snapshotKey))
+	}
+
+	if !create || b.Exists(key) {
+		return nil
+	}
+
+	err := b.Create(key, &CommittingVersion{
+		AccountID:  accountID,
+		DocumentID: model.ID(),
+		VersionID:  id,
+		Timestamp:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.Create(snapshotKey, model)
+}
+
+// GetCommittingVersions returns the versions being committed by all the accounts.
+func (r *repo) GetCommittingVersions() ([]*CommittingVersion, error) {
+	iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(CommittingPrefix)})
+	defer iter.Release()
+	var cvs []*CommittingVersion
+	for iter.Next() {
+		cv, ok := iter.Model().(*CommittingVersion)
+		if !ok {
+			continue
+		}
+
+		cvs = append(cvs, cv)
+	}
+
+	return cvs, iter.Error()
+}
+
+// GetCommitSnapshot returns the version, owned by accountID, as it was when the commit started.
+func (r *repo) GetCommitSnapshot(accountID, versionID []byte) (Model, error) {
+	m, err := r.db.Get(r.getCommitSnapshotKey(accountID, versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentNotFound, err)
+	}
+
+	model, ok := m.(Model)
+	if !ok {
+		return nil, errors.NewTypedError(ErrDocumentNotFound, errors.New("not a model object"))
+	}
+
+	return model, nil
+}
+
+// Rollback deletes the version being committed, owned by accountID, along with its commit records
+// and points the latest version index back to the previous version.
+// Latest version index and attribute index of the document are removed if there is no previous version.
+func (r *repo) Rollback(accountID []byte, model Model) error {
+	b := r.db.NewBatch()
+	id := model.CurrentVersion()
+	for _, key := range [][]byte{
+		r.getKey(accountID, id),
+		r.getCommittingKey(accountID, id),
+		r.getCommitSnapshotKey(accountID, id),
+		r.getAnchorCheckpointKey(accountID, id),
+	} {
+		if !b.Exists(key) {
+			continue
+		}
+
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	key := r.getLatestKey(accountID, model.ID())
+	lv, err := r.getLatest(key)
+	if err != nil || !bytes.Equal(lv.CurrentVersion, id) {
+		// latest version is not the rolled back version
+		return b.Commit()
+	}
+
+	prev, err := r.Get(accountID, model.PreviousVersion())
+	if err == nil && prev.GetStatus() != Rejected {
+		if err := r.storeLatestIndex(b, accountID, key, prev, true); err != nil {
+			return err
+		}
+
+		return b.Commit()
+	}
+
+	if err := b.Delete(key); err != nil {
+		return err
+	}
+
+	if err := r.attrIndex.RemoveBatch(b, accountID, model.ID()); err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+// StoreRejection stores the rejection of a document version, owned by accountID.
+// Existing rejection of the version is overwritten.
+func (r *repo) StoreRejection(accountID []byte, rejection *Rejection) error {
+	key := r.getRejectionKey(accountID, rejection.VersionID)
+	if r.db.Exists(key) {
+		return r.db.Update(key, rejection)
+	}
+
+	return r.db.Create(key, rejection)
+}
+
+// GetRejection returns the rejection of the document version, owned by accountID.
+func (r *repo) GetRejection(accountID, versionID []byte) (*Rejection, error) {
+	m, err := r.db.Get(r.getRejectionKey(accountID, versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrRejectionNotFound, err)
+	}
+
+	rejection, ok := m.(*Rejection)
+	if !ok {
+		return nil, errors.NewTypedError(ErrRejectionNotFound, errors.New("not a rejection object"))
+	}
+
+	return rejection, nil
+}
+
+// StoreAnchorCheckpoint stores the anchoring checkpoint of a document version.
+// Existing checkpoint of the version is overwritten.
+func (r *repo) StoreAnchorCheckpoint(checkpoint *AnchorCheckpoint) error {
+	key := r.getAnchorCheckpointKey(checkpoint.AccountID[:], checkpoint.VersionID)
+	if r.db.Exists(key) {
+		return r.db.Update(key, checkpoint)
+	}
+
+	return r.db.Create(key, checkpoint)
+}
+
+// GetAnchorCheckpoint returns the anchoring checkpoint of the document version, owned by accountID.
+func (r *repo) GetAnchorCheckpoint(accountID, versionID []byte) (*AnchorCheckpoint, error) {
+	m, err := r.db.Get(r.getAnchorCheckpointKey(accountID, versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrAnchorCheckpointNotFound, err)
+	}
+
+	checkpoint, ok := m.(*AnchorCheckpoint)
+	if !ok {
+		return nil, errors.NewTypedError(ErrAnchorCheckpointNotFound, errors.New("not an anchor checkpoint object"))
+	}
+
+	return checkpoint, nil
+}
+
+// DeleteAnchorCheckpoint deletes the anchoring checkpoint of the document version, owned by accountID.
+func (r *repo) DeleteAnchorCheckpoint(accountID, versionID []byte) error {
+	return r.db.Delete(r.getAnchorCheckpointKey(accountID, versionID))
+}
+
+// GetAnchorCheckpoints returns the anchoring checkpoints of all the accounts.
+func (r *repo) GetAnchorCheckpoints() ([]*AnchorCheckpoint, error) {
+	iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(AnchorCheckpointPrefix)})
+	defer iter.Release()
+	var checkpoints []*AnchorCheckpoint
+	for iter.Next() {
+		checkpoint, ok := iter.Model().(*AnchorCheckpoint)
+		if !ok {
+			continue
+		}
+
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, iter.Error()
+}
+
+// StoreDelivery stores the delivery status of a document version to a collaborator.
+// Existing delivery status is overwritten.
+// Pending deliveries are indexed along with the status so that the delivery queue reads only the pending ones.
+func (r *repo) StoreDelivery(delivery *Delivery) error {
+	b := r.db.NewBatch()
+	key := r.getDeliveryKey(delivery.AccountID[:], delivery.VersionID, delivery.Collaborator)
+	if err := storeInBatch(b, key, delivery); err != nil {
+		return err
+	}
+
+	pendingKey := r.getPendingDeliveryKey(delivery.AccountID[:], delivery.VersionID, delivery.Collaborator)
+	switch {
+	case delivery.Status == DeliveryPending:
+		if err := storeInBatch(b, pendingKey, delivery); err != nil {
+			return err
+		}
+	case b.Exists(pendingKey):
+		if err := b.Delete(pendingKey); err != nil {
+			return err
+		}
+	}
+
+	return b.Commit()
+}
+
+// storeInBatch adds the model to the batch. Existing model is overwritten.
+func storeInBatch(b storage.Batch, key []byte, model storage.Model) error {
+	if b.Exists(key) {
+		return b.Update(key, model)
+	}
+
+	return b.Create(key, model)
+}
+
+// GetDelivery returns the delivery status of the document version, owned by accountID, to the collaborator.
+func (r *repo) GetDelivery(accountID, versionID []byte, collaborator identity.DID) (*Delivery, error) {
+	m, err := r.db.Get(r.getDeliveryKey(accountID, versionID, collaborator))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDeliveryNotFound, err)
+	}
+
+	delivery, ok := m.(*Delivery)
+	if !ok {
+		return nil, errors.NewTypedError(ErrDeliveryNotFound, errors.New("not a delivery object"))
+	}
+
+	return delivery, nil
+}
+
+// GetDeliveries returns the delivery status of the document version, owned by accountID, to each collaborator.
+func (r *repo) GetDeliveries(accountID, versionID []byte) ([]*Delivery, error) {
+	return r.getDeliveries(r.getDeliveriesPrefix(accountID, versionID), func(*Delivery) bool {
+		return true
+	})
+}
+
+// GetPendingDeliveries returns the pending deliveries of all the accounts.
+func (r *repo) GetPendingDeliveries() ([]*Delivery, error) {
+	return r.getDeliveries(PendingDeliveryPrefix, func(d *Delivery) bool {
+		return d.Status == DeliveryPending
+	})
+}
+
+func (r *repo) getDeliveries(prefix string, filter func(d *Delivery) bool) ([]*Delivery, error) {
+	iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(prefix)})
+	defer iter.Release()
+	var deliveries []*Delivery
+	for iter.Next() {
+		d, ok := iter.Model().(*Delivery)
+		if !ok || !filter(d) {
+			continue
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, iter.Error()
 }
 
 // GetLatest returns thee latest version of the document.
@@ -144,6 +536,93 @@ func (r *repo) GetLatest(accountID, docID []byte) (Model, error) {
 	return r.Get(accountID, lv.CurrentVersion)
 }
 
+// Query returns a page of the latest versions of the documents, owned by accountID, that match the query.
+// Documents are listed in the order of their IDs.
+func (r *repo) Query(accountID []byte, query Query) (QueryResult, error) {
+	if len(query.AttributeFilters) > 0 {
+		return r.queryAttributes(accountID, query)
+	}
+
+	var res QueryResult
+	opts := storage.IterateOptions{Prefix: []byte(LatestPrefix + hexutil.Encode(accountID))}
+	if query.Cursor != nil {
+		opts.Cursor = r.getLatestKey(accountID, query.Cursor)
+	}
+
+	for {
+		// fetch only the rest of the page since some documents may not match
+		lvs, next, err := storage.Page(r.db, opts, query.Limit-len(res.Documents))
+		if err != nil {
+			return res, err
+		}
+
+		for _, m := range lvs {
+			if lv, ok := m.(*latestVersion); ok {
+				res.Documents = r.appendMatch(accountID, query, res.Documents, lv)
+			}
+		}
+
+		if next == nil {
+			return res, nil
+		}
+
+		opts.Cursor = next
+		if len(res.Documents) >= query.Limit {
+			res.Next, err = r.getLatestDocumentID(accountID, next)
+			return res, err
+		}
+	}
+}
+
+// queryAttributes returns a page of the documents that satisfy the attribute filters and match the query.
+func (r *repo) queryAttributes(accountID []byte, query Query) (res QueryResult, err error) {
+	ids, err := r.attrIndex.Find(accountID, query.AttributeFilters...)
+	if err != nil {
+		return res, err
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i], ids[j]) < 0
+	})
+
+	var last []byte
+	for _, id := range ids {
+		if query.Cursor != nil && bytes.Compare(id, query.Cursor) <= 0 {
+			continue
+		}
+
+		if query.Limit > 0 && len(res.Documents) >= query.Limit {
+			res.Next = last
+			return res, nil
+		}
+
+		last = id
+		lv, err := r.getLatest(r.getLatestKey(accountID, id))
+		if err != nil {
+			continue
+		}
+
+		res.Documents = r.appendMatch(accountID, query, res.Documents, lv)
+	}
+
+	return res, nil
+}
+
+// appendMatch appends the latest version to the documents if it matches the query.
+func (r *repo) appendMatch(accountID []byte, query Query, docs []Model, lv *latestVersion) []Model {
+	m, err := r.Get(accountID, lv.CurrentVersion)
+	if err != nil {
+		log.Warningf("failed to fetch latest version %s: %v", hexutil.Encode(lv.CurrentVersion), err)
+		return docs
+	}
+
+	if !query.Match(m) {
+		return docs
+	}
+
+	return append(docs, m)
+}
+
 func (r *repo) getLatest(key []byte) (*latestVersion, error) {
 	val, err := r.db.Get(key)
 	if err != nil {
@@ -164,6 +643,16 @@ func (r *repo) getLatest(key []byte) (*latestVersion, error) {
 	return nil, ErrDocumentNotFound
 }
 
+// getLatestDocumentID returns the ID of the document from the key of its latest version.
+func (r *repo) getLatestDocumentID(accountID, key []byte) ([]byte, error) {
+	id, err := hexutil.Decode(string(key[len(LatestPrefix):]))
+	if err != nil {
+		return nil, err
+	}
+
+	return id[len(accountID):], nil
+}
+
 // getLatestKey constructs the key to the latest version of the document.
 // Note: DocumentIdentifier needs to be passed here not the versionID.
 func (r *repo) getLatestKey(accountID, docID []byte) []byte {
@@ -171,10 +660,10 @@ func (r *repo) getLatestKey(accountID, docID []byte) []byte {
 	return append([]byte(LatestPrefix), []byte(hexKey)...)
 }
 
-// storeLatestIndex stores the latestVersion to db.
+// storeLatestIndex adds the latestVersion and the attribute indexes of the model to the batch.
 // If update is true, it is assumed that index is overwritten
 // else, index is created first time.
-func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
+func (r *repo) storeLatestIndex(b storage.Batch, accID, key []byte, model Model, update bool) error {
 	lv := &latestVersion{
 		CurrentVersion: model.CurrentVersion(),
 		NextVersion:    model.NextVersion(),
@@ -188,13 +677,18 @@ func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
 	lv.Timestamp = tm
 
 	if update {
-		return r.db.Update(key, lv)
+		err = b.Update(key, lv)
+	} else {
+		err = b.Create(key, lv)
+	}
+	if err != nil {
+		return err
 	}
 
-	return r.db.Create(key, lv)
+	return r.attrIndex.IndexBatch(b, accID, model)
 }
 
-// updateLatestIndex updates the latest version index.
+// updateLatestIndex adds the updates of the latest version index to the batch.
 // We check if the latest index is present for a model.
 // If not found, create a latest index and return.
 // Note: anchor timestamp is not available immediately, so don't error out if the timestamp is empty
@@ -203,16 +697,21 @@ func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
 // If not matches, check the model timestamp is greater than stored timestamp.
 // If greater update the latestVersion and return
 // If not, skip update and return.
-func (r *repo) updateLatestIndex(accID []byte, model Model) error {
+func (r *repo) updateLatestIndex(b storage.Batch, accID []byte, model Model) error {
+	// rejected versions are never the latest
+	if model.GetStatus() == Rejected {
+		return nil
+	}
+
 	key := r.getLatestKey(accID, model.ID())
 	lv, err := r.getLatest(key)
 	if err != nil {
 		// no index is created yet. create one
-		return r.storeLatestIndex(key, model, false)
+		return r.storeLatestIndex(b, accID, key, model, false)
 	}
 
 	if bytes.Equal(lv.NextVersion, model.CurrentVersion()) {
-		return r.storeLatestIndex(key, model, true)
+		return r.storeLatestIndex(b, accID, key, model, true)
 	}
 
 	// compare timestamps
@@ -223,7 +722,7 @@ func (r *repo) updateLatestIndex(accID []byte, model Model) error {
 
 	if lv.Timestamp.Before(ts) {
 		// newer version found. so update
-		return r.storeLatestIndex(key, model, true)
+		return r.storeLatestIndex(b, accID, key, model, true)
 	}
 
 	// must be an old version.
@@ -3,13 +3,17 @@
 package documents
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,9 +25,13 @@ func getRepository(ctx map[string]interface{}) Repository {
 
 type doc struct {
 	Model
-	DocID, Current, Next []byte
-	SomeString           string `json:"some_string"`
-	Time                 time.Time
+	DocID, Current, Next, Previous []byte
+	Root                           []byte
+	SomeString                     string `json:"some_string"`
+	Time                           time.Time
+	DocScheme                      string
+	Attrs                          []Attribute
+	DocStatus                      Status
 }
 
 type unknownDoc struct {
@@ -54,6 +62,10 @@ func (m *doc) NextVersion() []byte {
 	return m.Next
 }
 
+func (m *doc) PreviousVersion() []byte {
+	return m.Previous
+}
+
 func (m *doc) JSON() ([]byte, error) {
 	return json.Marshal(m)
 }
@@ -70,6 +82,27 @@ func (m *doc) Timestamp() (time.Time, error) {
 	return m.Time, nil
 }
 
+func (m *doc) GetStatus() Status {
+	return m.DocStatus
+}
+
+func (m *doc) SetStatus(st Status) error {
+	m.DocStatus = st
+	return nil
+}
+
+func (m *doc) CalculateDocumentRoot() ([]byte, error) {
+	return m.Root, nil
+}
+
+func (m *doc) Scheme() string {
+	return m.DocScheme
+}
+
+func (m *doc) GetAttributes() []Attribute {
+	return m.Attrs
+}
+
 func TestLevelDBRepo_Create_Exists(t *testing.T) {
 	repo := getRepository(ctx)
 	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
@@ -102,6 +135,41 @@ func TestLevelDBRepo_Update_Exists(t *testing.T) {
 	assert.True(t, repo.Exists(accountID, id), "doc must be [resent")
 }
 
+// unregisteredModel is never registered with the DB.
+type unregisteredModel struct{}
+
+func (unregisteredModel) Type() reflect.Type {
+	return reflect.TypeOf(unregisteredModel{})
+}
+
+func (u *unregisteredModel) JSON() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *unregisteredModel) FromJSON(j []byte) error {
+	return json.Unmarshal(j, u)
+}
+
+func TestLevelDBRepo_Create_Atomic(t *testing.T) {
+	r := getRepository(ctx)
+	rr := r.(*repo)
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, World!", DocID: id, Current: id}
+
+	// latest index can neither be read nor created
+	err := rr.db.Create(rr.getLatestKey(accountID, id), new(unregisteredModel))
+	assert.NoError(t, err)
+	err = r.Create(accountID, id, d)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+	assert.False(t, r.Exists(accountID, id), "doc must not be stored without its latest index")
+
+	// document and the index are stored together
+	assert.NoError(t, rr.db.Delete(rr.getLatestKey(accountID, id)))
+	assert.NoError(t, r.Create(accountID, id, d))
+	assert.True(t, r.Exists(accountID, id))
+	assert.True(t, rr.db.Exists(rr.getLatestKey(accountID, id)))
+}
+
 func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 	repor := getRepository(ctx)
 
@@ -201,8 +269,17 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		Next:    next,
 		Time:    tm,
 	}
+	update := func(d *doc) error {
+		b := rr.db.NewBatch()
+		if err := rr.updateLatestIndex(b, acc, d); err != nil {
+			return err
+		}
+
+		return b.Commit()
+	}
+
 	assert.False(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	err := rr.updateLatestIndex(acc, d)
+	err := update(d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err := rr.getLatest(rr.getLatestKey(acc, id))
@@ -216,7 +293,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	d.Current = next
 	d.Next = utils.RandomSlice(32)
 	d.Time = time.Now().UTC()
-	err = rr.updateLatestIndex(acc, d)
+	err = update(d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
@@ -232,7 +309,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	tm = time.Now().UTC()
 	assert.False(t, d.Time.Equal(tm))
 	d.Time = tm
-	err = rr.updateLatestIndex(acc, d)
+	err = update(d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
@@ -248,7 +325,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	oldN := d.Next
 	d.Current = utils.RandomSlice(32)
 	d.Next = utils.RandomSlice(32)
-	err = rr.updateLatestIndex(acc, d)
+	err = update(d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
@@ -257,4 +334,336 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		Timestamp:      tm,
 		NextVersion:    oldN,
 	}, lv)
+
+	// rejected version, dont update index
+	d.Time = time.Now().UTC()
+	d.Current = utils.RandomSlice(32)
+	d.DocStatus = Rejected
+	err = update(d)
+	assert.NoError(t, err)
+	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
+	assert.NoError(t, err)
+	assert.Equal(t, oldC, lv.CurrentVersion)
+}
+
+func TestRepo_StoreRejection_GetRejection(t *testing.T) {
+	r := getRepository(ctx)
+	acc := utils.RandomSlice(20)
+	version := utils.RandomSlice(32)
+
+	// missing rejection
+	_, err := r.GetRejection(acc, version)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrRejectionNotFound, err))
+
+	// success
+	rejection := &Rejection{
+		DocumentID: utils.RandomSlice(32),
+		VersionID:  version,
+		Codes:      []RejectionCode{RejectionCodeInvalidTimestamp, RejectionCodeUnauthorizedTransition},
+		Reason:     "invalid transition",
+		Timestamp:  time.Now().UTC(),
+	}
+	err = r.StoreRejection(acc, rejection)
+	assert.NoError(t, err)
+	got, err := r.GetRejection(acc, version)
+	assert.NoError(t, err)
+	assert.Equal(t, rejection, got)
+
+	// rejection of the same version is overwritten
+	rejection.Codes = []RejectionCode{RejectionCodeInvalidSignatures}
+	err = r.StoreRejection(acc, rejection)
+	assert.NoError(t, err)
+	got, err = r.GetRejection(acc, version)
+	assert.NoError(t, err)
+	assert.Equal(t, rejection.Codes, got.Codes)
+}
+
+func TestRepo_AnchorCheckpoints(t *testing.T) {
+	r := getRepository(ctx)
+	acc := testingidentity.GenerateRandomDID()
+	version := utils.RandomSlice(32)
+
+	// missing checkpoint
+	_, err := r.GetAnchorCheckpoint(acc[:], version)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAnchorCheckpointNotFound, err))
+
+	// success
+	checkpoint := &AnchorCheckpoint{
+		AccountID:  acc,
+		DocumentID: utils.RandomSlice(32),
+		VersionID:  version,
+		JobID:      jobs.NewJobID(),
+		Step:       AnchorStepPreparedForSignatures,
+		Timestamp:  time.Now().UTC(),
+	}
+	assert.NoError(t, r.StoreAnchorCheckpoint(checkpoint))
+	got, err := r.GetAnchorCheckpoint(acc[:], version)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, got)
+
+	// checkpoint of the same version is overwritten
+	checkpoint.Step = AnchorStepAnchored
+	assert.NoError(t, r.StoreAnchorCheckpoint(checkpoint))
+	checkpoints, err := r.GetAnchorCheckpoints()
+	assert.NoError(t, err)
+	var found bool
+	for _, c := range checkpoints {
+		if utils.IsSameByteSlice(c.VersionID, version) {
+			found = true
+			assert.Equal(t, AnchorStepAnchored, c.Step)
+		}
+	}
+	assert.True(t, found)
+
+	// delete
+	assert.NoError(t, r.DeleteAnchorCheckpoint(acc[:], version))
+	_, err = r.GetAnchorCheckpoint(acc[:], version)
+	assert.Error(t, err)
+}
+
+func TestRepo_Deliveries(t *testing.T) {
+	r := getRepository(ctx)
+	acc := testingidentity.GenerateRandomDID()
+	version := utils.RandomSlice(32)
+	c1, c2 := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+
+	// missing delivery
+	_, err := r.GetDelivery(acc[:], version, c1)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDeliveryNotFound, err))
+
+	// success
+	d1 := &Delivery{AccountID: acc, VersionID: version, Collaborator: c1, Status: DeliveryPending, Attempts: 1}
+	d2 := &Delivery{AccountID: acc, VersionID: version, Collaborator: c2, Status: DeliveryDelivered, Attempts: 1}
+	assert.NoError(t, r.StoreDelivery(d1))
+	assert.NoError(t, r.StoreDelivery(d2))
+	got, err := r.GetDelivery(acc[:], version, c1)
+	assert.NoError(t, err)
+	assert.Equal(t, d1.Status, got.Status)
+	ds, err := r.GetDeliveries(acc[:], version)
+	assert.NoError(t, err)
+	assert.Len(t, ds, 2)
+
+	// only pending deliveries
+	ds, err = r.GetPendingDeliveries()
+	assert.NoError(t, err)
+	for _, d := range ds {
+		assert.Equal(t, DeliveryPending, d.Status)
+		assert.False(t, d.Collaborator.Equal(c2))
+	}
+
+	// pending deliveries are indexed separately
+	rr := r.(*repo)
+	assert.True(t, rr.db.Exists(rr.getPendingDeliveryKey(acc[:], version, c1)))
+	assert.False(t, rr.db.Exists(rr.getPendingDeliveryKey(acc[:], version, c2)))
+
+	// delivery is overwritten
+	d1.Status = DeliveryDelivered
+	assert.NoError(t, r.StoreDelivery(d1))
+	ds, err = r.GetPendingDeliveries()
+	assert.NoError(t, err)
+	for _, d := range ds {
+		assert.False(t, d.Collaborator.Equal(c1))
+	}
+	assert.False(t, rr.db.Exists(rr.getPendingDeliveryKey(acc[:], version, c1)))
+	got, err = r.GetDelivery(acc[:], version, c1)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveryDelivered, got.Status)
+}
+
+func TestRepo_Query(t *testing.T) {
+	r := getRepository(ctx)
+	r.Register(new(doc))
+	acc := utils.RandomSlice(20)
+	tm := time.Now().UTC()
+
+	// no documents
+	res, err := r.Query(acc, Query{})
+	assert.NoError(t, err)
+	assert.Len(t, res.Documents, 0)
+	assert.Nil(t, res.Next)
+
+	var docs []*doc
+	for i, scheme := range []string{"generic", "entity", "generic"} {
+		id := utils.RandomSlice(32)
+		d := &doc{DocID: id, Current: id, Next: utils.RandomSlice(32), Time: tm.Add(time.Duration(i) * time.Minute), DocScheme: scheme}
+		assert.NoError(t, r.Create(acc, id, d))
+		docs = append(docs, d)
+	}
+
+	// document of another account
+	id := utils.RandomSlice(32)
+	assert.NoError(t, r.Create(utils.RandomSlice(20), id, &doc{DocID: id, Current: id, DocScheme: "generic"}))
+
+	// all documents in the order of their IDs
+	sort.Slice(docs, func(i, j int) bool {
+		return bytes.Compare(docs[i].DocID, docs[j].DocID) < 0
+	})
+	res, err = r.Query(acc, Query{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Model{docs[0], docs[1], docs[2]}, res.Documents)
+	assert.Nil(t, res.Next)
+
+	// filter by scheme
+	var generic []Model
+	for _, d := range docs {
+		if d.DocScheme == "generic" {
+			generic = append(generic, d)
+		}
+	}
+	res, err = r.Query(acc, Query{Scheme: "generic"})
+	assert.NoError(t, err)
+	assert.Equal(t, generic, res.Documents)
+
+	// filter by timestamp
+	res, err = r.Query(acc, Query{From: docs[1].Time, To: docs[1].Time})
+	assert.NoError(t, err)
+	assert.Equal(t, []Model{docs[1]}, res.Documents)
+
+	// pagination
+	res, err = r.Query(acc, Query{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Model{docs[0], docs[1]}, res.Documents)
+	assert.Equal(t, docs[1].DocID, res.Next)
+	res, err = r.Query(acc, Query{Cursor: res.Next, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Model{docs[2]}, res.Documents)
+	assert.Nil(t, res.Next)
+
+	// pages are filled with the matching documents
+	res, err = r.Query(acc, Query{Scheme: "generic", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, generic[:1], res.Documents)
+	assert.NotNil(t, res.Next)
+	res, err = r.Query(acc, Query{Scheme: "generic", Cursor: res.Next, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, generic[1:], res.Documents)
+}
+
+func TestRepo_Query_AttributeFilters(t *testing.T) {
+	r := getRepository(ctx)
+	r.Register(new(doc))
+	acc := utils.RandomSlice(20)
+	tm := time.Now().UTC()
+
+	var docs []*doc
+	for i, amount := range []string{"100", "200.5", "300"} {
+		attr, err := NewStringAttribute("amount", AttrDecimal, amount)
+		assert.NoError(t, err)
+		id := utils.RandomSlice(32)
+		d := &doc{DocID: id, Current: id, Time: tm.Add(time.Duration(i) * time.Minute), Attrs: []Attribute{attr}}
+		assert.NoError(t, r.Create(acc, id, d))
+		docs = append(docs, d)
+	}
+
+	key, err := AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	res, err := r.Query(acc, Query{AttributeFilters: []AttributeFilter{
+		{Key: key, Op: FilterGt, Value: "100"},
+		{Key: key, Op: FilterLte, Value: "300"},
+	}})
+	assert.NoError(t, err)
+	matched := []Model{docs[1], docs[2]}
+	sort.Slice(matched, func(i, j int) bool {
+		return bytes.Compare(matched[i].ID(), matched[j].ID()) < 0
+	})
+	assert.Equal(t, matched, res.Documents)
+
+	// pagination
+	filters := []AttributeFilter{{Key: key, Op: FilterGt, Value: "100"}}
+	res, err = r.Query(acc, Query{AttributeFilters: filters, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, matched[:1], res.Documents)
+	assert.Equal(t, matched[0].ID(), res.Next)
+	res, err = r.Query(acc, Query{AttributeFilters: filters, Cursor: res.Next, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, matched[1:], res.Documents)
+	assert.Nil(t, res.Next)
+
+	// filter value is not a decimal
+	res, err = r.Query(acc, Query{AttributeFilters: []AttributeFilter{{Key: key, Op: FilterGt, Value: "abc"}}})
+	assert.NoError(t, err)
+	assert.Len(t, res.Documents, 0)
+}
+
+func TestRepo_CommitRecords_Rollback(t *testing.T) {
+	r := getRepository(ctx)
+	rr := r.(*repo)
+	r.Register(new(doc))
+	did := testingidentity.GenerateRandomDID()
+	acc := did[:]
+	tm := time.Now().UTC()
+	attr, err := NewStringAttribute("amount", AttrDecimal, "100")
+	assert.NoError(t, err)
+	key, err := AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	filter := AttributeFilter{Key: key, Op: FilterEq, Value: "100"}
+
+	// committing version is indexed along with its snapshot
+	id, next := utils.RandomSlice(32), utils.RandomSlice(32)
+	d1 := &doc{DocID: id, Current: id, Next: next, Time: tm, DocStatus: Committing, Attrs: []Attribute{attr}}
+	assert.NoError(t, r.Create(acc, id, d1))
+	assert.True(t, rr.db.Exists(rr.getCommittingKey(acc, id)))
+	snapshot, err := r.GetCommitSnapshot(acc, id)
+	assert.NoError(t, err)
+	assert.Equal(t, d1, snapshot)
+	cvs, err := r.GetCommittingVersions()
+	assert.NoError(t, err)
+	var found bool
+	for _, cv := range cvs {
+		if utils.IsSameByteSlice(cv.VersionID, id) {
+			found = true
+			assert.Equal(t, acc, cv.AccountID)
+			assert.Equal(t, id, cv.DocumentID)
+		}
+	}
+	assert.True(t, found)
+
+	// snapshot is not overwritten by the updates while committing
+	d1.SomeString = "signed"
+	assert.NoError(t, r.Update(acc, id, d1))
+	snapshot, err = r.GetCommitSnapshot(acc, id)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshot.(*doc).SomeString)
+
+	// records are deleted once committed
+	d1.DocStatus = Committed
+	assert.NoError(t, r.Update(acc, id, d1))
+	assert.False(t, rr.db.Exists(rr.getCommittingKey(acc, id)))
+	_, err = r.GetCommitSnapshot(acc, id)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+
+	// rolled back version points the latest index back to the previous version
+	d2 := &doc{DocID: id, Current: next, Previous: id, Next: utils.RandomSlice(32), Time: tm.Add(time.Minute), DocStatus: Committing}
+	assert.NoError(t, r.Create(acc, next, d2))
+	assert.NoError(t, r.StoreAnchorCheckpoint(&AnchorCheckpoint{AccountID: did, DocumentID: id, VersionID: next}))
+	m, err := r.GetLatest(acc, id)
+	assert.NoError(t, err)
+	assert.Equal(t, next, m.CurrentVersion())
+	assert.NoError(t, r.Rollback(acc, d2))
+	assert.False(t, r.Exists(acc, next))
+	assert.False(t, rr.db.Exists(rr.getCommittingKey(acc, next)))
+	assert.False(t, rr.db.Exists(rr.getCommitSnapshotKey(acc, next)))
+	_, err = r.GetAnchorCheckpoint(acc, next)
+	assert.True(t, errors.IsOfType(ErrAnchorCheckpointNotFound, err))
+	m, err = r.GetLatest(acc, id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, m.CurrentVersion())
+	ids, err := rr.attrIndex.Find(acc, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{id}, ids)
+
+	// rolled back first version removes the document from the indexes
+	acc = utils.RandomSlice(20)
+	d1.DocStatus = Committing
+	assert.NoError(t, r.Create(acc, id, d1))
+	assert.NoError(t, r.Rollback(acc, d1))
+	assert.False(t, r.Exists(acc, id))
+	_, err = r.GetLatest(acc, id)
+	assert.Error(t, err)
+	ids, err = rr.attrIndex.Find(acc, filter)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/anchors"
-	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -17,6 +16,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/protobuf/proto"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -30,6 +30,15 @@ type DocumentProof struct {
 	RightDataRoot  []byte
 	SigningRoot    []byte
 	SignaturesRoot []byte
+
+	// Tree is the data tree the proofs of the data and cd_tree fields are generated from.
+	Tree string
+
+	// CircuitProofs holds the proofs of the data tree fields in the circuit format. Set only for the ZKTree.
+	CircuitProofs []CircuitProof
+
+	// Anchor holds the details of the anchor the proofs are verifiable against.
+	Anchor *AnchorInfo
 }
 
 // Patcher interface defines a Patch method for inner Models
@@ -51,6 +60,24 @@ type Service interface {
 	// GetVersion reads a document from the database
 	GetVersion(ctx context.Context, documentID []byte, version []byte) (Model, error)
 
+	// GetVersionByID reads a document version from the database using the version identifier alone.
+	GetVersionByID(ctx context.Context, version []byte) (Model, error)
+
+	// Query returns the latest versions of the account's documents that match the query.
+	Query(ctx context.Context, query Query) (QueryResult, error)
+
+	// GetVersions returns the version history of the document, oldest version first.
+	GetVersions(ctx context.Context, documentID []byte) ([]VersionInfo, error)
+
+	// GetVersionsDiff returns the changes made in the document version `to` compared to the version `from`.
+	GetVersionsDiff(ctx context.Context, documentID, from, to []byte) (Diff, error)
+
+	// GetDeliveries returns the delivery status of the anchored document version to each collaborator.
+	GetDeliveries(ctx context.Context, documentID, versionID []byte) ([]*Delivery, error)
+
+	// ResendDocument queues the anchored document version to be sent to the collaborator again.
+	ResendDocument(ctx context.Context, documentID, versionID []byte, collaborator identity.DID) (*Delivery, error)
+
 	// DeriveFromCoreDocument derives a model given the core document.
 	DeriveFromCoreDocument(cd coredocumentpb.CoreDocument) (Model, error)
 
@@ -60,6 +87,14 @@ type Service interface {
 	// CreateProofsForVersion creates proofs for a particular version of the document given the fields
 	CreateProofsForVersion(ctx context.Context, documentID, version []byte, fields []string) (*DocumentProof, error)
 
+	// CreateProofsFromZKTree creates proofs for the document version given the fields from the ZK data tree.
+	// Latest version is used if the version is empty.
+	CreateProofsFromZKTree(ctx context.Context, documentID, version []byte, fields []string) (*DocumentProof, error)
+
+	// CreateProofBundle creates a self-contained proof bundle of the document version given the fields.
+	// Latest version is used if the version is empty.
+	CreateProofBundle(ctx context.Context, documentID, version []byte, fields []string) (*ProofBundle, error)
+
 	// RequestDocumentSignature Validates and Signs document received over the p2p layer
 	RequestDocumentSignature(ctx context.Context, model Model, collaborator identity.DID) ([]*coredocumentpb.Signature, error)
 
@@ -152,27 +187,61 @@ func (s service) GetVersion(ctx context.Context, documentID []byte, version []by
 	return s.getVersion(ctx, documentID, version)
 }
 
+func (s service) GetVersionByID(ctx context.Context, version []byte) (Model, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	model, err := s.repo.Get(acc.GetIdentityID(), version)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentVersionNotFound, err)
+	}
+
+	return model, nil
+}
+
+func (s service) Query(ctx context.Context, query Query) (QueryResult, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return QueryResult{}, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.Query(acc.GetIdentityID(), query)
+}
+
 func (s service) CreateProofs(ctx context.Context, documentID []byte, fields []string) (*DocumentProof, error) {
 	model, err := s.GetCurrentVersion(ctx, documentID)
 	if err != nil {
 		return nil, err
 	}
-	return s.createProofs(model, fields)
+	return s.createProofs(false, model, fields)
 
 }
 
-func (s service) createProofs(model Model, fields []string) (*DocumentProof, error) {
+func (s service) createProofs(fromZKTree bool, model Model, fields []string) (*DocumentProof, error) {
 	if err := PostAnchoredValidator(s.idService, s.anchorSrv).Validate(nil, model); err != nil {
 		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
 	}
 
-	docProof, err := model.CreateProofs(fields)
+	var docProof *DocumentProof
+	var err error
+	if fromZKTree {
+		docProof, err = model.CreateProofsFromZKTree(fields)
+	} else {
+		docProof, err = model.CreateProofs(fields)
+	}
 	if err != nil {
 		return nil, errors.NewTypedError(ErrDocumentProof, err)
 	}
 
 	docProof.DocumentID = model.ID()
 	docProof.VersionID = model.CurrentVersion()
+	docProof.Anchor, err = GetAnchorInfo(s.anchorSrv, model.CurrentVersion())
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentProof, err)
+	}
+
 	return docProof, nil
 
 }
@@ -182,7 +251,22 @@ func (s service) CreateProofsForVersion(ctx context.Context, documentID, version
 	if err != nil {
 		return nil, errors.NewTypedError(ErrDocumentNotFound, err)
 	}
-	return s.createProofs(model, fields)
+	return s.createProofs(false, model, fields)
+}
+
+func (s service) CreateProofsFromZKTree(ctx context.Context, documentID, version []byte, fields []string) (*DocumentProof, error) {
+	var model Model
+	var err error
+	if utils.IsEmptyByteSlice(version) {
+		model, err = s.GetCurrentVersion(ctx, documentID)
+	} else {
+		model, err = s.getVersion(ctx, documentID, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createProofs(true, model, fields)
 }
 
 func (s service) RequestDocumentSignature(ctx context.Context, model Model, collaborator identity.DID) ([]*coredocumentpb.Signature, error) {
@@ -208,8 +292,10 @@ func (s service) RequestDocumentSignature(ctx context.Context, model Model, coll
 		}
 	}
 
-	if err := RequestDocumentSignatureValidator(s.anchorSrv, s.idService, collaborator, s.config.GetContractAddress(config.AnchorRepo)).Validate(old, model); err != nil {
-		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+	if err := RequestDocumentSignatureValidator(s.anchorSrv, s.idService, collaborator).Validate(old, model); err != nil {
+		rerr := NewRejectionError(err)
+		s.storeRejectedVersion(did, collaborator, model, rerr)
+		return nil, rerr
 	}
 
 	sr, err := model.CalculateSigningRoot()
@@ -241,7 +327,12 @@ func (s service) RequestDocumentSignature(ctx context.Context, model Model, coll
 		}
 	}
 
-	err = s.repo.Create(did[:], model.CurrentVersion(), model)
+	// version rejected earlier is replaced with the signed version
+	if s.isRejectedVersion(did, model.CurrentVersion()) {
+		err = s.repo.Update(did[:], model.CurrentVersion(), model)
+	} else {
+		err = s.repo.Create(did[:], model.CurrentVersion(), model)
+	}
 	if err != nil {
 		return nil, errors.NewTypedError(ErrDocumentPersistence, err)
 	}
@@ -250,6 +341,77 @@ func (s service) RequestDocumentSignature(ctx context.Context, model Model, coll
 	return []*coredocumentpb.Signature{sig}, nil
 }
 
+// isRejectedVersion returns true if the version is stored and is rejected.
+func (s service) isRejectedVersion(did identity.DID, version []byte) bool {
+	m, err := s.repo.Get(did[:], version)
+	if err != nil {
+		return false
+	}
+
+	return m.GetStatus() == Rejected
+}
+
+const (
+	// maxRejectedVersionSize is the maximum size of the rejected version stored by the node.
+	maxRejectedVersionSize = 1 << 20
+
+	// maxRejectionReasonLength is the maximum length of the stored reason of rejection.
+	maxRejectionReasonLength = 1024
+)
+
+// storeRejectedVersion persists the rejected version with the reason of rejection.
+// Failures are only logged since the requester must receive the rejection regardless.
+// Only the versions of the documents known to the node are stored so that the peers cannot fill the DB with
+// the rejected versions. An existing version is not overwritten.
+func (s service) storeRejectedVersion(did, requester identity.DID, model Model, rerr *RejectionError) {
+	srvLog.Infof("rejected document %x with version %x: %s", model.ID(), model.CurrentVersion(), rerr.Reason())
+	if !s.repo.Exists(did[:], model.PreviousVersion()) && !s.repo.Exists(did[:], model.ID()) {
+		return
+	}
+
+	reason := rerr.Error()
+	if len(reason) > maxRejectionReasonLength {
+		reason = reason[:maxRejectionReasonLength]
+	}
+
+	rejection := &Rejection{
+		DocumentID: model.ID(),
+		VersionID:  model.CurrentVersion(),
+		Requester:  requester,
+		Codes:      rerr.Codes,
+		Reason:     reason,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	if err := s.repo.StoreRejection(did[:], rejection); err != nil {
+		srvLog.Errorf("failed to store rejection of document %x with version %x: %v", model.ID(), model.CurrentVersion(), err)
+	}
+
+	if s.repo.Exists(did[:], model.CurrentVersion()) {
+		return
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		srvLog.Errorf("failed to pack rejected document %x with version %x: %v", model.ID(), model.CurrentVersion(), err)
+		return
+	}
+
+	if size := proto.Size(&cd); size > maxRejectedVersionSize {
+		srvLog.Warningf("rejected document %x with version %x is not stored: size %d exceeds %d bytes", model.ID(), model.CurrentVersion(), size, maxRejectedVersionSize)
+		return
+	}
+
+	if err := model.SetStatus(Rejected); err != nil {
+		srvLog.Errorf("failed to set rejected status on document %x with version %x: %v", model.ID(), model.CurrentVersion(), err)
+		return
+	}
+
+	if err := s.repo.Create(did[:], model.CurrentVersion(), model); err != nil {
+		srvLog.Errorf("failed to store rejected document %x with version %x: %v", model.ID(), model.CurrentVersion(), err)
+	}
+}
+
 func (s service) ReceiveAnchoredDocument(ctx context.Context, model Model, collaborator identity.DID) error {
 	acc, err := contextutil.Account(ctx)
 	if err != nil {
@@ -393,6 +555,12 @@ func (s service) UpdateModel(ctx context.Context, payload UpdatePayload) (Model,
 
 // Derive looks for specific document type service based in the schema and delegates the Derivation to that service.˜
 func (s service) Derive(ctx context.Context, payload UpdatePayload) (Model, error) {
+	var err error
+	payload.Attributes, err = PayloadAttributes(payload.CreatePayload)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+	}
+
 	if len(payload.DocumentID) == 0 {
 		did, err := contextutil.AccountDID(ctx)
 		if err != nil {
@@ -405,10 +573,19 @@ func (s service) Derive(ctx context.Context, payload UpdatePayload) (Model, erro
 		}
 
 		payload.Collaborators.ReadWriteCollaborators = append(payload.Collaborators.ReadWriteCollaborators, did)
+		payload.Attributes, err = s.withDefaultSignaturePolicy(payload.Scheme, payload.Attributes)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+		}
+
 		if err := doc.(Deriver).DeriveFromCreatePayload(ctx, payload.CreatePayload); err != nil {
 			return nil, errors.NewTypedError(ErrDocumentInvalid, err)
 		}
 
+		if _, err := GetSignaturePolicy(doc); err != nil {
+			return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+		}
+
 		return doc, nil
 	}
 
@@ -427,6 +604,10 @@ func (s service) Derive(ctx context.Context, payload UpdatePayload) (Model, erro
 		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
 	}
 
+	if _, err := GetSignaturePolicy(doc); err != nil {
+		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+	}
+
 	return doc, nil
 }
 
@@ -481,7 +662,8 @@ func (s service) Commit(ctx context.Context, model Model) (jobs.JobID, error) {
 	jobID := contextutil.Job(ctx)
 	jobID, _, err = CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, model.CurrentVersion())
 	if err != nil {
-		return jobs.NilJobID(), err
+		// pending version is kept when the commit fails
+		return jobs.NilJobID(), errors.AppendError(err, s.repo.Rollback(did[:], model))
 	}
 
 	return jobID, nil
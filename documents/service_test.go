@@ -141,9 +141,11 @@ func TestService_Commit(t *testing.T) {
 	mr = new(MockRepository)
 	mr.On("GetLatest", mock.Anything, mock.Anything).Return(nil, ErrDocumentVersionNotFound)
 	mr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mr.On("Rollback", mock.Anything, m).Return(nil).Once()
 	s.repo = mr
 	_, err = s.Commit(ctxh, m)
 	assert.Error(t, err)
+	mr.AssertExpectations(t)
 
 	// Commit success
 	jobMan = &testingjobs.MockJobManager{}
@@ -156,7 +158,7 @@ func TestService_Commit(t *testing.T) {
 func TestService_Derive(t *testing.T) {
 	scheme := "generic"
 	payload := UpdatePayload{CreatePayload: CreatePayload{Scheme: scheme}}
-	s := service{}
+	s := service{config: cfg}
 
 	// missing account ctx
 	ctx := context.Background()
@@ -181,7 +183,32 @@ func TestService_Derive(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(ErrDocumentInvalid, err))
 
+	// reserved attribute in payload
+	policy, err := NewStringAttribute(SignaturePolicyLabel, AttrString, "threshold:0")
+	assert.NoError(t, err)
+	payload.Attributes = map[AttrKey]Attribute{policy.Key: policy}
+	_, err = s.Derive(ctx, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrReservedAttr, err))
+	payload.Attributes = nil
+
+	// invalid signature policy in payload
+	payload.SignaturePolicy = "threshold:0"
+	_, err = s.Derive(ctx, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+	payload.SignaturePolicy = ""
+
+	// invalid signature policy
+	doc.On("DeriveFromCreatePayload", mock.Anything, mock.Anything).Return(nil).Once()
+	doc.On("AttributeExists", policy.Key).Return(true).Once()
+	doc.On("GetAttribute", policy.Key).Return(policy, nil).Once()
+	_, err = s.Derive(ctx, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
 	// create successful
+	doc.On("AttributeExists", policy.Key).Return(false)
 	doc.On("DeriveFromCreatePayload", mock.Anything, mock.Anything).Return(nil).Once()
 	gdoc, err := s.Derive(ctx, payload)
 	assert.NoError(t, err)
@@ -0,0 +1,253 @@
+package documents
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SignaturePolicyLabel is the label of the attribute holding the signature policy of the document.
+// The value is a string attribute in the format accepted by ParseSignaturePolicy.
+// The label is reserved, the policy is set through CreatePayload.SignaturePolicy.
+const SignaturePolicyLabel = ReservedAttrLabelPrefix + "signature_policy"
+
+// SignaturePolicyType is the type of the signature policy.
+type SignaturePolicyType string
+
+const (
+	// SignaturePolicyAll requires all the signer collaborators to sign the document.
+	SignaturePolicyAll SignaturePolicyType = "all"
+
+	// SignaturePolicyThreshold requires at least Threshold signer collaborators, author included, to sign the document.
+	SignaturePolicyThreshold SignaturePolicyType = "threshold"
+
+	// SignaturePolicyRole requires at least one of the collaborators of the Role to sign the document.
+	SignaturePolicyRole SignaturePolicyType = "role"
+)
+
+// SignaturePolicy defines the signatures required on a document version before it can be anchored.
+type SignaturePolicy struct {
+	Type SignaturePolicyType
+
+	// Threshold is the minimum number of signers for SignaturePolicyThreshold.
+	Threshold int
+
+	// Role is the 32 byte role key for SignaturePolicyRole.
+	Role []byte
+}
+
+// ParseSignaturePolicy parses the policy of the format "all", "threshold:<M>" or "role:<role key>".
+// Role key can either be plain text or 32 byte hex string.
+func ParseSignaturePolicy(policy string) (p SignaturePolicy, err error) {
+	parts := strings.SplitN(strings.TrimSpace(policy), ":", 2)
+	p.Type = SignaturePolicyType(parts[0])
+	switch p.Type {
+	case SignaturePolicyAll:
+		if len(parts) != 1 {
+			return p, errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unexpected value for policy %s", p.Type))
+		}
+	case SignaturePolicyThreshold:
+		if len(parts) != 2 {
+			return p, errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("threshold missing"))
+		}
+
+		p.Threshold, err = strconv.Atoi(parts[1])
+		if err != nil || p.Threshold < 1 {
+			return p, errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("threshold must be a positive number"))
+		}
+	case SignaturePolicyRole:
+		if len(parts) != 2 || parts[1] == "" {
+			return p, errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("role missing"))
+		}
+
+		p.Role, err = get32ByteKey(parts[1])
+		if err != nil {
+			return p, errors.NewTypedError(ErrInvalidSignaturePolicy, err)
+		}
+	default:
+		return p, errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unknown policy type %s", p.Type))
+	}
+
+	return p, nil
+}
+
+// String returns the policy in the format accepted by ParseSignaturePolicy.
+func (p SignaturePolicy) String() string {
+	switch p.Type {
+	case SignaturePolicyThreshold:
+		return fmt.Sprintf("%s:%d", p.Type, p.Threshold)
+	case SignaturePolicyRole:
+		return fmt.Sprintf("%s:%s", p.Type, hexutil.Encode(p.Role))
+	default:
+		return string(p.Type)
+	}
+}
+
+// Attribute returns the policy as the signature policy attribute.
+func (p SignaturePolicy) Attribute() (Attribute, error) {
+	return NewStringAttribute(SignaturePolicyLabel, AttrString, p.String())
+}
+
+// Validate checks if the signers meet the policy on the model.
+// Signers are expected to include the author.
+func (p SignaturePolicy) Validate(model Model, signers []identity.DID) error {
+	signed := func(did identity.DID) bool {
+		for _, s := range signers {
+			if s.Equal(did) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch p.Type {
+	case SignaturePolicyAll:
+		collaborators, err := model.GetSignerCollaborators()
+		if err != nil {
+			return err
+		}
+
+		for _, c := range collaborators {
+			if !signed(c) {
+				return errors.NewTypedError(ErrSignaturePolicyNotMet, errors.New("signature of %s missing", c))
+			}
+		}
+	case SignaturePolicyThreshold:
+		collaborators, err := model.GetSignerCollaborators()
+		if err != nil {
+			return err
+		}
+
+		var count int
+		for _, c := range collaborators {
+			if signed(c) {
+				count++
+			}
+		}
+
+		if count < p.Threshold {
+			return errors.NewTypedError(ErrSignaturePolicyNotMet, errors.New("%d of %d required signatures found", count, p.Threshold))
+		}
+	case SignaturePolicyRole:
+		role, err := model.GetRole(p.Role)
+		if err != nil {
+			return errors.NewTypedError(ErrSignaturePolicyNotMet, err)
+		}
+
+		for _, c := range role.Collaborators {
+			did, err := identity.NewDIDFromBytes(c)
+			if err != nil {
+				return err
+			}
+
+			if signed(did) {
+				return nil
+			}
+		}
+
+		return errors.NewTypedError(ErrSignaturePolicyNotMet, errors.New("signature of role %s missing", hexutil.Encode(p.Role)))
+	default:
+		return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unknown policy type %s", p.Type))
+	}
+
+	return nil
+}
+
+// GetSignaturePolicy returns the signature policy of the model.
+// Returns nil if the model has no signature policy, in which case anchoring does not depend on the collected signatures.
+func GetSignaturePolicy(model Model) (*SignaturePolicy, error) {
+	key, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	if !model.AttributeExists(key) {
+		return nil, nil
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if attr.Value.Type != AttrString {
+		return nil, errors.NewTypedError(ErrInvalidSignaturePolicy, ErrNotValidAttrType)
+	}
+
+	p, err := ParseSignaturePolicy(attr.Value.Str)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// PayloadAttributes validates the payload attributes and returns them along with
+// the signature policy attribute if the payload sets one.
+func PayloadAttributes(payload CreatePayload) (map[AttrKey]Attribute, error) {
+	if err := ValidatePayloadAttributes(payload.Attributes); err != nil {
+		return nil, err
+	}
+
+	if payload.SignaturePolicy == "" {
+		return payload.Attributes, nil
+	}
+
+	p, err := ParseSignaturePolicy(payload.SignaturePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	attr, err := p.Attribute()
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := make(map[AttrKey]Attribute, len(payload.Attributes)+1)
+	for k, v := range payload.Attributes {
+		attrs[k] = v
+	}
+
+	attrs[attr.Key] = attr
+	return attrs, nil
+}
+
+// withDefaultSignaturePolicy adds the default signature policy of the scheme to the attributes
+// if the attributes has no signature policy.
+func (s service) withDefaultSignaturePolicy(scheme string, attrs map[AttrKey]Attribute) (map[AttrKey]Attribute, error) {
+	policy, ok := s.config.GetSignaturePolicies()[scheme]
+	if !ok {
+		return attrs, nil
+	}
+
+	key, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := attrs[key]; ok {
+		return attrs, nil
+	}
+
+	p, err := ParseSignaturePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	attr, err := p.Attribute()
+	if err != nil {
+		return nil, err
+	}
+
+	if attrs == nil {
+		attrs = make(map[AttrKey]Attribute)
+	}
+
+	attrs[key] = attr
+	return attrs, nil
+}
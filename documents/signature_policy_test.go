@@ -0,0 +1,187 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseSignaturePolicy(t *testing.T) {
+	role := utils.RandomSlice(32)
+	tests := []struct {
+		policy string
+		result SignaturePolicy
+		err    bool
+	}{
+		{policy: "all", result: SignaturePolicy{Type: SignaturePolicyAll}},
+		{policy: "all:1", err: true},
+		{policy: "threshold:2", result: SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 2}},
+		{policy: "threshold", err: true},
+		{policy: "threshold:0", err: true},
+		{policy: "threshold:two", err: true},
+		{policy: "role:" + hexutil.Encode(role), result: SignaturePolicy{Type: SignaturePolicyRole, Role: role}},
+		{policy: "role:", err: true},
+		{policy: "any", err: true},
+	}
+
+	for _, c := range tests {
+		p, err := ParseSignaturePolicy(c.policy)
+		if c.err {
+			assert.Error(t, err, c.policy)
+			assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+			continue
+		}
+
+		assert.NoError(t, err, c.policy)
+		assert.Equal(t, c.result, p)
+		assert.Equal(t, c.policy, p.String())
+	}
+
+	// plain text role key
+	p, err := ParseSignaturePolicy("role:approver")
+	assert.NoError(t, err)
+	assert.Len(t, p.Role, 32)
+}
+
+func TestSignaturePolicy_Validate(t *testing.T) {
+	author := testingidentity.GenerateRandomDID()
+	c1 := testingidentity.GenerateRandomDID()
+	c2 := testingidentity.GenerateRandomDID()
+	model := new(mockModel)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{author, c1, c2}, nil)
+
+	// all
+	p := SignaturePolicy{Type: SignaturePolicyAll}
+	err := p.Validate(model, []identity.DID{author, c1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+	assert.NoError(t, p.Validate(model, []identity.DID{author, c1, c2}))
+
+	// threshold
+	p = SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 2}
+	err = p.Validate(model, []identity.DID{author, testingidentity.GenerateRandomDID()})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+	assert.NoError(t, p.Validate(model, []identity.DID{author, c2}))
+
+	// missing role
+	role := utils.RandomSlice(32)
+	p = SignaturePolicy{Type: SignaturePolicyRole, Role: role}
+	model.On("GetRole", role).Return(nil, ErrRoleNotExist).Once()
+	err = p.Validate(model, []identity.DID{author, c1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+
+	// role
+	model.On("GetRole", role).Return(&coredocumentpb.Role{RoleKey: role, Collaborators: [][]byte{c1[:], c2[:]}}, nil)
+	err = p.Validate(model, []identity.DID{author})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+	assert.NoError(t, p.Validate(model, []identity.DID{author, c2}))
+	model.AssertExpectations(t)
+}
+
+func TestGetSignaturePolicy(t *testing.T) {
+	key, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	assert.NoError(t, err)
+
+	// no policy
+	model := new(mockModel)
+	model.On("AttributeExists", key).Return(false).Once()
+	p, err := GetSignaturePolicy(model)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	// invalid attribute type
+	attr, err := NewStringAttribute(SignaturePolicyLabel, AttrBytes, "0x01")
+	assert.NoError(t, err)
+	model.On("AttributeExists", key).Return(true)
+	model.On("GetAttribute", key).Return(attr, nil).Once()
+	_, err = GetSignaturePolicy(model)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	// success
+	attr, err = SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 3}.Attribute()
+	assert.NoError(t, err)
+	model.On("GetAttribute", key).Return(attr, nil).Once()
+	p, err = GetSignaturePolicy(model)
+	assert.NoError(t, err)
+	assert.Equal(t, &SignaturePolicy{Type: SignaturePolicyThreshold, Threshold: 3}, p)
+	model.AssertExpectations(t)
+}
+
+func TestPayloadAttributes(t *testing.T) {
+	key, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	assert.NoError(t, err)
+	name, err := NewStringAttribute("name", AttrString, "alice")
+	assert.NoError(t, err)
+
+	// no policy
+	attrs, err := PayloadAttributes(CreatePayload{Attributes: map[AttrKey]Attribute{name.Key: name}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[AttrKey]Attribute{name.Key: name}, attrs)
+
+	// reserved attributes
+	for _, label := range []string{SignaturePolicyLabel, ReservedAttrLabelPrefix + "other"} {
+		attr, err := NewStringAttribute(label, AttrString, "all")
+		assert.NoError(t, err)
+		_, err = PayloadAttributes(CreatePayload{Attributes: map[AttrKey]Attribute{attr.Key: attr}})
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrReservedAttr, err))
+	}
+
+	// invalid policy
+	_, err = PayloadAttributes(CreatePayload{SignaturePolicy: "threshold"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	// policy added
+	payload := CreatePayload{Attributes: map[AttrKey]Attribute{name.Key: name}, SignaturePolicy: "threshold:2"}
+	attrs, err = PayloadAttributes(payload)
+	assert.NoError(t, err)
+	assert.Len(t, attrs, 2)
+	assert.Equal(t, "threshold:2", attrs[key].Value.Str)
+	assert.Len(t, payload.Attributes, 1)
+}
+
+func TestService_withDefaultSignaturePolicy(t *testing.T) {
+	key, err := AttrKeyFromLabel(SignaturePolicyLabel)
+	assert.NoError(t, err)
+	c := new(testingconfig.MockConfig)
+	c.On("GetSignaturePolicies").Return(map[string]string{"generic": "threshold:2", "entity": "any"})
+	s := service{config: c}
+
+	// no default policy
+	attrs, err := s.withDefaultSignaturePolicy("other", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, attrs)
+
+	// invalid default policy
+	_, err = s.withDefaultSignaturePolicy("entity", nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	// default policy added
+	attrs, err = s.withDefaultSignaturePolicy("generic", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "threshold:2", attrs[key].Value.Str)
+
+	// policy in attributes is kept
+	attr, err := SignaturePolicy{Type: SignaturePolicyAll}.Attribute()
+	assert.NoError(t, err)
+	attrs, err = s.withDefaultSignaturePolicy("generic", map[AttrKey]Attribute{key: attr})
+	assert.NoError(t, err)
+	assert.Equal(t, "all", attrs[key].Value.Str)
+	c.AssertExpectations(t)
+}
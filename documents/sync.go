@@ -0,0 +1,192 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Syncer pulls the anchored versions of a document that the account has missed from a collaborator.
+type Syncer interface {
+	// SyncDocument requests the versions newer than the latest known version of the document from the collaborator.
+	// Received versions are validated and stored oldest first. Details of the stored versions are returned.
+	SyncDocument(ctx context.Context, documentID []byte, collaborator identity.DID) ([]VersionInfo, error)
+}
+
+// syncer implements Syncer.
+type syncer struct {
+	docSrv    Service
+	repo      Repository
+	requester DocumentRequestProcessor
+	anchorSrv anchors.Service
+	idService identity.Service
+}
+
+// DefaultSyncer returns the default implementation of the Syncer.
+func DefaultSyncer(
+	docSrv Service,
+	repo Repository,
+	requester DocumentRequestProcessor,
+	anchorSrv anchors.Service,
+	idService identity.Service) Syncer {
+	return syncer{
+		docSrv:    docSrv,
+		repo:      repo,
+		requester: requester,
+		anchorSrv: anchorSrv,
+		idService: idService,
+	}
+}
+
+// SyncDocument requests the versions newer than the latest known version of the document from the collaborator.
+// Each version is validated against the transitions allowed for its author before it is stored.
+// If a version fails, the versions stored until then are returned along with the error.
+func (s syncer) SyncDocument(ctx context.Context, documentID []byte, collaborator identity.DID) ([]VersionInfo, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	if _, err := s.docSrv.GetCurrentVersion(ctx, documentID); err != nil {
+		return nil, err
+	}
+
+	missed, err := s.missedVersions(ctx, documentID, collaborator)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentSync, err)
+	}
+
+	var vis []VersionInfo
+	for _, m := range missed {
+		author, err := m.Author()
+		if err != nil {
+			return vis, errors.NewTypedError(ErrDocumentSync, errors.New("failed to get author of version %s: %v",
+				hexutil.Encode(m.CurrentVersion()), err))
+		}
+
+		// previous version is stored already, so the version is validated against it
+		err = s.storeVersion(acc.GetIdentityID(), m, author)
+		if err != nil {
+			return vis, err
+		}
+
+		vis = append(vis, getVersionInfo(s.anchorSrv, m))
+	}
+
+	return vis, nil
+}
+
+// storeVersion validates the missed version against its previous version and stores it as committed.
+// Version is not required to be the latest one since the newer versions are synced after it.
+func (s syncer) storeVersion(accountID []byte, m Model, author identity.DID) error {
+	old, err := s.repo.Get(accountID, m.PreviousVersion())
+	if err != nil {
+		return errors.NewTypedError(ErrDocumentSync, errors.New("failed to get previous version %s: %v",
+			hexutil.Encode(m.PreviousVersion()), err))
+	}
+
+	err = syncedVersionValidator(s.idService, s.anchorSrv, author).Validate(old, m)
+	if err != nil {
+		return errors.NewTypedError(ErrDocumentInvalid, err)
+	}
+
+	err = m.SetStatus(Committed)
+	if err != nil {
+		return err
+	}
+
+	// missed versions are not stored unless they were rejected or signed earlier
+	if s.repo.Exists(accountID, m.CurrentVersion()) {
+		err = s.repo.Update(accountID, m.CurrentVersion(), m)
+	} else {
+		err = s.repo.Create(accountID, m.CurrentVersion(), m)
+	}
+	if err != nil {
+		return errors.NewTypedError(ErrDocumentPersistence, err)
+	}
+
+	return nil
+}
+
+// missedVersions fetches the latest version of the document from the collaborator and follows the
+// previous version links until a locally known version is found. Versions are returned oldest first.
+func (s syncer) missedVersions(ctx context.Context, documentID []byte, collaborator identity.DID) ([]Model, error) {
+	m, err := s.requestVersion(ctx, collaborator, documentID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.isKnown(ctx, documentID, m.CurrentVersion()) {
+		return nil, nil
+	}
+
+	var missed []Model
+	seen := make(map[string]struct{})
+	for {
+		missed = append([]Model{m}, missed...)
+		seen[hexutil.Encode(m.CurrentVersion())] = struct{}{}
+		prev := m.PreviousVersion()
+		if utils.IsEmptyByteSlice(prev) {
+			return nil, errors.New("none of the versions of the collaborator are known")
+		}
+
+		// guard against malformed version links
+		if _, ok := seen[hexutil.Encode(prev)]; ok {
+			return nil, errors.New("version %s is linked more than once", hexutil.Encode(prev))
+		}
+
+		// first version shares the identifier with the document, so it is never requested
+		if s.isKnown(ctx, documentID, prev) {
+			return missed, nil
+		}
+
+		m, err = s.requestVersion(ctx, collaborator, documentID, prev)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// requestVersion requests the version with the identifier from the collaborator.
+// If the identifier is the document identifier, latest version of the collaborator is returned.
+func (s syncer) requestVersion(ctx context.Context, collaborator identity.DID, documentID, identifier []byte) (Model, error) {
+	resp, err := s.requester.RequestDocumentVersion(ctx, collaborator, identifier)
+	if err != nil {
+		return nil, errors.New("failed to request version %s: %v", hexutil.Encode(identifier), err)
+	}
+
+	if resp == nil || resp.Document == nil {
+		return nil, errors.New("collaborator returned no document for version %s", hexutil.Encode(identifier))
+	}
+
+	m, err := s.docSrv.DeriveFromCoreDocument(*resp.Document)
+	if err != nil {
+		return nil, errors.New("failed to derive version %s: %v", hexutil.Encode(identifier), err)
+	}
+
+	if !bytes.Equal(m.ID(), documentID) {
+		return nil, errors.New("collaborator returned a different document for version %s", hexutil.Encode(identifier))
+	}
+
+	if !bytes.Equal(identifier, documentID) && !bytes.Equal(m.CurrentVersion(), identifier) {
+		return nil, errors.New("collaborator returned version %s instead of %s",
+			hexutil.Encode(m.CurrentVersion()), hexutil.Encode(identifier))
+	}
+
+	if err := ReceiveBatchProof(s.anchorSrv, resp, m); err != nil {
+		return nil, errors.New("invalid batch proof for version %s: %v", hexutil.Encode(m.CurrentVersion()), err)
+	}
+
+	return m, nil
+}
+
+func (s syncer) isKnown(ctx context.Context, documentID, version []byte) bool {
+	_, err := s.docSrv.GetVersion(ctx, documentID, version)
+	return err == nil
+}
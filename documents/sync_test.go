@@ -0,0 +1,124 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncer_SyncDocument(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID, v2, v3 := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	collab, author := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	docSrv := new(MockService)
+	requester := new(testingcommons.MockRequestProcessor)
+	anchorSrv := new(mockAnchorService)
+	repo := new(MockRepository)
+	s := DefaultSyncer(docSrv, repo, requester, anchorSrv, nil)
+
+	// no account
+	_, err := s.SyncDocument(context.Background(), docID, collab)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// unknown document
+	docSrv.On("GetCurrentVersion", ctx, docID).Return(nil, errors.NewTypedError(ErrDocumentNotFound, errors.New("missing"))).Once()
+	_, err = s.SyncDocument(ctx, docID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+
+	// collaborator is offline
+	docSrv.On("GetCurrentVersion", ctx, docID).Return(new(MockModel), nil)
+	requester.On("RequestDocumentVersion", collab, docID).Return(nil, errors.New("offline")).Once()
+	_, err = s.SyncDocument(ctx, docID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentSync, err))
+
+	// v1(known) <- v2 <- v3
+	tm := time.Now().UTC()
+	cd2 := coredocumentpb.CoreDocument{CurrentVersion: v2}
+	cd3 := coredocumentpb.CoreDocument{CurrentVersion: v3}
+	m2, m3 := new(MockModel), new(MockModel)
+	for _, c := range []struct {
+		m          *MockModel
+		cd         coredocumentpb.CoreDocument
+		prev, curr []byte
+	}{
+		{m: m2, cd: cd2, prev: docID, curr: v2},
+		{m: m3, cd: cd3, prev: v2, curr: v3},
+	} {
+		c.m.On("ID").Return(docID)
+		c.m.On("CurrentVersion").Return(c.curr)
+		c.m.On("PreviousVersion").Return(c.prev)
+		c.m.On("GetStatus").Return(Committed)
+		c.m.On("Author").Return(author, nil)
+		c.m.On("Timestamp").Return(tm, nil)
+		docSrv.On("DeriveFromCoreDocument", c.cd).Return(c.m, nil)
+	}
+
+	// collaborator returned a different version
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd3}, nil).Once()
+	docSrv.On("GetVersion", docID, v3).Return(nil, errors.New("missing")).Once()
+	docSrv.On("GetVersion", docID, v2).Return(nil, errors.New("missing")).Once()
+	requester.On("RequestDocumentVersion", collab, v2).Return(&p2ppb.GetDocumentResponse{Document: &cd3}, nil).Once()
+	_, err = s.SyncDocument(ctx, docID, collab)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "instead of")
+
+	// up to date
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd3}, nil).Once()
+	docSrv.On("GetVersion", docID, v3).Return(m3, nil).Once()
+	vis, err := s.SyncDocument(ctx, docID, collab)
+	assert.NoError(t, err)
+	assert.Empty(t, vis)
+
+	// previous version is gone, validated and stored versions are covered with a real repository in documents_test
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd3}, nil).Once()
+	docSrv.On("GetVersion", docID, v3).Return(nil, errors.New("missing")).Once()
+	docSrv.On("GetVersion", docID, v2).Return(nil, errors.New("missing")).Once()
+	requester.On("RequestDocumentVersion", collab, v2).Return(&p2ppb.GetDocumentResponse{Document: &cd2}, nil).Once()
+	docSrv.On("GetVersion", docID, docID).Return(new(MockModel), nil).Once()
+	repo.On("Get", mock.Anything, docID).Return(nil, errors.New("missing")).Once()
+	vis, err = s.SyncDocument(ctx, docID, collab)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentSync, err))
+	assert.Empty(t, vis)
+
+	docSrv.AssertExpectations(t)
+	requester.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestSyncer_missedVersions_unknownHistory(t *testing.T) {
+	ctx := context.Background()
+	docID := utils.RandomSlice(32)
+	collab := testingidentity.GenerateRandomDID()
+	cd := coredocumentpb.CoreDocument{CurrentVersion: docID}
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	m.On("CurrentVersion").Return(docID)
+	m.On("PreviousVersion").Return([]byte(nil))
+	docSrv := new(MockService)
+	docSrv.On("DeriveFromCoreDocument", cd).Return(m, nil).Once()
+	docSrv.On("GetVersion", docID, docID).Return(nil, errors.New("missing")).Once()
+	requester := new(testingcommons.MockRequestProcessor)
+	requester.On("RequestDocumentVersion", collab, docID).Return(&p2ppb.GetDocumentResponse{Document: &cd}, nil).Once()
+	s := syncer{docSrv: docSrv, requester: requester}
+	_, err := s.missedVersions(ctx, docID, collab)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "none of the versions")
+	docSrv.AssertExpectations(t)
+	requester.AssertExpectations(t)
+}
@@ -30,6 +30,11 @@ func (m *MockModel) Scheme() string {
 	return args.String(0)
 }
 
+func (m *MockModel) DocumentType() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 func (m *MockModel) GetData() interface{} {
 	args := m.Called()
 	return args.Get(0)
@@ -45,6 +50,18 @@ func (m *MockModel) CurrentVersion() []byte {
 	return args.Get(0).([]byte)
 }
 
+func (m *MockModel) CalculateDocumentRoot() ([]byte, error) {
+	args := m.Called()
+	dr, _ := args.Get(0).([]byte)
+	return dr, args.Error(1)
+}
+
+func (m *MockModel) CalculateSignaturesRoot() ([]byte, error) {
+	args := m.Called()
+	sr, _ := args.Get(0).([]byte)
+	return sr, args.Error(1)
+}
+
 func (m *MockModel) CurrentVersionPreimage() []byte {
 	args := m.Called()
 	id, _ := args.Get(0).([]byte)
@@ -97,6 +114,12 @@ func (m *MockModel) AddTransitionRuleForAttribute(roleID []byte, key AttrKey) (*
 	return r, args.Error(1)
 }
 
+func (m *MockModel) AddReadRule(roleID []byte, action coredocumentpb.Action) (*coredocumentpb.ReadRule, error) {
+	args := m.Called(roleID, action)
+	r, _ := args.Get(0).(*coredocumentpb.ReadRule)
+	return r, args.Error(1)
+}
+
 func (m *MockModel) GetTransitionRule(ruleID []byte) (*coredocumentpb.TransitionRule, error) {
 	args := m.Called(ruleID)
 	r, _ := args.Get(0).(*coredocumentpb.TransitionRule)
@@ -125,6 +148,17 @@ func (m *MockService) GetCurrentVersion(ctx context.Context, docID []byte) (Mode
 	return doc, args.Error(1)
 }
 
+func (m *MockService) DeriveFromCoreDocument(cd coredocumentpb.CoreDocument) (Model, error) {
+	args := m.Called(cd)
+	doc, _ := args.Get(0).(Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) ReceiveAnchoredDocument(ctx context.Context, model Model, collaborator identity.DID) error {
+	args := m.Called(ctx, model, collaborator)
+	return args.Error(0)
+}
+
 func (m *MockService) Derive(ctx context.Context, payload UpdatePayload) (Model, error) {
 	args := m.Called(ctx, payload)
 	doc, _ := args.Get(0).(Model)
@@ -206,6 +240,11 @@ func (m *MockModel) AddAttributes(ca CollaboratorsAccess, prepareNewVersion bool
 	return args.Error(0)
 }
 
+func (m *MockModel) AccountCanRead(did identity.DID) bool {
+	args := m.Called(did)
+	return args.Bool(0)
+}
+
 func (m *MockModel) GetStatus() Status {
 	args := m.Called()
 	return args.Get(0).(Status)
@@ -244,7 +283,7 @@ func (m *MockRepository) Exists(accountID, id []byte) bool {
 func (m *MockRepository) Get(accountID, id []byte) (Model, error) {
 	args := m.Called(accountID, id)
 	doc, _ := args.Get(0).(Model)
-	return doc, args.Error(0)
+	return doc, args.Error(1)
 }
 
 func (m *MockRepository) Create(accountID, id []byte, model Model) error {
@@ -268,6 +307,85 @@ func (m *MockRepository) GetLatest(accountID, docID []byte) (Model, error) {
 	return doc, args.Error(1)
 }
 
+func (m *MockRepository) Query(accountID []byte, query Query) (QueryResult, error) {
+	args := m.Called(accountID, query)
+	res, _ := args.Get(0).(QueryResult)
+	return res, args.Error(1)
+}
+
+func (m *MockRepository) StoreRejection(accountID []byte, rejection *Rejection) error {
+	args := m.Called(accountID, rejection)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetRejection(accountID, versionID []byte) (*Rejection, error) {
+	args := m.Called(accountID, versionID)
+	r, _ := args.Get(0).(*Rejection)
+	return r, args.Error(1)
+}
+
+func (m *MockRepository) StoreAnchorCheckpoint(checkpoint *AnchorCheckpoint) error {
+	args := m.Called(checkpoint)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetAnchorCheckpoint(accountID, versionID []byte) (*AnchorCheckpoint, error) {
+	args := m.Called(accountID, versionID)
+	c, _ := args.Get(0).(*AnchorCheckpoint)
+	return c, args.Error(1)
+}
+
+func (m *MockRepository) DeleteAnchorCheckpoint(accountID, versionID []byte) error {
+	args := m.Called(accountID, versionID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetAnchorCheckpoints() ([]*AnchorCheckpoint, error) {
+	args := m.Called()
+	cs, _ := args.Get(0).([]*AnchorCheckpoint)
+	return cs, args.Error(1)
+}
+
+func (m *MockRepository) StoreDelivery(delivery *Delivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetDelivery(accountID, versionID []byte, collaborator identity.DID) (*Delivery, error) {
+	args := m.Called(accountID, versionID, collaborator)
+	d, _ := args.Get(0).(*Delivery)
+	return d, args.Error(1)
+}
+
+func (m *MockRepository) GetDeliveries(accountID, versionID []byte) ([]*Delivery, error) {
+	args := m.Called(accountID, versionID)
+	ds, _ := args.Get(0).([]*Delivery)
+	return ds, args.Error(1)
+}
+
+func (m *MockRepository) GetPendingDeliveries() ([]*Delivery, error) {
+	args := m.Called()
+	ds, _ := args.Get(0).([]*Delivery)
+	return ds, args.Error(1)
+}
+
+func (m *MockRepository) GetCommittingVersions() ([]*CommittingVersion, error) {
+	args := m.Called()
+	cvs, _ := args.Get(0).([]*CommittingVersion)
+	return cvs, args.Error(1)
+}
+
+func (m *MockRepository) GetCommitSnapshot(accountID, versionID []byte) (Model, error) {
+	args := m.Called(accountID, versionID)
+	doc, _ := args.Get(0).(Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockRepository) Rollback(accountID []byte, model Model) error {
+	args := m.Called(accountID, model)
+	return args.Error(0)
+}
+
 func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
 	if _, ok := context[storage.BootstrappedDB]; !ok {
 		return errors.New("initializing LevelDB repository failed")
@@ -6,6 +6,7 @@ import (
 	"hash"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/proofwire"
 	"github.com/centrifuge/precise-proofs/proofs"
 	"github.com/centrifuge/precise-proofs/proofs/proto"
 
@@ -13,6 +14,17 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+const (
+	// BasicTree is the data tree with sorted hashing. Proofs are generated from it by default.
+	BasicTree = proofwire.BasicTree
+
+	// ZKTree is the fixed depth data tree with ordered hashing that zk circuits verify the proofs against.
+	ZKTree = proofwire.ZKTree
+
+	// ZKTreeDepth is the depth of the ZKTree.
+	ZKTreeDepth = proofwire.ZKTreeDepth
+)
+
 func (cd *CoreDocument) defaultTreeWithPrefix(prefix string, compactPrefix []byte, hashSorting bool) (*proofs.DocumentTree, error) {
 	var prop proofs.Property
 	if prefix != "" {
@@ -62,7 +74,7 @@ func (cd *CoreDocument) DefaultZTreeWithPrefix(prefix string, compactPrefix []by
 		Hash:              b2bHash,
 		ParentPrefix:      prop,
 		Salts:             cd.DocumentSaltsFunc(),
-		TreeDepth:         20,
+		TreeDepth:         ZKTreeDepth,
 	})
 	return &t, err
 }
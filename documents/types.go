@@ -1,18 +1,20 @@
 package documents
 
 import (
+	"bytes"
+
+	"github.com/centrifuge/go-centrifuge/proofwire"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/precise-proofs/proofs"
 	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"golang.org/x/crypto/sha3"
 )
 
 // Proof represents a single proof
-type Proof struct {
-	Property     byteutils.HexBytes   `json:"property" swaggertype:"primitive,string"`
-	Value        byteutils.HexBytes   `json:"value" swaggertype:"primitive,string"`
-	Salt         byteutils.HexBytes   `json:"salt" swaggertype:"primitive,string"`
-	Hash         byteutils.HexBytes   `json:"hash" swaggertype:"primitive,string"`
-	SortedHashes []byteutils.HexBytes `json:"sorted_hashes" swaggertype:"array,string"`
-}
+type Proof = proofwire.Proof
+
+// MerkleHash is a sibling in the proof of an ordered tree. Only one of Left or Right is set.
+type MerkleHash = proofwire.MerkleHash
 
 // ConvertProofs converts proto proofs to JSON struct
 func ConvertProofs(fieldProofs []*proofspb.Proof) []Proof {
@@ -32,8 +34,60 @@ func ConvertProofs(fieldProofs []*proofspb.Proof) []Proof {
 		}
 
 		pff.SortedHashes = hashes
+		for _, h := range pf.Hashes {
+			pff.Hashes = append(pff.Hashes, MerkleHash{Left: h.Left, Right: h.Right})
+		}
+
 		proofs = append(proofs, pff)
 	}
 
 	return proofs
 }
+
+// CircuitProof is the proof of a ZKTree field in the format consumed by the zk circuits.
+type CircuitProof = proofwire.CircuitProof
+
+// isDataTreeProperty returns true if the compact property belongs to the data tree and not to the
+// document root or signatures tree.
+func isDataTreeProperty(property []byte) bool {
+	return !bytes.HasPrefix(property, CompactProperties(DRTreePrefix)) &&
+		!bytes.HasPrefix(property, CompactProperties(SignaturesTreePrefix))
+}
+
+// ConvertCircuitProofs converts the proto proofs of the ZKTree fields to circuit proofs.
+// Proofs of the document root and signatures tree fields are skipped.
+func ConvertCircuitProofs(fieldProofs []*proofspb.Proof) ([]CircuitProof, error) {
+	var cps []CircuitProof
+	for _, pf := range fieldProofs {
+		if !isDataTreeProperty(pf.GetCompactName()) {
+			continue
+		}
+
+		// leaves of the data trees are hashed with keccak256
+		leaf := pf.Hash
+		if len(leaf) == 0 {
+			var err error
+			leaf, err = proofs.CalculateHashForProofField(pf, sha3.NewLegacyKeccak256())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		cp := CircuitProof{Property: pf.GetCompactName(), Leaf: leaf}
+		for i, h := range pf.Hashes {
+			if len(h.Left) == 0 {
+				cp.Siblings = append(cp.Siblings, h.Right)
+				cp.PathIndices = append(cp.PathIndices, 0)
+				continue
+			}
+
+			cp.Siblings = append(cp.Siblings, h.Left)
+			cp.PathIndices = append(cp.PathIndices, 1)
+			cp.LeafIndex |= 1 << uint(i)
+		}
+
+		cps = append(cps, cp)
+	}
+
+	return cps, nil
+}
@@ -32,9 +32,8 @@ func TestConvertProofs(t *testing.T) {
 		Value:    utils.RandomSlice(32),
 		Salt:     utils.RandomSlice(32),
 		Hash:     []byte{},
-		SortedHashes: [][]byte{
-			utils.RandomSlice(32),
-			utils.RandomSlice(32),
+		Hashes: []*proofspb.MerkleHash{
+			{Left: utils.RandomSlice(32)},
 		},
 	}
 	input = append(input, p0)
@@ -46,4 +45,8 @@ func TestConvertProofs(t *testing.T) {
 	assert.Equal(t, hexutil.Encode(p1.GetCompactName()), pfs[1].Property.String())
 	assert.Len(t, pfs[0].SortedHashes, 2)
 	assert.Equal(t, hexutil.Encode(p0.SortedHashes[0]), pfs[0].SortedHashes[0].String())
+	assert.Empty(t, pfs[0].Hashes)
+	assert.Len(t, pfs[1].Hashes, 1)
+	assert.Equal(t, hexutil.Encode(p1.Hashes[0].Left), pfs[1].Hashes[0].Left.String())
+	assert.Empty(t, pfs[1].Hashes[0].Right)
 }
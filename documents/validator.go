@@ -8,7 +8,6 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -25,7 +24,7 @@ type Validator interface {
 // ValidatorGroup implements Validator for validating a set of validators.
 type ValidatorGroup []Validator
 
-//Validate will execute all group specific atomic validations
+// Validate will execute all group specific atomic validations
 func (group ValidatorGroup) Validate(oldState Model, newState Model) (errs error) {
 	for _, v := range group {
 		if err := v.Validate(oldState, newState); err != nil {
@@ -232,7 +231,8 @@ func documentTimestampForSigningValidator() Validator {
 // assumes signing root is verified
 // Note: can be used when during the signature request on collaborator side and post signature collection on sender side
 // Note: this will break the current flow where we proceed to anchor even signatures verification fails
-func signaturesValidator(idService identity.Service) Validator {
+// if validatePolicy is true, valid signatures must also meet the signature policy of the document if any.
+func signaturesValidator(idService identity.Service, validatePolicy bool) Validator {
 	return ValidatorFunc(func(_, model Model) error {
 		if model == nil {
 			return ErrModelNil
@@ -257,6 +257,7 @@ func signaturesValidator(idService identity.Service) Validator {
 		}
 
 		authorFound := false
+		var signers []identity.DID
 		for _, sig := range signatures {
 			sigDID, _ := identity.NewDIDFromBytes(sig.SignerId)
 			if author.Equal(sigDID) {
@@ -292,13 +293,32 @@ func signaturesValidator(idService identity.Service) Validator {
 				err = errors.AppendError(
 					err,
 					errors.New("signature_%s verification failed: %v", hexutil.Encode(sig.SignerId), erri))
+				continue
 			}
+
+			signers = append(signers, sigDID)
 		}
 		if !authorFound {
 			err = errors.AppendError(
 				err,
 				errors.New("signature verification failed: author's signature missing on document"))
 		}
+
+		if !validatePolicy {
+			return err
+		}
+
+		policy, perr := GetSignaturePolicy(model)
+		if perr != nil {
+			return errors.AppendError(err, perr)
+		}
+
+		if policy != nil {
+			if perr := policy.Validate(model, signers); perr != nil {
+				err = errors.AppendError(err, perr)
+			}
+		}
+
 		return err
 	})
 }
@@ -311,6 +331,11 @@ func anchoredValidator(anchorSrv anchors.Service) Validator {
 			return ErrModelNil
 		}
 
+		err := anchorBackendValidator(anchorSrv).Validate(nil, model)
+		if err != nil {
+			return err
+		}
+
 		anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
 		if err != nil {
 			return errors.New("failed to get anchorID: %v", err)
@@ -326,7 +351,7 @@ func anchoredValidator(anchorSrv anchors.Service) Validator {
 			return errors.New("failed to get document root: %v", err)
 		}
 
-		gotRoot, anchoredAt, err := anchorSrv.GetAnchorData(anchorID)
+		gotRoot, anchoredAt, _, err := anchorSrv.GetAnchorData(anchorID)
 		if err != nil {
 			return errors.New("failed to get document root for anchor %s from chain: %v", anchorID.String(), err)
 		}
@@ -356,7 +381,7 @@ func versionNotAnchoredValidator(anchorSrv anchors.Service, id []byte) error {
 		return errors.NewTypedError(ErrDocumentIdentifier, err)
 	}
 
-	_, _, err = anchorSrv.GetAnchorData(anchorID)
+	_, _, _, err = anchorSrv.GetAnchorData(anchorID)
 	if err == nil {
 		return ErrDocumentIDReused
 	}
@@ -397,12 +422,17 @@ func currentVersionValidator(anchorSrv anchors.Service) Validator {
 	})
 }
 
-// anchorRepoAddressValidator validates if the model is using the configured anchor repository address.
-func anchorRepoAddressValidator(anchoredRepoAddr common.Address) Validator {
+// anchorBackendValidator validates if the model is anchored on the backend of the node.
+// Documents anchored on other backends cannot be verified against the anchors of the node.
+func anchorBackendValidator(anchorSrv anchors.Service) Validator {
 	return ValidatorFunc(func(_, model Model) error {
+		if model == nil {
+			return ErrModelNil
+		}
+
 		addr := model.AnchorRepoAddress()
-		if !bytes.Equal(addr.Bytes(), anchoredRepoAddr.Bytes()) {
-			return ErrDifferentAnchoredAddress
+		if !bytes.Equal(addr.Bytes(), anchorSrv.RepositoryAddress().Bytes()) {
+			return errors.NewTypedError(ErrUnsupportedAnchorBackend, errors.New("document is anchored to %s", addr.Hex()))
 		}
 
 		return nil
@@ -430,7 +460,7 @@ func attributeValidator(anchorSrv anchors.Service, idSrv identity.Service) Valid
 				return err
 			}
 
-			_, ats, erro := anchorSrv.GetAnchorData(aid)
+			_, ats, _, erro := anchorSrv.GetAnchorData(aid)
 			if erro != nil {
 				// the attribute was added in this update itself.
 				// pick the update time from the model itself
@@ -469,11 +499,11 @@ func transitionValidator(collaborator identity.DID) Validator {
 // base validator
 // signing root validator
 // document root validator
-// signatures validator
+// signatures validator with the signature policy of the document
 // should be called before pre anchoring
 func PreAnchorValidator(idService identity.Service, anchorSrv anchors.Service) ValidatorGroup {
 	return ValidatorGroup{
-		SignatureValidator(idService, anchorSrv),
+		SignaturePolicyValidator(idService, anchorSrv),
 		documentRootValidator(),
 	}
 }
@@ -503,23 +533,37 @@ func ReceivedAnchoredDocumentValidator(
 	}
 }
 
+// syncedVersionValidator is a validator group with following validators
+// transitionValidator
+// PreAnchorValidator
+// anchoredValidator
+// it should be called on the versions pulled from a collaborator. Unlike ReceivedAnchoredDocumentValidator,
+// the version need not be the latest one since the newer versions are synced after it.
+func syncedVersionValidator(idService identity.Service, anchorSrv anchors.Service, author identity.DID) ValidatorGroup {
+	return ValidatorGroup{
+		transitionValidator(author),
+		PreAnchorValidator(idService, anchorSrv),
+		anchoredValidator(anchorSrv),
+	}
+}
+
 // RequestDocumentSignatureValidator is a validator group with the following validators
 // SignatureValidator
 // transitionsValidator
 // it should be called when a document is received over the p2p layer before signing
+// errors are typed with the reason of the RejectionCode of the failed validator.
 func RequestDocumentSignatureValidator(
 	anchorSrv anchors.Service,
 	idService identity.Service,
-	collaborator identity.DID,
-	anchorRepoAddress common.Address) ValidatorGroup {
+	collaborator identity.DID) ValidatorGroup {
 	return ValidatorGroup{
-		documentTimestampForSigningValidator(),
-		documentAuthorValidator(collaborator),
-		currentVersionValidator(anchorSrv),
-		LatestVersionValidator(anchorSrv),
-		anchorRepoAddressValidator(anchorRepoAddress),
-		transitionValidator(collaborator),
-		SignatureValidator(idService, anchorSrv),
+		rejectionValidator(RejectionCodeInvalidTimestamp, documentTimestampForSigningValidator()),
+		rejectionValidator(RejectionCodeInvalidAuthor, documentAuthorValidator(collaborator)),
+		rejectionValidator(RejectionCodeVersionAnchored, currentVersionValidator(anchorSrv)),
+		rejectionValidator(RejectionCodeVersionAnchored, LatestVersionValidator(anchorSrv)),
+		rejectionValidator(RejectionCodeInvalidAnchorRepository, anchorBackendValidator(anchorSrv)),
+		rejectionValidator(RejectionCodeUnauthorizedTransition, transitionValidator(collaborator)),
+		rejectionValidator(RejectionCodeInvalidSignatures, SignatureValidator(idService, anchorSrv)),
 	}
 }
 
@@ -527,12 +571,26 @@ func RequestDocumentSignatureValidator(
 // baseValidator
 // signingRootValidator
 // signaturesValidator
-// should be called after sender signing the document, before requesting the document and after signature collection
+// should be called after sender signing the document and before requesting the signatures
 func SignatureValidator(idService identity.Service, anchorSrv anchors.Service) ValidatorGroup {
 	return ValidatorGroup{
 		baseValidator(),
 		signingRootValidator(),
-		signaturesValidator(idService),
+		signaturesValidator(idService, false),
+		attributeValidator(anchorSrv, idService),
+	}
+}
+
+// SignaturePolicyValidator is a validator group with following validators
+// baseValidator
+// signingRootValidator
+// signaturesValidator with the signature policy of the document
+// should be called after signature collection
+func SignaturePolicyValidator(idService identity.Service, anchorSrv anchors.Service) ValidatorGroup {
+	return ValidatorGroup{
+		baseValidator(),
+		signingRootValidator(),
+		signaturesValidator(idService, true),
 		attributeValidator(anchorSrv, idService),
 	}
 }
@@ -424,7 +424,7 @@ func TestValidator_SignatureValidator(t *testing.T) {
 
 func TestValidator_signatureValidator(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	ssv := signaturesValidator(srv)
+	ssv := signaturesValidator(srv, false)
 
 	// fail to get signing root
 	model := new(mockModel)
@@ -464,7 +464,7 @@ func TestValidator_signatureValidator(t *testing.T) {
 	sid, err := identity.NewDIDFromBytes(s.SignerId)
 	assert.NoError(t, err)
 	srv.On("ValidateSignature", sid, s.PublicKey, s.Signature, payload, tm).Return(errors.New("error")).Once()
-	ssv = signaturesValidator(srv)
+	ssv = signaturesValidator(srv, false)
 	err = ssv.Validate(nil, model)
 	model.AssertExpectations(t)
 	srv.AssertExpectations(t)
@@ -481,11 +481,32 @@ func TestValidator_signatureValidator(t *testing.T) {
 	model.sigs = append(model.sigs, s)
 	srv = new(testingcommons.MockIdentityService)
 	srv.On("ValidateSignature", sid, s.PublicKey, s.Signature, payload, tm).Return(nil).Once()
-	ssv = signaturesValidator(srv)
+	ssv = signaturesValidator(srv, false)
 	err = ssv.Validate(nil, model)
 	model.AssertExpectations(t)
 	srv.AssertExpectations(t)
 	assert.Nil(t, err)
+
+	// signature policy not met
+	policy, err := SignaturePolicy{Type: SignaturePolicyAll}.Attribute()
+	assert.NoError(t, err)
+	model = new(mockModel)
+	model.On("CalculateSigningRoot").Return(sr, nil).Once()
+	model.On("Signatures").Return().Once()
+	model.On("Author").Return(did, nil)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did, testingidentity.GenerateRandomDID()}, nil)
+	model.On("Timestamp").Return(tm, nil)
+	model.On("AttributeExists", policy.Key).Return(true)
+	model.On("GetAttribute", policy.Key).Return(policy, nil)
+	model.sigs = append(model.sigs, s)
+	srv = new(testingcommons.MockIdentityService)
+	srv.On("ValidateSignature", sid, s.PublicKey, s.Signature, payload, tm).Return(nil).Once()
+	ssv = signaturesValidator(srv, true)
+	err = ssv.Validate(nil, model)
+	model.AssertExpectations(t)
+	srv.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrSignaturePolicyNotMet.Error())
 }
 
 func TestPreAnchorValidator(t *testing.T) {
@@ -578,18 +599,30 @@ func TestValidator_CurrentVersionValidator(t *testing.T) {
 }
 
 func TestValidator_anchoredValidator(t *testing.T) {
-	av := anchoredValidator(mockAnchorService{})
+	addr := testingidentity.GenerateRandomDID().ToAddress()
+	r := &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
+	av := anchoredValidator(r)
 
-	// failed anchorID
+	// anchored on another backend
 	model := new(mockModel)
-	model.On("CurrentVersion").Return(nil).Once()
+	model.On("AnchorRepoAddress").Return(anchors.LocalRepositoryAddress).Once()
 	err := av.Validate(nil, model)
 	model.AssertExpectations(t)
+	assert.True(t, errors.IsOfType(ErrUnsupportedAnchorBackend, err))
+
+	// failed anchorID
+	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
+	model.On("CurrentVersion").Return(nil).Once()
+	err = av.Validate(nil, model)
+	model.AssertExpectations(t)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get anchorID")
 
 	// failed docRoot
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
 	model.On("CalculateDocumentRoot").Return(nil, errors.New("error")).Once()
 	err = av.Validate(nil, model)
@@ -599,6 +632,7 @@ func TestValidator_anchoredValidator(t *testing.T) {
 
 	// invalid doc root
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
 	model.On("CalculateDocumentRoot").Return(utils.RandomSlice(30), nil).Once()
 	err = av.Validate(nil, model)
@@ -609,10 +643,12 @@ func TestValidator_anchoredValidator(t *testing.T) {
 	// failed to get docRoot from chain
 	anchorID, err := anchors.ToAnchorID(utils.RandomSlice(32))
 	assert.Nil(t, err)
-	r := &mockAnchorService{}
+	r = &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
 	av = anchoredValidator(r)
 	r.On("GetAnchorData", anchorID).Return(nil, time.Now(), errors.New("error")).Once()
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(anchorID[:]).Once()
 	model.On("CalculateDocumentRoot").Return(utils.RandomSlice(32), nil).Once()
 	err = av.Validate(nil, model)
@@ -624,9 +660,11 @@ func TestValidator_anchoredValidator(t *testing.T) {
 	// mismatched doc roots
 	docRoot := anchors.RandomDocumentRoot()
 	r = &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
 	av = anchoredValidator(r)
 	r.On("GetAnchorData", anchorID).Return(docRoot, time.Now(), nil).Once()
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(anchorID[:]).Once()
 	model.On("CalculateDocumentRoot").Return(utils.RandomSlice(32), nil).Once()
 	err = av.Validate(nil, model)
@@ -637,10 +675,12 @@ func TestValidator_anchoredValidator(t *testing.T) {
 
 	// anchored after max allowed time
 	r = &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
 	av = anchoredValidator(r)
 	tm := time.Now()
 	r.On("GetAnchorData", anchorID).Return(docRoot, tm, nil).Once()
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(anchorID[:]).Once()
 	model.On("CalculateDocumentRoot").Return(docRoot[:], nil).Once()
 	model.On("Timestamp").Return(tm.Add(-MaxAuthoredToCommitDuration-1), nil).Once()
@@ -652,9 +692,11 @@ func TestValidator_anchoredValidator(t *testing.T) {
 
 	// success
 	r = &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
 	av = anchoredValidator(r)
 	r.On("GetAnchorData", anchorID).Return(docRoot, time.Now(), nil).Once()
 	model = new(mockModel)
+	model.On("AnchorRepoAddress").Return(addr).Once()
 	model.On("CurrentVersion").Return(anchorID[:]).Once()
 	model.On("CalculateDocumentRoot").Return(docRoot[:], nil).Once()
 	model.On("Timestamp").Return(time.Now(), nil).Once()
@@ -708,21 +750,23 @@ func TestDocumentTimestampForSigningValidator(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestValidator_anchorRepoAddressValidator(t *testing.T) {
+func TestValidator_anchorBackendValidator(t *testing.T) {
 	addr := testingidentity.GenerateRandomDID().ToAddress()
-	arv := anchorRepoAddressValidator(addr)
+	r := &mockAnchorService{}
+	r.On("RepositoryAddress").Return(addr)
+	abv := anchorBackendValidator(r)
 
 	model := new(mockModel)
 	model.On("AnchorRepoAddress").Return(testingidentity.GenerateRandomDID().ToAddress()).Once()
 	model.On("AnchorRepoAddress").Return(addr).Once()
 
 	// failure
-	err := arv.Validate(nil, model)
+	err := abv.Validate(nil, model)
 	assert.Error(t, err)
-	assert.Equal(t, err.Error(), "anchor address is not the node configured address")
+	assert.True(t, errors.IsOfType(ErrUnsupportedAnchorBackend, err))
 
 	// success
-	assert.NoError(t, arv.Validate(nil, model))
+	assert.NoError(t, abv.Validate(nil, model))
 	model.AssertExpectations(t)
 }
 
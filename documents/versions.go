@@ -0,0 +1,127 @@
+package documents
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AnchorInfo holds the anchor details of a document version.
+type AnchorInfo struct {
+	AnchorID     anchors.AnchorID
+	DocumentRoot anchors.DocumentRoot
+	AnchoredAt   time.Time
+	BlockNumber  uint32
+}
+
+// VersionInfo holds the details of a single version of a document.
+type VersionInfo struct {
+	VersionID       []byte
+	PreviousVersion []byte
+	Author          *identity.DID
+	Timestamp       *time.Time
+	Status          Status
+
+	// Anchor is nil if the version is not anchored yet.
+	Anchor *AnchorInfo
+}
+
+// getVersionInfo derives the VersionInfo of the model.
+// Anchor details are only fetched for committed versions.
+func getVersionInfo(anchorSrv anchors.Service, model Model) VersionInfo {
+	vi := VersionInfo{
+		VersionID:       model.CurrentVersion(),
+		PreviousVersion: model.PreviousVersion(),
+		Status:          model.GetStatus(),
+	}
+
+	// author and timestamp are not available until the version is committed
+	if author, err := model.Author(); err == nil {
+		vi.Author = &author
+	}
+
+	if ts, err := model.Timestamp(); err == nil {
+		vi.Timestamp = &ts
+	}
+
+	if vi.Status != Committed {
+		return vi
+	}
+
+	ai, err := GetAnchorInfo(anchorSrv, model.CurrentVersion())
+	if err != nil {
+		srvLog.Warningf("failed to get anchor data for version %s: %v", hexutil.Encode(model.CurrentVersion()), err)
+		return vi
+	}
+
+	vi.Anchor = ai
+	return vi
+}
+
+// GetAnchorInfo returns the anchor details of the document version.
+func GetAnchorInfo(anchorSrv anchors.Service, versionID []byte) (*AnchorInfo, error) {
+	anchorID, err := anchors.ToAnchorID(versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	root, anchoredAt, bn, err := anchorSrv.GetAnchorData(anchorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnchorInfo{
+		AnchorID:     anchorID,
+		DocumentRoot: root,
+		AnchoredAt:   anchoredAt,
+		BlockNumber:  bn,
+	}, nil
+}
+
+// GetVersions returns the version history of the document starting from the first version.
+// The history is derived by following the previous version links from the latest version.
+// If an older version is missing in the DB, history is returned from the oldest version found.
+func (s service) GetVersions(ctx context.Context, documentID []byte) ([]VersionInfo, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	accID := acc.GetIdentityID()
+	m, err := s.repo.GetLatest(accID, documentID)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentNotFound, err)
+	}
+
+	var versions []VersionInfo
+	seen := make(map[string]struct{})
+	for {
+		vi := getVersionInfo(s.anchorSrv, m)
+		versions = append([]VersionInfo{vi}, versions...)
+		seen[hexutil.Encode(vi.VersionID)] = struct{}{}
+		prev := vi.PreviousVersion
+		if utils.IsEmptyByteSlice(prev) {
+			break
+		}
+
+		// guard against malformed version links
+		if _, ok := seen[hexutil.Encode(prev)]; ok {
+			break
+		}
+
+		m, err = s.repo.Get(accID, prev)
+		if err != nil {
+			srvLog.Warningf("failed to fetch previous version %s of document %s: %v",
+				hexutil.Encode(prev), hexutil.Encode(documentID), err)
+			break
+		}
+	}
+
+	return versions, nil
+}
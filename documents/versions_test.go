@@ -0,0 +1,90 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetVersions(t *testing.T) {
+	s := service{}
+	docID := utils.RandomSlice(32)
+
+	// missing account
+	_, err := s.GetVersions(context.Background(), docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// missing document
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(MockRepository)
+	repo.On("GetLatest", mock.Anything, docID).Return(nil, errors.New("not found")).Once()
+	s.repo = repo
+	_, err = s.GetVersions(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+
+	// v1(committed) <- v2(committed) <- v3(committing)
+	author := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+	v2, v3 := utils.RandomSlice(32), utils.RandomSlice(32)
+	m1, m2, m3 := new(MockModel), new(MockModel), new(MockModel)
+	for _, c := range []struct {
+		m          *MockModel
+		prev, curr []byte
+		status     Status
+	}{
+		{m: m1, prev: nil, curr: docID, status: Committed},
+		{m: m2, prev: docID, curr: v2, status: Committed},
+		{m: m3, prev: v2, curr: v3, status: Committing},
+	} {
+		c.m.On("CurrentVersion").Return(c.curr)
+		c.m.On("PreviousVersion").Return(c.prev)
+		c.m.On("GetStatus").Return(c.status)
+		c.m.On("Author").Return(author, nil)
+		c.m.On("Timestamp").Return(tm, nil)
+	}
+
+	root := anchors.RandomDocumentRoot()
+	anchorSrv := new(mockAnchorService)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(root, tm, nil).Once()
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("anchor missing")).Once()
+	s.anchorSrv = anchorSrv
+	repo.On("GetLatest", mock.Anything, docID).Return(m3, nil)
+	repo.On("Get", mock.Anything, v2).Return(m2, nil)
+	repo.On("Get", mock.Anything, docID).Return(m1, nil)
+	vs, err := s.GetVersions(ctx, docID)
+	assert.NoError(t, err)
+	assert.Len(t, vs, 3)
+	assert.Equal(t, docID, vs[0].VersionID)
+	assert.Nil(t, vs[0].Anchor)
+	assert.Equal(t, v2, vs[1].VersionID)
+	assert.Equal(t, root, vs[1].Anchor.DocumentRoot)
+	assert.Equal(t, tm, vs[1].Anchor.AnchoredAt)
+	assert.Equal(t, v3, vs[2].VersionID)
+	assert.Equal(t, Committing, vs[2].Status)
+	assert.Nil(t, vs[2].Anchor)
+	assert.Equal(t, author, *vs[2].Author)
+	anchorSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+
+	// missing previous version
+	repo = new(MockRepository)
+	repo.On("GetLatest", mock.Anything, docID).Return(m3, nil)
+	repo.On("Get", mock.Anything, v2).Return(nil, errors.New("not found"))
+	s.repo = repo
+	vs, err = s.GetVersions(ctx, docID)
+	assert.NoError(t, err)
+	assert.Len(t, vs, 1)
+	assert.Equal(t, v3, vs[0].VersionID)
+}
@@ -34,6 +34,8 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Get("/documents/{"+DocumentIDParam+"}/versions/{"+VersionIDParam+"}", h.GetDocumentVersion)
 	r.Post("/documents/{"+DocumentIDParam+"}/proofs", h.GenerateProofs)
 	r.Post("/documents/{"+DocumentIDParam+"}/versions/{"+VersionIDParam+"}/proofs", h.GenerateProofsForVersion)
+	r.Post("/documents/{"+DocumentIDParam+"}/proofs/bundle", h.GenerateProofBundle)
+	r.Post("/documents/{"+DocumentIDParam+"}/versions/{"+VersionIDParam+"}/proofs/bundle", h.GenerateProofBundleForVersion)
 	r.Get("/jobs/{"+jobIDParam+"}", h.GetJobStatus)
 	r.Post("/nfts/registries/{"+registryAddressParam+"}/mint", h.MintNFT)
 	r.Post("/nfts/registries/{"+registryAddressParam+"}/tokens/{"+tokenIDParam+"}/transfer", h.TransferNFT)
@@ -18,7 +18,7 @@ func TestRegister(t *testing.T) {
 		bootstrap.BootstrappedNFTService: new(testingnfts.MockNFTService),
 	}
 	Register(ctx, r)
-	assert.Len(t, r.Routes(), 13)
+	assert.Len(t, r.Routes(), 15)
 	assert.Equal(t, r.Routes()[0].Pattern, "/accounts")
 	assert.Len(t, r.Routes()[0].Handlers, 2)
 	assert.NotNil(t, r.Routes()[0].Handlers["GET"])
@@ -38,16 +38,20 @@ func TestRegister(t *testing.T) {
 	assert.NotNil(t, r.Routes()[5].Handlers["PUT"])
 	assert.Equal(t, r.Routes()[6].Pattern, "/documents/{document_id}/proofs")
 	assert.NotNil(t, r.Routes()[6].Handlers["POST"])
-	assert.Equal(t, r.Routes()[7].Pattern, "/documents/{document_id}/versions/{version_id}")
-	assert.NotNil(t, r.Routes()[7].Handlers["GET"])
-	assert.Equal(t, r.Routes()[8].Pattern, "/documents/{document_id}/versions/{version_id}/proofs")
-	assert.NotNil(t, r.Routes()[8].Handlers["POST"])
-	assert.Equal(t, r.Routes()[9].Pattern, "/jobs/{job_id}")
-	assert.NotNil(t, r.Routes()[9].Handlers["GET"])
-	assert.Equal(t, r.Routes()[10].Pattern, "/nfts/registries/{registry_address}/mint")
+	assert.Equal(t, r.Routes()[7].Pattern, "/documents/{document_id}/proofs/bundle")
+	assert.NotNil(t, r.Routes()[7].Handlers["POST"])
+	assert.Equal(t, r.Routes()[8].Pattern, "/documents/{document_id}/versions/{version_id}")
+	assert.NotNil(t, r.Routes()[8].Handlers["GET"])
+	assert.Equal(t, r.Routes()[9].Pattern, "/documents/{document_id}/versions/{version_id}/proofs")
+	assert.NotNil(t, r.Routes()[9].Handlers["POST"])
+	assert.Equal(t, r.Routes()[10].Pattern, "/documents/{document_id}/versions/{version_id}/proofs/bundle")
 	assert.NotNil(t, r.Routes()[10].Handlers["POST"])
-	assert.Equal(t, r.Routes()[11].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/owner")
+	assert.Equal(t, r.Routes()[11].Pattern, "/jobs/{job_id}")
 	assert.NotNil(t, r.Routes()[11].Handlers["GET"])
-	assert.Equal(t, r.Routes()[12].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/transfer")
+	assert.Equal(t, r.Routes()[12].Pattern, "/nfts/registries/{registry_address}/mint")
 	assert.NotNil(t, r.Routes()[12].Handlers["POST"])
+	assert.Equal(t, r.Routes()[13].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/owner")
+	assert.NotNil(t, r.Routes()[13].Handlers["GET"])
+	assert.Equal(t, r.Routes()[14].Pattern, "/nfts/registries/{registry_address}/tokens/{token_id}/transfer")
+	assert.NotNil(t, r.Routes()[14].Handlers["POST"])
 }
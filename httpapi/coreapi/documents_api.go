@@ -252,6 +252,7 @@ func (h handler) GetDocumentVersion(w http.ResponseWriter, r *http.Request) {
 // GenerateProofs returns proofs for the fields from latest version of the document.
 // @summary Generates proofs for the fields from latest version of the document.
 // @description Generates proofs for the fields from latest version of the document.
+// @description Proofs of the data and cd_tree fields are generated from the zk tree along with the circuit proofs if the tree is set to zk.
 // @id generate_document_proofs
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -291,7 +292,13 @@ func (h handler) GenerateProofs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	proofs, err := h.srv.GenerateProofs(r.Context(), docID, request.Fields)
+	if !isValidProofTree(request.Tree) {
+		code = http.StatusBadRequest
+		err = ErrInvalidProofTree
+		return
+	}
+
+	proofs, err := h.srv.GenerateProofs(r.Context(), docID, request.Tree, request.Fields)
 	if err != nil {
 		code = http.StatusInternalServerError
 		log.Error(err)
@@ -299,12 +306,13 @@ func (h handler) GenerateProofs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, convertProofs(proofs))
+	render.JSON(w, r, ToProofsResponse(proofs))
 }
 
 // GenerateProofsForVersion returns proofs for the fields from a specific document version.
 // @summary Generates proofs for the fields from a specific document version.
 // @description Generates proofs for the fields from a specific document version.
+// @description Proofs of the data and cd_tree fields are generated from the zk tree along with the circuit proofs if the tree is set to zk.
 // @id generate_document_version_proofs
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -351,7 +359,102 @@ func (h handler) GenerateProofsForVersion(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	proofs, err := h.srv.GenerateProofsForVersion(r.Context(), ids[0], ids[1], request.Fields)
+	if !isValidProofTree(request.Tree) {
+		code = http.StatusBadRequest
+		err = ErrInvalidProofTree
+		return
+	}
+
+	proofs, err := h.srv.GenerateProofsForVersion(r.Context(), ids[0], ids[1], request.Tree, request.Fields)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, ToProofsResponse(proofs))
+}
+
+// GenerateProofBundle returns a self-contained proof bundle for the fields from latest version of the document.
+// @summary Generates a proof bundle for the fields from latest version of the document.
+// @description Generates a proof bundle with the field proofs, signatures, signing key validity and anchor details of the latest version of the document.
+// @description Bundle can be verified without a node.
+// @id generate_document_proof_bundle
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param body body coreapi.ProofsRequest true "Document proof request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} coreapi.ProofBundle
+// @router /v1/documents/{document_id}/proofs/bundle [post]
+func (h handler) GenerateProofBundle(w http.ResponseWriter, r *http.Request) {
+	h.generateProofBundle(w, r, false)
+}
+
+// GenerateProofBundleForVersion returns a self-contained proof bundle for the fields from a specific document version.
+// @summary Generates a proof bundle for the fields from a specific document version.
+// @description Generates a proof bundle with the field proofs, signatures, signing key validity and anchor details of a specific document version.
+// @description Bundle can be verified without a node.
+// @id generate_document_version_proof_bundle
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier"
+// @param body body coreapi.ProofsRequest true "Document proof request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} coreapi.ProofBundle
+// @router /v1/documents/{document_id}/versions/{version_id}/proofs/bundle [post]
+func (h handler) GenerateProofBundleForVersion(w http.ResponseWriter, r *http.Request) {
+	h.generateProofBundle(w, r, true)
+}
+
+func (h handler) generateProofBundle(w http.ResponseWriter, r *http.Request, withVersion bool) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	params := []string{chi.URLParam(r, DocumentIDParam)}
+	if withVersion {
+		params = append(params, chi.URLParam(r, VersionIDParam))
+	}
+
+	ids := make([][]byte, 2)
+	for i, idStr := range params {
+		var id []byte
+		id, err = hexutil.Decode(idStr)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = ErrInvalidDocumentID
+			return
+		}
+
+		ids[i] = id
+	}
+
+	d, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var request ProofsRequest
+	err = json.Unmarshal(d, &request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	bundle, err := h.srv.GenerateProofBundle(r.Context(), ids[0], ids[1], request.Fields)
 	if err != nil {
 		code = http.StatusInternalServerError
 		log.Error(err)
@@ -359,5 +462,5 @@ func (h handler) GenerateProofsForVersion(w http.ResponseWriter, r *http.Request
 	}
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, convertProofs(proofs))
+	render.JSON(w, r, ToProofBundle(bundle))
 }
@@ -10,12 +10,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/precise-proofs/proofs"
 	"github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -442,6 +446,12 @@ func TestHandler_GenerateProofs(t *testing.T) {
 				},
 			},
 		},
+		Anchor: &documents.AnchorInfo{
+			DocumentRoot: anchors.DocumentRoot(utils.RandomByte32()),
+			AnchoredAt:   time.Unix(1600000000, 0),
+			BlockNumber:  12,
+		},
+		SigningRoot: utils.RandomSlice(32),
 	}
 	docSrv = new(testingdocuments.MockService)
 	docSrv.On("CreateProofs", mock.Anything, id, request.Fields).Return(proof, nil)
@@ -450,6 +460,44 @@ func TestHandler_GenerateProofs(t *testing.T) {
 	h.GenerateProofs(w, r)
 	assert.Equal(t, w.Code, http.StatusOK)
 	assert.Contains(t, w.Body.String(), hexutil.Encode(id))
+	assert.Contains(t, w.Body.String(), hexutil.Encode(proof.Anchor.DocumentRoot[:]))
+	assert.Contains(t, w.Body.String(), "\"anchored_at\":\"2020-09-13T12:26:40Z\"")
+	assert.Contains(t, w.Body.String(), "\"anchored_block\":12")
+	assert.Contains(t, w.Body.String(), hexutil.Encode(proof.SigningRoot))
+	assert.NotContains(t, w.Body.String(), "circuit_proofs")
+	docSrv.AssertExpectations(t)
+
+	// invalid tree
+	d, err = json.Marshal(ProofsRequest{Tree: "poseidon"})
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofs(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), ErrInvalidProofTree.Error())
+
+	// zk tree
+	request = ProofsRequest{Fields: []string{"cd_tree.document_type"}, Tree: documents.ZKTree}
+	d, err = json.Marshal(request)
+	assert.NoError(t, err)
+	proof.Tree = documents.ZKTree
+	proof.CircuitProofs = []documents.CircuitProof{
+		{
+			Property:    []byte{0, 0, 1},
+			Leaf:        []byte{1, 2, 4},
+			LeafIndex:   2,
+			Siblings:    []byteutils.HexBytes{{1, 2, 5}, {1, 2, 6}},
+			PathIndices: []int{0, 1},
+		},
+	}
+	docSrv = new(testingdocuments.MockService)
+	docSrv.On("CreateProofsFromZKTree", mock.Anything, id, []byte(nil), request.Fields).Return(proof, nil)
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofs(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Body.String(), "\"tree\":\"zk\"")
+	assert.Contains(t, w.Body.String(), "\"leaf_index\":2")
+	assert.Contains(t, w.Body.String(), "\"path_indices\":[0,1]")
 	docSrv.AssertExpectations(t)
 }
 
@@ -531,4 +579,99 @@ func TestHandler_GenerateProofsForVersion(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusOK)
 	assert.Contains(t, w.Body.String(), hexutil.Encode(id))
 	docSrv.AssertExpectations(t)
+
+	// zk tree
+	request = ProofsRequest{Tree: documents.ZKTree}
+	d, err = json.Marshal(request)
+	assert.NoError(t, err)
+	proof.Tree = documents.ZKTree
+	docSrv.On("CreateProofsFromZKTree", mock.Anything, id, vid, request.Fields).Return(proof, nil)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofsForVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Contains(t, w.Body.String(), "\"tree\":\"zk\"")
+	docSrv.AssertExpectations(t)
+}
+
+func TestHandler_GenerateProofBundle(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/proofs/bundle", body).WithContext(ctx)
+	}
+
+	// invalid document_id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("document_id", "invalid")
+	rctx.URLParams.Add("version_id", "invalid")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h.GenerateProofBundle(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), ErrInvalidDocumentID.Error())
+
+	// invalid version_id
+	id, vid := utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.GenerateProofBundleForVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), ErrInvalidDocumentID.Error())
+
+	// failed json input
+	rctx.URLParams.Values[1] = hexutil.Encode(vid)
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.GenerateProofBundle(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), "unexpected end of JSON input")
+
+	// failed to generate bundle
+	request := ProofsRequest{Fields: []string{"cd_tree.document_type"}}
+	d, err := json.Marshal(request)
+	assert.NoError(t, err)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("CreateProofBundle", mock.Anything, id, []byte(nil), request.Fields).Return(nil, errors.New("failed to generate bundle")).Once()
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofBundle(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Contains(t, w.Body.String(), "failed to generate bundle")
+
+	// success
+	did := testingidentity.GenerateRandomDID()
+	bundle := &documents.ProofBundle{
+		Version: documents.ProofBundleVersion,
+		Proof: &documents.DocumentProof{
+			DocumentID: id,
+			VersionID:  vid,
+			Anchor: &documents.AnchorInfo{
+				AnchorID:     anchors.AnchorID(utils.RandomByte32()),
+				DocumentRoot: anchors.DocumentRoot(utils.RandomByte32()),
+				AnchoredAt:   time.Unix(1600000000, 0),
+				BlockNumber:  12,
+			},
+		},
+		Signatures: []documents.SignatureInfo{
+			{
+				SignatureID: utils.RandomSlice(52),
+				SignerID:    did,
+				PublicKey:   utils.RandomSlice(32),
+				Signature:   utils.RandomSlice(65),
+				KeyValid:    true,
+			},
+		},
+	}
+	docSrv.On("CreateProofBundle", mock.Anything, id, vid, request.Fields).Return(bundle, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofBundleForVersion(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp ProofBundle
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, documents.ProofBundleVersion, resp.Version)
+	assert.Equal(t, bundle.Proof.Anchor.AnchorID[:], resp.AnchorID.Bytes())
+	assert.Equal(t, vid, resp.Proofs.Header.VersionID.Bytes())
+	assert.Equal(t, uint32(12), resp.Proofs.Header.AnchoredBlock)
+	assert.Len(t, resp.Signatures, 1)
+	assert.Equal(t, did[:], resp.Signatures[0].SignerID.Bytes())
+	assert.True(t, resp.Signatures[0].KeyValid)
+	docSrv.AssertExpectations(t)
 }
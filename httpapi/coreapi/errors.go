@@ -8,4 +8,7 @@ const (
 
 	// ErrDocumentNotFound is a sentinel error for missing documents.
 	ErrDocumentNotFound = errors.Error("document not found")
+
+	// ErrInvalidProofTree is a sentinel error for unknown proof trees.
+	ErrInvalidProofTree = errors.Error("invalid proof tree")
 )
@@ -55,16 +55,29 @@ func (s Service) GetDocumentVersion(ctx context.Context, docID, versionID []byte
 	return s.docSrv.GetVersion(ctx, docID, versionID)
 }
 
-// GenerateProofs returns the proofs for the latest version of the document.
-func (s Service) GenerateProofs(ctx context.Context, docID []byte, fields []string) (*documents.DocumentProof, error) {
+// GenerateProofs returns the proofs for the latest version of the document from the tree.
+func (s Service) GenerateProofs(ctx context.Context, docID []byte, tree string, fields []string) (*documents.DocumentProof, error) {
+	if tree == documents.ZKTree {
+		return s.docSrv.CreateProofsFromZKTree(ctx, docID, nil, fields)
+	}
+
 	return s.docSrv.CreateProofs(ctx, docID, fields)
 }
 
-// GenerateProofsForVersion returns the proofs for the specific version of the document.
-func (s Service) GenerateProofsForVersion(ctx context.Context, docID, versionID []byte, fields []string) (*documents.DocumentProof, error) {
+// GenerateProofsForVersion returns the proofs for the specific version of the document from the tree.
+func (s Service) GenerateProofsForVersion(ctx context.Context, docID, versionID []byte, tree string, fields []string) (*documents.DocumentProof, error) {
+	if tree == documents.ZKTree {
+		return s.docSrv.CreateProofsFromZKTree(ctx, docID, versionID, fields)
+	}
+
 	return s.docSrv.CreateProofsForVersion(ctx, docID, versionID, fields)
 }
 
+// GenerateProofBundle returns the proof bundle for the document version. Latest version is used if the version is empty.
+func (s Service) GenerateProofBundle(ctx context.Context, docID, versionID []byte, fields []string) (*documents.ProofBundle, error) {
+	return s.docSrv.CreateProofBundle(ctx, docID, versionID, fields)
+}
+
 // MintNFT mints an NFT.
 func (s Service) MintNFT(ctx context.Context, request nft.MintNFTRequest) (*nft.TokenResponse, error) {
 	resp, _, err := s.nftSrv.MintNFT(ctx, request)
@@ -14,6 +14,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/proofwire"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/ethereum/go-ethereum/common"
@@ -41,11 +42,12 @@ type AttributeMapRequest map[string]AttributeRequest
 
 // CreateDocumentRequest defines the payload for creating documents.
 type CreateDocumentRequest struct {
-	Scheme      string              `json:"scheme" enums:"generic,entity"`
-	ReadAccess  []identity.DID      `json:"read_access" swaggertype:"array,string"`
-	WriteAccess []identity.DID      `json:"write_access" swaggertype:"array,string"`
-	Data        interface{}         `json:"data"`
-	Attributes  AttributeMapRequest `json:"attributes"`
+	Scheme          string              `json:"scheme" enums:"generic,entity"`
+	ReadAccess      []identity.DID      `json:"read_access" swaggertype:"array,string"`
+	WriteAccess     []identity.DID      `json:"write_access" swaggertype:"array,string"`
+	Data            interface{}         `json:"data"`
+	Attributes      AttributeMapRequest `json:"attributes"`
+	SignaturePolicy string              `json:"signature_policy"` // one of all, threshold:<M> or role:<role key>
 }
 
 // GenerateAccountPayload holds required fields to generate account with defaults.
@@ -92,6 +94,10 @@ type ResponseHeader struct {
 	JobID       string         `json:"job_id,omitempty"`
 	NFTs        []NFT          `json:"nfts"`
 	Status      string         `json:"status,omitempty"`
+
+	// anchor details are set only for committed versions
+	AnchoredAt    string `json:"anchored_at,omitempty"`
+	AnchoredBlock uint32 `json:"anchored_block,omitempty"`
 }
 
 // DocumentResponse is the common response for Document APIs.
@@ -126,7 +132,7 @@ func toDocumentAttributes(cattrs map[string]AttributeRequest) (map[documents.Att
 		attrs[attr.Key] = attr
 	}
 
-	return attrs, nil
+	return attrs, documents.ValidatePayloadAttributes(attrs)
 }
 
 // ToDocumentsCreatePayload converts CoreAPI create payload to documents payload.
@@ -137,6 +143,7 @@ func ToDocumentsCreatePayload(request CreateDocumentRequest) (documents.CreatePa
 			ReadCollaborators:      request.ReadAccess,
 			ReadWriteCollaborators: request.WriteAccess,
 		},
+		SignaturePolicy: request.SignaturePolicy,
 	}
 
 	data, err := json.Marshal(request.Data)
@@ -180,7 +187,8 @@ func convertNFTs(tokenRegistry documents.TokenRegistry, nfts []*coredocumentpb.N
 	return nnfts, err
 }
 
-func toAttributeMapResponse(attrs []documents.Attribute) (AttributeMapResponse, error) {
+// ToAttributeMapResponse converts the document attributes to the attribute map response.
+func ToAttributeMapResponse(attrs []documents.Attribute) (AttributeMapResponse, error) {
 	m := make(AttributeMapResponse)
 	for _, v := range attrs {
 		vx := v // convert to value
@@ -265,7 +273,7 @@ func DeriveResponseHeader(tokenRegistry documents.TokenRegistry, model documents
 func GetDocumentResponse(model documents.Model, tokenRegistry documents.TokenRegistry, jobID jobs.JobID) (resp DocumentResponse, err error) {
 	docData := model.GetData()
 	scheme := model.Scheme()
-	attrMap, err := toAttributeMapResponse(model.GetAttributes())
+	attrMap, err := ToAttributeMapResponse(model.GetAttributes())
 	if err != nil {
 		return resp, err
 	}
@@ -279,34 +287,87 @@ func GetDocumentResponse(model documents.Model, tokenRegistry documents.TokenReg
 }
 
 // ProofsRequest holds the fields for which proofs are generated.
+// Tree selects the data tree the proofs are generated from. Basic tree is used if empty.
 type ProofsRequest struct {
 	Fields []string `json:"fields"`
+	Tree   string   `json:"tree,omitempty" enums:"basic,zk"`
 }
 
-// ProofResponseHeader holds the document details.
-type ProofResponseHeader struct {
-	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
-	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
-	State      string             `json:"state"`
+// isValidProofTree returns true if the proofs can be generated from the tree.
+func isValidProofTree(tree string) bool {
+	switch tree {
+	case "", documents.BasicTree, documents.ZKTree:
+		return true
+	default:
+		return false
+	}
 }
 
+// ProofResponseHeader holds the document details.
+type ProofResponseHeader = proofwire.ProofResponseHeader
+
 // ProofsResponse holds the proofs for the fields given for a document.
-type ProofsResponse struct {
-	Header      ProofResponseHeader `json:"header"`
-	FieldProofs []documents.Proof   `json:"field_proofs"`
-}
+type ProofsResponse = proofwire.ProofsResponse
+
+// ToProofsResponse converts the document proof to the proofs response.
+func ToProofsResponse(proof *documents.DocumentProof) ProofsResponse {
+	header := ProofResponseHeader{
+		DocumentID:     proof.DocumentID,
+		VersionID:      proof.VersionID,
+		State:          proof.State,
+		SigningRoot:    proof.SigningRoot,
+		SignaturesRoot: proof.SignaturesRoot,
+		BasicDataRoot:  proof.LeftDataRooot,
+		ZKDataRoot:     proof.RightDataRoot,
+		Tree:           proof.Tree,
+	}
+
+	if proof.Anchor != nil {
+		header.DocumentRoot = proof.Anchor.DocumentRoot[:]
+		header.AnchoredAt = proof.Anchor.AnchoredAt.UTC().Format(time.RFC3339)
+		header.AnchoredBlock = proof.Anchor.BlockNumber
+	}
 
-func convertProofs(proof *documents.DocumentProof) ProofsResponse {
 	return ProofsResponse{
-		Header: ProofResponseHeader{
-			DocumentID: proof.DocumentID,
-			VersionID:  proof.VersionID,
-			State:      proof.State,
-		},
-		FieldProofs: documents.ConvertProofs(proof.FieldProofs),
+		Header:        header,
+		FieldProofs:   documents.ConvertProofs(proof.FieldProofs),
+		CircuitProofs: proof.CircuitProofs,
 	}
 }
 
+// ProofBundleSignature holds a collaborator signature on the document version along with the validity of the signing key.
+type ProofBundleSignature = proofwire.ProofBundleSignature
+
+// ProofBundle is a self-contained export of the proofs of a document version.
+type ProofBundle = proofwire.ProofBundle
+
+// ToProofBundle converts the document proof bundle to the proof bundle response.
+func ToProofBundle(bundle *documents.ProofBundle) ProofBundle {
+	resp := ProofBundle{
+		Version:    bundle.Version,
+		Proofs:     ToProofsResponse(bundle.Proof),
+		Signatures: []ProofBundleSignature{},
+	}
+
+	if bundle.Proof.Anchor != nil {
+		resp.AnchorID = bundle.Proof.Anchor.AnchorID[:]
+	}
+
+	for _, sig := range bundle.Signatures {
+		resp.Signatures = append(resp.Signatures, ProofBundleSignature{
+			SignatureID:         sig.SignatureID,
+			SignerID:            sig.SignerID[:],
+			PublicKey:           sig.PublicKey,
+			Signature:           sig.Signature,
+			TransitionValidated: sig.TransitionValidated,
+			KeyValid:            sig.KeyValid,
+			KeyRevokedAt:        sig.KeyRevokedAt,
+		})
+	}
+
+	return resp
+}
+
 // MintNFTRequest holds required fields for minting NFT
 type MintNFTRequest struct {
 	DocumentID          byteutils.HexBytes    `json:"document_id" swaggertype:"primitive,string"`
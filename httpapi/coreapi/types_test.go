@@ -54,7 +54,7 @@ func TestTypes_toAttributeMapResponse(t *testing.T) {
 	for _, v := range atts {
 		attrList = append(attrList, v)
 	}
-	cattrs, err := toAttributeMapResponse(attrList)
+	cattrs, err := ToAttributeMapResponse(attrList)
 	assert.NoError(t, err)
 	assert.Len(t, cattrs, len(attrs))
 	assert.Equal(t, cattrs["string_test"].Value, attrs["string_test"].Value)
@@ -77,7 +77,7 @@ func TestTypes_toAttributeMapResponse(t *testing.T) {
 	assert.Error(t, err)
 
 	attrList = append(attrList, documents.Attribute{Value: documents.AttrVal{Type: "invalid"}})
-	_, err = toAttributeMapResponse(attrList)
+	_, err = ToAttributeMapResponse(attrList)
 	assert.Error(t, err)
 }
 
@@ -140,10 +140,12 @@ func TestTypes_toDocumentCreatePayload(t *testing.T) {
 	request.Data = invoiceData()
 
 	// success
+	request.SignaturePolicy = "all"
 	payload, err := ToDocumentsCreatePayload(request)
 	assert.NoError(t, err)
 	assert.Equal(t, payload.Scheme, "invoice")
 	assert.NotNil(t, payload.Data)
+	assert.Equal(t, "all", payload.SignaturePolicy)
 
 	// failure
 	request.Attributes = map[string]AttributeRequest{
@@ -152,6 +154,15 @@ func TestTypes_toDocumentCreatePayload(t *testing.T) {
 
 	_, err = ToDocumentsCreatePayload(request)
 	assert.Error(t, err)
+
+	// reserved attribute
+	request.Attributes = map[string]AttributeRequest{
+		documents.SignaturePolicyLabel: {Type: "string", Value: "all"},
+	}
+
+	_, err = ToDocumentsCreatePayload(request)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrReservedAttr, err))
 }
 
 func TestTypes_convertNFTs(t *testing.T) {
@@ -1,7 +1,9 @@
 package v2
 
 import (
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/disclosure"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/pending"
@@ -20,14 +22,38 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("failed to get %s", pending.BootstrappedPendingDocumentService)
 	}
 
+	docSrv, ok := ctx[documents.BootstrappedDocumentService].(documents.Service)
+	if !ok {
+		return errors.New("failed to get %s", documents.BootstrappedDocumentService)
+	}
+
+	syncer, ok := ctx[documents.BootstrappedDocumentSyncer].(documents.Syncer)
+	if !ok {
+		return errors.New("failed to get %s", documents.BootstrappedDocumentSyncer)
+	}
+
 	nftSrv, ok := ctx[bootstrap.BootstrappedNFTService].(documents.TokenRegistry)
 	if !ok {
 		return errors.New("failed to get %s", bootstrap.BootstrappedNFTService)
 	}
 
+	anchorSrv, ok := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+	if !ok {
+		return errors.New("failed to get %s", anchors.BootstrappedAnchorService)
+	}
+
+	disclosureSrv, ok := ctx[disclosure.BootstrappedDisclosureService].(disclosure.Service)
+	if !ok {
+		return errors.New("failed to get %s", disclosure.BootstrappedDisclosureService)
+	}
+
 	ctx[BootstrappedService] = Service{
 		pendingDocSrv: pendingDocSrv,
+		docSrv:        docSrv,
+		syncer:        syncer,
 		tokenRegistry: nftSrv,
+		anchorSrv:     anchorSrv,
+		disclosureSrv: disclosureSrv,
 	}
 	return nil
 }
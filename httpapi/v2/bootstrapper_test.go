@@ -5,8 +5,13 @@ package v2
 import (
 	"testing"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/disclosure"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/pending"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingnfts "github.com/centrifuge/go-centrifuge/testingutils/nfts"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,15 +25,39 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), pending.BootstrappedPendingDocumentService)
 
-	// missing nft service
+	// missing document service
 	ctx[pending.BootstrappedPendingDocumentService] = new(pending.MockService)
 	err = b.Bootstrap(ctx)
 	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.BootstrappedDocumentService)
+
+	// missing document syncer
+	ctx[documents.BootstrappedDocumentService] = new(testingdocuments.MockService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.BootstrappedDocumentSyncer)
+
+	// missing nft service
+	ctx[documents.BootstrappedDocumentSyncer] = new(testingdocuments.MockSyncer)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
 	assert.Contains(t, err.Error(), bootstrap.BootstrappedNFTService)
 
-	// success
+	// missing anchor service
 	ctx[bootstrap.BootstrappedNFTService] = new(testingnfts.MockNFTService)
 	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), anchors.BootstrappedAnchorService)
+
+	// missing disclosure service
+	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), disclosure.BootstrappedDisclosureService)
+
+	// success
+	ctx[disclosure.BootstrappedDisclosureService] = new(disclosure.MockService)
+	err = b.Bootstrap(ctx)
 	assert.NoError(t, b.Bootstrap(ctx))
 	assert.NotNil(t, ctx[BootstrappedService])
 }
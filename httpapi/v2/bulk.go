@@ -0,0 +1,99 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/render"
+)
+
+// BulkCommitRequest defines the payload for creating and committing documents in bulk.
+type BulkCommitRequest struct {
+	Documents []CreateDocumentRequest `json:"documents"`
+}
+
+// BulkDocumentResponse holds a document committed in bulk.
+// Header contains the anchor job of the document. Error is set if the document failed to commit.
+type BulkDocumentResponse struct {
+	coreapi.DocumentResponse
+	Error string `json:"error,omitempty"`
+}
+
+// BulkCommitResponse holds the parent job of the bulk commit and the committed documents.
+type BulkCommitResponse struct {
+	JobID     string                 `json:"job_id"`
+	Documents []BulkDocumentResponse `json:"documents"`
+}
+
+// BulkCommit creates and commits documents in bulk.
+// @summary Creates and commits documents in bulk.
+// @description Creates and commits documents in bulk. Each document is anchored in its own job. The parent job succeeds once all the documents are anchored and holds the status of each document as a task.
+// @id bulk_commit_documents
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.BulkCommitRequest true "Bulk Commit request"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 202 {object} v2.BulkCommitResponse
+// @router /v2/documents/bulk [post]
+func (h handler) BulkCommit(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req BulkCommitRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	var payloads []documents.UpdatePayload
+	for i, dr := range req.Documents {
+		var payload documents.UpdatePayload
+		payload, err = toDocumentsPayload(dr.DocumentRequest, dr.DocumentID.Bytes())
+		if err != nil {
+			code = http.StatusBadRequest
+			err = errors.New("document %d: %v", i, err)
+			log.Error(err)
+			return
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	jobID, bds, err := h.srv.BulkCommit(r.Context(), payloads)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := BulkCommitResponse{JobID: jobID.String()}
+	for _, bd := range bds {
+		var dr coreapi.DocumentResponse
+		dr, err = toDocumentResponse(bd.Document, h.srv.tokenRegistry, bd.JobID)
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		br := BulkDocumentResponse{DocumentResponse: dr}
+		if bd.Error != nil {
+			br.Error = bd.Error.Error()
+		}
+
+		resp.Documents = append(resp.Documents, br)
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, resp)
+}
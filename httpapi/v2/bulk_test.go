@@ -0,0 +1,99 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func bulkPayload(t *testing.T, n int) io.Reader {
+	var docs []map[string]interface{}
+	for i := 0; i < n; i++ {
+		docs = append(docs, map[string]interface{}{
+			"scheme": "generic",
+			"data":   map[string]interface{}{},
+		})
+	}
+
+	d, err := json.Marshal(map[string]interface{}{"documents": docs})
+	assert.NoError(t, err)
+	return bytes.NewReader(d)
+}
+
+func TestHandler_BulkCommit(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/bulk", b).WithContext(ctx)
+	}
+
+	// empty body
+	ctx := context.Background()
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h.BulkCommit(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid document
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte(`{"documents": [{"scheme": "generic"}, {"document_id": "invalid"}]}`)))
+	h.BulkCommit(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// failed bulk commit
+	psrv.On("BulkCommit", ctx, mock.Anything).Return(jobs.NilJobID(), nil,
+		errors.NewTypedError(pending.ErrBulkCommitInvalid, errors.New("document 1: failed"))).Once()
+	w, r = getHTTPReqAndResp(ctx, bulkPayload(t, 2))
+	h.BulkCommit(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrBulkCommitInvalid.Error())
+
+	// success
+	var bds []pending.BulkDocument
+	for i := 0; i < 2; i++ {
+		doc := new(testingdocuments.MockModel)
+		doc.On("GetData").Return(generic.Data{}).Once()
+		doc.On("Scheme").Return("generic").Once()
+		doc.On("GetAttributes").Return(nil).Once()
+		doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+		doc.On("ID").Return(utils.RandomSlice(32)).Once()
+		doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+		doc.On("Author").Return(nil, errors.New("somerror")).Once()
+		doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+		doc.On("NFTs").Return(nil).Once()
+		doc.On("GetStatus").Return(documents.Committing).Once()
+		bds = append(bds, pending.BulkDocument{Document: doc, JobID: jobs.NewJobID()})
+	}
+
+	bds[1].JobID = jobs.NilJobID()
+	bds[1].Error = errors.New("failed to commit")
+	parentID := jobs.NewJobID()
+	psrv.On("BulkCommit", ctx, mock.Anything).Return(parentID, bds, nil).Once().Run(func(args mock.Arguments) {
+		assert.Len(t, args.Get(1).([]documents.UpdatePayload), 2)
+	})
+	w, r = getHTTPReqAndResp(ctx, bulkPayload(t, 2))
+	h.BulkCommit(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp BulkCommitResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, parentID.String(), resp.JobID)
+	assert.Len(t, resp.Documents, 2)
+	assert.Equal(t, bds[0].JobID.String(), resp.Documents[0].Header.JobID)
+	assert.Empty(t, resp.Documents[0].Error)
+	assert.Equal(t, "failed to commit", resp.Documents[1].Error)
+	psrv.AssertExpectations(t)
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func toDocumentsPayload(req DocumentRequest, docID []byte) (payload documents.UpdatePayload, err error) {
@@ -31,6 +33,23 @@ func toDocumentResponse(doc documents.Model, tokenRegistry documents.TokenRegist
 	return resp, err
 }
 
+// setAnchorDetails sets the anchor details of a committed document version to the response header.
+// Details are left out if the anchor cannot be fetched.
+func (s Service) setAnchorDetails(header *coreapi.ResponseHeader, doc documents.Model) {
+	if doc.GetStatus() != documents.Committed {
+		return
+	}
+
+	ai, err := documents.GetAnchorInfo(s.anchorSrv, doc.CurrentVersion())
+	if err != nil {
+		log.Warningf("failed to get anchor details of version %s: %v", hexutil.Encode(doc.CurrentVersion()), err)
+		return
+	}
+
+	header.AnchoredAt = ai.AnchoredAt.UTC().Format(time.RFC3339)
+	header.AnchoredBlock = ai.BlockNumber
+}
+
 // unmarshalBody unmarshals req.Body to val.
 // val should always be a pointer to the struct.
 func unmarshalBody(r *http.Request, val interface{}) error {
@@ -0,0 +1,157 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// CollaboratorParam is the key for the collaborator DID in the API path.
+const CollaboratorParam = "collaborator"
+
+// Delivery holds the delivery status of an anchored document version to a collaborator.
+type Delivery struct {
+	Collaborator identity.DID `json:"collaborator" swaggertype:"primitive,string"`
+	Status       string       `json:"status" enums:"pending,delivered,failed"`
+	Attempts     int          `json:"attempts"`
+	LastError    string       `json:"last_error,omitempty"`
+	NextAttempt  *time.Time   `json:"next_attempt,omitempty" swaggertype:"primitive,string"`
+	UpdatedAt    time.Time    `json:"updated_at" swaggertype:"primitive,string"`
+}
+
+// Deliveries holds the delivery status of an anchored document version to each collaborator.
+type Deliveries struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	Deliveries []Delivery         `json:"deliveries"`
+}
+
+func toDelivery(d *documents.Delivery) Delivery {
+	delivery := Delivery{
+		Collaborator: d.Collaborator,
+		Status:       string(d.Status),
+		Attempts:     d.Attempts,
+		LastError:    d.LastError,
+		UpdatedAt:    d.UpdatedAt,
+	}
+
+	if d.Status == documents.DeliveryPending {
+		next := d.NextAttempt
+		delivery.NextAttempt = &next
+	}
+
+	return delivery
+}
+
+func getDocumentAndVersionIDs(r *http.Request) (docID, versionID []byte, err error) {
+	docID, err = hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versionID, err = hexutil.Decode(chi.URLParam(r, coreapi.VersionIDParam))
+	return docID, versionID, err
+}
+
+// GetDocumentDeliveries returns the delivery status of the anchored document version to each collaborator.
+// @summary Returns the delivery status of the document version to the collaborators.
+// @description Returns the delivery status of the anchored document version to each collaborator. Failed deliveries are retried with exponential backoff.
+// @id get_document_deliveries
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Deliveries
+// @router /v2/documents/{document_id}/versions/{version_id}/deliveries [get]
+func (h handler) GetDocumentDeliveries(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, versionID, err := getDocumentAndVersionIDs(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	ds, err := h.srv.GetDocumentDeliveries(r.Context(), docID, versionID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	resp := Deliveries{DocumentID: docID, VersionID: versionID, Deliveries: []Delivery{}}
+	for _, d := range ds {
+		resp.Deliveries = append(resp.Deliveries, toDelivery(d))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// ResendDocument queues the anchored document version to be sent to the collaborator again.
+// @summary Resends the document version to the collaborator.
+// @description Queues the anchored document version to be sent to the collaborator again. Delivery attempts are reset.
+// @id resend_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier"
+// @param collaborator path string true "Collaborator DID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 202 {object} v2.Delivery
+// @router /v2/documents/{document_id}/versions/{version_id}/deliveries/{collaborator}/resend [post]
+func (h handler) ResendDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, versionID, err := getDocumentAndVersionIDs(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	collaborator, err := identity.NewDIDFromString(chi.URLParam(r, CollaboratorParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	d, err := h.srv.ResendDocument(r.Context(), docID, versionID, collaborator)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentVersionNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, toDelivery(d))
+}
@@ -0,0 +1,111 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDocumentDeliveries(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/deliveries", nil).WithContext(ctx)
+	}
+
+	// invalid version id
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, hexutil.Encode(docID))
+	rctx.URLParams.Add(coreapi.VersionIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	docSrv := new(testingdocuments.MockService)
+	h := handler{srv: Service{docSrv: docSrv}}
+	h.GetDocumentDeliveries(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing version
+	rctx.URLParams.Values[1] = hexutil.Encode(versionID)
+	docSrv.On("GetDeliveries", ctx, docID, versionID).Return(nil, errors.New("not found")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentDeliveries(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success
+	collab := testingidentity.GenerateRandomDID()
+	docSrv.On("GetDeliveries", ctx, docID, versionID).Return([]*documents.Delivery{{
+		Collaborator: collab,
+		Status:       documents.DeliveryPending,
+		Attempts:     2,
+		LastError:    "offline",
+		NextAttempt:  time.Now().UTC(),
+	}}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentDeliveries(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), collab.String())
+	assert.Contains(t, w.Body.String(), "\"status\":\"pending\"")
+	assert.Contains(t, w.Body.String(), "\"next_attempt\"")
+	docSrv.AssertExpectations(t)
+}
+
+func TestHandler_ResendDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/versions/{version_id}/deliveries/{collaborator}/resend", nil).WithContext(ctx)
+	}
+
+	// invalid collaborator
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, hexutil.Encode(docID))
+	rctx.URLParams.Add(coreapi.VersionIDParam, hexutil.Encode(versionID))
+	rctx.URLParams.Add(CollaboratorParam, "some invalid did")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	docSrv := new(testingdocuments.MockService)
+	h := handler{srv: Service{docSrv: docSrv}}
+	h.ResendDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing version
+	collab := testingidentity.GenerateRandomDID()
+	rctx.URLParams.Values[2] = collab.String()
+	docSrv.On("ResendDocument", ctx, docID, versionID, collab).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentVersionNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ResendDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// not a collaborator
+	docSrv.On("ResendDocument", ctx, docID, versionID, collab).Return(
+		nil, errors.NewTypedError(documents.ErrDeliveryInvalidCollaborator, errors.New("not a collaborator"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ResendDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	docSrv.On("ResendDocument", ctx, docID, versionID, collab).Return(&documents.Delivery{
+		Collaborator: collab,
+		Status:       documents.DeliveryPending,
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ResendDocument(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), collab.String())
+	docSrv.AssertExpectations(t)
+}
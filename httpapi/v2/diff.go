@@ -0,0 +1,121 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ToVersionIDParam is the key for the version compared against in the diff API path.
+const ToVersionIDParam = "to_version_id"
+
+// FieldChange holds the name, old and new value of a changed field.
+type FieldChange struct {
+	Name string `json:"name"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// ChangeSet holds the fields added, removed and modified in a document version.
+type ChangeSet struct {
+	Added    []FieldChange `json:"added"`
+	Removed  []FieldChange `json:"removed"`
+	Modified []FieldChange `json:"modified"`
+}
+
+// DocumentDiff holds the changes made in a document version compared to another version.
+type DocumentDiff struct {
+	DocumentID    byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	FromVersionID byteutils.HexBytes `json:"from_version_id" swaggertype:"primitive,string"`
+	ToVersionID   byteutils.HexBytes `json:"to_version_id" swaggertype:"primitive,string"`
+	Attributes    ChangeSet          `json:"attributes"`
+	Roles         ChangeSet          `json:"roles"`
+	Collaborators ChangeSet          `json:"collaborators"`
+	NFTs          ChangeSet          `json:"nfts"`
+	Data          ChangeSet          `json:"data"`
+}
+
+func toFieldChanges(fcs []documents.FieldChange) []FieldChange {
+	changes := make([]FieldChange, len(fcs))
+	for i, fc := range fcs {
+		changes[i] = FieldChange{Name: fc.Name, Old: fc.Old, New: fc.New}
+	}
+
+	return changes
+}
+
+func toChangeSet(cs documents.ChangeSet) ChangeSet {
+	return ChangeSet{
+		Added:    toFieldChanges(cs.Added),
+		Removed:  toFieldChanges(cs.Removed),
+		Modified: toFieldChanges(cs.Modified),
+	}
+}
+
+func toDocumentDiff(docID []byte, diff documents.Diff) DocumentDiff {
+	return DocumentDiff{
+		DocumentID:    docID,
+		FromVersionID: diff.FromVersion,
+		ToVersionID:   diff.ToVersion,
+		Attributes:    toChangeSet(diff.Attributes),
+		Roles:         toChangeSet(diff.Roles),
+		Collaborators: toChangeSet(diff.Collaborators),
+		NFTs:          toChangeSet(diff.NFTs),
+		Data:          toChangeSet(diff.Data),
+	}
+}
+
+// GetDocumentVersionsDiff returns the changes made in a document version compared to another version.
+// @summary Returns the changes between two versions of the document.
+// @description Returns the attributes, roles, collaborators, NFTs and data fields added, removed and modified in the version to_version_id compared to the version version_id.
+// @id get_document_versions_diff
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier to compare from"
+// @param to_version_id path string true "Document Version Identifier to compare to"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentDiff
+// @router /v2/documents/{document_id}/versions/{version_id}/diff/{to_version_id} [get]
+func (h handler) GetDocumentVersionsDiff(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ids := make([][]byte, 3)
+	for i, idStr := range []string{
+		chi.URLParam(r, coreapi.DocumentIDParam),
+		chi.URLParam(r, coreapi.VersionIDParam),
+		chi.URLParam(r, ToVersionIDParam)} {
+		var id []byte
+		id, err = hexutil.Decode(idStr)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+
+		ids[i] = id
+	}
+
+	diff, err := h.srv.GetDocumentVersionsDiff(r.Context(), ids[0], ids[1], ids[2])
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toDocumentDiff(ids[0], diff))
+}
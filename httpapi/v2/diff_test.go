@@ -0,0 +1,66 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDocumentVersionsDiff(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/diff/{to_version_id}", nil).WithContext(ctx)
+	}
+
+	// invalid to version id
+	docID, from, to := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, hexutil.Encode(docID))
+	rctx.URLParams.Add(coreapi.VersionIDParam, hexutil.Encode(from))
+	rctx.URLParams.Add(ToVersionIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	docSrv := new(testingdocuments.MockService)
+	h := handler{srv: Service{docSrv: docSrv}}
+	h.GetDocumentVersionsDiff(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing version
+	rctx.URLParams.Values[2] = hexutil.Encode(to)
+	docSrv.On("GetVersionsDiff", ctx, docID, from, to).Return(nil, errors.New("not found")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersionsDiff(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success
+	docSrv.On("GetVersionsDiff", ctx, docID, from, to).Return(documents.Diff{
+		FromVersion: from,
+		ToVersion:   to,
+		Attributes: documents.ChangeSet{
+			Added: []documents.FieldChange{{Name: "amount", New: "100"}},
+		},
+		Data: documents.ChangeSet{
+			Modified: []documents.FieldChange{{Name: "entity.legal_name", Old: "0x01", New: "0x02"}},
+		},
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersionsDiff(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(to))
+	assert.Contains(t, w.Body.String(), "{\"name\":\"amount\",\"new\":\"100\"}")
+	assert.Contains(t, w.Body.String(), "{\"name\":\"entity.legal_name\",\"old\":\"0x01\",\"new\":\"0x02\"}")
+	docSrv.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/disclosure"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ExportDisclosure returns the redacted document version disclosing the given fields.
+// @summary Exports a redacted document disclosing the given fields.
+// @description Returns the redacted document version with the values and proofs of the given fields, the document signatures and anchor details.
+// @description Redacted document can be imported by any node without read access to the document.
+// @id export_disclosure
+// @tags Disclosures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Document Version Identifier"
+// @param body body coreapi.ProofsRequest true "Fields to disclose"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} disclosure.RedactedDocument
+// @router /v2/documents/{document_id}/versions/{version_id}/disclose [post]
+func (h handler) ExportDisclosure(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, versionID, err := getDocumentAndVersionIDs(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	d, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var request coreapi.ProofsRequest
+	err = json.Unmarshal(d, &request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.ExportDisclosure(r.Context(), docID, versionID, request.Fields)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) || errors.IsOfType(documents.ErrDocumentVersionNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, doc)
+}
+
+// ImportDisclosure verifies and stores the redacted document disclosed by another identity.
+// @summary Imports a redacted document.
+// @description Verifies the disclosed fields against the anchored document root, the document signatures and the discloser's signature and stores the redacted document.
+// @id import_disclosure
+// @tags Disclosures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body disclosure.RedactedDocument true "Redacted document"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 201 {object} disclosure.RedactedDocument
+// @router /v2/disclosures [post]
+func (h handler) ImportDisclosure(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	d, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	var request disclosure.RedactedDocument
+	err = json.Unmarshal(d, &request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.ImportDisclosure(r.Context(), request)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, doc)
+}
+
+// GetDisclosure returns the imported redacted document version.
+// @summary Returns the imported redacted document version.
+// @description Returns the redacted document version imported by the account.
+// @id get_disclosure
+// @tags Disclosures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param version_id path string true "Document Version Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} disclosure.RedactedDocument
+// @router /v2/disclosures/{version_id} [get]
+func (h handler) GetDisclosure(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	versionID, err := hexutil.Decode(chi.URLParam(r, coreapi.VersionIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	doc, err := h.srv.GetDisclosure(r.Context(), versionID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, doc)
+}
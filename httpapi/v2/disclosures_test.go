@@ -0,0 +1,145 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/disclosure"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ExportDisclosure(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/versions/{version_id}/disclose", bytes.NewReader(body)).WithContext(ctx)
+	}
+
+	// invalid version id
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, hexutil.Encode(docID))
+	rctx.URLParams.Add(coreapi.VersionIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	srv := new(disclosure.MockService)
+	h := handler{srv: Service{disclosureSrv: srv}}
+	h.ExportDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	rctx.URLParams.Values[1] = hexutil.Encode(versionID)
+	w, r = getHTTPReqAndResp(ctx, []byte("invalid"))
+	h.ExportDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing version
+	fields := []string{"cd_tree.document_type"}
+	body, err := json.Marshal(coreapi.ProofsRequest{Fields: fields})
+	assert.NoError(t, err)
+	srv.On("Export", mock.Anything, docID, versionID, fields).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentVersionNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.ExportDisclosure(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// no fields
+	srv.On("Export", mock.Anything, docID, versionID, fields).Return(nil, disclosure.ErrNoFields).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.ExportDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), disclosure.ErrNoFields.Error())
+
+	// success
+	doc := &disclosure.RedactedDocument{
+		Scheme: "generic",
+		Fields: []disclosure.Field{{Name: fields[0], Property: utils.RandomSlice(8), Value: utils.RandomSlice(32)}},
+	}
+	srv.On("Export", mock.Anything, docID, versionID, fields).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.ExportDisclosure(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp disclosure.RedactedDocument
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, doc.Fields, resp.Fields)
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_ImportDisclosure(t *testing.T) {
+	getHTTPReqAndResp := func(body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/disclosures", bytes.NewReader(body))
+	}
+
+	// invalid body
+	srv := new(disclosure.MockService)
+	h := handler{srv: Service{disclosureSrv: srv}}
+	w, r := getHTTPReqAndResp([]byte("invalid"))
+	h.ImportDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid disclosure
+	doc := disclosure.RedactedDocument{
+		Scheme: "generic",
+		Fields: []disclosure.Field{{Name: "cd_tree.document_type", Property: utils.RandomSlice(8), Value: utils.RandomSlice(32)}},
+	}
+	body, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	srv.On("Import", mock.Anything, mock.Anything).Return(
+		nil, errors.NewTypedError(disclosure.ErrInvalidDisclosure, errors.New("document root mismatch"))).Once()
+	w, r = getHTTPReqAndResp(body)
+	h.ImportDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "document root mismatch")
+
+	// success
+	srv.On("Import", mock.Anything, mock.Anything).Return(&doc, nil).Once()
+	w, r = getHTTPReqAndResp(body)
+	h.ImportDisclosure(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "cd_tree.document_type")
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_GetDisclosure(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/disclosures/{version_id}", nil).WithContext(ctx)
+	}
+
+	// invalid version id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.VersionIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	srv := new(disclosure.MockService)
+	h := handler{srv: Service{disclosureSrv: srv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetDisclosure(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// not imported
+	versionID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(versionID)
+	srv.On("Get", mock.Anything, versionID).Return(nil, disclosure.ErrDisclosureNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDisclosure(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	srv.On("Get", mock.Anything, versionID).Return(&disclosure.RedactedDocument{Scheme: "generic"}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDisclosure(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"scheme\":\"generic\"")
+	srv.AssertExpectations(t)
+}
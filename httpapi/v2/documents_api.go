@@ -219,6 +219,8 @@ func (h handler) getDocumentWithStatus(w http.ResponseWriter, r *http.Request, s
 		return
 	}
 
+	h.srv.setAnchorDetails(&resp.Header, doc)
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -308,6 +310,8 @@ func (h handler) GetDocumentVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.srv.setAnchorDetails(&resp.Header, doc)
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
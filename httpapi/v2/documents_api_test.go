@@ -11,11 +11,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/pending"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -306,9 +308,9 @@ func TestHandler_GetDocument(t *testing.T) {
 
 	// failed conversion
 	doc := new(testingdocuments.MockModel)
-	doc.On("GetData").Return(generic.Data{}).Times(3)
-	doc.On("Scheme").Return("generic").Times(3)
-	doc.On("GetAttributes").Return(nil).Times(3)
+	doc.On("GetData").Return(generic.Data{}).Times(4)
+	doc.On("Scheme").Return("generic").Times(4)
+	doc.On("GetAttributes").Return(nil).Times(4)
 	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, errors.New("failed to get collaborators")).Once()
 	pendingSrv.On("Get", ctx, docID, mock.Anything).Return(doc, nil)
 	w, r = getHTTPReqAndResp(ctx, nil)
@@ -317,20 +319,37 @@ func TestHandler_GetDocument(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "failed to get collaborators")
 
 	// success pending
-	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Twice()
-	doc.On("ID").Return(utils.RandomSlice(32)).Twice()
-	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Twice()
-	doc.On("Author").Return(nil, errors.New("somerror")).Twice()
-	doc.On("Timestamp").Return(nil, errors.New("somerror")).Twice()
-	doc.On("NFTs").Return(nil).Twice()
+	versionID := utils.RandomSlice(32)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Times(3)
+	doc.On("ID").Return(utils.RandomSlice(32)).Times(3)
+	doc.On("CurrentVersion").Return(versionID)
+	doc.On("Author").Return(nil, errors.New("somerror")).Times(3)
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Times(3)
+	doc.On("NFTs").Return(nil).Times(3)
 	doc.On("GetStatus").Return(documents.Pending).Twice()
 	w, r = getHTTPReqAndResp(ctx, nil)
 	h.GetPendingDocument(w, r)
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "anchored_at")
+
+	// success committed
+	anchorSrv := new(testinganchors.MockAnchorService)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(anchors.RandomDocumentRoot(), nil).Once()
+	h.srv.anchorSrv = anchorSrv
+	doc.On("GetStatus").Return(documents.Committed)
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.GetCommittedDocument(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"anchored_at\"")
+
+	// committed with missing anchor
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("anchor missing")).Once()
 	w, r = getHTTPReqAndResp(ctx, nil)
 	h.GetCommittedDocument(w, r)
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "anchored_at")
 	pendingSrv.AssertExpectations(t)
+	anchorSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
 }
 
@@ -389,10 +408,11 @@ func TestHandler_GetDocumentVersion(t *testing.T) {
 	doc.On("Author").Return(nil, errors.New("somerror")).Once()
 	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
 	doc.On("NFTs").Return(nil).Once()
-	doc.On("GetStatus").Return(documents.Pending).Once()
+	doc.On("GetStatus").Return(documents.Pending).Twice()
 	w, r = getHTTPReqAndResp(ctx)
 	h.GetDocumentVersion(w, r)
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "anchored_at")
 	pendingSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
 }
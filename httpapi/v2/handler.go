@@ -18,12 +18,20 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	srv := ctx[BootstrappedService].(Service)
 	h := handler{srv: srv}
 
+	r.Get("/documents", h.ListDocuments)
 	r.Post("/documents", h.CreateDocument)
+	r.Post("/documents/bulk", h.BulkCommit)
 	r.Patch("/documents/{"+coreapi.DocumentIDParam+"}", h.UpdateDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/commit", h.Commit)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/pending", h.GetPendingDocument)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/committed", h.GetCommittedDocument)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions", h.GetDocumentVersions)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}", h.GetDocumentVersion)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/diff/{"+ToVersionIDParam+"}", h.GetDocumentVersionsDiff)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/deliveries", h.GetDocumentDeliveries)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/deliveries/{"+CollaboratorParam+"}/resend", h.ResendDocument)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/disclose", h.ExportDisclosure)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/sync/{"+CollaboratorParam+"}", h.SyncDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/signed_attribute", h.AddSignedAttribute)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/collaborators", h.RemoveCollaborators)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/roles/{"+RoleIDParam+"}", h.GetRole)
@@ -32,4 +40,11 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules", h.AddTransitionRules)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.GetTransitionRule)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.DeleteTransitionRule)
+	r.Post("/disclosures", h.ImportDisclosure)
+	r.Get("/disclosures/{"+coreapi.VersionIDParam+"}", h.GetDisclosure)
+	r.Post("/templates", h.CreateTemplate)
+	r.Get("/templates", h.GetTemplates)
+	r.Get("/templates/{"+TemplateIDParam+"}", h.GetTemplate)
+	r.Delete("/templates/{"+TemplateIDParam+"}", h.DeleteTemplate)
+	r.Post("/templates/{"+TemplateIDParam+"}/documents", h.CreateDocumentFromTemplate)
 }
@@ -0,0 +1,204 @@
+package v2
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/render"
+)
+
+const (
+	// defaultListLimit is the page size used when no limit is provided in the list request.
+	defaultListLimit = 50
+
+	// maxListLimit is the maximum page size allowed in the list request.
+	maxListLimit = 500
+)
+
+// attributeFilterParam matches the attribute filter query parameters. Ex: attribute[amount][gt]
+var attributeFilterParam = regexp.MustCompile(`^attribute\[(.+)\]\[([a-z]+)\]$`)
+
+// ErrInvalidListQuery for invalid query parameters in the list documents request.
+const ErrInvalidListQuery = errors.Error("Invalid list documents query")
+
+// ListDocumentsResponse holds a single page of the documents matched by the list query.
+// Next is the cursor of the next page and is omitted on the last page.
+type ListDocumentsResponse struct {
+	Documents []coreapi.DocumentResponse `json:"documents"`
+	Next      byteutils.HexBytes         `json:"next,omitempty" swaggertype:"primitive,string"`
+	Limit     int                        `json:"limit"`
+}
+
+// toDocumentsQuery converts the url query parameters to documents.Query.
+func toDocumentsQuery(vals url.Values) (q documents.Query, err error) {
+	q.Scheme = vals.Get("scheme")
+	q.Status = documents.Status(vals.Get("status"))
+	switch q.Status {
+	// pending documents are not part of the latest index.
+	case "", documents.Committing, documents.Committed:
+	default:
+		return q, errors.New("unsupported status %s", q.Status)
+	}
+
+	for param, did := range map[string]**identity.DID{"author": &q.Author, "collaborator": &q.Collaborator} {
+		v := vals.Get(param)
+		if v == "" {
+			continue
+		}
+
+		d, err := identity.NewDIDFromString(v)
+		if err != nil {
+			return q, errors.New("invalid %s: %v", param, err)
+		}
+
+		*did = &d
+	}
+
+	for param, tm := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		v := vals.Get(param)
+		if v == "" {
+			continue
+		}
+
+		*tm, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return q, errors.New("invalid %s: %v", param, err)
+		}
+	}
+
+	q.AttributeFilters, err = toAttributeFilters(vals)
+	if err != nil {
+		return q, err
+	}
+
+	if v := vals.Get("cursor"); v != "" {
+		q.Cursor, err = hexutil.Decode(v)
+		if err != nil {
+			return q, errors.New("invalid cursor: %v", err)
+		}
+	}
+
+	q.Limit = defaultListLimit
+	if v := vals.Get("limit"); v != "" {
+		q.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return q, errors.New("invalid limit: %s", v)
+		}
+	}
+
+	if q.Limit < 1 || q.Limit > maxListLimit {
+		return q, errors.New("limit must be between 1 and %d", maxListLimit)
+	}
+
+	return q, nil
+}
+
+// toAttributeFilters converts the attribute[<label>][<op>]=<value> query parameters to attribute filters.
+func toAttributeFilters(vals url.Values) ([]documents.AttributeFilter, error) {
+	var params []string
+	for param := range vals {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	var filters []documents.AttributeFilter
+	for _, param := range params {
+		m := attributeFilterParam.FindStringSubmatch(param)
+		if m == nil {
+			continue
+		}
+
+		key, err := documents.AttrKeyFromLabel(m[1])
+		if err != nil {
+			return nil, errors.New("invalid attribute label %s: %v", m[1], err)
+		}
+
+		op, err := documents.AttributeFilterOpFromString(m[2])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range vals[param] {
+			filters = append(filters, documents.AttributeFilter{Key: key, Op: op, Value: v})
+		}
+	}
+
+	return filters, nil
+}
+
+// ListDocuments returns the latest versions of the documents that match the query.
+// Documents can be filtered on attribute values using attribute[<label>][<op>]=<value> query parameters,
+// where op is one of eq, lt, lte, gt, gte. Ex: attribute[amount][gt]=100&attribute[due_date][lt]=2020-10-01T00:00:00Z
+// @summary Returns the latest versions of the documents that match the query.
+// @description Returns a page of the latest versions of the documents that match the query, in the order of their IDs.
+// @id list_documents
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param scheme query string false "Document scheme"
+// @param status query string false "Document status" Enums(committing, committed)
+// @param author query string false "Author of the latest version"
+// @param collaborator query string false "Read or write collaborator of the document"
+// @param from query string false "Minimum timestamp of the latest version in RFC3339"
+// @param to query string false "Maximum timestamp of the latest version in RFC3339"
+// @param attribute[label][op] query string false "Filters the attribute with label using op(eq, lt, lte, gt, gte) against the value"
+// @param cursor query string false "Cursor of the page as returned in next of the previous page"
+// @param limit query int false "Maximum number of documents to return"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.ListDocumentsResponse
+// @router /v2/documents [get]
+func (h handler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	q, err := toDocumentsQuery(r.URL.Query())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(ErrInvalidListQuery, err)
+		return
+	}
+
+	res, err := h.srv.ListDocuments(r.Context(), q)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := ListDocumentsResponse{
+		Documents: []coreapi.DocumentResponse{},
+		Next:      res.Next,
+		Limit:     q.Limit,
+	}
+
+	for _, doc := range res.Documents {
+		var d coreapi.DocumentResponse
+		d, err = toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		resp.Documents = append(resp.Documents, d)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
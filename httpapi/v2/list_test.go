@@ -0,0 +1,151 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestToDocumentsQuery(t *testing.T) {
+	// defaults
+	q, err := toDocumentsQuery(url.Values{})
+	assert.NoError(t, err)
+	assert.Equal(t, documents.Query{Limit: defaultListLimit}, q)
+
+	// invalid values
+	for k, v := range map[string]string{
+		"status":       "pending",
+		"author":       "invalid",
+		"collaborator": "0x1234",
+		"from":         "yesterday",
+		"to":           "2020-01-01",
+		"cursor":       "abc",
+		"limit":        "1000",
+	} {
+		_, err = toDocumentsQuery(url.Values{k: []string{v}})
+		assert.Error(t, err, k)
+	}
+
+	// all filters
+	author := testingidentity.GenerateRandomDID()
+	collab := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC().Truncate(time.Second)
+	q, err = toDocumentsQuery(url.Values{
+		"scheme":                []string{"generic"},
+		"status":                []string{"committed"},
+		"author":                []string{author.String()},
+		"collaborator":          []string{collab.String()},
+		"from":                  []string{tm.Format(time.RFC3339)},
+		"to":                    []string{tm.Add(time.Hour).Format(time.RFC3339)},
+		"attribute[amount][eq]": []string{"100"},
+		"cursor":                []string{"0x0102"},
+		"limit":                 []string{"5"},
+	})
+	assert.NoError(t, err)
+	key, err := documents.AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	assert.Equal(t, documents.Query{
+		Scheme:       "generic",
+		Status:       documents.Committed,
+		Author:       &author,
+		Collaborator: &collab,
+		From:         tm,
+		To:           tm.Add(time.Hour),
+		AttributeFilters: []documents.AttributeFilter{
+			{Key: key, Op: documents.FilterEq, Value: "100"},
+		},
+		Cursor: []byte{1, 2},
+		Limit:  5,
+	}, q)
+}
+
+func TestHandler_ListDocuments(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid query
+	ctx := context.Background()
+	docSrv := new(testingdocuments.MockService)
+	h := handler{srv: Service{docSrv: docSrv}}
+	w, r := getHTTPReqAndResp(ctx, "limit=abc")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidListQuery.Error())
+
+	// failed query
+	docSrv.On("Query", ctx, mock.Anything).Return(nil, errors.New("failed to query documents")).Once()
+	w, r = getHTTPReqAndResp(ctx, "scheme=generic")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to query documents")
+
+	// failed conversion
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, errors.New("failed to get collaborators")).Once()
+	docSrv.On("Query", ctx, documents.Query{Scheme: "generic", Limit: defaultListLimit}).Return(
+		documents.QueryResult{Documents: []documents.Model{doc}, Next: []byte{1, 2}}, nil)
+	w, r = getHTTPReqAndResp(ctx, "scheme=generic")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to get collaborators")
+
+	// success
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+	doc.On("ID").Return(utils.RandomSlice(32)).Once()
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+	doc.On("Author").Return(nil, errors.New("somerror")).Once()
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+	doc.On("NFTs").Return(nil).Once()
+	doc.On("GetStatus").Return(documents.Committed).Once()
+	w, r = getHTTPReqAndResp(ctx, "scheme=generic")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"status\":\"committed\"")
+	assert.Contains(t, w.Body.String(), "\"next\":\"0x0102\"")
+	docSrv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
+
+func TestToAttributeFilters(t *testing.T) {
+	// no filters
+	filters, err := toAttributeFilters(url.Values{"scheme": []string{"generic"}})
+	assert.NoError(t, err)
+	assert.Nil(t, filters)
+
+	// invalid operator
+	_, err = toAttributeFilters(url.Values{"attribute[amount][ne]": []string{"100"}})
+	assert.Error(t, err)
+
+	// success
+	filters, err = toAttributeFilters(url.Values{
+		"attribute[amount][gt]":   []string{"100"},
+		"attribute[due_date][lt]": []string{"2020-10-01T00:00:00Z"},
+	})
+	assert.NoError(t, err)
+	amount, err := documents.AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	dueDate, err := documents.AttrKeyFromLabel("due_date")
+	assert.NoError(t, err)
+	assert.Equal(t, []documents.AttributeFilter{
+		{Key: amount, Op: documents.FilterGt, Value: "100"},
+		{Key: dueDate, Op: documents.FilterLt, Value: "2020-10-01T00:00:00Z"},
+	}, filters)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/disclosure"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -13,7 +15,16 @@ import (
 // Service is the entry point for all the V2 APIs.
 type Service struct {
 	pendingDocSrv pending.Service
+	docSrv        documents.Service
+	syncer        documents.Syncer
 	tokenRegistry documents.TokenRegistry
+	anchorSrv     anchors.Service
+	disclosureSrv disclosure.Service
+}
+
+// ListDocuments returns the latest versions of the documents that match the query.
+func (s Service) ListDocuments(ctx context.Context, query documents.Query) (documents.QueryResult, error) {
+	return s.docSrv.Query(ctx, query)
 }
 
 // CreateDocument creates a pending document from the given payload.
@@ -27,6 +38,11 @@ func (s Service) UpdateDocument(ctx context.Context, req documents.UpdatePayload
 	return s.pendingDocSrv.Update(ctx, req)
 }
 
+// BulkCommit creates and commits the documents from the payloads.
+func (s Service) BulkCommit(ctx context.Context, payloads []documents.UpdatePayload) (jobs.JobID, []pending.BulkDocument, error) {
+	return s.pendingDocSrv.BulkCommit(ctx, payloads)
+}
+
 // Commit creates a document out of a pending document.
 func (s Service) Commit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error) {
 	return s.pendingDocSrv.Commit(ctx, docID)
@@ -42,6 +58,31 @@ func (s Service) GetDocumentVersion(ctx context.Context, docID, versionID []byte
 	return s.pendingDocSrv.GetVersion(ctx, docID, versionID)
 }
 
+// GetDocumentVersions returns the version history of the document.
+func (s Service) GetDocumentVersions(ctx context.Context, docID []byte) ([]documents.VersionInfo, error) {
+	return s.docSrv.GetVersions(ctx, docID)
+}
+
+// GetDocumentVersionsDiff returns the changes made in the document version `to` compared to the version `from`.
+func (s Service) GetDocumentVersionsDiff(ctx context.Context, docID, from, to []byte) (documents.Diff, error) {
+	return s.docSrv.GetVersionsDiff(ctx, docID, from, to)
+}
+
+// GetDocumentDeliveries returns the delivery status of the anchored document version to each collaborator.
+func (s Service) GetDocumentDeliveries(ctx context.Context, docID, versionID []byte) ([]*documents.Delivery, error) {
+	return s.docSrv.GetDeliveries(ctx, docID, versionID)
+}
+
+// ResendDocument queues the anchored document version to be sent to the collaborator again.
+func (s Service) ResendDocument(ctx context.Context, docID, versionID []byte, collaborator identity.DID) (*documents.Delivery, error) {
+	return s.docSrv.ResendDocument(ctx, docID, versionID, collaborator)
+}
+
+// SyncDocument pulls the versions of the document missed by the account from the collaborator.
+func (s Service) SyncDocument(ctx context.Context, docID []byte, collaborator identity.DID) ([]documents.VersionInfo, error) {
+	return s.syncer.SyncDocument(ctx, docID, collaborator)
+}
+
 // AddSignedAttribute signs the payload with acc signing key and add it the document associated with docID.
 func (s Service) AddSignedAttribute(ctx context.Context, docID []byte, label string, payload []byte, valType documents.AttributeType) (documents.Model, error) {
 	return s.pendingDocSrv.AddSignedAttribute(ctx, docID, label, payload, valType)
@@ -82,3 +123,43 @@ func (s Service) GetTransitionRule(ctx context.Context, docID, ruleID []byte) (*
 func (s Service) DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error {
 	return s.pendingDocSrv.DeleteTransitionRule(ctx, docID, ruleID)
 }
+
+// CreateTemplate validates and stores the document template.
+func (s Service) CreateTemplate(ctx context.Context, t pending.Template) (*pending.Template, error) {
+	return s.pendingDocSrv.CreateTemplate(ctx, t)
+}
+
+// GetTemplates returns the document templates of the account.
+func (s Service) GetTemplates(ctx context.Context) ([]*pending.Template, error) {
+	return s.pendingDocSrv.GetTemplates(ctx)
+}
+
+// GetTemplate returns the document template.
+func (s Service) GetTemplate(ctx context.Context, templateID []byte) (*pending.Template, error) {
+	return s.pendingDocSrv.GetTemplate(ctx, templateID)
+}
+
+// DeleteTemplate deletes the document template.
+func (s Service) DeleteTemplate(ctx context.Context, templateID []byte) error {
+	return s.pendingDocSrv.DeleteTemplate(ctx, templateID)
+}
+
+// CreateDocumentFromTemplate creates a pending document from the template.
+func (s Service) CreateDocumentFromTemplate(ctx context.Context, templateID []byte, payload documents.CreatePayload) (documents.Model, error) {
+	return s.pendingDocSrv.CreateFromTemplate(ctx, templateID, payload)
+}
+
+// ExportDisclosure returns the redacted document version disclosing the fields.
+func (s Service) ExportDisclosure(ctx context.Context, docID, versionID []byte, fields []string) (*disclosure.RedactedDocument, error) {
+	return s.disclosureSrv.Export(ctx, docID, versionID, fields)
+}
+
+// ImportDisclosure verifies and stores the redacted document.
+func (s Service) ImportDisclosure(ctx context.Context, doc disclosure.RedactedDocument) (*disclosure.RedactedDocument, error) {
+	return s.disclosureSrv.Import(ctx, doc)
+}
+
+// GetDisclosure returns the imported redacted document version.
+func (s Service) GetDisclosure(ctx context.Context, versionID []byte) (*disclosure.RedactedDocument, error) {
+	return s.disclosureSrv.Get(ctx, versionID)
+}
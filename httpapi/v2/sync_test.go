@@ -0,0 +1,78 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_SyncDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/sync/{collaborator}", nil).WithContext(ctx)
+	}
+
+	// invalid document id
+	docID := utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, "some invalid id")
+	rctx.URLParams.Add(CollaboratorParam, "some invalid did")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	syncer := new(testingdocuments.MockSyncer)
+	h := handler{srv: Service{syncer: syncer}}
+	h.SyncDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid collaborator
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.SyncDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// unknown document
+	collab := testingidentity.GenerateRandomDID()
+	rctx.URLParams.Values[1] = collab.String()
+	syncer.On("SyncDocument", ctx, docID, collab).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.SyncDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// sync failed
+	syncer.On("SyncDocument", ctx, docID, collab).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentSync, errors.New("offline"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.SyncDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrDocumentSync.Error())
+
+	// success
+	versionID := utils.RandomSlice(32)
+	syncer.On("SyncDocument", ctx, docID, collab).Return([]documents.VersionInfo{{
+		VersionID:       versionID,
+		PreviousVersion: docID,
+		Author:          &collab,
+		Status:          documents.Committed,
+	}}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.SyncDocument(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(versionID))
+	assert.Contains(t, w.Body.String(), collab.String())
+	syncer.AssertExpectations(t)
+}
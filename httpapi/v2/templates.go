@@ -0,0 +1,371 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// TemplateIDParam is the key for the template ID in the API path.
+const TemplateIDParam = "template_id"
+
+// ErrInvalidTemplateID for invalid template ID in the api path.
+const ErrInvalidTemplateID = errors.Error("Invalid Template ID")
+
+// TemplateRequest defines the payload for creating a document template.
+type TemplateRequest struct {
+	Name        string                      `json:"name"`
+	Scheme      string                      `json:"scheme" enums:"generic,entity"`
+	ReadAccess  []identity.DID              `json:"read_access" swaggertype:"array,string"`
+	WriteAccess []identity.DID              `json:"write_access" swaggertype:"array,string"`
+	Attributes  coreapi.AttributeMapRequest `json:"attributes"`
+	Roles       []AddRole                   `json:"roles"`
+	Rules       []pending.AttributeRule     `json:"rules"`
+	ReadRules   []pending.ReadRule          `json:"read_rules"`
+}
+
+// TemplateResponse holds a document template.
+type TemplateResponse struct {
+	ID          byteutils.HexBytes           `json:"id" swaggertype:"primitive,string"`
+	Name        string                       `json:"name"`
+	Scheme      string                       `json:"scheme" enums:"generic,entity"`
+	ReadAccess  []identity.DID               `json:"read_access" swaggertype:"array,string"`
+	WriteAccess []identity.DID               `json:"write_access" swaggertype:"array,string"`
+	Attributes  coreapi.AttributeMapResponse `json:"attributes"`
+	Roles       []AddRole                    `json:"roles"`
+	Rules       []pending.AttributeRule      `json:"rules"`
+	ReadRules   []pending.ReadRule           `json:"read_rules"`
+	CreatedAt   time.Time                    `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// TemplatesResponse holds the document templates of the account.
+type TemplatesResponse struct {
+	Templates []TemplateResponse `json:"templates"`
+}
+
+// CreateFromTemplateRequest defines the payload for creating a document from a template.
+// Attributes and collaborators are applied over the template defaults.
+// Scheme is optional and must match the scheme of the template if given.
+type CreateFromTemplateRequest struct {
+	Scheme          string                      `json:"scheme" enums:"generic,entity"`
+	ReadAccess      []identity.DID              `json:"read_access" swaggertype:"array,string"`
+	WriteAccess     []identity.DID              `json:"write_access" swaggertype:"array,string"`
+	Data            interface{}                 `json:"data"`
+	Attributes      coreapi.AttributeMapRequest `json:"attributes"`
+	SignaturePolicy string                      `json:"signature_policy"`
+}
+
+func toTemplate(req TemplateRequest) (pending.Template, error) {
+	cp, err := coreapi.ToDocumentsCreatePayload(coreapi.CreateDocumentRequest{
+		Scheme:      req.Scheme,
+		ReadAccess:  req.ReadAccess,
+		WriteAccess: req.WriteAccess,
+		Attributes:  req.Attributes,
+	})
+	if err != nil {
+		return pending.Template{}, err
+	}
+
+	t := pending.Template{
+		Name:          req.Name,
+		Scheme:        cp.Scheme,
+		Collaborators: cp.Collaborators,
+		Rules:         req.Rules,
+		ReadRules:     req.ReadRules,
+	}
+
+	for _, attr := range cp.Attributes {
+		t.Attributes = append(t.Attributes, attr)
+	}
+
+	for _, r := range req.Roles {
+		t.Roles = append(t.Roles, pending.TemplateRole{Key: r.Key, Collaborators: r.Collaborators})
+	}
+
+	return t, nil
+}
+
+func toTemplateResponse(t *pending.Template) (TemplateResponse, error) {
+	attrs, err := coreapi.ToAttributeMapResponse(t.Attributes)
+	if err != nil {
+		return TemplateResponse{}, err
+	}
+
+	resp := TemplateResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Scheme:      t.Scheme,
+		ReadAccess:  t.Collaborators.ReadCollaborators,
+		WriteAccess: t.Collaborators.ReadWriteCollaborators,
+		Attributes:  attrs,
+		Roles:       []AddRole{},
+		Rules:       append([]pending.AttributeRule{}, t.Rules...),
+		ReadRules:   append([]pending.ReadRule{}, t.ReadRules...),
+		CreatedAt:   t.CreatedAt,
+	}
+
+	for _, r := range t.Roles {
+		resp.Roles = append(resp.Roles, AddRole{Key: r.Key, Collaborators: r.Collaborators})
+	}
+
+	return resp, nil
+}
+
+// CreateTemplate creates a document template.
+// @summary Creates a new document template.
+// @description Creates a new document template with the scheme, default attributes, collaborators, roles, transition rules and read rules. Rules refer to the template roles with the 32 byte role ID.
+// @id create_template
+// @tags Templates
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.TemplateRequest true "Template Create request"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 201 {object} v2.TemplateResponse
+// @router /v2/templates [post]
+func (h handler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req TemplateRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	t, err := toTemplate(req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	nt, err := h.srv.CreateTemplate(r.Context(), t)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := toTemplateResponse(nt)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+// GetTemplates returns the document templates of the account.
+// @summary Returns the document templates of the account.
+// @description Returns the document templates of the account.
+// @id get_templates
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.TemplatesResponse
+// @router /v2/templates [get]
+func (h handler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ts, err := h.srv.GetTemplates(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := TemplatesResponse{Templates: []TemplateResponse{}}
+	for _, t := range ts {
+		var tr TemplateResponse
+		tr, err = toTemplateResponse(t)
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		resp.Templates = append(resp.Templates, tr)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// GetTemplate returns the document template.
+// @summary Returns the document template.
+// @description Returns the document template.
+// @id get_template
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template_id path string true "Template Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.TemplateResponse
+// @router /v2/templates/{template_id} [get]
+func (h handler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	templateID, err := hexutil.Decode(chi.URLParam(r, TemplateIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidTemplateID
+		return
+	}
+
+	t, err := h.srv.GetTemplate(r.Context(), templateID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = pending.ErrTemplateNotFound
+		return
+	}
+
+	resp, err := toTemplateResponse(t)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// DeleteTemplate deletes the document template.
+// @summary Deletes the document template.
+// @description Deletes the document template. Documents created from the template are not affected.
+// @id delete_template
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template_id path string true "Template Identifier"
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 204
+// @router /v2/templates/{template_id} [delete]
+func (h handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	templateID, err := hexutil.Decode(chi.URLParam(r, TemplateIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidTemplateID
+		return
+	}
+
+	err = h.srv.DeleteTemplate(r.Context(), templateID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(pending.ErrTemplateNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// CreateDocumentFromTemplate creates a pending document from the template.
+// @summary Creates a new pending document from the template.
+// @description Creates a new pending document with the scheme, attributes, collaborators, roles, transition rules and read rules of the template. Attributes and collaborators in the request are applied over the template defaults.
+// @id create_document_from_template
+// @tags Templates
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template_id path string true "Template Identifier"
+// @param body body v2.CreateFromTemplateRequest true "Create from Template request"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 201 {object} coreapi.DocumentResponse
+// @router /v2/templates/{template_id}/documents [post]
+func (h handler) CreateDocumentFromTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	templateID, err := hexutil.Decode(chi.URLParam(r, TemplateIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidTemplateID
+		return
+	}
+
+	var req CreateFromTemplateRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	payload, err := coreapi.ToDocumentsCreatePayload(coreapi.CreateDocumentRequest{
+		Scheme:          req.Scheme,
+		ReadAccess:      req.ReadAccess,
+		WriteAccess:     req.WriteAccess,
+		Data:            req.Data,
+		Attributes:      req.Attributes,
+		SignaturePolicy: req.SignaturePolicy,
+	})
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.CreateDocumentFromTemplate(r.Context(), templateID, payload)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(pending.ErrTemplateNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,279 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func templateRoleID(t *testing.T) []byte {
+	rk, err := documents.RoleKeyFromString("role")
+	assert.NoError(t, err)
+	return rk
+}
+
+func templatePayload(t *testing.T, collab identity.DID) io.Reader {
+	d, err := json.Marshal(map[string]interface{}{
+		"name":   "invoice",
+		"scheme": "generic",
+		"attributes": map[string]map[string]string{
+			"test": {
+				"type":  "string",
+				"value": "value",
+			},
+		},
+		"roles": []map[string]interface{}{
+			{
+				"key":           "role",
+				"collaborators": []string{collab.String()},
+			},
+		},
+		"rules": []map[string]string{
+			{
+				"key_label": "test",
+				"role_id":   hexutil.Encode(templateRoleID(t)),
+			},
+		},
+		"read_rules": []map[string]string{
+			{
+				"role_id": hexutil.Encode(templateRoleID(t)),
+				"action":  "read",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	return bytes.NewReader(d)
+}
+
+func TestHandler_CreateTemplate(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/templates", b).WithContext(ctx)
+	}
+
+	// empty body
+	ctx := context.Background()
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid attributes
+	w, r = getHTTPReqAndResp(ctx, invalidAttrPayload(t))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "not a valid attribute type")
+
+	// invalid template
+	collab := testingidentity.GenerateRandomDID()
+	psrv.On("CreateTemplate", ctx, mock.Anything).Return(nil, pending.ErrTemplateInvalid).Once()
+	w, r = getHTTPReqAndResp(ctx, templatePayload(t, collab))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrTemplateInvalid.Error())
+
+	// success
+	id := utils.RandomSlice(32)
+	psrv.On("CreateTemplate", ctx, mock.Anything).Return(&pending.Template{
+		ID:        id,
+		Name:      "invoice",
+		Scheme:    "generic",
+		Roles:     []pending.TemplateRole{{Key: "role", Collaborators: []identity.DID{collab}}},
+		Rules:     []pending.AttributeRule{{KeyLabel: "test", RoleID: templateRoleID(t)}},
+		ReadRules: []pending.ReadRule{{RoleID: templateRoleID(t), Action: "read"}},
+		CreatedAt: time.Now().UTC(),
+	}, nil).Once().Run(func(args mock.Arguments) {
+		tmpl := args.Get(1).(pending.Template)
+		assert.Equal(t, "invoice", tmpl.Name)
+		assert.Equal(t, "generic", tmpl.Scheme)
+		assert.Len(t, tmpl.Attributes, 1)
+		assert.Equal(t, []pending.TemplateRole{{Key: "role", Collaborators: []identity.DID{collab}}}, tmpl.Roles)
+		assert.Equal(t, []pending.AttributeRule{{KeyLabel: "test", RoleID: templateRoleID(t)}}, tmpl.Rules)
+		assert.Equal(t, []pending.ReadRule{{RoleID: templateRoleID(t), Action: "read"}}, tmpl.ReadRules)
+	})
+	w, r = getHTTPReqAndResp(ctx, templatePayload(t, collab))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(id))
+	assert.Contains(t, w.Body.String(), "\"read_rules\":[{\"role_id\":\""+hexutil.Encode(templateRoleID(t)))
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_GetTemplates(t *testing.T) {
+	ctx := context.Background()
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/templates", nil).WithContext(ctx)
+	}
+
+	// failed
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	psrv.On("GetTemplates", ctx).Return(nil, errors.New("failed to get templates")).Once()
+	w, r := getHTTPReqAndResp()
+	h.GetTemplates(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	id := utils.RandomSlice(32)
+	psrv.On("GetTemplates", ctx).Return([]*pending.Template{{ID: id, Name: "invoice"}}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetTemplates(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp TemplatesResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Templates, 1)
+	assert.Equal(t, id, resp.Templates[0].ID.Bytes())
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_GetTemplate(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/templates/{template_id}", nil).WithContext(ctx)
+	}
+
+	// invalid template id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(TemplateIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidTemplateID.Error())
+
+	// missing template
+	id := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	psrv.On("GetTemplate", ctx, id).Return(nil, errors.New("missing")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetTemplate(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrTemplateNotFound.Error())
+
+	// success
+	psrv.On("GetTemplate", ctx, id).Return(&pending.Template{ID: id, Name: "invoice"}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetTemplate(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "invoice")
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_DeleteTemplate(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("DELETE", "/templates/{template_id}", nil).WithContext(ctx)
+	}
+
+	// invalid template id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(TemplateIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.DeleteTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidTemplateID.Error())
+
+	// missing template
+	id := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	psrv.On("DeleteTemplate", ctx, id).Return(errors.NewTypedError(pending.ErrTemplateNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeleteTemplate(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	psrv.On("DeleteTemplate", ctx, id).Return(nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeleteTemplate(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_CreateDocumentFromTemplate(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/templates/{template_id}/documents", b).WithContext(ctx)
+	}
+
+	// invalid template id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(TemplateIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidTemplateID.Error())
+
+	// empty body
+	id := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid attributes
+	w, r = getHTTPReqAndResp(ctx, invalidAttrPayload(t))
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "not a valid attribute type")
+
+	// missing template
+	psrv.On("CreateFromTemplate", ctx, id, mock.Anything).Return(
+		nil, errors.NewTypedError(pending.ErrTemplateNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte(`{"data": {}}`)))
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// scheme mismatch
+	psrv.On("CreateFromTemplate", ctx, id, mock.MatchedBy(func(p documents.CreatePayload) bool {
+		return p.Scheme == "entity"
+	})).Return(nil, errors.NewTypedError(pending.ErrTemplateSchemeMismatch, errors.New("mismatch"))).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte(`{"scheme": "entity", "data": {}}`)))
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrTemplateSchemeMismatch.Error())
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{}).Once()
+	doc.On("Scheme").Return("generic").Once()
+	doc.On("GetAttributes").Return(nil).Once()
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+	doc.On("ID").Return(utils.RandomSlice(32)).Once()
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+	doc.On("Author").Return(nil, errors.New("somerror")).Once()
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+	doc.On("NFTs").Return(nil).Once()
+	doc.On("GetStatus").Return(documents.Pending).Once()
+	psrv.On("CreateFromTemplate", ctx, id, mock.Anything).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte(`{"data": {}}`)))
+	h.CreateDocumentFromTemplate(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "\"status\":\"pending\"")
+	psrv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
@@ -0,0 +1,103 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// AnchorInfo holds the anchor details of a document version.
+type AnchorInfo struct {
+	AnchorID     byteutils.HexBytes `json:"anchor_id" swaggertype:"primitive,string"`
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	AnchoredAt   time.Time          `json:"anchored_at" swaggertype:"primitive,string"`
+	BlockNumber  uint32             `json:"anchored_block"`
+}
+
+// DocumentVersion holds the details of a single document version.
+type DocumentVersion struct {
+	VersionID         byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	PreviousVersionID byteutils.HexBytes `json:"previous_version_id" swaggertype:"primitive,string"`
+	Author            *identity.DID      `json:"author,omitempty" swaggertype:"primitive,string"`
+	Timestamp         *time.Time         `json:"timestamp,omitempty" swaggertype:"primitive,string"`
+	Status            string             `json:"status"`
+	Anchor            *AnchorInfo        `json:"anchor,omitempty"`
+}
+
+// DocumentVersions holds the version history of a document, oldest version first.
+type DocumentVersions struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	Versions   []DocumentVersion  `json:"versions"`
+}
+
+func toDocumentVersions(docID []byte, vis []documents.VersionInfo) DocumentVersions {
+	dvs := DocumentVersions{DocumentID: docID, Versions: []DocumentVersion{}}
+	for _, vi := range vis {
+		dv := DocumentVersion{
+			VersionID:         vi.VersionID,
+			PreviousVersionID: vi.PreviousVersion,
+			Author:            vi.Author,
+			Timestamp:         vi.Timestamp,
+			Status:            string(vi.Status),
+		}
+
+		if vi.Anchor != nil {
+			dv.Anchor = &AnchorInfo{
+				AnchorID:     vi.Anchor.AnchorID[:],
+				DocumentRoot: vi.Anchor.DocumentRoot[:],
+				AnchoredAt:   vi.Anchor.AnchoredAt,
+				BlockNumber:  vi.Anchor.BlockNumber,
+			}
+		}
+
+		dvs.Versions = append(dvs.Versions, dv)
+	}
+
+	return dvs
+}
+
+// GetDocumentVersions returns the version history of the document.
+// @summary Returns the version history of the document.
+// @description Returns the version history of the document, oldest version first, with author, timestamp, status and anchor details of each version.
+// @id get_document_versions
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentVersions
+// @router /v2/documents/{document_id}/versions [get]
+func (h handler) GetDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	vis, err := h.srv.GetDocumentVersions(r.Context(), docID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toDocumentVersions(docID, vis))
+}
@@ -0,0 +1,75 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDocumentVersions(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(coreapi.DocumentIDParam, "some invalid id")
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	docSrv := new(testingdocuments.MockService)
+	h := handler{srv: Service{docSrv: docSrv}}
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	docSrv.On("GetVersions", ctx, docID).Return(nil, errors.New("not found")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success
+	author := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+	v2 := utils.RandomSlice(32)
+	docSrv.On("GetVersions", ctx, docID).Return([]documents.VersionInfo{
+		{
+			VersionID: docID,
+			Author:    &author,
+			Timestamp: &tm,
+			Status:    documents.Committed,
+			Anchor:    &documents.AnchorInfo{DocumentRoot: anchors.RandomDocumentRoot(), AnchoredAt: tm},
+		},
+		{
+			VersionID:       v2,
+			PreviousVersion: docID,
+			Status:          documents.Committing,
+		},
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(v2))
+	assert.Contains(t, w.Body.String(), author.String())
+	assert.Contains(t, w.Body.String(), "\"status\":\"committing\"")
+	assert.Contains(t, w.Body.String(), "document_root")
+	docSrv.AssertExpectations(t)
+}
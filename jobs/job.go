@@ -164,6 +164,10 @@ type StatusResponse struct {
 	Status      string    `json:"status"`
 	Message     string    `json:"message"`
 	LastUpdated time.Time `json:"last_updated" swaggertype:"primitive,string"`
+
+	// Tasks holds the status of the individual tasks of the job.
+	// For a bulk commit job, each task is a document ID with the status of its anchor job.
+	Tasks map[string]Status `json:"tasks,omitempty" swaggertype:"object"`
 }
 
 // Config is the config interface for jobs package
@@ -178,6 +182,7 @@ type Manager interface {
 	GetJob(accountID identity.DID, id JobID) (*Job, error)
 	UpdateJobWithValue(accountID identity.DID, id JobID, key string, value []byte) error
 	UpdateTaskStatus(accountID identity.DID, id JobID, status Status, taskName, message string) error
+	UpdateJobStatus(accountID identity.DID, id JobID, status Status, message string) error
 	GetJobStatus(accountID identity.DID, id JobID) (StatusResponse, error)
 	WaitForJob(accountID identity.DID, txID JobID) error
 	GetDefaultTaskTimeout() time.Duration
@@ -53,6 +53,19 @@ func (s *manager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID, status
 	return s.saveJob(tx)
 }
 
+// UpdateJobStatus updates the status of the job and logs the message.
+// This is used to complete an existing job whose work is resumed outside of the original ExecuteWithinJob.
+func (s *manager) UpdateJobStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, message string) error {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	job.Status = status
+	job.Logs = append(job.Logs, jobs.NewLog(managerLogPrefix, message))
+	return s.saveJob(job)
+}
+
 // ExecuteWithinJob executes a task within a Job.
 func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
 	job, err := s.repo.Get(accountID, existingJobID)
@@ -209,5 +222,6 @@ func (s *manager) GetJobStatus(accountID identity.DID, id jobs.JobID) (resp jobs
 		Status:      string(job.Status),
 		Message:     msg,
 		LastUpdated: lastUpdated,
+		Tasks:       job.TaskStatus,
 	}, nil
 }
@@ -125,6 +125,7 @@ func TestService_GetTransaction(t *testing.T) {
 	log := jobs.NewLog("action", "some message")
 	job.Logs = append(job.Logs, log)
 	job.Status = jobs.Success
+	job.TaskStatus["task"] = jobs.Success
 	assert.Nil(t, repo.Save(job))
 
 	// log with message
@@ -135,6 +136,7 @@ func TestService_GetTransaction(t *testing.T) {
 	assert.Equal(t, string(jobs.Success), jobStatus.Status)
 	assert.Equal(t, log.Message, jobStatus.Message)
 	assert.Equal(t, log.CreatedAt, jobStatus.LastUpdated)
+	assert.Equal(t, map[string]jobs.Status{"task": jobs.Success}, jobStatus.Tasks)
 }
 
 func TestService_CreateTransaction(t *testing.T) {
@@ -165,3 +167,20 @@ func TestService_WaitForTransaction(t *testing.T) {
 	assert.NoError(t, repo.Save(job))
 	assert.NoError(t, srv.WaitForJob(did, job.ID))
 }
+
+func TestService_UpdateJobStatus(t *testing.T) {
+	srv := ctx[jobs.BootstrappedService].(extendedManager)
+	did := testingidentity.GenerateRandomDID()
+
+	// missing job
+	err := srv.UpdateJobStatus(did, jobs.NewJobID(), jobs.Success, "done")
+	assert.Error(t, err)
+
+	job, err := srv.createJob(did, "test")
+	assert.NoError(t, err)
+	assert.NoError(t, srv.UpdateJobStatus(did, job.ID, jobs.Success, "done"))
+	job, err = srv.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+	assert.Equal(t, "done", job.Logs[len(job.Logs)-1].Message)
+}
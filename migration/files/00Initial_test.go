@@ -5,8 +5,9 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
+	ldb "github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestInitial00(t *testing.T) {
@@ -16,8 +17,8 @@ func TestInitial00(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := ldb.OpenFile(targetDir, nil)
 	assert.NoError(t, err)
 
-	assert.NoError(t, Initial00(db))
+	assert.NoError(t, Initial00(leveldb.NewKeyValueStore(db), leveldb.NewLevelDBRepository(db)))
 }
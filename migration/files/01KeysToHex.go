@@ -4,34 +4,29 @@ import (
 	"encoding/hex"
 	"regexp"
 
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // KeysToHex01 Converts all keys to hex
-func KeysToHex01(db *leveldb.DB) error {
-	iter := db.NewIterator(util.BytesPrefix([]byte{}), nil)
-	for iter.Next() {
-		key := iter.Key()
-		data := iter.Value()
+func KeysToHex01(db storage.KeyValueStore, repo storage.Repository) error {
+	err := db.Iterate([]byte{}, func(key, data []byte) error {
 		// Do nothing if key is already hex
 		if isHexKey(key) || isKnownPlainTextKey(key) {
-			continue
+			return nil
 		}
-		err := db.Put([]byte(hexutil.Encode(key)), data, nil)
-		if err != nil {
-			return err
-		}
-		err = db.Delete(key, nil)
+		err := db.Put([]byte(hexutil.Encode(key)), data)
 		if err != nil {
 			return err
 		}
+		return db.Delete(key)
+	})
+	if err != nil {
+		return err
 	}
-	iter.Release()
 
 	log.Infof("01KeysToHex Migration Run successfully")
-	return iter.Error()
+	return nil
 }
 
 func isHexKey(key []byte) bool {
@@ -6,9 +6,10 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
+	ldb "github.com/syndtr/goleveldb/leveldb"
 )
 
 type content struct {
@@ -44,7 +45,7 @@ func TestKeysToHex01(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := ldb.OpenFile(targetDir, nil)
 	assert.NoError(t, err)
 
 	var keys [][]byte
@@ -72,7 +73,7 @@ func TestKeysToHex01(t *testing.T) {
 	err = db.Put([]byte("account-123143"), data, nil)
 	assert.NoError(t, err)
 
-	err = KeysToHex01(db)
+	err = KeysToHex01(leveldb.NewKeyValueStore(db), leveldb.NewLevelDBRepository(db))
 	assert.NoError(t, err)
 
 	for i := 0; i < 5; i++ {
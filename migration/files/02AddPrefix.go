@@ -4,8 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // value is an internal representation of how levelDb stores the model.
@@ -15,14 +14,11 @@ type value struct {
 }
 
 // AddPrefix02 Adds db prefix to documents and jobs
-func AddPrefix02(db *leveldb.DB) error {
-	iter := db.NewIterator(util.BytesPrefix([]byte{}), nil)
-	for iter.Next() {
-		key := iter.Key()
-		data := iter.Value()
+func AddPrefix02(db storage.KeyValueStore, repo storage.Repository) error {
+	err := db.Iterate([]byte{}, func(key, data []byte) error {
 		// Do nothing if entry type is prefixed already
 		if isKnownPlainTextKey(key) {
-			continue
+			return nil
 		}
 		v := new(value)
 		err := json.Unmarshal(data, v)
@@ -33,18 +29,12 @@ func AddPrefix02(db *leveldb.DB) error {
 		if strings.Contains(v.Type, "jobs.Job") {
 			prefix = []byte("job_")
 		}
-		err = db.Put(append(prefix, key...), data, nil)
+		err = db.Put(append(prefix, key...), data)
 		if err != nil {
 			return err
 		}
-		err = db.Delete(key, nil)
-		if err != nil {
-			return err
-		}
-	}
-	iter.Release()
-
-	err := iter.Error()
+		return db.Delete(key)
+	})
 	if err != nil {
 		return err
 	}
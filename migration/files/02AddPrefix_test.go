@@ -6,9 +6,10 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
+	ldb "github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestAddPrefix02(t *testing.T) {
@@ -18,7 +19,7 @@ func TestAddPrefix02(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := ldb.OpenFile(targetDir, nil)
 	assert.NoError(t, err)
 
 	var keys [][]byte
@@ -50,7 +51,7 @@ func TestAddPrefix02(t *testing.T) {
 	err = db.Put([]byte("account-123143"), data, nil)
 	assert.NoError(t, err)
 
-	err = AddPrefix02(db)
+	err = AddPrefix02(leveldb.NewKeyValueStore(db), leveldb.NewLevelDBRepository(db))
 	assert.NoError(t, err)
 
 	for i := 0; i < 5; i++ {
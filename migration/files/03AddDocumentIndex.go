@@ -8,29 +8,24 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	ldb "github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // AddDocumentIndex03 adds index to the document for efficient fetching.
-func AddDocumentIndex03(db *ldb.DB) error {
-	strRepo := leveldb.NewLevelDBRepository(db)
+func AddDocumentIndex03(db storage.KeyValueStore, strRepo storage.Repository) error {
 	repo := documents.NewDBRepository(strRepo)
 	repo.Register(new(entityrelationship.EntityRelationship))
 	repo.Register(new(entity.Entity))
 	repo.Register(new(generic.Generic))
-	iter := db.NewIterator(util.BytesPrefix([]byte("document_")), nil)
 	var c, e int
-	for iter.Next() {
+	err := db.Iterate([]byte("document_"), func(key, _ []byte) error {
 		c++
-		key := iter.Key()
 		acc, id, err := getAccountAndID(key)
 		if err != nil {
 			e++
 			// must have been an older document. skipping
-			continue
+			return nil
 		}
 		m, err := strRepo.Get(key)
 		if err != nil {
@@ -42,19 +37,13 @@ func AddDocumentIndex03(db *ldb.DB) error {
 			return documents.ErrDocumentInvalidType
 		}
 
-		err = repo.Update(acc, id, mm)
-		if err != nil {
-			return err
-		}
-
-	}
-	log.Infof("Updated index for %d documents\n", c-e)
-	iter.Release()
-	err := iter.Error()
+		return repo.Update(acc, id, mm)
+	})
 	if err != nil {
 		return err
 	}
 
+	log.Infof("Updated index for %d documents\n", c-e)
 	log.Infof("AddDocumentIndex03 Migration Run successfully")
 	return nil
 }
@@ -5,28 +5,23 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
-	ldb "github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // AddStatusToDocuments04 adds status to committed.
-func AddStatusToDocuments04(db *ldb.DB) error {
-	strRepo := leveldb.NewLevelDBRepository(db)
+func AddStatusToDocuments04(db storage.KeyValueStore, strRepo storage.Repository) error {
 	repo := documents.NewDBRepository(strRepo)
 	repo.Register(new(entityrelationship.EntityRelationship))
 	repo.Register(new(entity.Entity))
 	repo.Register(new(generic.Generic))
-	iter := db.NewIterator(util.BytesPrefix([]byte("document_")), nil)
 	var c, e int
-	for iter.Next() {
+	err := db.Iterate([]byte("document_"), func(key, _ []byte) error {
 		c++
-		key := iter.Key()
 		m, err := strRepo.Get(key)
 		if err != nil {
 			// model fetch failed, skip
 			e++
-			continue
+			return nil
 		}
 
 		mm, ok := m.(documents.Model)
@@ -39,20 +34,13 @@ func AddStatusToDocuments04(db *ldb.DB) error {
 			return err
 		}
 
-		err = strRepo.Update(key, mm)
-		if err != nil {
-			return err
-		}
-
-	}
-
-	log.Infof("Updated status for %d documents\n", c-e)
-	iter.Release()
-	err := iter.Error()
+		return strRepo.Update(key, mm)
+	})
 	if err != nil {
 		return err
 	}
 
+	log.Infof("Updated status for %d documents\n", c-e)
 	log.Infof("AddStatusToDocuments04 Migration Run successfully")
 	return nil
 }
@@ -33,7 +33,7 @@ func TestAddStatusToDocuments04(t *testing.T) {
 	g := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
 	assert.Equal(t, g.GetStatus(), documents.Pending)
 	assert.NoError(t, repo.Create(did[:], g.CurrentVersion(), g))
-	assert.NoError(t, AddStatusToDocuments04(db))
+	assert.NoError(t, AddStatusToDocuments04(leveldb.NewKeyValueStore(db), strRepo))
 	m, err := repo.Get(did[:], g.CurrentVersion())
 	assert.NoError(t, err)
 	g, ok := m.(*generic.Generic)
@@ -0,0 +1,62 @@
+package migrationfiles
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/entity"
+	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AddAttributeIndex05 indexes the attributes of the latest version of every document.
+func AddAttributeIndex05(db storage.KeyValueStore, strRepo storage.Repository) error {
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(entityrelationship.EntityRelationship))
+	repo.Register(new(entity.Entity))
+	repo.Register(new(generic.Generic))
+	attrIndex := documents.NewAttributeIndex(strRepo)
+	var c, e int
+	err := db.Iterate([]byte(documents.LatestPrefix), func(key, _ []byte) error {
+		c++
+		acc, id, err := getAccountAndIDFromLatestKey(key)
+		if err != nil {
+			e++
+			return nil
+		}
+
+		m, err := repo.GetLatest(acc, id)
+		if err != nil {
+			// latest version missing, skip
+			e++
+			return nil
+		}
+
+		return attrIndex.Index(acc, m)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Indexed attributes of %d documents\n", c-e)
+	log.Infof("AddAttributeIndex05 Migration Run successfully")
+	return nil
+}
+
+func getAccountAndIDFromLatestKey(key []byte) (acc, id []byte, err error) {
+	str := strings.TrimSpace(strings.TrimPrefix(string(key), documents.LatestPrefix))
+	d, err := hexutil.Decode(str)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(d) != 52 {
+		return nil, nil, errors.New("invalid key(%v) of length %d found", string(key), len(d))
+	}
+
+	// first 20 bytes are account and last 32 are id
+	return d[:20], d[20:], nil
+}
@@ -0,0 +1,59 @@
+// +build unit
+
+package migrationfiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+	ldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestAddAttributeIndex05(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := ldb.OpenFile(targetDir, nil)
+	assert.NoError(t, err)
+	strRepo := leveldb.NewLevelDBRepository(db)
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(generic.Generic))
+	did := testingidentity.GenerateRandomDID()
+	attr, err := documents.NewStringAttribute("amount", documents.AttrDecimal, "100")
+	assert.NoError(t, err)
+	payload := generic.CreateGenericPayload(t, nil)
+	payload.Attributes = map[documents.AttrKey]documents.Attribute{attr.Key: attr}
+	g := generic.InitGeneric(t, did, payload)
+	assert.NoError(t, repo.Create(did[:], g.CurrentVersion(), g))
+
+	// drop the index created by the repo to simulate an older db
+	iter := db.NewIterator(util.BytesPrefix([]byte("attribute_")), nil)
+	var c int
+	for iter.Next() {
+		c++
+		assert.NoError(t, db.Delete(iter.Key(), nil))
+	}
+	iter.Release()
+	assert.Equal(t, 2, c)
+
+	attrIndex := documents.NewAttributeIndex(strRepo)
+	filter := documents.AttributeFilter{Key: attr.Key, Op: documents.FilterEq, Value: "100"}
+	ids, err := attrIndex.Find(did[:], filter)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 0)
+
+	assert.NoError(t, AddAttributeIndex05(leveldb.NewKeyValueStore(db), strRepo))
+	ids, err = attrIndex.Find(did[:], filter)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{g.ID()}, ids)
+}
@@ -0,0 +1,68 @@
+package migrationfiles
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/entity"
+	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AddCommittingIndex06 indexes the document versions left in Committing so that the anchor recovery rolls them back.
+func AddCommittingIndex06(db storage.KeyValueStore, strRepo storage.Repository) error {
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(entityrelationship.EntityRelationship))
+	repo.Register(new(entity.Entity))
+	repo.Register(new(generic.Generic))
+	var c int
+	err := db.Iterate([]byte(documents.DocPrefix), func(key, _ []byte) error {
+		acc, id, err := getAccountAndIDFromDocumentKey(key)
+		if err != nil {
+			// not a document version
+			return nil
+		}
+
+		m, err := repo.Get(acc, id)
+		if err != nil || m.GetStatus() != documents.Committing {
+			return nil
+		}
+
+		ckey := []byte(documents.CommittingPrefix + hexutil.Encode(append(acc, id...)))
+		if strRepo.Exists(ckey) {
+			return nil
+		}
+
+		c++
+		return strRepo.Create(ckey, &documents.CommittingVersion{
+			AccountID:  acc,
+			DocumentID: m.ID(),
+			VersionID:  id,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Indexed %d committing document versions\n", c)
+	log.Infof("AddCommittingIndex06 Migration Run successfully")
+	return nil
+}
+
+func getAccountAndIDFromDocumentKey(key []byte) (acc, id []byte, err error) {
+	str := strings.TrimSpace(strings.TrimPrefix(string(key), documents.DocPrefix))
+	d, err := hexutil.Decode(str)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(d) != 52 {
+		return nil, nil, errors.New("invalid key(%v) of length %d found", string(key), len(d))
+	}
+
+	// first 20 bytes are account and last 32 are version
+	return d[:20], d[20:], nil
+}
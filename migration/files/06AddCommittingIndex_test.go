@@ -0,0 +1,65 @@
+// +build unit
+
+package migrationfiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+	ldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestAddCommittingIndex06(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := ldb.OpenFile(targetDir, nil)
+	assert.NoError(t, err)
+	strRepo := leveldb.NewLevelDBRepository(db)
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(generic.Generic))
+	did := testingidentity.GenerateRandomDID()
+	committing := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	assert.NoError(t, committing.SetStatus(documents.Committing))
+	assert.NoError(t, repo.Create(did[:], committing.CurrentVersion(), committing))
+	committed := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	assert.NoError(t, committed.SetStatus(documents.Committed))
+	assert.NoError(t, repo.Create(did[:], committed.CurrentVersion(), committed))
+
+	// drop the commit records created by the repo to simulate an older db
+	for _, p := range []string{documents.CommittingPrefix, documents.CommitSnapshotPrefix} {
+		iter := db.NewIterator(util.BytesPrefix([]byte(p)), nil)
+		for iter.Next() {
+			assert.NoError(t, db.Delete(iter.Key(), nil))
+		}
+		iter.Release()
+	}
+
+	cvs, err := repo.GetCommittingVersions()
+	assert.NoError(t, err)
+	assert.Len(t, cvs, 0)
+
+	assert.NoError(t, AddCommittingIndex06(leveldb.NewKeyValueStore(db), strRepo))
+	cvs, err = repo.GetCommittingVersions()
+	assert.NoError(t, err)
+	assert.Len(t, cvs, 1)
+	assert.Equal(t, did[:], cvs[0].AccountID)
+	assert.Equal(t, committing.ID(), cvs[0].DocumentID)
+	assert.Equal(t, committing.CurrentVersion(), cvs[0].VersionID)
+
+	// already indexed
+	assert.NoError(t, AddCommittingIndex06(leveldb.NewKeyValueStore(db), strRepo))
+	cvs, err = repo.GetCommittingVersions()
+	assert.NoError(t, err)
+	assert.Len(t, cvs, 1)
+}
@@ -4,16 +4,22 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 	"github.com/go-errors/errors"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
-const dbPrefix = "migration_"
+// DBPrefix is the DB key prefix of the migration items.
+const DBPrefix = "migration_"
 
 // Repository holds DB info
 type Repository struct {
-	db     *leveldb.DB
-	dbPath string
+	db      storage.KeyValueStore
+	repo    storage.Repository
+	backend string
+	dbPath  string
+	enc     encryption.Config
 }
 
 // Item holds migration item info
@@ -24,23 +30,25 @@ type Item struct {
 	Duration time.Duration `json:"duration,string"`
 }
 
-// NewMigrationRepository takes a path and creates a DB repository
-func NewMigrationRepository(path string) (*Repository, error) {
-	i, err := leveldb.OpenFile(path, nil)
+// NewMigrationRepository takes a storage backend, a path and the encryption config and creates a DB repository.
+// Encryption config can be nil if the DB is not sealed.
+func NewMigrationRepository(backendName, path string, enc encryption.Config) (*Repository, error) {
+	repo := &Repository{backend: backendName, dbPath: path, enc: enc}
+	err := repo.Open()
 	if err != nil {
 		return nil, err
 	}
-	return &Repository{i, path}, nil
+	return repo, nil
 }
 
 func getKeyFromID(id string) []byte {
-	return []byte(dbPrefix + id)
+	return []byte(DBPrefix + id)
 }
 
 // Exists checks that migrationID has been ran
 func (repo *Repository) Exists(id string) bool {
 	key := getKeyFromID(id)
-	res, err := repo.db.Has(key, nil)
+	res, err := repo.db.Has(key)
 	if err != nil {
 		return false
 	}
@@ -51,7 +59,7 @@ func (repo *Repository) Exists(id string) bool {
 func (repo *Repository) GetMigrationByID(id string) (*Item, error) {
 	v := new(Item)
 	key := getKeyFromID(id)
-	data, err := repo.db.Get(key, nil)
+	data, err := repo.db.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -75,12 +83,12 @@ func (repo *Repository) CreateMigration(migrationItem *Item) error {
 	if err != nil {
 		return err
 	}
-	return repo.db.Put(key, data, nil)
+	return repo.db.Put(key, data)
 }
 
 // Open opens a DB, requires it to be closed before or it will error out
 func (repo *Repository) Open() (err error) {
-	repo.db, err = leveldb.OpenFile(repo.dbPath, nil)
+	repo.db, repo.repo, err = backend.Open(repo.backend, repo.dbPath, repo.enc)
 	return err
 }
 
@@ -6,23 +6,34 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	mfiles "github.com/centrifuge/go-centrifuge/migration/files"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 	logging "github.com/ipfs/go-log"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 var log = logging.Logger("migrate-cmd")
 
-var migrations = map[string]func(*leveldb.DB) error{
+// migrations run against the raw key value store and the model repository of the same DB.
+var migrations = map[string]func(storage.KeyValueStore, storage.Repository) error{
 	"00Initial":              mfiles.Initial00,
 	"01KeysToHex":            mfiles.KeysToHex01,
 	"02AddPrefix":            mfiles.AddPrefix02,
 	"03AddDocumentIndex":     mfiles.AddDocumentIndex03,
 	"04AddStatusToDocuments": mfiles.AddStatusToDocuments04,
+	"05AddAttributeIndex":    mfiles.AddAttributeIndex05,
+	"06AddCommittingIndex":   mfiles.AddCommittingIndex06,
+}
+
+// IsKnown returns true if the migration is known to this node.
+func IsKnown(id string) bool {
+	_, ok := migrations[id]
+	return ok
 }
 
 // Runner is the actor that runs the migrations
@@ -33,9 +44,10 @@ func NewMigrationRunner() *Runner {
 	return &Runner{}
 }
 
-// RunMigrations executes the migrations
-func (mr *Runner) RunMigrations(dbPath string) error {
-	repo, err := NewMigrationRepository(dbPath)
+// RunMigrations executes the migrations on the DB of the storage backend at dbPath.
+// Encryption config can be nil if the DB is not sealed.
+func (mr *Runner) RunMigrations(backend, dbPath string, enc encryption.Config) error {
+	repo, err := NewMigrationRepository(backend, dbPath, enc)
 	if err != nil {
 		return err
 	}
@@ -62,7 +74,7 @@ func (mr *Runner) RunMigrations(dbPath string) error {
 		}
 
 		// execute migration file
-		if err = migrations[k](repo.db); err != nil {
+		if err = migrations[k](repo.db, repo.repo); err != nil {
 			log.Errorf("Migration %s failed", k)
 			err1 := revertDBToBackup(repo, bkpRepo)
 			if err1 != nil {
@@ -98,8 +110,9 @@ func (mr *Runner) RunMigrations(dbPath string) error {
 }
 
 func getBackupName(path, name string) string {
-	bkpPath := strings.TrimSuffix(path, ".leveldb")
-	return fmt.Sprintf("%s_%s.leveldb", bkpPath, name)
+	ext := filepath.Ext(path)
+	bkpPath := strings.TrimSuffix(path, ext)
+	return fmt.Sprintf("%s_%s%s", bkpPath, name, ext)
 }
 
 func backupDB(srcRepo *Repository, migrationID string) (bkp *Repository, err error) {
@@ -110,7 +123,7 @@ func backupDB(srcRepo *Repository, migrationID string) (bkp *Repository, err err
 	}
 
 	dstPath := getBackupName(srcRepo.dbPath, migrationID)
-	err = CopyDir(srcRepo.dbPath, dstPath)
+	err = copyDB(srcRepo.dbPath, dstPath)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +134,21 @@ func backupDB(srcRepo *Repository, migrationID string) (bkp *Repository, err err
 		return nil, err
 	}
 
-	return NewMigrationRepository(dstPath)
+	return NewMigrationRepository(srcRepo.backend, dstPath, srcRepo.enc)
+}
+
+// copyDB copies the levelDB directory or the SQLite DB file.
+func copyDB(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return CopyDir(src, dst)
+	}
+
+	return CopyFile(src, dst)
 }
 
 func revertDBToBackup(srcDB, bkpDB *Repository) error {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -17,8 +18,8 @@ type content struct {
 }
 
 // Test migration items
-func Migration0(db *leveldb.DB) error {
-	err := db.Put([]byte("new"), []byte("sample"), nil)
+func Migration0(db storage.KeyValueStore, repo storage.Repository) error {
+	err := db.Put([]byte("new"), []byte("sample"))
 	if err != nil {
 		return err
 	}
@@ -26,8 +27,8 @@ func Migration0(db *leveldb.DB) error {
 	return nil
 }
 
-func Migration1(db *leveldb.DB) error {
-	err := db.Put([]byte("revert"), []byte("shouldbe"), nil)
+func Migration1(db storage.KeyValueStore, repo storage.Repository) error {
+	err := db.Put([]byte("revert"), []byte("shouldbe"))
 	if err != nil {
 		return err
 	}
@@ -52,7 +53,7 @@ func TestRunner_RunMigrations_AlreadyOpenError(t *testing.T) {
 	assert.NoError(t, err)
 
 	runner := NewMigrationRunner()
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(storage.LevelDBBackend, targetDir, nil)
 	assert.Error(t, err)
 }
 
@@ -63,7 +64,7 @@ func TestBackupDB(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(storage.LevelDBBackend, targetDir, nil)
 	assert.NoError(t, err)
 
 	// Force DB close error
@@ -89,7 +90,7 @@ func TestRevertToBackupDB(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(storage.LevelDBBackend, targetDir, nil)
 	assert.NoError(t, err)
 
 	bkp, err := backupDB(repo, "SomeID")
@@ -139,12 +140,12 @@ func TestRunMigrations_singleSuccess(t *testing.T) {
 	assert.NoError(t, db.Close())
 
 	// Override migrations for testing purposes
-	migrations = map[string]func(*leveldb.DB) error{
+	migrations = map[string]func(storage.KeyValueStore, storage.Repository) error{
 		"0SuccessMigration": Migration0,
 	}
 	runner := NewMigrationRunner()
 	// Run migration to convert binary key to hex
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(storage.LevelDBBackend, targetDir, nil)
 	assert.NoError(t, err)
 
 	db, err = leveldb.OpenFile(targetDir, nil)
@@ -158,7 +159,7 @@ func TestRunMigrations_singleSuccess(t *testing.T) {
 	assert.NoError(t, db.Close())
 
 	// Check that migration success status is stored
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(storage.LevelDBBackend, targetDir, nil)
 	assert.NoError(t, err)
 	mi, err := repo.GetMigrationByID("0SuccessMigration")
 	assert.NoError(t, err)
@@ -169,7 +170,7 @@ func TestRunMigrations_singleSuccess(t *testing.T) {
 
 	// Try running again, and run should be skipped
 	dRun := mi.DateRun
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(storage.LevelDBBackend, targetDir, nil)
 	assert.NoError(t, err)
 	err = repo.Open()
 	assert.NoError(t, err)
@@ -199,12 +200,12 @@ func TestRunner_RunMigrations_Failure(t *testing.T) {
 	assert.NoError(t, db.Close())
 
 	// Override migrations for testing purposes
-	migrations = map[string]func(*leveldb.DB) error{
+	migrations = map[string]func(storage.KeyValueStore, storage.Repository) error{
 		"1FailedMigration": Migration1,
 	}
 	// Run migration to convert binary key to hex
 	runner := NewMigrationRunner()
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(storage.LevelDBBackend, targetDir, nil)
 	assert.Error(t, err)
 
 	db, err = leveldb.OpenFile(targetDir, nil)
@@ -217,3 +218,48 @@ func TestRunner_RunMigrations_Failure(t *testing.T) {
 	assert.False(t, has)
 	assert.NoError(t, db.Close())
 }
+
+func TestRunMigrations_SQLite(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetFile := fmt.Sprintf("%s.db", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	// Override migrations for testing purposes
+	migrations = map[string]func(storage.KeyValueStore, storage.Repository) error{
+		"0SuccessMigration": Migration0,
+	}
+	runner := NewMigrationRunner()
+	err := runner.RunMigrations(storage.SQLiteBackend, targetFile, nil)
+	assert.NoError(t, err)
+
+	repo, err := NewMigrationRepository(storage.SQLiteBackend, targetFile, nil)
+	assert.NoError(t, err)
+	has, err := repo.db.Has([]byte("new"))
+	assert.NoError(t, err)
+	assert.True(t, has)
+	mi, err := repo.GetMigrationByID("0SuccessMigration")
+	assert.NoError(t, err)
+	assert.Equal(t, "0SuccessMigration", mi.ID)
+	assert.NoError(t, repo.Close())
+
+	// failed migration is reverted from the backup file
+	migrations = map[string]func(storage.KeyValueStore, storage.Repository) error{
+		"1FailedMigration": Migration1,
+	}
+	err = runner.RunMigrations(storage.SQLiteBackend, targetFile, nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, repo.Open())
+	has, err = repo.db.Has([]byte("revert"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+	assert.NoError(t, repo.Close())
+	_, err = os.Stat(getBackupName(targetFile, "1FailedMigration"))
+	assert.True(t, os.IsNotExist(err))
+
+	// unknown backend
+	err = runner.RunMigrations("unknown", targetFile, nil)
+	assert.Contains(t, err.Error(), storage.ErrUnknownBackend.Error())
+}
@@ -68,5 +68,19 @@ func GetServers(ctx map[string]interface{}) ([]Server, error) {
 
 	var servers []Server
 	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server))
+
+	// document servers are only available when documents are bootstrapped
+	for _, key := range []string{
+		bootstrap.BootstrappedAnchorRecovery,
+		bootstrap.BootstrappedDeliveryQueue,
+		bootstrap.BootstrappedAnchorExpiryMonitor,
+		// re-encryption is only available when the storage encryption is enabled
+		bootstrap.BootstrappedStorageReencryption,
+	} {
+		if srv, ok := ctx[key]; ok {
+			servers = append(servers, srv.(Server))
+		}
+	}
+
 	return servers, nil
 }
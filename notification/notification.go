@@ -21,10 +21,12 @@ type Status int
 
 // Constants defined for notification delivery.
 const (
-	ReceivedPayload EventType = 1
-	JobCompleted    EventType = 2
-	Failure         Status    = 0
-	Success         Status    = 1
+	ReceivedPayload  EventType = 1
+	JobCompleted     EventType = 2
+	DocumentRejected EventType = 3
+	AnchorExpiring   EventType = 4
+	Failure          Status    = 0
+	Success          Status    = 1
 )
 
 // Message is the payload used to send the notifications.
@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
@@ -208,16 +209,42 @@ func (s *peer) getPeerID(ctx context.Context, id identity.DID) (libp2pPeer.ID, e
 	return peerID, nil
 }
 
+// signatureRequest holds the document version the signatures are requested for.
+// It is prepared before the collection so that the model can be signed while the signatures arrive.
+type signatureRequest struct {
+	cd          *coredocumentpb.CoreDocument
+	signingRoot []byte
+	timestamp   time.Time
+}
+
+func newSignatureRequest(model documents.Model) (req signatureRequest, err error) {
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return req, errors.New("failed to pack core document: %v", err)
+	}
+
+	// packed document shares the signatures with the model
+	req.cd = proto.Clone(&cd).(*coredocumentpb.CoreDocument)
+	req.timestamp, err = model.Timestamp()
+	if err != nil {
+		return req, errors.New("cannot get model timestamp : %s", err.Error())
+	}
+
+	req.signingRoot, err = model.CalculateSigningRoot()
+	if err != nil {
+		return req, errors.New("failed to calculate signing root: %s", err.Error())
+	}
+
+	return req, nil
+}
+
 // getSignatureForDocument requests the target node to sign the document
-func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Model, collaborator, sender identity.DID) (*p2ppb.SignatureResponse, error) {
+func (s *peer) getSignatureForDocument(ctx context.Context, req signatureRequest, collaborator, sender identity.DID) (*p2ppb.SignatureResponse, error) {
 	nc, err := s.config.GetConfig()
 	if err != nil {
 		return nil, err
 	}
-	cd, err := model.PackCoreDocument()
-	if err != nil {
-		return nil, errors.New("failed to pack core document: %v", err)
-	}
+	cd := proto.Clone(req.cd).(*coredocumentpb.CoreDocument)
 	var resp *p2ppb.SignatureResponse
 	var header *p2ppb.Header
 	tc, err := s.config.GetAccount(collaborator[:])
@@ -230,7 +257,7 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 			return nil, err
 		}
 
-		resp, err = h.RequestDocumentSignature(localPeerCtx, &p2ppb.SignatureRequest{Document: &cd}, sender)
+		resp, err = h.RequestDocumentSignature(localPeerCtx, &p2ppb.SignatureRequest{Document: cd}, sender)
 		if err != nil {
 			return nil, err
 		}
@@ -246,7 +273,7 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 		if err != nil {
 			return nil, err
 		}
-		envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
+		envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: cd})
 		if err != nil {
 			return nil, err
 		}
@@ -274,7 +301,7 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 		header = recvEnvelope.Header
 	}
 
-	err = s.validateSignatureResp(model, collaborator, header, resp)
+	err = s.validateSignatureResp(req, collaborator, header, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -284,23 +311,28 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 }
 
 type signatureResponseWrap struct {
-	resp *p2ppb.SignatureResponse
-	err  error
+	collaborator identity.DID
+	resp         *p2ppb.SignatureResponse
+	err          error
 }
 
-func (s *peer) getSignatureAsync(ctx context.Context, model documents.Model, collaborator, sender identity.DID, out chan<- signatureResponseWrap) {
-	resp, err := s.getSignatureForDocument(ctx, model, collaborator, sender)
+func (s *peer) getSignatureAsync(ctx context.Context, req signatureRequest, collaborator, sender identity.DID, out chan<- signatureResponseWrap) {
+	resp, err := s.getSignatureForDocument(ctx, req, collaborator, sender)
 	out <- signatureResponseWrap{
-		resp: resp,
-		err:  err,
+		collaborator: collaborator,
+		resp:         resp,
+		err:          err,
 	}
 }
 
 // GetSignaturesForDocument requests peer nodes for the signature, verifies them, and returns those signatures.
-func (s *peer) GetSignaturesForDocument(ctx context.Context, model documents.Model) (signatures []*coredocumentpb.Signature, signatureCollectionErrors []error, err error) {
-	in := make(chan signatureResponseWrap)
-	defer close(in)
-
+// Signatures of each collaborator are passed to collect as they arrive. Collection stops once collect returns true,
+// the pending requests are cancelled. Collect can be nil to wait for all the collaborators.
+// Signature collection errors are of type documents.CollaboratorError.
+func (s *peer) GetSignaturesForDocument(
+	ctx context.Context,
+	model documents.Model,
+	collect func(signatures []*coredocumentpb.Signature) bool) (signatures []*coredocumentpb.Signature, signatureCollectionErrors []error, err error) {
 	nc, err := s.config.GetConfig()
 	if err != nil {
 		return nil, nil, err
@@ -316,33 +348,41 @@ func (s *peer) GetSignaturesForDocument(ctx context.Context, model documents.Mod
 		return nil, nil, errors.New("failed to get external collaborators")
 	}
 
-	var count int
+	req, err := newSignatureRequest(model)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// buffered so that the pending requests can finish once the collection stops
+	in := make(chan signatureResponseWrap, len(cs))
 	peerCtx, cancel := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
 	defer cancel()
 	for _, c := range cs {
-		count++
-		go s.getSignatureAsync(peerCtx, model, c, selfDID, in)
-	}
-
-	var responses []signatureResponseWrap
-	for i := 0; i < count; i++ {
-		responses = append(responses, <-in)
+		go s.getSignatureAsync(peerCtx, req, c, selfDID, in)
 	}
 
-	for _, resp := range responses {
+	for i := 0; i < len(cs); i++ {
+		resp := <-in
 		if resp.err != nil {
-			signatureCollectionErrors = append(signatureCollectionErrors, resp.err)
+			signatureCollectionErrors = append(signatureCollectionErrors, documents.CollaboratorError{
+				Collaborator: resp.collaborator,
+				Err:          resp.err,
+			})
 			continue
 		}
 
 		signatures = append(signatures, resp.resp.Signatures...)
+		if collect != nil && collect(resp.resp.Signatures) {
+			log.Infof("Signatures collected for document %#x, %d collaborators pending", model.ID(), len(cs)-i-1)
+			break
+		}
 	}
 
 	return signatures, signatureCollectionErrors, nil
 }
 
 func (s *peer) validateSignatureResp(
-	model documents.Model,
+	req signatureRequest,
 	receiver identity.DID,
 	header *p2ppb.Header,
 	resp *p2ppb.SignatureResponse) error {
@@ -352,23 +392,13 @@ func (s *peer) validateSignatureResp(
 		return version.IncompatibleVersionError(header.NodeVersion)
 	}
 
-	tm, err := model.Timestamp()
-	if err != nil {
-		return errors.New("cannot get model timestamp : %s", err.Error())
-	}
-
-	signingRoot, err := model.CalculateSigningRoot()
-	if err != nil {
-		return errors.New("failed to calculate signing root: %s", err.Error())
-	}
-
 	for _, sig := range resp.Signatures {
-		err = identity.ValidateDIDBytes(sig.SignerId, receiver)
+		err := identity.ValidateDIDBytes(sig.SignerId, receiver)
 		if err != nil {
 			return errors.New("signature invalid with err: %s", err.Error())
 		}
 
-		err = s.idService.ValidateSignature(receiver, sig.PublicKey, sig.Signature, documents.ConsensusSignaturePayload(signingRoot, sig.TransitionValidated), tm)
+		err = s.idService.ValidateSignature(receiver, sig.PublicKey, sig.Signature, documents.ConsensusSignaturePayload(req.signingRoot, sig.TransitionValidated), req.timestamp)
 		if err != nil {
 			return errors.New("signature invalid with err: %s", err.Error())
 		}
@@ -67,7 +67,7 @@ func TestClient_GetSignaturesForDocument(t *testing.T) {
 	ctxh, err := contextutil.New(context.Background(), acci)
 	assert.Nil(t, err)
 	dm := prepareDocumentForP2PHandler(t, [][]byte{tc.IdentityID})
-	signs, _, err := client.GetSignaturesForDocument(ctxh, dm)
+	signs, _, err := client.GetSignaturesForDocument(ctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, signs)
 }
@@ -83,7 +83,7 @@ func TestClient_GetSignaturesForDocumentValidationCheck(t *testing.T) {
 	ctxh, err := contextutil.New(context.Background(), acci)
 	assert.NoError(t, err)
 	dm := prepareDocumentForP2PHandler(t, [][]byte{tc.IdentityID})
-	signs, signatureErrors, err := client.GetSignaturesForDocument(ctxh, dm)
+	signs, signatureErrors, err := client.GetSignaturesForDocument(ctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "[5]signature invalid with err: no contract code at given address")
 	assert.Equal(t, 0, len(signs))
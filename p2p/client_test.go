@@ -4,11 +4,14 @@ package p2p
 
 import (
 	"context"
+	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -19,6 +22,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-centrifuge/version"
 	"github.com/golang/protobuf/proto"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	libp2pPeer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/stretchr/testify/assert"
@@ -50,11 +54,14 @@ func TestGetSignatureForDocument_fail_connect(t *testing.T) {
 	m := &MockMessenger{}
 	testClient := &peer{config: cfg, idService: idService, mes: m, disablePeerStore: true}
 	model, cd := generic.CreateGenericWithEmbedCD(t, ctx, did, nil)
+	assert.NoError(t, model.AddUpdateLog(did))
 	_, err = p2pcommon.PrepareP2PEnvelope(ctx, c.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
 	assert.NoError(t, err, "signature request could not be created")
 
 	m.On("SendMessage", ctx, mock.Anything, mock.Anything, p2pcommon.ProtocolForDID(&did)).Return(nil, errors.New("some error"))
-	resp, err := testClient.getSignatureForDocument(ctx, model, did, did)
+	req, err := newSignatureRequest(model)
+	assert.NoError(t, err)
+	resp, err := testClient.getSignatureForDocument(ctx, req, did, did)
 	m.AssertExpectations(t)
 	assert.Error(t, err, "must fail")
 	assert.Nil(t, resp, "must be nil")
@@ -69,11 +76,14 @@ func TestGetSignatureForDocument_fail_version_check(t *testing.T) {
 	m := &MockMessenger{}
 	testClient := &peer{config: cfg, idService: idService, mes: m, disablePeerStore: true}
 	model, cd := generic.CreateGenericWithEmbedCD(t, ctx, did, nil)
+	assert.NoError(t, model.AddUpdateLog(did))
 	_, err = p2pcommon.PrepareP2PEnvelope(ctx, c.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
 	assert.NoError(t, err, "signature request could not be created")
 
 	m.On("SendMessage", ctx, mock.Anything, mock.Anything, p2pcommon.ProtocolForDID(&did)).Return(testClient.createSignatureResp("", nil), nil)
-	resp, err := testClient.getSignatureForDocument(ctx, model, did, did)
+	req, err := newSignatureRequest(model)
+	assert.NoError(t, err)
+	resp, err := testClient.getSignatureForDocument(ctx, req, did, did)
 	m.AssertExpectations(t)
 	assert.Error(t, err, "must fail")
 	assert.Contains(t, err.Error(), "Incompatible version")
@@ -98,7 +108,9 @@ func TestGetSignatureForDocument_fail_did(t *testing.T) {
 	signatures := []*coredocumentpb.Signature{{SignatureId: utils.RandomSlice(52), SignerId: randomBytes, PublicKey: utils.RandomSlice(32)}}
 	m.On("SendMessage", ctx, mock.Anything, mock.Anything, p2pcommon.ProtocolForDID(&did)).Return(testClient.createSignatureResp(version.GetVersion().String(), signatures), nil)
 
-	resp, err := testClient.getSignatureForDocument(ctx, model, did, did)
+	req, err := newSignatureRequest(model)
+	assert.NoError(t, err)
+	resp, err := testClient.getSignatureForDocument(ctx, req, did, did)
 
 	m.AssertExpectations(t)
 	assert.Nil(t, resp, "must be nil")
@@ -107,6 +119,61 @@ func TestGetSignatureForDocument_fail_did(t *testing.T) {
 
 }
 
+func TestGetSignaturesForDocument_collaborator_never_answers(t *testing.T) {
+	c, err := cfg.GetConfig()
+	assert.NoError(t, err)
+	c = updateKeys(c)
+	ctx := testingconfig.CreateAccountContext(t, c)
+	self, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	online, offline := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	model, _ := generic.CreateGenericWithEmbedCD(t, ctx, self, []identity.DID{online, offline})
+	assert.NoError(t, model.AddUpdateLog(self))
+
+	idService := new(testingcommons.MockIdentityService)
+	m := new(MockMessenger)
+	peers := make(map[identity.DID]libp2pPeer.ID)
+	for _, collab := range []identity.DID{online, offline} {
+		_, pub, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+		assert.NoError(t, err)
+		pid, err := libp2pPeer.IDFromPublicKey(pub)
+		assert.NoError(t, err)
+		peers[collab] = pid
+		idService.On("CurrentP2PKey", collab).Return(pid.Pretty(), nil)
+	}
+	idService.On("Exists", mock.Anything, mock.Anything).Return(nil)
+	idService.On("ValidateSignature", online, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	testClient := &peer{config: cfg, idService: idService, mes: m, disablePeerStore: true}
+
+	sig := &coredocumentpb.Signature{SignerId: online[:], PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(32)}
+	m.On("SendMessage", mock.Anything, peers[online], mock.Anything, mock.Anything).Return(
+		testClient.createSignatureResp(version.GetVersion().String(), []*coredocumentpb.Signature{sig}), nil)
+	cancelled := make(chan struct{})
+	m.On("SendMessage", mock.Anything, peers[offline], mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+		close(cancelled)
+	}).Return(nil, errors.New("timed out"))
+
+	// collection stops with the signature of the online collaborator
+	start := time.Now()
+	signs, errs, err := testClient.GetSignaturesForDocument(ctx, model, func(signs []*coredocumentpb.Signature) bool {
+		model.AppendSignatures(signs...)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Len(t, signs, 1)
+	assert.Equal(t, sig.Signature, signs[0].Signature)
+	assert.True(t, time.Since(start) < c.GetP2PConnectionTimeout())
+
+	// request to the offline collaborator is cancelled
+	select {
+	case <-cancelled:
+	case <-time.After(c.GetP2PConnectionTimeout()):
+		t.Fatal("request to the offline collaborator is not cancelled")
+	}
+}
+
 func getIDMocks(ctx context.Context, did identity.DID) *testingcommons.MockIdentityService {
 	idService := &testingcommons.MockIdentityService{}
 	idService.On("CurrentP2PKey", did).Return("QmVf6EN6mkqWejWKW2qPu16XpdG3kJo1T3mhahPB5Se5n1", nil)
@@ -3,6 +3,8 @@ package p2pcommon
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
 	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -43,7 +46,7 @@ const (
 	MessageTypeGetDocRep MessageType = "MessageTypeGetDocRep"
 )
 
-//MessageTypes map for MessageTypeFromString function
+// MessageTypes map for MessageTypeFromString function
 var messageTypes = map[string]MessageType{
 	"MessageTypeError":               "MessageTypeError",
 	"MessageTypeInvalid":             "MessageTypeInvalid",
@@ -152,9 +155,48 @@ func ConvertClientError(recv *p2ppb.Envelope) error {
 	if err != nil {
 		return err
 	}
+
+	if resp.Code != 0 {
+		return convertRejectionError(resp)
+	}
+
 	return errors.New(resp.Message)
 }
 
+// ConvertRejectionError converts the RejectionError to error proto.
+// Code is set to the first rejection code and Errors holds all the rejection codes with their reasons.
+func ConvertRejectionError(rerr *documents.RejectionError) *errorspb.Error {
+	errPb := &errorspb.Error{Message: documents.ErrDocumentRejected.Error(), Errors: make(map[string]string)}
+	for _, c := range rerr.Codes {
+		if errPb.Code == 0 {
+			errPb.Code = int32(c)
+		}
+
+		errPb.Errors[strconv.Itoa(int(c))] = c.String()
+	}
+
+	return errPb
+}
+
+// convertRejectionError converts the error proto back to RejectionError.
+func convertRejectionError(errPb *errorspb.Error) *documents.RejectionError {
+	codes := []documents.RejectionCode{documents.RejectionCode(errPb.Code)}
+	for k := range errPb.Errors {
+		c, err := strconv.Atoi(k)
+		if err != nil || documents.RejectionCode(c) == codes[0] {
+			continue
+		}
+
+		codes = append(codes, documents.RejectionCode(c))
+	}
+
+	sort.Slice(codes[1:], func(i, j int) bool {
+		return codes[i+1] < codes[j+1]
+	})
+
+	return &documents.RejectionError{Codes: codes}
+}
+
 // ConvertP2PEnvelopeToError converts p2pEnvelope containing an error to Error
 func ConvertP2PEnvelopeToError(p2pEnvelope *protocolpb.P2PEnvelope) error {
 	envelope, err := ResolveDataEnvelope(p2pEnvelope)
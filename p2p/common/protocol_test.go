@@ -7,6 +7,7 @@ import (
 	"os"
 	"testing"
 
+	errorspb "github.com/centrifuge/centrifuge-protobufs/gen/go/errors"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
@@ -14,6 +15,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/golang/protobuf/proto"
@@ -116,3 +119,31 @@ func TestPrepareP2PEnvelope(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, dataEnv)
 }
+
+func TestConvertClientError(t *testing.T) {
+	// generic error
+	body, err := proto.Marshal(&errorspb.Error{Message: "some error"})
+	assert.NoError(t, err)
+	err = ConvertClientError(&p2ppb.Envelope{Body: body})
+	assert.Error(t, err)
+	assert.Equal(t, "some error", err.Error())
+
+	// rejection
+	rerr := &documents.RejectionError{Codes: []documents.RejectionCode{
+		documents.RejectionCodeUnauthorizedTransition,
+		documents.RejectionCodeInvalidTimestamp,
+		documents.RejectionCodeInvalidAuthor,
+	}}
+	errPb := ConvertRejectionError(rerr)
+	assert.Equal(t, int32(documents.RejectionCodeUnauthorizedTransition), errPb.Code)
+	assert.Len(t, errPb.Errors, 3)
+	body, err = proto.Marshal(errPb)
+	assert.NoError(t, err)
+	err = ConvertClientError(&p2ppb.Envelope{Body: body})
+	assert.True(t, errors.IsOfType(documents.ErrDocumentRejected, err))
+	assert.Equal(t, []documents.RejectionCode{
+		documents.RejectionCodeUnauthorizedTransition,
+		documents.RejectionCodeInvalidTimestamp,
+		documents.RejectionCodeInvalidAuthor,
+	}, err.(*documents.RejectionError).Codes)
+}
@@ -7,6 +7,7 @@ import (
 	errorspb "github.com/centrifuge/centrifuge-protobufs/gen/go/errors"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	pb "github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
@@ -28,6 +29,7 @@ type Handler struct {
 	config             config.Service
 	handshakeValidator ValidatorGroup
 	docSrv             documents.Service
+	anchorSrv          anchors.Service
 	tokenRegistry      documents.TokenRegistry
 	srvDID             identity.Service
 }
@@ -37,12 +39,14 @@ func New(
 	config config.Service,
 	handshakeValidator ValidatorGroup,
 	docSrv documents.Service,
+	anchorSrv anchors.Service,
 	tokenRegistry documents.TokenRegistry,
 	srvDID identity.Service) *Handler {
 	return &Handler{
 		config:             config,
 		handshakeValidator: handshakeValidator,
 		docSrv:             docSrv,
+		anchorSrv:          anchorSrv,
 		tokenRegistry:      tokenRegistry,
 		srvDID:             srvDID,
 	}
@@ -193,6 +197,12 @@ func (srv *Handler) SendAnchoredDocument(ctx context.Context, docReq *p2ppb.Anch
 		return nil, errors.New("failed to derive from core doc: %v", err)
 	}
 
+	// batch proof is stored before the document is validated so that the anchor can be verified.
+	err = documents.ReceiveBatchProof(srv.anchorSrv, docReq, model)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalid, err)
+	}
+
 	err = srv.docSrv.ReceiveAnchoredDocument(ctx, model, collaborator)
 	if err != nil {
 		return nil, err
@@ -233,10 +243,16 @@ func (srv *Handler) HandleGetDocument(ctx context.Context, peer peer.ID, protoc
 }
 
 // GetDocument receives document identifier and retrieves the corresponding CoreDocument from the repository
+// If the identifier is not a document identifier, it is looked up as a version identifier so that
+// collaborators can sync the versions they have missed.
 func (srv *Handler) GetDocument(ctx context.Context, docReq *p2ppb.GetDocumentRequest, requester identity.DID) (*p2ppb.GetDocumentResponse, error) {
 	model, err := srv.docSrv.GetCurrentVersion(ctx, docReq.DocumentIdentifier)
 	if err != nil {
-		return nil, err
+		var verr error
+		model, verr = srv.docSrv.GetVersionByID(ctx, docReq.DocumentIdentifier)
+		if verr != nil {
+			return nil, err
+		}
 	}
 
 	if err = srv.validateDocumentAccess(ctx, docReq, model, requester); err != nil {
@@ -248,7 +264,12 @@ func (srv *Handler) GetDocument(ctx context.Context, docReq *p2ppb.GetDocumentRe
 		return nil, err
 	}
 
-	return &p2ppb.GetDocumentResponse{Document: &cd}, nil
+	resp := &p2ppb.GetDocumentResponse{Document: &cd}
+	if err := documents.AttachBatchProof(srv.anchorSrv, resp, cd.CurrentVersion); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
 // validateDocumentAccess validates the GetDocument request against the AccessType indicated in the request
@@ -291,6 +312,9 @@ func (srv *Handler) convertToErrorEnvelop(ierr error) (*pb.P2PEnvelope, error) {
 
 	ierr = errors.Mask(ierr)
 	errPb := &errorspb.Error{Message: ierr.Error()}
+	if rerr, ok := ierr.(*documents.RejectionError); ok {
+		errPb = p2pcommon.ConvertRejectionError(rerr)
+	}
 	errBytes, errx := proto.Marshal(errPb)
 	if errx != nil {
 		return nil, errx
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	errorspb "github.com/centrifuge/centrifuge-protobufs/gen/go/errors"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
@@ -26,6 +27,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/anchors"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
@@ -46,6 +48,7 @@ var (
 	registry      *documents.ServiceRegistry
 	cfg           config.Configuration
 	mockIDService *testingcommons.MockIdentityService
+	anchorSrv     anchors.Service
 	defaultPID    libp2pPeer.ID
 )
 
@@ -74,11 +77,12 @@ func TestMain(m *testing.M) {
 	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
 	cfgService := ctx[config.BootstrappedConfigStorage].(config.Service)
 	registry = ctx[documents.BootstrappedRegistry].(*documents.ServiceRegistry)
+	anchorSrv = ctx[anchors.BootstrappedAnchorService].(anchors.Service)
 	docSrv := documents.DefaultService(cfg, nil, nil, registry, mockIDService, nil, nil)
 	_, pub, _ := crypto.GenerateEd25519Key(rand.Reader)
 	defaultPID, _ = libp2pPeer.IDFromPublicKey(pub)
 	mockIDService.On("ValidateKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	handler = New(cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, new(testingdocuments.MockRegistry), mockIDService)
+	handler = New(cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, anchorSrv, new(testingdocuments.MockRegistry), mockIDService)
 	result := m.Run()
 	bootstrap.RunTestTeardown(ibootstappers)
 	os.Exit(result)
@@ -91,7 +95,7 @@ func TestHandler_HandleInterceptor_noConfig(t *testing.T) {
 	assert.NoError(t, err)
 	fkRepo := configstore.NewDBRepository(leveldb.NewLevelDBRepository(db))
 	fkCfg := configstore.DefaultService(fkRepo, mockIDService)
-	hndlr := New(fkCfg, nil, nil, nil, nil)
+	hndlr := New(fkCfg, nil, nil, nil, nil, nil)
 	resp, err := hndlr.HandleInterceptor(context.Background(), libp2pPeer.ID("SomePeer"), protocol.ID("protocolX"), &protocolpb.P2PEnvelope{})
 	assert.NoError(t, err)
 	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
@@ -275,3 +279,80 @@ func TestConvertToErrorEnvelope(t *testing.T) {
 	assert.NoError(t, errx)
 	assert.Equal(t, errPayload.Error(), m.Message)
 }
+
+func TestHandler_GetDocument_version(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	h := New(nil, nil, docSrv, anchorSrv, nil, nil)
+	versionID := utils.RandomSlice(32)
+	requester := testingidentity.GenerateRandomDID()
+	req := &p2ppb.GetDocumentRequest{
+		DocumentIdentifier: versionID,
+		AccessType:         p2ppb.AccessType_ACCESS_TYPE_REQUESTER_VERIFICATION,
+	}
+
+	// unknown identifier
+	docSrv.On("GetCurrentVersion", versionID).Return(nil, documents.ErrDocumentNotFound).Once()
+	docSrv.On("GetVersionByID", versionID).Return(nil, documents.ErrDocumentVersionNotFound).Once()
+	_, err := h.GetDocument(context.Background(), req, requester)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// identifier of a version
+	m := new(documents.MockModel)
+	m.On("AccountCanRead", requester).Return(true).Once()
+	cd := coredocumentpb.CoreDocument{CurrentVersion: versionID}
+	m.On("PackCoreDocument").Return(cd, nil).Once()
+	docSrv.On("GetCurrentVersion", versionID).Return(nil, documents.ErrDocumentNotFound).Once()
+	docSrv.On("GetVersionByID", versionID).Return(m, nil).Once()
+	resp, err := h.GetDocument(context.Background(), req, requester)
+	assert.NoError(t, err)
+	assert.Equal(t, versionID, resp.Document.CurrentVersion)
+	docSrv.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
+
+func TestHandler_SendAnchoredDocument_batchProof(t *testing.T) {
+	versionID := utils.RandomSlice(32)
+	anchorID, err := anchors.ToAnchorID(versionID)
+	assert.NoError(t, err)
+	proof := &anchors.BatchProof{
+		AnchorID:         anchorID,
+		DocumentRoot:     anchors.RandomDocumentRoot(),
+		SigningRootProof: utils.RandomByte32(),
+		BatchAnchorID:    utils.RandomByte32(),
+	}
+	sender := new(testinganchors.MockAnchorService)
+	sender.On("GetBatchProof", anchorID).Return(proof, nil).Once()
+	cd := coredocumentpb.CoreDocument{CurrentVersion: versionID}
+	req := &p2ppb.AnchorDocumentRequest{Document: &cd}
+	assert.NoError(t, documents.AttachBatchProof(sender, req, versionID))
+	data, err := proto.Marshal(req)
+	assert.NoError(t, err)
+	req = new(p2ppb.AnchorDocumentRequest)
+	assert.NoError(t, proto.Unmarshal(data, req))
+
+	collaborator := testingidentity.GenerateRandomDID()
+	m := new(documents.MockModel)
+	m.On("CurrentVersion").Return(versionID)
+	m.On("CalculateSignaturesRoot").Return(proof.SigningRootProof[:], nil)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("DeriveFromCoreDocument", mock.Anything).Return(m, nil)
+	receiver := new(testinganchors.MockAnchorService)
+	h := New(nil, nil, docSrv, receiver, nil, nil)
+
+	// proof is not verified against the batch anchor
+	receiver.On("StoreBatchProof", proof).Return(anchors.ErrBatchProofInvalid).Once()
+	_, err = h.SendAnchoredDocument(context.Background(), req, collaborator)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// proof is stored before the document is validated
+	receiver.On("StoreBatchProof", proof).Return(nil).Once()
+	docSrv.On("ReceiveAnchoredDocument").Return(nil).Once()
+	resp, err := h.SendAnchoredDocument(context.Background(), req, collaborator)
+	assert.NoError(t, err)
+	assert.True(t, resp.Accepted)
+	sender.AssertExpectations(t)
+	receiver.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
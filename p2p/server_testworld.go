@@ -49,27 +49,28 @@ func (s *peer) getSignatureForDocumentIncorrectMessage(ctx context.Context, mode
 		return nil, err
 	}
 
-	cd, err := model.PackCoreDocument()
+	req, err := newSignatureRequest(model)
 	if err != nil {
-		return nil, errors.New("failed to pack core document: %v", err)
+		return nil, err
 	}
+	cd := req.cd
 
 	//select which envelope preparing function to call based on the error type
 	var envelope *protocolpb.P2PEnvelope
 	var envelopeErr error
 	switch errorType {
 	case "incorrectNodeVersion":
-		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeIncorrectNodeVersion(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
+		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeIncorrectNodeVersion(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: cd})
 		if envelopeErr != nil {
 			return nil, envelopeErr
 		}
 	case "invalidBody":
-		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeInvalidBody(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
+		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeInvalidBody(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: cd})
 		if envelopeErr != nil {
 			return nil, envelopeErr
 		}
 	default:
-		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeInvalidHeader(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
+		envelope, envelopeErr = p2pcommon.PrepareP2PEnvelopeInvalidHeader(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: cd})
 		if envelopeErr != nil {
 			return nil, envelopeErr
 		}
@@ -97,7 +98,7 @@ func (s *peer) getSignatureForDocumentIncorrectMessage(ctx context.Context, mode
 	}
 	header = recvEnvelope.Header
 
-	err = s.validateSignatureResp(model, collaborator, header, resp)
+	err = s.validateSignatureResp(req, collaborator, header, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +161,7 @@ func (s *peer) GetSignaturesForDocumentIncorrectMessage(ctx context.Context, mod
 	return signatures, signatureCollectionErrors, nil
 }
 
-//send message over the accepted maximum message size
+// send message over the accepted maximum message size
 func (s *peer) SendOverSizedMessage(ctx context.Context, model documents.Model, length int) (envelope *protocolpb.P2PEnvelope, err error) {
 	nc, err := s.config.GetConfig()
 	if err != nil {
@@ -1,8 +1,10 @@
 package pending
 
 import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -25,7 +27,13 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	if !ok {
 		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
 	}
+	jobManager, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", jobs.BootstrappedService)
+	}
+
 	repo := NewRepository(ldb)
-	ctx[BootstrappedPendingDocumentService] = DefaultService(docSrv, repo)
+	ctx[bootstrap.BootstrappedPendingRepository] = repo
+	ctx[BootstrappedPendingDocumentService] = DefaultService(docSrv, repo, jobManager)
 	return nil
 }
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +29,11 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	ctx[documents.BootstrappedDocumentService] = new(testingdocuments.MockService)
 	assert.Error(t, b.Bootstrap(ctx))
 
-	// success
+	// missing job manager
 	ctx[storage.BootstrappedDB] = repo
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// success
+	ctx[jobs.BootstrappedService] = new(testingjobs.MockJobManager)
 	assert.NoError(t, b.Bootstrap(ctx))
 }
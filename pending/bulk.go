@@ -0,0 +1,135 @@
+package pending
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrBulkCommitInvalid is a sentinel error used when the bulk commit request is not valid.
+	ErrBulkCommitInvalid = errors.Error("invalid bulk commit request")
+
+	// MaxBulkDocuments is the maximum number of documents that can be committed in a single bulk request.
+	MaxBulkDocuments = 500
+)
+
+// BulkDocument is a document committed as part of a bulk commit along with its anchor job.
+// If the commit failed, JobID is nil and Error holds the reason.
+type BulkDocument struct {
+	Document documents.Model
+	JobID    jobs.JobID
+	Error    error
+}
+
+// BulkCommit creates the documents from the payloads and commits them.
+// All the payloads are derived before any document is committed so that an invalid payload fails the whole request.
+// Each document is anchored in its own job. The returned parent job tracks the anchor jobs and succeeds only when
+// all the documents are anchored.
+func (s service) BulkCommit(ctx context.Context, payloads []documents.UpdatePayload) (jobs.JobID, []BulkDocument, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return jobs.NilJobID(), nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if len(payloads) < 1 || len(payloads) > MaxBulkDocuments {
+		return jobs.NilJobID(), nil, errors.NewTypedError(ErrBulkCommitInvalid,
+			errors.New("expected between 1 and %d documents, got %d", MaxBulkDocuments, len(payloads)))
+	}
+
+	docs, err := s.deriveBulk(ctx, did, payloads)
+	if err != nil {
+		return jobs.NilJobID(), nil, errors.NewTypedError(ErrBulkCommitInvalid, err)
+	}
+
+	var bds []BulkDocument
+	for _, doc := range docs {
+		jobID, err := s.docSrv.Commit(ctx, doc)
+		bds = append(bds, BulkDocument{Document: doc, JobID: jobID, Error: err})
+	}
+
+	parentID, _, err := s.jobManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "bulk commit documents",
+		func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
+			errChan <- waitForBulkJobs(accountID, jobID, jobsMan, bds)
+		})
+	if err != nil {
+		return jobs.NilJobID(), nil, err
+	}
+
+	return parentID, bds, nil
+}
+
+// deriveBulk derives a document from each payload.
+func (s service) deriveBulk(ctx context.Context, did identity.DID, payloads []documents.UpdatePayload) ([]documents.Model, error) {
+	seen := make(map[string]struct{})
+	var docs []documents.Model
+	for i, payload := range payloads {
+		if len(payload.DocumentID) > 0 {
+			id := hexutil.Encode(payload.DocumentID)
+			if _, ok := seen[id]; ok {
+				return nil, errors.New("document %d: document %s is repeated", i, id)
+			}
+			seen[id] = struct{}{}
+
+			if _, err := s.pendingRepo.Get(did[:], payload.DocumentID); err == nil {
+				return nil, errors.New("document %d: %v", i, ErrPendingDocumentExists)
+			}
+		}
+
+		doc, err := s.docSrv.Derive(ctx, payload)
+		if err != nil {
+			return nil, errors.New("document %d: %v", i, err)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// waitForBulkJobs waits for the anchor job of each document and records the outcome as a task of the parent job.
+// Tasks are named after the document ID.
+func waitForBulkJobs(did identity.DID, parentID jobs.JobID, jobsMan jobs.Manager, bds []BulkDocument) error {
+	for _, bd := range bds {
+		task := hexutil.Encode(bd.Document.ID())
+		status, msg := jobs.Pending, fmt.Sprintf("anchor job %s", bd.JobID.String())
+		if bd.Error != nil {
+			status, msg = jobs.Failed, fmt.Sprintf("failed to commit: %v", bd.Error)
+		}
+
+		if err := jobsMan.UpdateTaskStatus(did, parentID, status, task, msg); err != nil {
+			return err
+		}
+	}
+
+	var failed int
+	for _, bd := range bds {
+		if bd.Error != nil {
+			failed++
+			continue
+		}
+
+		task := hexutil.Encode(bd.Document.ID())
+		status, msg := jobs.Success, fmt.Sprintf("anchor job %s succeeded", bd.JobID.String())
+		if err := jobsMan.WaitForJob(did, bd.JobID); err != nil {
+			failed++
+			status, msg = jobs.Failed, fmt.Sprintf("anchor job %s failed: %v", bd.JobID.String(), err)
+		}
+
+		if err := jobsMan.UpdateTaskStatus(did, parentID, status, task, msg); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return errors.New("%d of %d documents failed to anchor", failed, len(bds))
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// +build unit
+
+package pending
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_BulkCommit(t *testing.T) {
+	s := service{}
+
+	// missing did
+	_, _, err := s.BulkCommit(context.Background(), nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// no documents
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	_, _, err = s.BulkCommit(ctx, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBulkCommitInvalid, err))
+
+	// too many documents
+	_, _, err = s.BulkCommit(ctx, make([]documents.UpdatePayload, MaxBulkDocuments+1))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBulkCommitInvalid, err))
+
+	// repeated document
+	docID := utils.RandomSlice(32)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found"))
+	s.pendingRepo = repo
+	p1 := documents.UpdatePayload{DocumentID: docID}
+	d1, d2 := new(documents.MockModel), new(documents.MockModel)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Derive", ctx, p1).Return(d1, nil).Once()
+	s.docSrv = docSrv
+	_, _, err = s.BulkCommit(ctx, []documents.UpdatePayload{p1, p1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBulkCommitInvalid, err))
+	assert.Contains(t, err.Error(), "document 1")
+
+	// invalid payload fails before any document is committed
+	p2 := documents.UpdatePayload{CreatePayload: documents.CreatePayload{Scheme: "generic", Data: []byte("2")}}
+	docSrv.On("Derive", ctx, p2).Return(d2, nil)
+	docSrv.On("Derive", ctx, p1).Return(nil, errors.New("failed to derive")).Once()
+	_, _, err = s.BulkCommit(ctx, []documents.UpdatePayload{p2, p1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrBulkCommitInvalid, err))
+
+	// success with one failed commit
+	docSrv.On("Derive", ctx, p1).Return(d1, nil).Once()
+	jobID := jobs.NewJobID()
+	docSrv.On("Commit", ctx, d1).Return(jobID, nil).Once()
+	docSrv.On("Commit", ctx, d2).Return(nil, errors.New("failed to commit")).Once()
+	parentID := jobs.NewJobID()
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("ExecuteWithinJob", mock.Anything, did, jobs.NilJobID(), "bulk commit documents", mock.Anything).
+		Return(parentID, make(chan error), nil).Once()
+	s.jobManager = jobMan
+	pid, bds, err := s.BulkCommit(ctx, []documents.UpdatePayload{p1, p2})
+	assert.NoError(t, err)
+	assert.Equal(t, parentID, pid)
+	assert.Len(t, bds, 2)
+	assert.Equal(t, d1, bds[0].Document)
+	assert.Equal(t, jobID, bds[0].JobID)
+	assert.NoError(t, bds[0].Error)
+	assert.Equal(t, d2, bds[1].Document)
+	assert.Error(t, bds[1].Error)
+	docSrv.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+}
+
+func TestWaitForBulkJobs(t *testing.T) {
+	d1, d2, d3 := new(documents.MockModel), new(documents.MockModel), new(documents.MockModel)
+	id1, id2, id3 := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	d1.On("ID").Return(id1)
+	d2.On("ID").Return(id2)
+	d3.On("ID").Return(id3)
+	j1, j2 := jobs.NewJobID(), jobs.NewJobID()
+	bds := []BulkDocument{
+		{Document: d1, JobID: j1},
+		{Document: d2, JobID: j2},
+		{Document: d3, JobID: jobs.NilJobID(), Error: errors.New("failed to commit")},
+	}
+
+	var did identity.DID
+	parentID := jobs.NewJobID()
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("UpdateTaskStatus", did, parentID, jobs.Pending, hexutil.Encode(id1), mock.Anything).Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, parentID, jobs.Pending, hexutil.Encode(id2), mock.Anything).Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, parentID, jobs.Failed, hexutil.Encode(id3), mock.Anything).Return(nil).Once()
+	jobMan.On("WaitForJob", did, j1).Return(nil).Once()
+	jobMan.On("WaitForJob", did, j2).Return(errors.New("job failed")).Once()
+	jobMan.On("UpdateTaskStatus", did, parentID, jobs.Success, hexutil.Encode(id1), mock.Anything).Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, parentID, jobs.Failed, hexutil.Encode(id2), mock.Anything).Return(nil).Once()
+	err := waitForBulkJobs(did, parentID, jobMan, bds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 3 documents failed")
+	jobMan.AssertExpectations(t)
+
+	// all anchored
+	jobMan = new(testingjobs.MockJobManager)
+	jobMan.On("UpdateTaskStatus", did, parentID, mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(4)
+	jobMan.On("WaitForJob", did, mock.Anything).Return(nil).Twice()
+	assert.NoError(t, waitForBulkJobs(did, parentID, jobMan, bds[:2]))
+	jobMan.AssertExpectations(t)
+}
@@ -10,6 +10,9 @@ import (
 const (
 	// DocPrefix holds the generic prefix of a document in DB
 	DocPrefix string = "pending_document_"
+
+	// TemplatePrefix holds the prefix of a document template in DB
+	TemplatePrefix string = "document_template_"
 )
 
 // Repository defines the required methods for a document repository.
@@ -28,10 +31,24 @@ type Repository interface {
 
 	// Delete deletes the data associated with account and ID.
 	Delete(accountID, id []byte) error
+
+	// CreateTemplate stores the document template of the account.
+	// should error out if the template exists.
+	CreateTemplate(accountID []byte, template *Template) error
+
+	// GetTemplate returns the document template associated with ID, owned by accountID.
+	GetTemplate(accountID, id []byte) (*Template, error)
+
+	// GetTemplates returns all the document templates owned by accountID.
+	GetTemplates(accountID []byte) ([]*Template, error)
+
+	// DeleteTemplate deletes the document template associated with account and ID.
+	DeleteTemplate(accountID, id []byte) error
 }
 
 // NewRepository creates an instance of the pending document Repository
 func NewRepository(db storage.Repository) Repository {
+	db.Register(new(Template))
 	return &repo{db: db}
 }
 
@@ -45,6 +62,12 @@ func (r *repo) getKey(accountID, id []byte) []byte {
 	return append([]byte(DocPrefix), []byte(hexKey)...)
 }
 
+// getTemplateKey returns document_template_+accountID+id
+func (r *repo) getTemplateKey(accountID, id []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, id...))
+	return append([]byte(TemplatePrefix), []byte(hexKey)...)
+}
+
 // Get returns the Model associated with ID, owned by accountID
 func (r *repo) Get(accountID, id []byte) (documents.Model, error) {
 	key := r.getKey(accountID, id)
@@ -77,3 +100,49 @@ func (r *repo) Delete(accountID, id []byte) error {
 	key := r.getKey(accountID, id)
 	return r.db.Delete(key)
 }
+
+// CreateTemplate stores the document template of the account.
+// should error out if the template exists.
+func (r *repo) CreateTemplate(accountID []byte, template *Template) error {
+	key := r.getTemplateKey(accountID, template.ID)
+	return r.db.Create(key, template)
+}
+
+// GetTemplate returns the document template associated with ID, owned by accountID.
+func (r *repo) GetTemplate(accountID, id []byte) (*Template, error) {
+	key := r.getTemplateKey(accountID, id)
+	model, err := r.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := model.(*Template)
+	if !ok {
+		return nil, errors.New("template %s for account %s is of invalid type", hexutil.Encode(id), hexutil.Encode(accountID))
+	}
+
+	return t, nil
+}
+
+// GetTemplates returns all the document templates owned by accountID.
+func (r *repo) GetTemplates(accountID []byte) ([]*Template, error) {
+	iter := r.db.Iterate(storage.IterateOptions{Prefix: []byte(TemplatePrefix + hexutil.Encode(accountID))})
+	defer iter.Release()
+	var templates []*Template
+	for iter.Next() {
+		t, ok := iter.Model().(*Template)
+		if !ok {
+			continue
+		}
+
+		templates = append(templates, t)
+	}
+
+	return templates, iter.Error()
+}
+
+// DeleteTemplate deletes the document template associated with account and ID.
+func (r *repo) DeleteTemplate(accountID, id []byte) error {
+	key := r.getTemplateKey(accountID, id)
+	return r.db.Delete(key)
+}
@@ -167,3 +167,40 @@ func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 		assert.Contains(t, err.Error(), "is not a model object")
 	}
 }
+
+func TestLevelDBRepo_Templates(t *testing.T) {
+	repor := getRepository(ctx)
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	_, err := repor.GetTemplate(accountID, id)
+	assert.Error(t, err)
+
+	attr, err := documents.NewStringAttribute("test", documents.AttrString, "value")
+	assert.NoError(t, err)
+	tmpl := &Template{
+		ID:         id,
+		Name:       "invoice",
+		Scheme:     "generic",
+		Attributes: []documents.Attribute{attr},
+		Roles:      []TemplateRole{{Key: "role", Collaborators: []identity.DID{did}}},
+		Rules:      []AttributeRule{{KeyLabel: "test", RoleID: utils.RandomSlice(32)}},
+		ReadRules:  []ReadRule{{RoleID: utils.RandomSlice(32), Action: "read_sign"}},
+		CreatedAt:  time.Now().UTC(),
+	}
+	assert.NoError(t, repor.CreateTemplate(accountID, tmpl))
+	got, err := repor.GetTemplate(accountID, id)
+	assert.NoError(t, err)
+	assert.Equal(t, tmpl, got)
+
+	// templates of other accounts are not returned
+	assert.NoError(t, repor.CreateTemplate(utils.RandomSlice(32), &Template{ID: utils.RandomSlice(32)}))
+	ts, err := repor.GetTemplates(accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Template{tmpl}, ts)
+
+	assert.NoError(t, repor.DeleteTemplate(accountID, id))
+	_, err = repor.GetTemplate(accountID, id)
+	assert.Error(t, err)
+	ts, err = repor.GetTemplates(accountID)
+	assert.NoError(t, err)
+	assert.Empty(t, ts)
+}
@@ -57,19 +57,41 @@ type Service interface {
 
 	// DeleteTransitionRule deletes the transition rule associated with ruleID in th document.
 	DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error
+
+	// CreateTemplate validates and stores the document template of the account.
+	CreateTemplate(ctx context.Context, template Template) (*Template, error)
+
+	// GetTemplate returns the document template of the account.
+	GetTemplate(ctx context.Context, templateID []byte) (*Template, error)
+
+	// GetTemplates returns all the document templates of the account.
+	GetTemplates(ctx context.Context) ([]*Template, error)
+
+	// DeleteTemplate deletes the document template of the account.
+	DeleteTemplate(ctx context.Context, templateID []byte) error
+
+	// CreateFromTemplate creates a new pending document from the template.
+	// Attributes, collaborators and data in the payload are applied over the template defaults.
+	CreateFromTemplate(ctx context.Context, templateID []byte, payload documents.CreatePayload) (documents.Model, error)
+
+	// BulkCommit creates the documents from the payloads and commits them.
+	// Each document is anchored in its own job and the returned parent job tracks all of them.
+	BulkCommit(ctx context.Context, payloads []documents.UpdatePayload) (jobs.JobID, []BulkDocument, error)
 }
 
 // service implements Service
 type service struct {
 	docSrv      documents.Service
 	pendingRepo Repository
+	jobManager  jobs.Manager
 }
 
 // DefaultService returns the default implementation of the service
-func DefaultService(docSrv documents.Service, repo Repository) Service {
+func DefaultService(docSrv documents.Service, repo Repository, jobManager jobs.Manager) Service {
 	return service{
 		docSrv:      docSrv,
 		pendingRepo: repo,
+		jobManager:  jobManager,
 	}
 }
 
@@ -163,6 +185,11 @@ func (s service) Update(ctx context.Context, payload documents.UpdatePayload) (d
 		return nil, documents.ErrNotPatcher
 	}
 
+	payload.Attributes, err = documents.PayloadAttributes(payload.CreatePayload)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalid, err)
+	}
+
 	err = mp.Patch(payload)
 	if err != nil {
 		return nil, err
@@ -291,6 +318,28 @@ type AttributeRule struct {
 	RoleID byteutils.HexBytes `json:"role_id" swaggertype:"primitive,string"`
 }
 
+// ReadRule gives read access to the document for the collaborators of the role with RoleID.
+// Note: role ID should already exist in the document.
+type ReadRule struct {
+	// roleID is 32 byte role ID in hex. RoleID should already be part of the document.
+	RoleID byteutils.HexBytes `json:"role_id" swaggertype:"primitive,string"`
+
+	// action is either read or read_sign. Collaborators with read_sign are expected to sign the document.
+	Action string `json:"action" enums:"read,read_sign"`
+}
+
+// readAction returns the read rule action.
+func (r ReadRule) readAction() (coredocumentpb.Action, error) {
+	switch r.Action {
+	case "read":
+		return coredocumentpb.Action_ACTION_READ, nil
+	case "read_sign":
+		return coredocumentpb.Action_ACTION_READ_SIGN, nil
+	default:
+		return 0, errors.NewTypedError(documents.ErrInvalidReadAction, errors.New("unknown action %s", r.Action))
+	}
+}
+
 // AddTransitionRules contains list of attribute rules to be created.
 type AddTransitionRules struct {
 	AttributeRules []AttributeRule `json:"attribute_rules"`
@@ -46,6 +46,28 @@ func (m *mockRepo) Update(accID, id []byte, doc documents.Model) error {
 	return args.Error(0)
 }
 
+func (m *mockRepo) CreateTemplate(accID []byte, t *Template) error {
+	args := m.Called(accID, t)
+	return args.Error(0)
+}
+
+func (m *mockRepo) GetTemplate(accID, id []byte) (*Template, error) {
+	args := m.Called(accID, id)
+	t, _ := args.Get(0).(*Template)
+	return t, args.Error(1)
+}
+
+func (m *mockRepo) GetTemplates(accID []byte) ([]*Template, error) {
+	args := m.Called(accID)
+	ts, _ := args.Get(0).([]*Template)
+	return ts, args.Error(1)
+}
+
+func (m *mockRepo) DeleteTemplate(accID, id []byte) error {
+	args := m.Called(accID, id)
+	return args.Error(0)
+}
+
 func TestService_Commit(t *testing.T) {
 	s := service{}
 
@@ -216,10 +238,19 @@ func TestService_Update(t *testing.T) {
 	_, err = s.Update(ctx, payload)
 	assert.Error(t, err)
 
-	// Patch error
+	// reserved attribute
 	oldModel := new(documents.MockModel)
-	oldModel.On("Patch", payload).Return(errors.New("error patching")).Once()
 	repo.On("Get", did[:], payload.DocumentID).Return(oldModel, nil)
+	policy, err := documents.NewStringAttribute(documents.SignaturePolicyLabel, documents.AttrString, "all")
+	assert.NoError(t, err)
+	_, err = s.Update(ctx, documents.UpdatePayload{CreatePayload: documents.CreatePayload{
+		Attributes: map[documents.AttrKey]documents.Attribute{policy.Key: policy},
+	}})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrReservedAttr, err))
+
+	// Patch error
+	oldModel.On("Patch", payload).Return(errors.New("error patching")).Once()
 	_, err = s.Update(ctx, payload)
 	assert.Error(t, err)
 
@@ -0,0 +1,248 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrTemplateNotFound is a sentinel error used when the document template is not found.
+	ErrTemplateNotFound = errors.Error("document template not found")
+
+	// ErrTemplateInvalid is a sentinel error used when the document template is not valid.
+	ErrTemplateInvalid = errors.Error("invalid document template")
+
+	// ErrTemplateSchemeMismatch is a sentinel error used when the scheme of the payload differs from the template.
+	ErrTemplateSchemeMismatch = errors.Error("scheme does not match the document template")
+)
+
+// TemplateRole is a role added to the documents created from the template.
+type TemplateRole struct {
+	// Key is either hex encoded 32 byte ID or string label.
+	Key           string         `json:"key"`
+	Collaborators []identity.DID `json:"collaborators"`
+}
+
+// Template holds the scheme, default attributes, collaborators, roles, transition rules and read rules
+// used to create pending documents of an account.
+// Rules refer to the roles of the template with the 32 byte role key of the role.
+type Template struct {
+	ID            []byte                        `json:"id"`
+	Name          string                        `json:"name"`
+	Scheme        string                        `json:"scheme"`
+	Attributes    []documents.Attribute         `json:"attributes"`
+	Collaborators documents.CollaboratorsAccess `json:"collaborators"`
+	Roles         []TemplateRole                `json:"roles"`
+	Rules         []AttributeRule               `json:"rules"`
+	ReadRules     []ReadRule                    `json:"read_rules"`
+	CreatedAt     time.Time                     `json:"created_at"`
+}
+
+// JSON marshals Template to json bytes.
+func (t *Template) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// Type returns the type of Template.
+func (t *Template) Type() reflect.Type {
+	return reflect.TypeOf(t)
+}
+
+// FromJSON loads json bytes to Template.
+func (t *Template) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// validate checks that the roles are unique and the rules refer to the roles of the template.
+func (t *Template) validate() error {
+	if strings.TrimSpace(t.Scheme) == "" {
+		return errors.New("scheme is empty")
+	}
+
+	roles := make(map[string]struct{})
+	for _, r := range t.Roles {
+		if len(r.Collaborators) < 1 {
+			return errors.New("role %s has no collaborators", r.Key)
+		}
+
+		rk, err := documents.RoleKeyFromString(r.Key)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := roles[hexutil.Encode(rk)]; ok {
+			return errors.New("role %s is defined more than once", r.Key)
+		}
+
+		roles[hexutil.Encode(rk)] = struct{}{}
+	}
+
+	for _, r := range t.Rules {
+		if _, err := documents.AttrKeyFromLabel(r.KeyLabel); err != nil {
+			return err
+		}
+
+		if _, ok := roles[r.RoleID.String()]; !ok {
+			return errors.New("rule for %s refers to unknown role %s", r.KeyLabel, r.RoleID.String())
+		}
+	}
+
+	for _, r := range t.ReadRules {
+		if _, err := r.readAction(); err != nil {
+			return err
+		}
+
+		if _, ok := roles[r.RoleID.String()]; !ok {
+			return errors.New("read rule refers to unknown role %s", r.RoleID.String())
+		}
+	}
+
+	return nil
+}
+
+// CreateTemplate validates and stores the document template of the account.
+func (s service) CreateTemplate(ctx context.Context, template Template) (*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if err := template.validate(); err != nil {
+		return nil, errors.NewTypedError(ErrTemplateInvalid, err)
+	}
+
+	if _, err := s.docSrv.New(template.Scheme); err != nil {
+		return nil, errors.NewTypedError(ErrTemplateInvalid, err)
+	}
+
+	template.ID = utils.RandomSlice(32)
+	template.CreatedAt = time.Now().UTC()
+	return &template, s.pendingRepo.CreateTemplate(did[:], &template)
+}
+
+// GetTemplate returns the document template of the account.
+func (s service) GetTemplate(ctx context.Context, templateID []byte) (*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	t, err := s.pendingRepo.GetTemplate(did[:], templateID)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrTemplateNotFound, err)
+	}
+
+	return t, nil
+}
+
+// GetTemplates returns all the document templates of the account.
+func (s service) GetTemplates(ctx context.Context) ([]*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.pendingRepo.GetTemplates(did[:])
+}
+
+// DeleteTemplate deletes the document template of the account.
+func (s service) DeleteTemplate(ctx context.Context, templateID []byte) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return contextutil.ErrDIDMissingFromContext
+	}
+
+	if _, err := s.pendingRepo.GetTemplate(did[:], templateID); err != nil {
+		return errors.NewTypedError(ErrTemplateNotFound, err)
+	}
+
+	return s.pendingRepo.DeleteTemplate(did[:], templateID)
+}
+
+// CreateFromTemplate creates a new pending document from the template.
+// Attributes, collaborators and data in the payload are applied over the template defaults.
+// Scheme of the payload, if given, must match the scheme of the template.
+// Roles, transition rules and read rules of the template are added to the document before it is stored.
+func (s service) CreateFromTemplate(ctx context.Context, templateID []byte, payload documents.CreatePayload) (documents.Model, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	t, err := s.pendingRepo.GetTemplate(did[:], templateID)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrTemplateNotFound, err)
+	}
+
+	if payload.Scheme != "" && payload.Scheme != t.Scheme {
+		return nil, errors.NewTypedError(ErrTemplateSchemeMismatch,
+			errors.New("expected scheme %s but got %s", t.Scheme, payload.Scheme))
+	}
+
+	attrs := make(map[documents.AttrKey]documents.Attribute)
+	for _, attr := range t.Attributes {
+		attrs[attr.Key] = attr
+	}
+
+	for k, attr := range payload.Attributes {
+		attrs[k] = attr
+	}
+
+	cp := documents.CreatePayload{
+		Scheme:          t.Scheme,
+		Attributes:      attrs,
+		Data:            payload.Data,
+		SignaturePolicy: payload.SignaturePolicy,
+		Collaborators: documents.CollaboratorsAccess{
+			ReadCollaborators: append(append([]identity.DID{},
+				t.Collaborators.ReadCollaborators...), payload.Collaborators.ReadCollaborators...),
+			ReadWriteCollaborators: append(append([]identity.DID{},
+				t.Collaborators.ReadWriteCollaborators...), payload.Collaborators.ReadWriteCollaborators...),
+		},
+	}
+
+	doc, err := s.docSrv.Derive(ctx, documents.UpdatePayload{CreatePayload: cp})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range t.Roles {
+		if _, err := doc.AddRole(r.Key, r.Collaborators); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, r := range t.Rules {
+		key, err := documents.AttrKeyFromLabel(r.KeyLabel)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := doc.AddTransitionRuleForAttribute(r.RoleID[:], key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, r := range t.ReadRules {
+		action, err := r.readAction()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := doc.AddReadRule(r.RoleID[:], action); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, s.pendingRepo.Create(did[:], doc.ID(), doc)
+}
@@ -0,0 +1,311 @@
+// +build unit
+
+package pending
+
+import (
+	"context"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTemplate_validate(t *testing.T) {
+	collabs := []identity.DID{testingidentity.GenerateRandomDID()}
+	roleID, err := documents.RoleKeyFromString("role")
+	assert.NoError(t, err)
+	tests := []struct {
+		template Template
+		err      string
+	}{
+		{
+			template: Template{},
+			err:      "scheme is empty",
+		},
+
+		{
+			template: Template{Scheme: "generic", Roles: []TemplateRole{{Collaborators: collabs}}},
+			err:      documents.ErrEmptyRoleKey.Error(),
+		},
+
+		{
+			template: Template{Scheme: "generic", Roles: []TemplateRole{{Key: "role"}}},
+			err:      "has no collaborators",
+		},
+
+		{
+			template: Template{Scheme: "generic", Roles: []TemplateRole{
+				{Key: "role", Collaborators: collabs},
+				{Key: "role", Collaborators: collabs},
+			}},
+			err: "more than once",
+		},
+
+		// same role with the label and the hex key
+		{
+			template: Template{Scheme: "generic", Roles: []TemplateRole{
+				{Key: "role", Collaborators: collabs},
+				{Key: hexutil.Encode(roleID), Collaborators: collabs},
+			}},
+			err: "more than once",
+		},
+
+		{
+			template: Template{Scheme: "generic", Rules: []AttributeRule{{RoleID: roleID}}},
+			err:      "empty",
+		},
+
+		{
+			template: Template{
+				Scheme: "generic",
+				Roles:  []TemplateRole{{Key: "role", Collaborators: collabs}},
+				Rules:  []AttributeRule{{KeyLabel: "test", RoleID: utils.RandomSlice(32)}},
+			},
+			err: "unknown role",
+		},
+
+		{
+			template: Template{
+				Scheme:    "generic",
+				Roles:     []TemplateRole{{Key: "role", Collaborators: collabs}},
+				ReadRules: []ReadRule{{RoleID: roleID, Action: "write"}},
+			},
+			err: documents.ErrInvalidReadAction.Error(),
+		},
+
+		{
+			template: Template{
+				Scheme:    "generic",
+				Roles:     []TemplateRole{{Key: "role", Collaborators: collabs}},
+				ReadRules: []ReadRule{{RoleID: utils.RandomSlice(32), Action: "read"}},
+			},
+			err: "unknown role",
+		},
+
+		{
+			template: Template{
+				Scheme:    "generic",
+				Roles:     []TemplateRole{{Key: "role", Collaborators: collabs}},
+				Rules:     []AttributeRule{{KeyLabel: "test", RoleID: roleID}},
+				ReadRules: []ReadRule{{RoleID: roleID, Action: "read"}},
+			},
+		},
+	}
+
+	for _, c := range tests {
+		err := c.template.validate()
+		if c.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), c.err)
+		}
+	}
+}
+
+func TestService_CreateTemplate(t *testing.T) {
+	s := service{}
+
+	// missing did
+	_, err := s.CreateTemplate(context.Background(), Template{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// invalid template
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	_, err = s.CreateTemplate(ctx, Template{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateInvalid, err))
+
+	// unknown scheme
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("New", "unknown").Return(nil, errors.New("unknown scheme")).Once()
+	s.docSrv = docSrv
+	_, err = s.CreateTemplate(ctx, Template{Scheme: "unknown"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateInvalid, err))
+
+	// success
+	docSrv.On("New", "generic").Return(new(documents.MockModel), nil).Once()
+	repo := new(mockRepo)
+	repo.On("CreateTemplate", did[:], mock.Anything).Return(nil).Once()
+	s.pendingRepo = repo
+	tmpl, err := s.CreateTemplate(ctx, Template{Name: "test", Scheme: "generic"})
+	assert.NoError(t, err)
+	assert.Len(t, tmpl.ID, 32)
+	assert.False(t, tmpl.CreatedAt.IsZero())
+	assert.Equal(t, "test", tmpl.Name)
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestService_GetTemplate(t *testing.T) {
+	s := service{}
+	id := utils.RandomSlice(32)
+
+	// missing did
+	_, err := s.GetTemplate(context.Background(), id)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing template
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("GetTemplate", did[:], id).Return(nil, errors.New("not found")).Once()
+	s.pendingRepo = repo
+	_, err = s.GetTemplate(ctx, id)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+
+	// success
+	tmpl := &Template{ID: id}
+	repo.On("GetTemplate", did[:], id).Return(tmpl, nil).Once()
+	got, err := s.GetTemplate(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, tmpl, got)
+
+	// all templates
+	repo.On("GetTemplates", did[:]).Return([]*Template{tmpl}, nil).Once()
+	ts, err := s.GetTemplates(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Template{tmpl}, ts)
+	repo.AssertExpectations(t)
+}
+
+func TestService_DeleteTemplate(t *testing.T) {
+	s := service{}
+	id := utils.RandomSlice(32)
+
+	// missing did
+	err := s.DeleteTemplate(context.Background(), id)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing template
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("GetTemplate", did[:], id).Return(nil, errors.New("not found")).Once()
+	s.pendingRepo = repo
+	err = s.DeleteTemplate(ctx, id)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+
+	// success
+	repo.On("GetTemplate", did[:], id).Return(&Template{ID: id}, nil).Once()
+	repo.On("DeleteTemplate", did[:], id).Return(nil).Once()
+	assert.NoError(t, s.DeleteTemplate(ctx, id))
+	repo.AssertExpectations(t)
+}
+
+func TestService_CreateFromTemplate(t *testing.T) {
+	s := service{}
+	id := utils.RandomSlice(32)
+
+	// missing did
+	_, err := s.CreateFromTemplate(context.Background(), id, documents.CreatePayload{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing template
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("GetTemplate", did[:], id).Return(nil, errors.New("not found")).Once()
+	s.pendingRepo = repo
+	_, err = s.CreateFromTemplate(ctx, id, documents.CreatePayload{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+
+	// payload attributes and collaborators are applied over the template
+	tattr, err := documents.NewStringAttribute("test", documents.AttrString, "template")
+	assert.NoError(t, err)
+	other, err := documents.NewStringAttribute("other", documents.AttrString, "template")
+	assert.NoError(t, err)
+	pattr, err := documents.NewStringAttribute("test", documents.AttrString, "payload")
+	assert.NoError(t, err)
+	tcollab, pcollab := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	roleCollabs := []identity.DID{testingidentity.GenerateRandomDID()}
+	roleID, err := documents.RoleKeyFromString("role")
+	assert.NoError(t, err)
+	tmpl := &Template{
+		ID:            id,
+		Scheme:        "generic",
+		Attributes:    []documents.Attribute{tattr, other},
+		Collaborators: documents.CollaboratorsAccess{ReadCollaborators: []identity.DID{tcollab}},
+		Roles:         []TemplateRole{{Key: "role", Collaborators: roleCollabs}},
+		Rules:         []AttributeRule{{KeyLabel: "test", RoleID: roleID}},
+		ReadRules:     []ReadRule{{RoleID: roleID, Action: "read_sign"}},
+	}
+	repo.On("GetTemplate", did[:], id).Return(tmpl, nil)
+
+	// scheme differs from the template
+	payload := documents.CreatePayload{
+		Scheme:        "entity",
+		Data:          []byte("data"),
+		Attributes:    map[documents.AttrKey]documents.Attribute{pattr.Key: pattr},
+		Collaborators: documents.CollaboratorsAccess{ReadCollaborators: []identity.DID{pcollab}},
+	}
+	_, err = s.CreateFromTemplate(ctx, id, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateSchemeMismatch, err))
+
+	payload.Scheme = ""
+	expected := documents.UpdatePayload{CreatePayload: documents.CreatePayload{
+		Scheme: "generic",
+		Data:   []byte("data"),
+		Attributes: map[documents.AttrKey]documents.Attribute{
+			pattr.Key: pattr,
+			other.Key: other,
+		},
+		Collaborators: documents.CollaboratorsAccess{
+			ReadCollaborators:      []identity.DID{tcollab, pcollab},
+			ReadWriteCollaborators: []identity.DID{},
+		},
+	}}
+
+	// failed derive
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Derive", ctx, expected).Return(nil, errors.New("failed to derive")).Once()
+	s.docSrv = docSrv
+	_, err = s.CreateFromTemplate(ctx, id, payload)
+	assert.Error(t, err)
+
+	// failed to add role
+	doc := new(documents.MockModel)
+	docSrv.On("Derive", ctx, expected).Return(doc, nil)
+	doc.On("AddRole", "role", roleCollabs).Return(nil, errors.New("failed to add role")).Once()
+	_, err = s.CreateFromTemplate(ctx, id, payload)
+	assert.Error(t, err)
+
+	// failed to add read rule
+	doc.On("AddRole", "role", roleCollabs).Return(&coredocumentpb.Role{RoleKey: roleID}, nil).Once()
+	doc.On("AddTransitionRuleForAttribute", roleID, tattr.Key).Return(new(coredocumentpb.TransitionRule), nil).Once()
+	doc.On("AddReadRule", roleID, coredocumentpb.Action_ACTION_READ_SIGN).Return(nil, documents.ErrRoleNotExist).Once()
+	_, err = s.CreateFromTemplate(ctx, id, payload)
+	assert.Error(t, err)
+
+	// success, matching scheme is accepted
+	payload.Scheme = "generic"
+	doc.On("AddRole", "role", roleCollabs).Return(&coredocumentpb.Role{RoleKey: roleID}, nil).Once()
+	doc.On("AddTransitionRuleForAttribute", roleID, tattr.Key).Return(new(coredocumentpb.TransitionRule), nil).Once()
+	doc.On("AddReadRule", roleID, coredocumentpb.Action_ACTION_READ_SIGN).Return(new(coredocumentpb.ReadRule), nil).Once()
+	doc.On("ID").Return(id)
+	repo.On("Create", did[:], id, doc).Return(nil).Once()
+	got, err := s.CreateFromTemplate(ctx, id, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, got)
+	repo.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
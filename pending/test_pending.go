@@ -102,3 +102,38 @@ func (m *MockService) DeleteTransitionRule(ctx context.Context, docID, ruleID []
 	args := m.Called(ctx, docID, ruleID)
 	return args.Error(0)
 }
+
+func (m *MockService) CreateTemplate(ctx context.Context, template Template) (*Template, error) {
+	args := m.Called(ctx, template)
+	t, _ := args.Get(0).(*Template)
+	return t, args.Error(1)
+}
+
+func (m *MockService) GetTemplate(ctx context.Context, templateID []byte) (*Template, error) {
+	args := m.Called(ctx, templateID)
+	t, _ := args.Get(0).(*Template)
+	return t, args.Error(1)
+}
+
+func (m *MockService) GetTemplates(ctx context.Context) ([]*Template, error) {
+	args := m.Called(ctx)
+	ts, _ := args.Get(0).([]*Template)
+	return ts, args.Error(1)
+}
+
+func (m *MockService) DeleteTemplate(ctx context.Context, templateID []byte) error {
+	args := m.Called(ctx, templateID)
+	return args.Error(0)
+}
+
+func (m *MockService) CreateFromTemplate(ctx context.Context, templateID []byte, payload documents.CreatePayload) (documents.Model, error) {
+	args := m.Called(ctx, templateID, payload)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) BulkCommit(ctx context.Context, payloads []documents.UpdatePayload) (jobs.JobID, []BulkDocument, error) {
+	args := m.Called(ctx, payloads)
+	bds, _ := args.Get(1).([]BulkDocument)
+	return args.Get(0).(jobs.JobID), bds, args.Error(2)
+}
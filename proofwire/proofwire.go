@@ -0,0 +1,131 @@
+// Package proofwire defines the formats of the document proofs and proof bundles handed out by the node.
+// Package has no dependencies on the node so that the proofs can be verified without one.
+package proofwire
+
+import (
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+)
+
+const (
+	// BundleVersion is the version of the proof bundle format.
+	BundleVersion = 1
+
+	// BasicTree is the data tree with sorted hashing. Proofs are generated from it by default.
+	BasicTree = "basic"
+
+	// ZKTree is the fixed depth data tree with ordered hashing that zk circuits verify the proofs against.
+	ZKTree = "zk"
+
+	// ZKTreeDepth is the depth of the ZKTree.
+	ZKTreeDepth = 20
+)
+
+// SignaturesTreeProperty returns the compact property prefix of the signatures tree fields.
+func SignaturesTreeProperty() []byte {
+	return []byte{3, 0, 0, 0}
+}
+
+// DRTreeProperty returns the compact property prefix of the document root tree fields.
+func DRTreeProperty() []byte {
+	return []byte{4, 0, 0, 0}
+}
+
+// Proof represents a single proof
+type Proof struct {
+	Property     byteutils.HexBytes   `json:"property" swaggertype:"primitive,string"`
+	Value        byteutils.HexBytes   `json:"value" swaggertype:"primitive,string"`
+	Salt         byteutils.HexBytes   `json:"salt" swaggertype:"primitive,string"`
+	Hash         byteutils.HexBytes   `json:"hash" swaggertype:"primitive,string"`
+	SortedHashes []byteutils.HexBytes `json:"sorted_hashes" swaggertype:"array,string"`
+
+	// Hashes are set instead of SortedHashes for the fields of the ordered trees.
+	Hashes []MerkleHash `json:"hashes,omitempty"`
+}
+
+// MerkleHash is a sibling in the proof of an ordered tree. Only one of Left or Right is set.
+type MerkleHash struct {
+	Left  byteutils.HexBytes `json:"left,omitempty" swaggertype:"primitive,string"`
+	Right byteutils.HexBytes `json:"right,omitempty" swaggertype:"primitive,string"`
+}
+
+// CircuitProof is the proof of a ZKTree field in the format consumed by the zk circuits.
+// Siblings are ordered from the leaf to the root. PathIndices holds 1 at the levels the path node is the right child
+// and 0 otherwise, which are the bits of the LeafIndex.
+type CircuitProof struct {
+	Property    byteutils.HexBytes   `json:"property" swaggertype:"primitive,string"`
+	Leaf        byteutils.HexBytes   `json:"leaf" swaggertype:"primitive,string"`
+	LeafIndex   uint64               `json:"leaf_index"`
+	Siblings    []byteutils.HexBytes `json:"siblings" swaggertype:"array,string"`
+	PathIndices []int                `json:"path_indices"`
+}
+
+// ProofResponseHeader holds the document details.
+type ProofResponseHeader struct {
+	DocumentID    byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID     byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	State         string             `json:"state"`
+	DocumentRoot  byteutils.HexBytes `json:"document_root,omitempty" swaggertype:"primitive,string"`
+	AnchoredAt    string             `json:"anchored_at,omitempty"`
+	AnchoredBlock uint32             `json:"anchored_block,omitempty"`
+
+	// roots the field proofs lead to. Document root is derived from the signing and signatures roots.
+	SigningRoot    byteutils.HexBytes `json:"signing_root" swaggertype:"primitive,string"`
+	SignaturesRoot byteutils.HexBytes `json:"signatures_root" swaggertype:"primitive,string"`
+	BasicDataRoot  byteutils.HexBytes `json:"basic_data_root" swaggertype:"primitive,string"`
+	ZKDataRoot     byteutils.HexBytes `json:"zk_data_root" swaggertype:"primitive,string"`
+
+	// data tree the data and cd_tree field proofs lead to. Empty is the basic tree.
+	Tree string `json:"tree,omitempty"`
+}
+
+// ProofsResponse holds the proofs for the fields given for a document.
+// Circuit proofs are set for the data tree fields when the proofs are generated from the zk tree.
+type ProofsResponse struct {
+	Header        ProofResponseHeader `json:"header"`
+	FieldProofs   []Proof             `json:"field_proofs"`
+	CircuitProofs []CircuitProof      `json:"circuit_proofs,omitempty"`
+}
+
+// ProofBundleSignature holds a collaborator signature on the document version along with the validity of the signing key.
+type ProofBundleSignature struct {
+	SignatureID         byteutils.HexBytes `json:"signature_id" swaggertype:"primitive,string"`
+	SignerID            byteutils.HexBytes `json:"signer_id" swaggertype:"primitive,string"`
+	PublicKey           byteutils.HexBytes `json:"public_key" swaggertype:"primitive,string"`
+	Signature           byteutils.HexBytes `json:"signature" swaggertype:"primitive,string"`
+	TransitionValidated bool               `json:"transition_validated"`
+
+	// validity of the signing key at the anchoring time as per the signer's identity, as seen by the exporting node.
+	// Verifiers must check the key against the identity themselves.
+	KeyValid     bool   `json:"key_valid"`
+	KeyRevokedAt uint32 `json:"key_revoked_at,omitempty"`
+}
+
+// ProofBundle is a self-contained export of the proofs of a document version.
+// Proofs contain a field proof for each of the signatures.
+type ProofBundle struct {
+	Version    int                    `json:"version"`
+	AnchorID   byteutils.HexBytes     `json:"anchor_id" swaggertype:"primitive,string"`
+	Proofs     ProofsResponse         `json:"proofs"`
+	Signatures []ProofBundleSignature `json:"signatures"`
+}
+
+// SigningPayload returns the payload the collaborators sign for the signing root.
+func SigningPayload(signingRoot []byte, transitionValidated bool) []byte {
+	return appendFlag(signingRoot, transitionValidated)
+}
+
+// SignatureLeafValue returns the value of the signatures tree leaf of the signature.
+func SignatureLeafValue(signature []byte, transitionValidated bool) []byte {
+	return appendFlag(signature, transitionValidated)
+}
+
+// appendFlag returns a copy of b with the flag byte appended.
+func appendFlag(b []byte, flag bool) []byte {
+	res := make([]byte, len(b), len(b)+1)
+	copy(res, b)
+	if flag {
+		return append(res, 1)
+	}
+
+	return append(res, 0)
+}
@@ -0,0 +1,26 @@
+// +build unit
+
+package proofwire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningPayload(t *testing.T) {
+	root := []byte{1, 2, 3}
+	assert.Equal(t, []byte{1, 2, 3, 1}, SigningPayload(root, true))
+	assert.Equal(t, []byte{1, 2, 3, 0}, SigningPayload(root, false))
+	assert.Equal(t, []byte{1, 2, 3}, root)
+}
+
+func TestSignatureLeafValue(t *testing.T) {
+	sig := make([]byte, 3, 10)
+	v := SignatureLeafValue(sig, true)
+	assert.Equal(t, []byte{0, 0, 0, 1}, v)
+
+	// appending to the result must not touch the signature
+	v[0] = 5
+	assert.Equal(t, []byte{0, 0, 0}, sig)
+}
@@ -25,7 +25,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xdd\x6f\xdb\xb0\x11\x7f\xd7\x5f\x71\x70\x5e\xda\xa1\xb5\x2d\xf9\x23\x8e\x80\x3d\x38\x71\xe2\xa6\xf9\x80\x13\xa7\x49\xdb\x97\x81\xa6\x4e\x12\x6b\x89\x54\x49\xca\xb6\xf2\xd7\x0f\x47\x49\x4e\xd2\xb4\xeb\xd6\x61\x03\x06\x2c\x2f\x11\x48\xde\xef\x8e\x77\xbf\xfb\xa0\x0f\x60\x86\x31\x2b\x33\x0b\x11\x6e\x30\x53\x45\x8e\xd2\x82\x45\x63\x25\x5a\x60\x09\x13\xd2\x58\x58\xab\x0d\x93\x1e\x47\x69\xb5\x88\xcb\x04\xaf\xd1\x6e\x95\x5e\x87\x10\x67\x42\x5a\xcf\x81\x08\x89\x60\x53\x84\xa8\xc1\x93\xf5\x19\x03\x36\x65\x16\x4e\xf6\xb2\x90\x33\x21\x2d\xe1\x7a\xed\x91\xd0\x03\x38\x80\x4b\xc5\x59\xe6\x54\x0b\x99\x00\x57\xd2\x6a\xc6\x2d\xb0\x28\xd2\x68\x0c\x1a\x90\x88\x11\x58\x05\x2b\x04\x83\x16\xb6\xc2\xa6\x80\x72\x03\x1b\xa6\x05\x5b\x65\x68\xba\x1e\xb4\xf2\x04\x09\x20\xa2\x10\x06\x83\x81\xfb\x46\x9b\xa2\xc6\x32\x6f\x6c\x3f\x8f\x42\x98\x0c\x26\xf5\xde\x4a\x29\x6b\xac\x66\xc5\x02\x51\x9b\x5a\xf6\x3d\x74\x7a\xa2\x18\xf6\xfc\xe0\xb0\xdb\xef\xf6\xbb\x7e\xcf\xf2\xa2\x37\x98\x04\xfd\xa0\x27\x8a\xd8\xf4\x6e\xf2\xbb\x9b\xdd\x6a\xbb\x2e\xbf\x7e\xf9\x32\x8b\xcb\xc7\xbb\xd5\xee\x74\x7a\x8b\x77\xd7\x27\x97\xea\xb1\xaa\x46\xa3\xc9\xe6\x46\x26\xf7\x9b\xc5\xd5\xb7\xcb\x2f\xeb\xce\x6f\x40\x07\x2d\xe8\x7d\x3c\x3e\xbd\x1e\xe7\xeb\xef\x0f\xf8\xed\xe1\xe2\x21\xf8\xbe\x28\xfd\xf1\xe7\x22\x9a\x0f\xd6\x1f\x95\x7f\x37\xc8\x53\x96\x2e\x8e\x47\x4b\x1c\x49\xbf\x06\x6d\x5d\x35\x6d\x3d\x55\x5f\x80\xae\x8f\xd2\x0a\x5b\x9d\x31\x6e\x95\xae\x42\xe8\x74\x3c\xe7\xea\x2b\x26\xe4\xab\x80\xb7\x11\x83\x37\x17\x14\xee\xb7\x1e\xd4\xe1\xad\xd1\x0e\xe0\xba\xcc\x51\x0b\x0e\xe7\x33\x50\xb1\x0b\xf5\xb3\xa0\x36\xb2\x7b\xaf\xfb\x41\x23\x75\xdc\xba\x16\x32\x61\x2c\x49\x4a\x15\xe1\x6b\x56\x14\x5a\x6d\x84\xdb\x50\x0e\xdb\xa9\x6e\x89\xf8\xdb\x20\x0d\x46\xdd\x60\x18\x74\x83\x41\xbf\xeb\xfb\xe3\x1f\x23\xe5\x07\xb3\xc1\x85\x52\x0f\xcb\xd5\x6e\x75\x71\xb2\xfa\x9a\x1e\x7d\xbc\xb7\xe6\xa6\xba\x9f\x47\x77\x0b\xcd\x86\xb7\xc5\x72\x3a\xb4\xab\x8d\x19\x33\xe9\xfb\xdf\xb6\xf3\x69\xf0\xd8\x79\x85\x3f\x18\x76\x0f\x83\xae\x1f\x1c\xfe\x0a\xfe\x26\x0f\xf8\x32\xd7\xa7\x82\x2d\xaf\xee\x87\xc9\xa7\xcd\xe1\xc3\x3c\x2d\x92\xdb\xad\x9a\x6c\xd5\xd9\xd2\x7c\x48\xbf\xce\x57\x73\x31\x60\xd3\xc9\xae\xd3\xb8\xe7\xb4\x61\xe5\xde\xf9\xe7\x33\x78\x0f\x2e\x00\xbf\x62\xed\xb0\x75\xed\x25\x73\x61\x8b\xb0\xc8\x54\x85\x11\x2c\x73\xa6\x2d\x9c\x34\x6c\x30\x10\x2b\xed\x5c\x99\x88\x0d\xca\x17\xae\xfc\x17\x18\xd3\xdf\xf9\x83\x71\x70\xca\x8f\xe3\xc9\xf8\xf0\x28\x18\x0e\x4e\x83\x61\x3c\xed\x9f\x9e\x0c\x83\x51\x14\xa0\xdf\x9f\xf6\x27\x41\x30\xe0\x87\xb3\xe7\xdc\x32\x96\x25\x94\xc5\xaf\x29\xc5\xf2\x15\xea\x3f\xa3\x94\xff\x6f\x52\xca\xa9\xfe\x2d\xa5\xfe\xf3\xa4\xfa\x3f\xad\xfe\x90\x56\xd4\x92\x9e\x58\x91\xd7\x2b\x7f\xc6\xa5\xfe\x3f\x53\x52\xfc\xa3\x49\xd7\x0f\x82\xae\xef\xff\x32\x38\xd3\x64\x70\xca\xa7\x56\x7f\xb9\x3f\xd9\x6d\x1f\xc7\xeb\xb1\xb9\x3b\x12\x5f\x97\xb7\x8f\xf6\xf1\x68\x76\x58\x7d\x7a\x2c\x8e\x17\xb7\xa7\x67\x8f\xfa\x93\xba\x7f\x5d\x52\x88\x5d\x81\xdf\xf5\xfd\x57\xcd\xa5\xc5\xbf\x98\x6f\xc5\xee\x33\xca\xf2\xf3\xf4\xfe\xfb\xfa\xe3\x45\x2e\x3f\x2c\xa7\x1f\x67\xdf\x1e\xe3\x43\x9c\x5f\xa9\xb1\xd5\x4a\x24\x5f\x77\xf9\xe1\x74\x74\xfb\x8f\x83\xdf\xb8\xeb\x57\xe1\xf7\xff\xbb\xd1\x9f\x9e\x0d\x47\x63\xee\x8f\x07\x93\x31\x1b\x0f\xe3\x68\x78\x36\x5c\x8d\x8f\x58\xec\x0f\xd8\x64\x3c\x8b\xfb\xc7\xa3\x71\x30\x65\xfd\x7e\xc7\xa3\xe9\x82\x59\x06\x4b\xab\x34\x4b\xd0\x33\xf5\xff\x7a\x66\x58\x30\x9b\x3a\x93\x32\x6a\x66\xb3\x63\x88\x45\x86\x1e\x40\xc1\x6c\x1a\x42\xcf\xe6\x45\xef\x69\x6a\xf9\x5b\xc4\x2c\xeb\xba\x93\xd1\x8a\x70\x4f\x94\x8c\x45\x52\x6a\x66\x85\x92\x7b\x05\xdc\xad\x2e\xff\x5c\x4d\x0d\xf0\x4a\xdb\x94\x73\x55\x4a\x6b\x60\x8d\x15\x34\xb7\xf0\x58\xb3\x48\x7a\xd6\x58\xd1\x32\x36\x88\xed\x16\xc9\x9e\x4b\x8b\x3a\x66\x1c\x61\x4b\x91\x73\x11\x98\x2e\xce\x81\xc9\x08\x16\xc1\x02\x96\xa8\x37\xa8\x5d\x3d\x44\x49\x05\xcf\xa3\x92\xf8\x41\x19\x2b\x59\x8e\xd4\x8e\x9b\x79\xc3\x3b\x80\x85\xd2\xb6\x81\x21\x88\x9f\x8b\xd2\xa1\x10\x26\xfd\x49\x40\xea\x29\x3d\xde\x5b\xf5\xbe\x40\xd4\xc0\x9f\x7b\xcd\x78\x45\x50\xd4\x4e\x5a\x16\xc8\x45\x5c\xc1\xe9\xce\xa2\x96\x2c\x83\xf3\xc5\x33\x6b\x09\x14\x38\x93\x34\xbd\x69\x64\x3c\xc5\x08\x98\x05\x11\xc3\x0a\x53\x21\x23\xb8\x9e\xde\x11\x0c\x36\xd2\xe7\x8b\x10\xb6\xdd\x5d\xb7\xea\x3e\xd6\x21\x20\xab\x4b\x83\xd1\x9e\x81\x74\xef\x8c\x55\xa8\x29\x10\xce\x5c\x97\x3f\xee\xf4\x9d\xc8\x51\x95\xee\x9a\x12\x54\x81\xb2\x19\x29\x25\x72\x67\x35\xb5\x04\xba\x8c\xf1\xa0\x5d\x6e\x44\x42\xe8\x0c\xfa\xa6\xe3\x50\x72\x21\x45\x5e\xe6\x10\x61\xc6\x2a\xa7\x17\x37\xa8\x2b\x28\x82\x02\x34\x9a\x42\x49\x83\x84\xc4\x36\x4a\x44\x60\x45\x4e\x5a\x98\xb5\x8c\xaf\x8d\x03\x60\xd1\xb7\xd2\x58\x58\x31\xb2\x5b\x49\x48\x95\xb1\x24\xa9\x4a\xcd\xd1\xc0\x9b\xe5\x72\xf6\x0e\x4e\x16\x9f\xde\x01\x57\x1a\x0d\x74\xbb\xdd\xb7\xcd\x2c\xac\xd6\x20\x24\x64\x2a\x71\x29\x17\x42\x87\xec\x23\x5b\x4d\x99\x63\x04\xab\x8a\xae\x55\xc7\xa0\x43\x5e\xdc\xfd\xf5\xcd\x86\x65\x25\xde\x22\x8b\xe0\x2f\x10\xbc\x05\x61\x20\x43\xe3\xda\xa2\x04\xb7\x07\x2b\xcc\xd4\xf6\x1d\x79\x4f\x02\x4f\x99\x4c\x70\x7f\x8f\x99\xbb\xa3\x55\xb0\xf3\xe0\xe5\x62\x08\x9d\x51\xbf\x9f\x1b\x97\x8a\x37\x25\x96\xf8\x03\x05\x9c\x67\x98\xa9\x24\x4f\xb5\x92\xaa\x34\xd4\x79\x39\x1a\x23\x64\xe2\x7d\x27\x81\x9a\x20\xf5\x23\xc1\xd4\x74\x28\x5d\x33\x56\x31\x50\x01\x42\x6d\x7a\xcd\xd5\x74\xd3\xc7\xb7\x22\xcb\x88\x2b\x2c\xcb\x14\x67\xb6\x66\x8b\xb1\x4c\xdb\xb2\xf0\x80\xe4\x1f\x6a\x41\x2a\xe6\x7d\x87\x7f\xa6\x11\x0d\x94\x05\x79\x14\x78\xc5\x33\x34\x35\x01\x6a\x15\xe4\x90\x2d\x13\xee\x75\xd1\xc4\x92\xb2\x0b\x9a\xed\x07\x26\x1c\x07\xae\x96\x75\x31\x3c\x80\x69\x4e\xf9\xe7\xba\x09\xf9\x9e\x81\x65\x66\x4d\x28\x1b\x96\x89\x08\x62\xad\x72\x77\x17\xae\xd1\x39\xc2\x83\x7a\xe7\xcc\xc5\xcb\x0f\xd2\x8e\xe7\xaa\x0c\x4a\x7b\x92\xba\xa9\xc8\x65\x88\xe0\x2f\xfd\xe7\xde\x55\xee\x00\xb9\x89\xf2\xe4\xd3\xed\x65\x08\x5b\x13\xf6\x9e\xde\x09\xe1\xd1\xd1\x70\xe8\xac\xba\xa6\x44\xb2\x9a\x49\xc3\x1c\x97\xa1\x50\x2a\x83\x9c\xed\x40\xa3\xd5\xa2\x1e\x77\x0c\xca\x88\x0c\x7e\x76\x4c\x6d\x5c\xa6\xe4\x6c\x77\x5b\x9f\x0b\x21\x68\x1c\xf7\x73\x48\x41\x35\x67\xc3\x32\x87\x5b\xd5\x9e\x64\x64\x3a\x2f\xb5\x76\x8f\x86\x67\x12\x29\x33\xb0\x42\xa4\x57\x85\x45\x6e\x31\xf2\x60\x0f\x40\xfa\x88\x45\x41\x93\x56\xed\x8b\x33\x13\x31\x36\xc4\xb4\x8a\x72\xbb\xd6\xc1\x55\x9e\x0b\xeb\xc2\xc4\x24\x30\xc9\x53\xa2\x57\xf3\x12\x75\xfe\x46\x69\xb9\x73\xe8\x7b\xf0\xa1\x42\x46\xf7\xaa\xcf\x5d\x8a\x18\x4d\xc1\x64\x08\x9d\xc9\xe1\xb8\x9f\x3a\xce\xee\xfb\xe1\x2f\xfc\xdf\x76\xc3\xa6\x8c\x61\x86\xd4\xe8\xb6\xa9\xe0\xe9\xbe\x53\x42\x53\x8d\x5b\x4b\x9b\x11\x43\x11\x9f\x9b\x39\x33\xa2\x84\x75\xf6\x95\xc6\xaa\xbc\x51\xd2\xb6\x8a\xe6\x59\xdc\x34\x81\x6b\x57\x95\x3b\xd4\x93\x3b\xfb\xc7\x6f\x1d\xa6\x1a\x78\xaf\x97\x67\x82\x7c\xed\xca\xe7\x9b\x2d\xe5\xeb\xf7\x52\x68\x84\xad\x01\xa5\x41\x14\xbc\x79\x11\xd3\x03\x98\x3e\x39\xb3\x64\xb6\xe3\xf6\xdb\xe7\x7c\x4a\xad\x2d\xc2\x5e\x8f\xb2\x29\xa3\x3a\x14\x1e\x8d\x86\xa3\xba\xcc\xb1\x9d\x2b\x73\x94\x6a\x5b\x8c\x20\x61\x74\x27\xc1\x1d\x5e\xd1\x54\xbe\x97\x64\x12\x12\xb6\x28\x9c\x74\xd0\x87\xf9\x16\x05\x48\xb5\xad\xe9\x35\x67\x66\x41\xd2\x8e\x5f\xed\x9f\x3b\x3a\x67\x06\x32\x91\x8b\x66\x8c\x88\x44\x1c\xa3\x63\xd2\x3e\x42\xfb\x9a\x46\x79\x99\x30\x73\xe9\x4e\xb7\x8f\xf9\x13\x4a\x34\x74\x09\xdf\x60\xd2\xea\x34\x8a\x2e\xb0\x0a\x61\xf0\x7c\xf1\x16\x37\x6a\x8d\x6e\x7d\x34\x6a\x97\x6b\x8e\x9c\x38\x7e\x85\x30\xf9\x61\x7d\xa1\xb1\xdd\xf2\x9f\xa0\x64\x6c\xaf\xe8\x11\x0c\x47\x2f\xd6\xee\xc8\x19\x31\xea\x33\xad\xf2\x10\xfc\xd1\x7e\x8f\x19\x83\x76\x59\xb7\xf1\x31\xad\xc2\xc1\xbe\x96\x69\xcc\xd5\x86\x2a\x99\x01\xa3\x94\xa4\xff\x2b\x2d\xa2\x04\xa9\xa8\x50\xb6\x24\x9a\xd5\xa9\xf3\xd4\xc1\xac\x72\x45\xab\x8e\x81\x7c\xe2\xc5\xf3\x68\x34\x0c\x88\xa2\xfa\xf7\x11\x06\xab\x4c\xf1\xb5\x1b\x0e\x6a\x22\x80\xd5\x22\x49\x50\x3b\x6c\x9a\xd3\x70\x67\xdb\x7a\x57\xf7\xbc\x71\xbf\x6d\x7a\x3f\x53\xac\xa9\xa9\x28\x99\x3d\x6b\x3a\x66\x9f\x92\xad\x49\x4f\xd0\xd4\x83\x5e\xc2\xfb\xa3\x06\xfd\x7f\xbb\x7a\x79\x07\xc0\x64\x05\x11\xae\xca\x24\x69\x46\x0a\xca\x71\x17\xe0\x44\x01\x39\xc2\x73\xbb\x75\x2d\x41\xe9\xd2\xd2\xad\x50\x2f\x27\x19\x0f\xe8\x2b\x84\x98\x65\x06\xdd\xa9\xa2\xd0\x2a\xae\x33\xa2\x05\xa6\x91\x86\x56\xdb\x63\x5e\x4d\xd1\xe6\x87\xad\x42\x23\x6f\x98\x6a\x75\x89\xde\xdf\x03\x00\x00\xff\xff\x9a\x8d\x48\xb5\xc5\x13\x00\x00")
+var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x6b\x6f\xdb\x3a\xd2\xfe\xae\x5f\x31\xb0\xbf\xb4\x2f\x52\xc7\x92\x2f\x71\x84\xf3\x1e\xc0\x49\x9c\x34\x6d\x9a\xe3\xc6\x6e\x7a\xda\x2f\x05\x2d\x8d\x24\xd6\x12\xa9\x92\x94\x2f\x59\xec\x7f\x5f\x0c\x45\xf9\xd2\xcb\x39\xbb\x67\x2f\xd8\x5d\x2c\x0a\x34\x09\x45\x3e\x9c\x19\x3e\xf3\xcc\x90\x6d\xb8\xc2\x84\x55\xb9\x81\x18\x57\x98\xcb\xb2\x40\x61\xc0\xa0\x36\x02\x0d\xb0\x94\x71\xa1\x0d\x2c\xe5\x8a\x09\x2f\x42\x61\x14\x4f\xaa\x14\xef\xd1\xac\xa5\x5a\x86\x90\xe4\x5c\x18\xcf\x82\x70\x81\x60\x32\x84\xd8\xe1\x89\x7a\x8e\x06\x93\x31\x03\x97\xbb\xb5\x50\x30\x2e\x0c\xe1\x7a\xcd\x94\xd0\x03\x68\xc3\x9d\x8c\x58\x6e\xb7\xe6\x22\x85\x48\x0a\xa3\x58\x64\x80\xc5\xb1\x42\xad\x51\x83\x40\x8c\xc1\x48\x58\x20\x68\x34\xb0\xe6\x26\x03\x14\x2b\x58\x31\xc5\xd9\x22\x47\xdd\xf1\xa0\x59\x4f\x90\x00\x3c\x0e\xa1\xd7\xeb\xd9\xdf\xd1\x64\xa8\xb0\x2a\x9c\xed\xb7\x71\x08\xa3\xde\xa8\xfe\xd6\x86\xb1\x88\x32\xa9\x68\xe3\x05\x8b\x96\x28\x62\x90\x89\x75\xc7\xd9\x18\x02\x79\x1f\x65\x8c\x8b\x93\x1d\x14\x48\x05\x39\x59\x6d\x41\x98\x85\xb8\xa8\x97\x1f\xcc\xb7\x1f\x17\x52\x1a\x6d\x14\x2b\xa7\x88\x4a\xd7\xd6\xbd\x80\xd6\x29\x2f\xfb\xa7\x7e\x70\xd6\xe9\x76\xba\x1d\xff\xd4\x44\xe5\x69\x6f\x14\x74\x83\x53\x5e\x26\xfa\xf4\x6d\x31\x7f\xbb\x59\xac\x97\xd5\xc7\x0f\x1f\xae\x92\xea\x69\xbe\xd8\x4c\xc6\x0f\x38\xbf\xbf\xbc\x93\x4f\xdb\xed\x60\x30\x5a\xbd\x15\xe9\xe3\x6a\xfa\xe6\xf3\xdd\x87\x65\xeb\x77\x40\x7b\x0d\xe8\x63\x32\x9c\xdc\x0f\x8b\xe5\x97\xf7\xf8\xf9\xfd\xeb\xf7\xc1\x97\x69\xe5\x0f\x7f\x2d\xe3\x9b\xde\xf2\x95\xf4\xe7\xbd\x22\x63\xd9\xf4\x62\x30\xc3\x81\xf0\x6b\xd0\xe6\x30\xc6\xcd\x59\xd4\x0e\x50\x80\x51\x18\x6e\xb6\xd7\x2c\x32\x52\x6d\x43\x68\xb5\x3c\x7b\x98\x6f\x18\x17\xdf\x50\xaa\x09\x26\x3c\x7b\x4d\x84\x7a\xee\x41\x4d\xa0\xd0\x1d\xc2\x7d\x55\xa0\xe2\x11\xdc\x5e\x35\xd1\x3f\xa0\x8d\x5b\xbb\x3b\x57\x3f\x70\xab\x2e\x9a\xd0\x42\xce\xb5\xa1\x95\x42\xc6\xf8\x2d\xef\x4a\x25\x57\xdc\x7e\x90\xf6\x64\xed\xd6\x0d\xd5\x7f\xf7\x90\x7a\x83\x4e\xd0\x0f\x3a\x41\xaf\xdb\xf1\xfd\xe1\xd7\x27\xe5\x07\x57\xbd\xd7\x52\xbe\x9f\x2d\x36\x8b\xd7\x97\x8b\x8f\xd9\xf9\xab\x47\xa3\xdf\x6e\x1f\x6f\xe2\xf9\x54\xb1\xfe\x43\x39\x1b\xf7\xcd\x62\xa5\x87\x4c\xf8\xfe\xe7\xf5\xcd\x38\x78\x3a\x3e\x2f\xc2\xef\xf5\x3b\x67\x41\xc7\x0f\xce\x7e\x04\xff\xb6\x08\xa2\x59\xa1\x26\x9c\xcd\xde\x3c\xf6\xd3\x77\xab\xb3\xf7\x37\x59\x99\x3e\xac\xe5\x68\x2d\xaf\x67\xfa\x65\xf6\xf1\x66\x71\xc3\x7b\x6c\x3c\xda\xb4\x5c\x78\x26\x0d\x59\x9b\xe0\xdf\x5e\xc1\x0b\xb0\x07\xf0\xa3\xbc\xe8\x07\xff\xa2\xac\x68\xc3\x1d\xa3\x03\x80\x18\xcb\x5c\x6e\x31\x86\x59\xc1\x94\x81\x4b\xc7\x37\x0d\x89\x54\x76\xc3\x94\xaf\x50\x1c\x1d\xd6\xdf\xc0\xc9\xee\xc6\xef\x0d\x83\x49\x74\x91\x8c\x86\x67\xe7\x41\xbf\x37\x09\xfa\xc9\xb8\x3b\xb9\xec\x07\x83\x38\x40\xbf\x3b\xee\x8e\x82\xa0\x17\x9d\x5d\x1d\xb2\x57\x1b\x96\x92\xeb\xdf\x92\x96\x15\x0b\x54\x7f\x8c\xb4\xfe\xdf\x49\x5a\xbb\xf5\xef\x92\xf6\x9f\x4f\xdb\xff\x11\xf7\xdf\x96\xb8\x54\xb8\x9d\x9b\x1e\xd8\x6a\x2b\xd0\xfc\x31\xb6\x76\xff\x1a\x59\xf4\xcf\x47\x1d\x3f\x08\x3a\xbe\xff\xc3\xe3\x1f\xa7\xbd\x49\x34\x36\xea\xc3\xe3\xe5\x66\xfd\x34\x5c\x0e\xf5\xfc\x9c\x7f\x9c\x3d\x3c\x99\xa7\xf3\xab\xb3\xed\xbb\xa7\xf2\x62\xfa\x30\xb9\x7e\x52\xef\xe4\xe3\xb7\xb2\x48\xfc\x0d\xfc\x8e\xef\xfb\x3f\xc2\x7f\x7d\xb3\xe6\x9b\x5f\x51\x54\xbf\x8e\x1f\xbf\x2c\x5f\xbd\x2e\xc4\xcb\xd9\xf8\xd5\xd5\xe7\xa7\xe4\x0c\x6f\xde\xc8\xa1\x51\x92\xa7\x1f\x37\xc5\xd9\x78\xf0\xf0\xdb\xf4\x72\xe1\xfa\x11\xc1\xfc\xff\x36\x7e\x8d\xaf\xfb\x83\x61\xe4\x0f\x7b\xa3\x21\x1b\xf6\x93\xb8\x7f\xdd\x5f\x0c\xcf\x59\xe2\xf7\xd8\x68\x78\x95\x74\x2f\x06\xc3\x60\xcc\xba\xdd\x96\x47\x5d\x1e\x33\x0c\x66\x46\x2a\x96\xa2\xa7\xeb\x9f\x44\xac\x36\x5c\x1c\x47\x21\xa6\x89\x4c\xc4\xc4\xf5\x84\xa7\x95\x62\x86\x4b\x52\x54\xbb\xa4\x03\x13\x4e\x21\x81\x9c\xfa\x83\x78\x41\x79\xa7\xbf\xe4\xdc\xa0\x07\x4d\x3c\xc3\xe6\xa3\x85\x9f\x32\x93\xd9\x8c\xb2\x83\x57\x17\x90\xf0\x1c\x69\xd9\xec\xed\x1d\x37\x08\x6e\xc4\x03\x28\x99\xc9\x42\x38\x35\x45\x79\xba\x6f\x57\x3f\x91\x3d\x9d\x43\xc0\x89\x88\xd4\xb6\xb4\x46\x39\x93\xc9\x36\x8c\x61\xc5\xf2\x0a\x35\x30\x03\x0a\xb5\xe9\xc0\xb8\x2c\x73\xbe\x97\xdf\xef\xfa\x03\x4c\xc3\x1a\xf3\xbc\xe3\x52\x50\x1b\x54\xb0\xc4\x2d\x70\x0d\x0a\x59\x0c\x89\x92\x05\x0d\x5c\xf3\x1c\x4f\x20\x93\x79\x4c\xcc\x61\x90\xe1\x06\x50\x44\x32\xc6\x18\x7a\x01\x2c\xb6\x06\x69\xda\x09\x79\x16\xa3\xe2\x2b\x74\x6b\x69\xeb\x92\x69\x5d\x66\x8a\x69\xec\xb8\x98\x34\x7f\x43\xc4\xc4\x51\x53\x4c\xd3\x2f\x27\xf7\xf3\x4f\xb3\xf9\x2f\x0f\xe3\x9b\xc9\xa7\xc9\xfd\xe5\xc3\x87\xe9\xfc\xf6\x97\xfb\x4f\xd3\xf1\x6c\x36\x7d\xf9\x30\x9e\x4d\x8e\x7a\x67\xa0\x3e\x1f\x59\x5c\x63\xcf\x25\x28\x69\x98\xa9\x3b\xfa\x62\xe7\xd1\x09\x14\x72\x55\x0f\x46\x95\x52\x74\x55\x20\x3f\x8d\x84\x52\xe1\x8a\xcb\x4a\xdb\x53\x27\x43\x68\x8e\xc0\x35\xf9\x53\x63\x3e\xba\xc8\x2a\x04\x85\x2f\xb0\x3e\x00\x8c\xf7\x16\xbb\xd9\xc0\x85\xdd\x80\x88\x90\x2a\x59\x91\x76\x8b\xa8\xde\x94\x1a\x3b\xd0\x86\x29\x63\x7b\x7d\x07\xc2\xa5\x20\x16\x02\xa0\xa0\x6b\x40\x1c\x42\xc2\x72\x4d\x64\x82\x26\xea\xb6\x31\xa5\xbf\xf7\x51\xdc\x0f\x39\xd3\x9b\x34\xf9\x7a\xc9\x37\x8b\xbc\x36\x5c\x1e\xf1\xa0\x49\x89\x9a\x1d\xee\xaf\xf0\x1f\xc2\xdc\x1a\xf2\x98\xc0\x5e\x1b\xc6\x51\x24\x2b\x61\x34\xf9\xd7\xd0\xd0\x63\x6e\x90\x76\x5e\xe2\x96\x86\xd1\x21\x36\x9f\x28\x8b\x6f\x85\x41\x95\xb0\x08\x61\x4d\x0a\x68\x03\x3b\x9e\xde\xda\x93\x9b\x06\x53\x98\xa1\x5a\x51\x72\x72\x6d\x50\x10\xf3\x3d\x0a\xfb\x4b\xa9\x8d\x60\x05\x86\xb0\xbb\x59\x78\x6d\x98\x4a\x65\x1c\x0c\x41\x7c\x7f\x29\x4d\x0a\x61\xd4\x1d\x05\xb4\x3d\x15\x91\x17\x46\xbe\x28\x11\xd5\x71\x3e\x69\xaf\x0c\x4a\x32\xbe\x0d\xb3\x12\x23\x9e\x6c\x61\xb2\x31\xa8\x04\xcb\xe1\x76\x7a\x60\x2d\x81\x36\xa4\x57\xc8\xa2\x0c\x63\xca\x58\x9e\xc0\x02\x33\x2e\x62\xb8\x1f\xcf\x09\x06\xdd\xea\xdb\x69\x08\xeb\xce\xa6\xb3\xed\x3c\xd1\x70\x6d\x75\xa5\x31\xde\xa9\x28\xf9\x9d\xb3\x2d\x2a\x92\x10\x6b\xae\xad\x32\x76\xf6\x9c\x17\x28\x2b\xeb\xa6\x00\x59\xa2\x70\xd7\x53\x81\x91\xb5\x9a\xb4\x81\x9c\xd1\x1e\x34\xc3\x6e\x49\x08\xad\x5e\x57\x13\x8b\xda\x50\x70\xc1\x8b\xaa\x80\x18\x73\xb6\xb5\xfb\xe2\x0a\xd5\x16\xca\xa0\x24\xa9\x29\xa5\xd0\x48\x48\x6c\x25\x79\x0c\x86\x17\xb4\x0b\x33\x86\x45\x4b\x02\x6e\x03\x8b\x3f\x57\xda\xc0\x82\x91\xdd\x52\x40\x26\xb5\x15\x29\x59\xa9\x08\x35\x3c\x9b\xcd\xae\x4e\xe0\x72\xfa\xee\x04\x22\xa9\x50\x43\xa7\xd3\x79\xee\xee\xd5\x72\x49\x49\x95\xcb\xd4\x96\x8d\x10\x5a\x64\x1f\xd9\xaa\xab\x02\x63\x58\x6c\xc9\xad\xfa\x0c\x5a\xc0\x13\xd8\xfc\xff\x33\xab\x83\x0f\x24\x5e\xff\x07\xc1\x73\x52\xb2\x1c\xb5\xbd\x53\x89\x5a\x23\x61\x81\xb9\x5c\x9f\x50\xf4\x04\x44\x19\x13\x29\xee\xfc\xb8\xb2\x3e\x1a\x09\x1b\x0f\x8e\x07\x43\x68\x0d\xba\xdd\x42\xdb\x72\xf2\xb6\xc2\xea\x2b\x49\xb5\x06\x02\xd3\x5b\x11\x65\x4a\x0a\x12\x94\x52\xc9\x08\xb5\xe6\x22\xf5\xbe\xd0\x82\x9a\x20\xf5\x83\x03\x19\x84\x20\x2a\xdb\x14\xcb\x04\xa8\xf4\xa2\xd2\xa7\xce\x35\xe5\xfa\xe9\x35\xcf\x73\xe2\x0a\xcb\xa9\xbd\x33\x35\x5b\xac\x8c\x54\xa5\x07\xb4\xfe\x7d\xbd\x30\x04\xbf\x4b\x3d\x4f\x1b\xae\x15\xa2\x86\xaa\xa4\x88\x42\xb4\x8d\x72\xd4\x35\x01\xea\x2d\x28\x20\x6b\xc6\xe9\xa5\xa1\x39\x4b\x61\xe8\x9c\xea\xcf\xef\x19\x37\x14\xe3\x37\xb3\xba\x65\x68\xc3\xb8\xa0\xd4\xb4\x3d\x17\xc5\x9e\x81\x61\x7a\x49\x28\x2b\x96\xf3\x03\x95\x8f\x14\xda\x40\x78\x40\x61\xe6\xf1\xb5\x54\x21\xb4\xfc\x20\x6b\x79\x14\x32\x6a\xd6\x2e\xa9\x0b\x05\x6d\x33\x84\x47\xc7\xf1\xb3\x6f\x34\x76\x02\x85\x89\xf2\xe4\xdd\xc3\x5d\x08\x6b\x1d\x9e\xee\x5f\x04\xc2\xf3\xf3\x7e\xdf\x7a\xf9\x50\x89\xdd\x1b\x0f\x13\xc0\xc5\x0b\x17\xed\xc3\xae\xd0\xc2\x35\xf5\x61\xd7\xe7\x50\x0a\x32\xd3\x6c\xd1\xb1\xaa\x22\x62\x97\x52\x87\x17\x7f\x92\x15\xf7\x28\x43\x9a\xdd\x86\x5f\x44\xbe\xfd\xba\xf3\x74\x7b\x50\xbd\x2c\x73\x16\x61\x1c\xda\x19\xbb\x1e\x2d\xca\x39\x0a\x73\xd2\xb4\x30\x54\x91\x09\xf7\xfe\x7a\xae\x41\x1b\x3a\x5e\x97\x79\x94\x42\x68\xb2\x7b\x67\x96\x07\xc0\xc5\xb4\xf6\x69\x5f\x16\xda\x40\xdf\xc1\x28\x26\x34\xb3\x49\x0c\xa5\x94\x39\x14\x6c\x03\x0a\x8d\x72\x05\x5f\x53\x47\xc3\x8e\xa6\xc9\x95\x95\x88\x82\x6d\x1e\xea\x79\x21\x04\xdd\xee\x6f\x40\x72\x12\xdb\x15\xcb\x2d\xee\xb6\xa6\x10\xa3\x33\x6b\xea\xe7\xe1\x8a\x8c\x69\x58\x20\xd2\xc3\x89\xc1\xc8\x60\x6c\xcd\xaf\x01\x68\x3f\xba\x12\x04\x4e\x4f\x9a\x67\xbb\x9c\x27\xe8\x32\xd2\x48\xa8\xb4\x55\x75\x01\x91\x2c\x0a\x6e\x2c\x3f\x99\x70\xbd\xe6\xee\xa8\x29\xb4\xbb\x7e\x13\x5e\x80\x0f\x5b\x64\xe4\x57\x3d\xef\x8e\x27\xa8\x4b\x26\x42\x68\x8d\xce\x86\x5d\xa2\xde\x41\xbb\xfc\x03\xe2\x35\x4d\xae\xd3\x6f\xcc\x91\xba\xd4\x75\xc6\xa3\x6c\xdf\x00\xbb\x32\xd4\x58\xea\xb8\x24\x29\x91\xdd\x45\x37\x6e\xca\x7f\x54\x69\x23\x0b\xb7\x49\x53\x23\xdd\xdb\xa2\xab\x7e\xf7\xb6\x1c\xb5\xa8\x65\x6f\x39\xa5\x8b\xd8\x61\x4f\xb7\xdb\xb7\xa6\x8f\x25\x2b\x3c\x5b\x93\x50\x7d\xa9\xb8\x42\x58\x6b\x6a\xb5\x78\x19\xb9\x67\x45\x6a\x1f\xe8\xd7\x88\x19\x32\x7b\x85\xc2\xe8\xe7\x87\x89\x94\x19\x53\x86\xa7\xa7\x24\x23\x39\x09\x70\x78\x3e\xe8\x0f\xec\xde\x05\xdb\x58\x7d\x27\x8d\x59\x63\x0c\x29\x23\x9f\x38\x35\x2e\x12\x4a\x27\xf9\xc7\x64\xe2\x02\xd6\xc8\xed\xea\xa0\x0b\x37\x6b\xe4\x20\xe4\xba\xa6\xd7\x0d\xd3\x53\xc5\x23\xb4\xfc\x6a\xfe\xd9\xa9\x37\x4c\x43\xce\x0b\xee\xee\x00\x31\x4f\x12\xb4\x9d\xd8\xee\x84\x76\x62\x4e\x82\x94\x32\x7d\x67\x67\x37\x2f\xa2\x97\xa4\x30\x54\xc6\x77\x98\x34\x3a\x8e\xe3\xd7\xb8\x0d\xa1\x77\x38\xf8\x80\x2b\xb9\x44\x3b\x3e\x18\x34\xc3\x35\x47\x2e\x2d\xbf\x42\x18\x7d\x35\x3e\x55\xd8\x7c\xf2\xf7\x50\x22\x31\x6f\xb8\x30\x21\x9c\x1f\x8d\xcd\x89\xfb\x09\xaa\x6b\x25\x8b\x10\xfc\xc1\xee\x1b\xd3\x1a\x0d\xf5\x51\x18\xc2\x90\x46\xa1\xbd\x13\x71\x85\xd4\x83\xc6\xd4\x74\x6b\x29\x05\xfd\x5c\x28\x1e\xa7\x48\x6a\x4a\xe9\x96\x2a\x56\xa7\xce\xbe\x74\x1b\x69\xd5\xda\x06\x8c\x89\x3d\x1f\x0f\x4f\xc3\x31\x20\x26\x0d\xa3\x3a\x0c\x8b\x5c\x46\x4b\xdb\x15\xe1\x0a\xa9\xd7\x32\x8a\xa7\x29\x2a\x8b\x4d\x97\x2c\xdc\x98\x46\xe8\xeb\x62\x3f\xec\x36\xd5\xfe\x7b\x1b\xdb\xab\x80\x14\xf9\x41\xb5\xd5\xbb\x94\x6c\x4c\xda\x43\x53\xf1\x3d\x86\xf7\x07\xba\xf5\x1b\x52\xf3\x9f\xa2\x5e\x5e\x1b\x98\xd8\x42\x8c\x8b\x2a\x4d\x5d\x2f\x45\x39\x6e\x0f\x38\x95\x40\x81\xf0\xec\x57\xa2\x6c\xdb\x75\xf5\xf5\x7c\xc8\xa5\x5d\xe3\x01\xfd\x76\xa8\xe8\x65\xa9\x64\xe2\x8a\x8f\x03\xa6\x5e\x8e\x46\x9b\x69\x1e\x6b\x6e\xed\x04\x5c\x2a\x8c\x1c\x53\x8d\xaa\x6a\x10\xa7\x2f\xa0\x79\x2a\x98\xa9\x14\x42\x29\x73\x1e\x51\x44\x9d\xa4\xc4\x32\xaa\xe8\x3d\xdb\x76\xde\x75\xef\x74\x38\x0c\x3a\xca\xb0\x70\x37\x34\xbb\x76\xdb\x34\xaa\x2d\x96\xe7\xad\x13\x68\x99\x4c\xa1\xa6\x3b\x60\xf8\xd3\x9b\x9f\x5b\xa4\x3f\x2d\x25\x73\x0c\x7f\xa2\xff\x09\xf5\xe7\x56\xc7\x3b\x7e\x63\xb0\x45\x19\x63\xbd\xbf\x08\x45\x32\x27\x81\xc5\x78\x6f\xaa\x86\x02\xdd\xbd\xab\xde\x98\x50\x76\x5f\xa7\xce\x8f\x10\xfe\xf4\x67\x8b\xce\xd2\x54\x61\x4a\x8f\x58\xc7\x0e\x28\x7a\xec\xa1\x2a\x5f\xd0\x21\xd5\x11\xb2\x79\x45\x29\x51\xa0\x5a\x92\x42\x2a\x44\x9b\x18\x75\x40\xc9\x2e\x57\xd2\x77\xa8\x16\x87\x2c\x68\x03\x17\x51\x5e\x69\x62\x48\xa9\xa4\x4c\x08\x9b\x7a\xf6\xe3\x2d\x29\x79\xdd\x15\xdc\x85\x94\x34\x97\x00\x16\xa4\xc5\x3f\xbc\xdf\xed\x85\x77\xdf\x05\x7e\xe5\x4b\x6d\x64\x5d\x59\x18\x50\x23\x99\x63\x8d\xda\xb1\xa8\x9a\x3f\x61\xd3\xf8\x1d\x02\xba\x46\xed\xd8\x4c\x92\x91\x5a\x78\xe9\xa7\x45\x69\x94\xc3\x6d\x53\x83\x36\xc4\x3f\xbc\x02\x34\xd1\x72\xb5\xf9\xd8\x55\x60\x74\xb9\x51\xf4\xfa\x11\x43\x25\x0c\xcf\x29\x0a\x5b\xc0\x4d\xc9\x95\x25\x94\xfd\x6d\xdb\x3c\xe4\x35\x37\x3a\xbb\x6e\xcd\x94\x20\xd9\xca\x94\xac\xd2\xfa\x36\xbd\xc6\x45\x26\xe5\x72\x47\x19\xae\x76\xfb\xd3\x0a\x93\x71\x0d\x51\x2e\xeb\x4b\x47\x0d\xdd\xb1\xd0\x84\x45\x29\x02\xad\xb3\x80\x6a\x3f\x8d\xb5\xe9\xca\xde\x70\xe1\xfb\x74\xa1\xd1\xdc\xbd\x59\x35\x1f\x57\xa8\xe8\xdc\x49\x16\x12\xa9\xbe\x36\x63\xef\x59\xbd\x81\x40\xaa\x9b\xbb\x35\x64\xa5\x26\x94\xdd\xd3\x4b\x9e\xb3\x85\x54\xcc\x50\x0c\xad\x6e\x02\xcb\xa5\x48\xf7\x2f\x08\x96\x5f\x3b\x6b\xe8\x3d\xa1\x76\xb9\xde\xc3\xee\x10\x42\xc2\x72\x8d\xde\x5f\x06\x00\x51\x25\x98\x2b\xf8\x1c\x00\x00")
 
 func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	return bindata_read(
@@ -34,7 +34,7 @@ func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	)
 }
 
-var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xc9\x6e\x1c\x37\x10\xbd\xf7\x57\x10\xcc\xc1\x97\x59\xb8\x6f\x37\x43\xf1\x12\x08\x11\xe2\x38\x80\x9c\x63\x91\xac\x96\x1a\xa3\x5e\xc2\x66\x4b\x1e\x1b\xfe\xf7\xa0\x67\x46\xb6\x6f\x51\x6e\x64\x75\xbd\xf7\x58\xd5\xef\x25\x1c\x6a\xe9\xda\xe5\x0e\x6f\xb0\x3e\x8d\xe5\x10\x48\xc5\xb9\x76\xc3\x5d\x83\xf5\x1e\x0b\x2e\x7d\x68\x08\x81\x94\xc6\x65\xa8\xf3\x7a\x26\xa4\x87\x6e\x08\xe4\x74\x24\xe4\x80\xc7\x40\x5e\x7d\xa5\x90\x73\xc1\x79\xa6\x81\x3a\x1f\x19\x38\xa3\x9d\x4c\x4a\x29\x05\xa9\xcd\x96\x47\x65\x24\xb2\x2c\x93\xd6\x80\x5c\x71\x01\x9a\x6e\x68\x2a\xc7\xa9\x8e\x34\x7c\xa5\xa9\x9b\xee\xb1\xd0\x40\x01\xe7\x2d\x17\x6e\x9b\x6a\x59\x1b\x4e\xe5\x8a\x9f\x2b\x0d\x34\x59\xeb\x5b\x27\xad\xcf\xd6\xb2\xec\x45\x6a\x13\xcf\x39\x2b\x70\xad\xe4\x59\x03\x83\x9c\x5c\x2b\x80\x45\x01\x5c\x31\x2e\x2d\xcb\xd2\x48\xd6\x4a\x97\x58\x72\xf0\x9d\x6f\x82\x02\xfd\xbc\xca\x76\x8f\x34\x50\x69\x12\x37\x0e\xad\x8c\xad\x77\xac\x45\xab\x23\xb3\xc2\xb6\xce\x33\xb0\x1c\x32\xfd\xb6\xa1\x87\xdc\xd2\x40\xe7\xd3\x83\xe9\xe9\xfa\x83\x24\x1f\x1e\x70\xa0\x41\x8a\x0d\x1d\x68\x10\x46\x70\xa5\x36\x74\xa2\x81\x6f\x68\xa1\xc1\x6d\xe8\x0c\x0f\xeb\x00\x19\x79\x44\x6e\x50\x26\xef\xb8\x57\x2a\x73\x4c\x20\xa2\x8b\xc2\xa2\x42\x83\x2c\xea\xd8\x46\x25\x23\x32\x69\x0d\xe8\xec\x9c\xf3\x2d\x18\xeb\x41\x38\x2e\xc4\xfa\x90\x1e\xd2\xba\x8a\xc4\x85\x8b\x8e\x6b\xad\x75\x04\x8e\x90\x6d\x02\xf4\xcc\x30\x74\x4e\x09\x68\x13\x38\xa9\x4d\x66\x46\x69\x1d\xb3\x07\x6d\xb5\x88\x60\xda\x94\x98\x17\xd8\xae\x4c\x5d\xa6\x81\x2a\x8d\xcc\x30\x30\xdb\x2c\x00\xb7\x4a\x46\xb7\xf5\x42\xb4\x5b\xa5\x9c\xf0\xca\xfb\x2c\x6d\xa6\x1b\xfa\x88\x65\xee\xc6\x75\xc8\x6f\xaf\x2e\x3f\x7e\x82\x79\x7e\x1a\x4b\x0e\xe4\xd5\x73\xe9\xe2\x81\x40\x5e\x6a\x81\xa6\xe9\x32\x0e\xb5\xab\xc7\xdf\x72\x20\x94\x7d\x7e\xb1\x77\x9a\x66\xb5\xee\xd5\xfd\x6a\xc5\x1f\x06\x3d\xfb\xb3\x3b\x73\x65\x25\xb5\x97\xc9\x72\xdd\xe6\x2c\x79\x32\x9c\x2b\x0e\x31\x33\x05\xde\xb7\xd9\x38\x21\x92\xd3\xda\x39\xad\x52\xca\x28\x3d\x68\xe3\x14\x5a\xd0\x26\x83\xb0\x99\x9e\xc8\x66\x4c\x05\x6b\x20\x74\xbf\x7f\xfd\xd0\x25\x3c\x57\xbf\x4f\x4a\xf5\xbb\xf2\xf4\x08\x6f\xde\xea\x2f\x9f\xa2\x30\x6f\xbf\xf8\x92\x3e\x4c\xbf\xde\x7e\xd4\xf6\xaa\xbe\xf9\xf3\xfd\x74\x83\xf7\x9f\xae\xfe\x48\x37\xe3\xfb\x77\xd7\x4b\xfd\xf0\x37\x6d\x9a\x5f\xc8\xeb\x4b\x9e\xd6\xf4\x90\xb9\x8e\x05\xee\xb0\xf9\x39\x64\x07\x3c\xae\x65\x0c\x64\x5f\xfb\x69\xff\xfc\xa9\x69\xfe\x59\x70\xc1\xb5\x63\x58\xfa\xdb\xb1\x1c\xb0\xcc\x81\x88\x86\x90\xa7\xd3\xe5\x16\xba\xfa\x57\xd7\xe3\xef\x1f\x03\xe1\x4d\xb3\xd2\xac\xcd\x93\x98\xce\xab\x99\x96\xf8\xd0\xa5\xeb\x35\xb3\xbb\xdd\x7e\xb7\xdb\xc7\xa5\x7b\xc8\xfb\x82\xf3\xb8\x94\x84\xf3\x7e\x12\xd3\x35\x1e\x77\xd3\x12\x77\x13\xf6\x67\x4c\xe9\x1e\xa1\xe2\x7f\x83\x0e\x2b\xf0\x04\x9a\xbb\xbb\xa1\x1b\xee\x5e\xa8\x79\xe9\xfe\xff\xba\x3f\x01\x9f\xb5\x1b\x18\xd2\xfd\x58\x2e\xe2\x53\xc1\x34\xf6\x7d\x57\x03\xa9\x65\xc1\xe6\xdf\x00\x00\x00\xff\xff\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
+var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xc9\x72\xdc\x36\x10\xbd\xf3\x2b\x50\xc8\xc1\x97\x59\xb0\x6f\x37\x97\xe2\x25\xa5\x8a\x2a\x8e\x53\x25\xe7\xd8\x00\x9a\x12\x6b\x86\x4b\x40\x50\xf2\xd8\xe5\x7f\x4f\x71\x34\x8a\x9d\x53\x94\x13\xc1\x66\xbf\xf7\xba\x89\xf7\x12\x0e\xb5\x74\xed\x72\x87\x37\x58\x1f\xc7\x72\x08\xa4\xe2\x5c\xbb\xe1\xae\xc1\x7a\x8f\x05\x97\x3e\x34\x84\x40\x4a\xe3\x32\xd4\x79\x3d\x13\xd2\x43\x37\x04\x72\x3e\x12\x72\xc0\x53\x20\xaf\xbe\x52\xc8\xb9\xe0\x3c\xd3\x40\x9d\x8f\x0c\x9c\xd1\x4e\x26\xa5\x94\x82\xd4\x66\xcb\xa3\x32\x12\x59\x96\x49\x6b\x40\xae\xb8\x00\x4d\x37\x34\x95\xd3\x54\x47\x1a\xbe\xd2\xd4\x4d\xf7\x58\x68\xa0\x80\xf3\x96\x0b\xb7\x4d\xb5\xac\x0d\xe7\x72\xc5\xcf\x95\x06\x9a\xac\xf5\xad\x93\xd6\x67\x6b\x59\xf6\x22\xb5\x89\xe7\x9c\x15\xb8\x56\xf2\xac\x81\x41\x4e\xae\x15\xc0\xa2\x00\xae\x18\x97\x96\x65\x69\x24\x6b\xa5\x4b\x2c\x39\xf8\x87\x6f\x82\x02\xfd\xbc\xca\x76\x0f\x34\x50\x69\x12\x37\x0e\xad\x8c\xad\x77\xac\x45\xab\x23\xb3\xc2\xb6\xce\x33\xb0\x1c\x32\xfd\xb6\xa1\x87\xdc\xd2\x40\xe7\xf3\xc0\xf4\xfc\xfa\x9d\x24\x1f\x8e\x38\xd0\x20\xc5\x86\x0e\x34\x08\x23\xb8\x52\x1b\x3a\xd1\xc0\x37\xb4\xd0\xe0\x36\x74\x86\xe3\xba\x40\x46\x1e\x91\x1b\x94\xc9\x3b\xee\x95\xca\x1c\x13\x88\xe8\xa2\xb0\xa8\xd0\x20\x8b\x3a\xb6\x51\xc9\x88\x4c\x5a\x03\x3a\x3b\xe7\x7c\x0b\xc6\x7a\x10\x8e\x0b\xb1\x0e\xd2\x43\x5a\x7f\x45\xe2\xc2\x45\xc7\xb5\xd6\x3a\x02\x47\xc8\x36\x01\x7a\x66\x18\x3a\xa7\x04\xb4\x09\x9c\xd4\x26\x33\xa3\xb4\x8e\xd9\x83\xb6\x5a\x44\x30\x6d\x4a\xcc\x0b\x6c\x57\xa6\x2e\xd3\x40\x95\x46\x66\x18\x98\x6d\x16\x80\x5b\x25\xa3\xdb\x7a\x21\xda\xad\x52\x4e\x78\xe5\x7d\x96\x36\xd3\x0d\x7d\xc0\x32\x77\xe3\xba\xe4\xb7\x57\x97\x8b\x9f\x60\x9e\x1f\xc7\x92\x03\x79\xf5\x5c\xba\x78\x20\x90\x97\x5a\xa0\x69\xba\x8c\x43\xed\xea\xe9\x97\x1c\x08\x65\x9f\x5f\xec\x9d\xa6\x59\xad\x7b\x75\xbf\x5a\xf1\xbb\x41\x9f\xfc\xd9\x3d\x71\x65\x25\xb5\x97\xc9\x72\xdd\xe6\x2c\x79\x32\x9c\x2b\x0e\x31\x33\x05\xde\xb7\xd9\x38\x21\x92\xd3\xda\x39\xad\x52\xca\x28\x3d\x68\xe3\x14\x5a\xd0\x26\x83\xb0\x99\x9e\xc9\x66\x4c\x05\x6b\x20\x74\xbf\x7f\x7d\xec\x12\xd2\xe6\x5f\x9b\x52\xfd\xae\x3c\x3e\xc0\x9b\xb7\xfa\xcb\xa7\x28\xcc\xdb\x2f\xbe\xa4\x0f\xd3\xcf\xb7\x1f\xb5\xbd\xaa\x6f\x7e\x7f\x3f\xdd\xe0\xfd\xa7\xab\xdf\xd2\xcd\xf8\xfe\xdd\xf5\x52\x3f\xfc\x49\x9b\xe6\x27\xf2\xfa\x92\xa7\x35\x3d\x64\xae\x63\x81\x3b\x6c\x7e\x0c\xd9\x01\x4f\x6b\x19\x03\xd9\xd7\x7e\xda\x3f\x7f\x6a\x9a\xbf\x16\x5c\x70\xed\x18\x96\xfe\x76\x2c\x07\x2c\x73\x20\xa2\x21\x64\x0d\x2f\x96\x5b\xe8\xea\x1f\x5d\x8f\xbf\x7e\x0c\x84\x37\xcd\x4a\xb3\x36\x4f\x62\x5a\x1f\x84\x4c\x4b\x3c\x76\xe9\x7a\xcd\xec\x6e\xb7\xdf\xed\xf6\x71\xe9\x8e\x79\x5f\x70\x1e\x97\x92\x70\xde\x4f\x62\xba\xc6\xd3\x6e\x5a\xe2\x6e\xc2\xfe\x09\x53\xba\x07\xa8\xf8\xdf\xa0\xc3\x0a\x3c\x83\xe6\xee\x6e\xe8\x86\xbb\x17\x6a\x5e\xba\xff\xbf\xee\x0f\xc0\x67\xed\x06\x86\x74\x3f\x96\x8b\xf8\x54\x30\x8d\x7d\xdf\xd5\x40\x6a\x59\xb0\xf9\x7b\x00\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
 
 func go_centrifuge_build_configs_testing_config_yaml() ([]byte, error) {
 	return bindata_read(
@@ -68,15 +68,18 @@ var _bindata = map[string]func() ([]byte, error){
 	"go-centrifuge/build/configs/default_config.yaml": go_centrifuge_build_configs_default_config_yaml,
 	"go-centrifuge/build/configs/testing_config.yaml": go_centrifuge_build_configs_testing_config_yaml,
 }
+
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -104,17 +107,16 @@ func AssetDir(name string) ([]string, error) {
 }
 
 type _bintree_t struct {
-	Func func() ([]byte, error)
+	Func     func() ([]byte, error)
 	Children map[string]*_bintree_t
 }
+
 var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	"go-centrifuge": &_bintree_t{nil, map[string]*_bintree_t{
 		"build": &_bintree_t{nil, map[string]*_bintree_t{
 			"configs": &_bintree_t{nil, map[string]*_bintree_t{
-				"default_config.yaml": &_bintree_t{go_centrifuge_build_configs_default_config_yaml, map[string]*_bintree_t{
-				}},
-				"testing_config.yaml": &_bintree_t{go_centrifuge_build_configs_testing_config_yaml, map[string]*_bintree_t{
-				}},
+				"default_config.yaml": &_bintree_t{go_centrifuge_build_configs_default_config_yaml, map[string]*_bintree_t{}},
+				"testing_config.yaml": &_bintree_t{go_centrifuge_build_configs_testing_config_yaml, map[string]*_bintree_t{}},
 			}},
 		}},
 	}},
@@ -0,0 +1,148 @@
+// Package backend opens the storage on the backend chosen in the config.
+package backend
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/sqlite"
+)
+
+// Config holds the storage backend configuration.
+type Config interface {
+	GetStorageBackend() string
+	IsStorageEncryptionEnabled() bool
+}
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap runs the bootstrapper of the configured storage backend.
+// If the encryption is enabled, the server re-encrypting the stored values is added to the context.
+func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
+	if _, ok := context[bootstrap.BootstrappedConfig]; !ok {
+		return errors.New("config not initialised")
+	}
+	cfg := context[bootstrap.BootstrappedConfig].(Config)
+
+	var err error
+	switch backend := cfg.GetStorageBackend(); backend {
+	case "", storage.LevelDBBackend:
+		err = new(leveldb.Bootstrapper).Bootstrap(context)
+	case storage.SQLiteBackend:
+		err = new(sqlite.Bootstrapper).Bootstrap(context)
+	default:
+		err = errors.NewTypedError(storage.ErrUnknownBackend, errors.New("%s", backend))
+	}
+	if err != nil || !cfg.IsStorageEncryptionEnabled() {
+		return err
+	}
+
+	var repos []storage.Reencrypter
+	for _, key := range []string{storage.BootstrappedConfigDB, storage.BootstrappedDB} {
+		if repo, ok := context[key].(storage.Reencrypter); ok {
+			repos = append(repos, repo)
+		}
+	}
+
+	context[bootstrap.BootstrappedStorageReencryption] = encryption.NewReencryption(repos...)
+	return nil
+}
+
+// Open opens the DB of the backend at path. The key value store and the repository share the DB,
+// closing either of them closes the DB. Values are sealed as per the encryption config, which can be nil.
+// A sealed DB is refused if the encryption is disabled.
+func Open(backend, path string, cfg encryption.Config) (storage.KeyValueStore, storage.Repository, error) {
+	switch backend {
+	case "", storage.LevelDBBackend:
+		db, err := leveldb.NewLevelDBStorage(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		kv := leveldb.NewKeyValueStore(db)
+		repo, err := openRepository(kv, cfg, func(c storage.Cipher) (storage.Repository, error) {
+			return leveldb.NewEncryptedLevelDBRepository(db, c)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return kv, repo, nil
+	case storage.SQLiteBackend:
+		db, err := sqlite.NewSQLiteStorage(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		kv := sqlite.NewKeyValueStore(db)
+		repo, err := openRepository(kv, cfg, func(c storage.Cipher) (storage.Repository, error) {
+			return sqlite.NewEncryptedSQLiteRepository(db, c)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return kv, repo, nil
+	default:
+		return nil, nil, errors.NewTypedError(storage.ErrUnknownBackend, errors.New("%s", backend))
+	}
+}
+
+// openRepository returns the repository of the DB with the cipher of the config. DB is closed on failure.
+func openRepository(
+	kv storage.KeyValueStore,
+	cfg encryption.Config,
+	newRepo func(c storage.Cipher) (storage.Repository, error)) (repo storage.Repository, err error) {
+	defer func() {
+		if err != nil {
+			_ = kv.Close()
+		}
+	}()
+
+	var c storage.Cipher
+	if cfg != nil {
+		c, err = encryption.NewCipherFromConfig(cfg, kv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newRepo(c)
+}
+
+// OpenKeyValueStore opens the DB of the backend at path as is, whether it is sealed or not.
+func OpenKeyValueStore(backend, path string) (storage.KeyValueStore, error) {
+	switch backend {
+	case "", storage.LevelDBBackend:
+		db, err := leveldb.NewLevelDBStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return leveldb.NewKeyValueStore(db), nil
+	case storage.SQLiteBackend:
+		db, err := sqlite.NewSQLiteStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return sqlite.NewKeyValueStore(db), nil
+	default:
+		return nil, errors.NewTypedError(storage.ErrUnknownBackend, errors.New("%s", backend))
+	}
+}
+
+// Snapshot copies the DB of the backend at src to dst. The DB may be open by a running node.
+func Snapshot(backend, src, dst string) error {
+	switch backend {
+	case "", storage.LevelDBBackend:
+		return leveldb.Snapshot(src, dst)
+	case storage.SQLiteBackend:
+		return sqlite.Snapshot(src, dst)
+	default:
+		return errors.NewTypedError(storage.ErrUnknownBackend, errors.New("%s", backend))
+	}
+}
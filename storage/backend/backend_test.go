@@ -0,0 +1,364 @@
+// +build unit
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	Index int `json:"index"`
+}
+
+func (d *doc) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *doc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+func (d *doc) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+type mockConfig struct {
+	backend    string
+	passphrase string
+}
+
+func (m mockConfig) GetStorageBackend() string {
+	return m.backend
+}
+
+func (m mockConfig) IsStorageEncryptionEnabled() bool {
+	return m.passphrase != ""
+}
+
+func (m mockConfig) GetStorageEncryptionKeyFile() string            { return "" }
+func (m mockConfig) GetStorageEncryptionPassphrase() string         { return m.passphrase }
+func (m mockConfig) GetStorageEncryptionPreviousKeyFile() string    { return "" }
+func (m mockConfig) GetStorageEncryptionPreviousPassphrase() string { return "" }
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	err := (&Bootstrapper{}).Bootstrap(map[string]interface{}{})
+	assert.Error(t, err, "Should throw an error because of empty context")
+
+	err = (&Bootstrapper{}).Bootstrap(map[string]interface{}{bootstrap.BootstrappedConfig: mockConfig{backend: "unknown"}})
+	assert.True(t, errors.IsOfType(storage.ErrUnknownBackend, err))
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		backend string
+		path    string
+	}{
+		{backend: storage.LevelDBBackend, path: leveldb.GetRandomTestStoragePath()},
+		{backend: storage.SQLiteBackend, path: sqlite.GetRandomTestStoragePath()},
+	}
+
+	for _, c := range tests {
+		t.Run(c.backend, func(t *testing.T) {
+			kv, repo, err := Open(c.backend, c.path, nil)
+			assert.NoError(t, err)
+			assert.NoError(t, kv.Put([]byte("key"), []byte("value")))
+			assert.True(t, repo.Exists([]byte("key")))
+			assert.NoError(t, kv.Close())
+
+			// sealed DB is refused without the encryption
+			cfg := mockConfig{backend: c.backend, passphrase: "secret"}
+			kv, repo, err = Open(c.backend, c.path, cfg)
+			assert.NoError(t, err)
+			repo.Register(&doc{})
+			assert.NoError(t, repo.Create([]byte("doc"), &doc{Index: 1}))
+			assert.NoError(t, kv.Close())
+			for _, enc := range []encryption.Config{nil, mockConfig{backend: c.backend}} {
+				_, _, err = Open(c.backend, c.path, enc)
+				assert.True(t, errors.IsOfType(storage.ErrDBSealed, err))
+			}
+
+			kv, repo, err = Open(c.backend, c.path, cfg)
+			assert.NoError(t, err)
+			repo.Register(&doc{})
+			m, err := repo.Get([]byte("doc"))
+			assert.NoError(t, err)
+			assert.Equal(t, &doc{Index: 1}, m)
+			assert.NoError(t, kv.Close())
+
+			// raw values of a sealed DB are still read
+			kv, err = OpenKeyValueStore(c.backend, c.path)
+			assert.NoError(t, err)
+			data, err := kv.Get([]byte("doc"))
+			assert.NoError(t, err)
+			assert.NotContains(t, string(data), "index")
+			assert.NoError(t, kv.Close())
+		})
+	}
+
+	_, _, err := Open("unknown", "", nil)
+	assert.True(t, errors.IsOfType(storage.ErrUnknownBackend, err))
+}
+
+func TestIterate(t *testing.T) {
+	for _, backend := range []string{storage.LevelDBBackend, storage.SQLiteBackend} {
+		t.Run(backend, func(t *testing.T) {
+			path := leveldb.GetRandomTestStoragePath()
+			if backend == storage.SQLiteBackend {
+				path = sqlite.GetRandomTestStoragePath()
+			}
+
+			_, repo, err := Open(backend, path, nil)
+			assert.NoError(t, err)
+			defer repo.Close()
+			repo.Register(&doc{})
+
+			// more than a chunk of the SQLite iterator
+			for i := 0; i < 250; i++ {
+				assert.NoError(t, repo.Create([]byte(fmt.Sprintf("doc_%03d", i)), &doc{Index: i}))
+			}
+			assert.NoError(t, repo.Create([]byte("other"), &doc{Index: -1}))
+
+			indexes := func(opts storage.IterateOptions) (res []int) {
+				iter := repo.Iterate(opts)
+				defer iter.Release()
+				for iter.Next() {
+					d := iter.Model().(*doc)
+					assert.Equal(t, fmt.Sprintf("doc_%03d", d.Index), string(iter.Key()))
+					res = append(res, d.Index)
+				}
+				assert.NoError(t, iter.Error())
+				return res
+			}
+
+			res := indexes(storage.IterateOptions{Prefix: []byte("doc_")})
+			assert.Len(t, res, 250)
+			assert.Equal(t, 0, res[0])
+			assert.Equal(t, 249, res[249])
+
+			res = indexes(storage.IterateOptions{Prefix: []byte("doc_"), Reverse: true})
+			assert.Len(t, res, 250)
+			assert.Equal(t, 249, res[0])
+
+			res = indexes(storage.IterateOptions{Prefix: []byte("doc_"), Start: []byte("doc_100"), End: []byte("doc_110")})
+			assert.Equal(t, []int{100, 101, 102, 103, 104, 105, 106, 107, 108, 109}, res)
+
+			res = indexes(storage.IterateOptions{Prefix: []byte("doc_"), Cursor: []byte("doc_120"), Limit: 3})
+			assert.Equal(t, []int{121, 122, 123}, res)
+
+			res = indexes(storage.IterateOptions{Prefix: []byte("doc_"), Cursor: []byte("doc_120"), Limit: 3, Reverse: true})
+			assert.Equal(t, []int{119, 118, 117}, res)
+
+			// pages cover all the models exactly once
+			var cursor []byte
+			var pages, count int
+			for {
+				models, next, err := storage.Page(repo, storage.IterateOptions{Prefix: []byte("doc_"), Cursor: cursor}, 100)
+				assert.NoError(t, err)
+				for _, m := range models {
+					assert.Equal(t, count, m.(*doc).Index)
+					count++
+				}
+
+				pages++
+				if next == nil {
+					break
+				}
+				cursor = next
+			}
+			assert.Equal(t, 3, pages)
+			assert.Equal(t, 250, count)
+
+			// last page is full
+			models, next, err := storage.Page(repo, storage.IterateOptions{Prefix: []byte("doc_"), Cursor: []byte("doc_239")}, 10)
+			assert.NoError(t, err)
+			assert.Len(t, models, 10)
+			assert.Nil(t, next)
+		})
+	}
+}
+
+// openEncrypted returns the key value store of a new DB of the backend and a constructor of the repositories
+// over the same DB sealing the values with the cipher.
+func openEncrypted(t *testing.T, backend string) (storage.KeyValueStore, func(storage.Cipher) storage.Repository) {
+	if backend == storage.SQLiteBackend {
+		db, err := sqlite.NewSQLiteStorage(sqlite.GetRandomTestStoragePath())
+		assert.NoError(t, err)
+		return sqlite.NewKeyValueStore(db), func(c storage.Cipher) storage.Repository {
+			repo, err := sqlite.NewEncryptedSQLiteRepository(db, c)
+			assert.NoError(t, err)
+			return repo
+		}
+	}
+
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return leveldb.NewKeyValueStore(db), func(c storage.Cipher) storage.Repository {
+		repo, err := leveldb.NewEncryptedLevelDBRepository(db, c)
+		assert.NoError(t, err)
+		return repo
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, encryption.KeySize), bytes.Repeat([]byte{2}, encryption.KeySize)
+	for _, backend := range []string{storage.LevelDBBackend, storage.SQLiteBackend} {
+		t.Run(backend, func(t *testing.T) {
+			// ciphers refuse plaintext once their DB is sealed
+			oldCipher, err := encryption.NewCipher(oldKey)
+			assert.NoError(t, err)
+			newCipher, err := encryption.NewCipher(newKey, oldKey)
+			assert.NoError(t, err)
+			onlyNewCipher, err := encryption.NewCipher(newKey)
+			assert.NoError(t, err)
+
+			kv, open := openEncrypted(t, backend)
+			defer kv.Close()
+			repo := func(c storage.Cipher) storage.Repository {
+				r := open(c)
+				r.Register(&doc{})
+				return r
+			}
+
+			// stored before the encryption was enabled, more than a chunk of the SQLite scan
+			plain := repo(nil)
+			for i := 0; i < 150; i++ {
+				assert.NoError(t, plain.Create([]byte(fmt.Sprintf("doc_%03d", i)), &doc{Index: i}))
+			}
+			assert.NoError(t, kv.Put([]byte("migration_1"), []byte(`{"id":"1"}`)))
+
+			// plaintext values are read as is, new values are sealed
+			enc := repo(oldCipher)
+			m, err := enc.Get([]byte("doc_007"))
+			assert.NoError(t, err)
+			assert.Equal(t, 7, m.(*doc).Index)
+			assert.NoError(t, enc.Create([]byte("doc_150"), &doc{Index: 150}))
+			b := enc.NewBatch()
+			assert.NoError(t, b.Create([]byte("doc_151"), &doc{Index: 151}))
+			assert.NoError(t, b.Commit())
+			for _, key := range []string{"doc_150", "doc_151"} {
+				data, err := kv.Get([]byte(key))
+				assert.NoError(t, err)
+				assert.True(t, oldCipher.IsEncrypted(data))
+				assert.NotContains(t, string(data), "index")
+			}
+
+			// plaintext models are sealed, other values are kept
+			n, err := enc.(storage.Reencrypter).Reencrypt(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 150, n)
+			n, err = enc.(storage.Reencrypter).Reencrypt(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+			data, err := kv.Get([]byte("doc_000"))
+			assert.NoError(t, err)
+			assert.True(t, oldCipher.IsEncrypted(data))
+			data, err = kv.Get([]byte("migration_1"))
+			assert.NoError(t, err)
+			assert.Equal(t, `{"id":"1"}`, string(data))
+			_, err = plain.Get([]byte("doc_000"))
+			assert.Error(t, err)
+
+			// plaintext values are refused once all the values are sealed, also after a restart
+			assert.NoError(t, kv.Put([]byte("doc_900"), []byte(`{"type":"backend.doc","data":{"index":900}}`)))
+			_, err = enc.Get([]byte("doc_900"))
+			assert.True(t, errors.IsOfType(storage.ErrModelRepositorySerialisation, err))
+			_, err = repo(oldCipher).Get([]byte("doc_900"))
+			assert.True(t, errors.IsOfType(storage.ErrModelRepositorySerialisation, err))
+			n, err = enc.(storage.Reencrypter).Reencrypt(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 0, n)
+
+			// sealed values can't be moved to another key
+			assert.NoError(t, kv.Put([]byte("doc_901"), data))
+			_, err = enc.Get([]byte("doc_901"))
+			assert.True(t, errors.IsOfType(storage.ErrModelRepositorySerialisation, err))
+			assert.NoError(t, kv.Delete([]byte("doc_900")))
+			assert.NoError(t, kv.Delete([]byte("doc_901")))
+
+			// rotation
+			rotated := repo(newCipher)
+			n, err = rotated.(storage.Reencrypter).Reencrypt(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 152, n)
+			models, err := repo(onlyNewCipher).GetAllByPrefix("doc_")
+			assert.NoError(t, err)
+			assert.Len(t, models, 152)
+			_, err = enc.Get([]byte("doc_000"))
+			assert.True(t, errors.IsOfType(storage.ErrModelRepositorySerialisation, err))
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	for _, backend := range []string{storage.LevelDBBackend, storage.SQLiteBackend} {
+		t.Run(backend, func(t *testing.T) {
+			path, dst := leveldb.GetRandomTestStoragePath(), leveldb.GetRandomTestStoragePath()
+			if backend == storage.SQLiteBackend {
+				path, dst = sqlite.GetRandomTestStoragePath(), sqlite.GetRandomTestStoragePath()
+			}
+
+			assert.Error(t, Snapshot(backend, path, dst))
+			_, repo, err := Open(backend, path, nil)
+			assert.NoError(t, err)
+			defer repo.Close()
+			repo.Register(&doc{})
+
+			// pairs of docs are written atomically while the DB is copied
+			started, done := make(chan struct{}), make(chan struct{})
+			written := make(chan int)
+			go func() {
+				var i int
+				defer func() { written <- i }()
+				for ; ; i++ {
+					if i == 500 {
+						close(started)
+					}
+
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					b := repo.NewBatch()
+					assert.NoError(t, b.Create([]byte(fmt.Sprintf("a_%06d", i)), &doc{Index: i}))
+					assert.NoError(t, b.Create([]byte(fmt.Sprintf("b_%06d", i)), &doc{Index: i}))
+					assert.NoError(t, b.Commit())
+				}
+			}()
+
+			<-started
+			for i := 0; i < 3; i++ {
+				assert.NoError(t, Snapshot(backend, path, fmt.Sprintf("%s_%d", dst, i)))
+			}
+			close(done)
+			n := <-written
+
+			for i := 0; i < 3; i++ {
+				_, copied, err := Open(backend, fmt.Sprintf("%s_%d", dst, i), nil)
+				assert.NoError(t, err)
+				copied.Register(&doc{})
+				as, err := copied.GetAllByPrefix("a_")
+				assert.NoError(t, err)
+				bs, err := copied.GetAllByPrefix("b_")
+				assert.NoError(t, err)
+				assert.Equal(t, len(as), len(bs))
+				assert.True(t, len(as) >= 500 && len(as) <= n)
+				assert.NoError(t, copied.Close())
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("storage")
+
+// sealedKey is the DB key marking that all the models of the DB are sealed.
+// Marker is stored in plaintext like the salt so that it is read before any value.
+const sealedKey = "encryption_sealed"
+
+// SaltKey is the DB key of the random salt the passphrase keys of the DB are derived with.
+// Salt is stored in plaintext so that the master key can be derived before any value is read.
+const SaltKey = "encryption_salt"
+
+// value is how the models are stored by the repositories: the model JSON along with its type.
+type value struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ModelCodec encodes the models into the values stored by the repositories and decodes them back.
+// Values are sealed with the cipher if it is set. Models must be registered to be decoded.
+// ModelCodec is shared by the storage backends so that their values are interchangeable.
+type ModelCodec struct {
+	models map[string]reflect.Type
+	mu     sync.RWMutex // to protect the models
+
+	// cipher seals the values at rest. Values are stored in plaintext if nil.
+	cipher Cipher
+
+	// sealed is set to 1 once the DB is marked as sealed.
+	sealed int32
+}
+
+// NewModelCodec returns the codec sealing the values with the cipher. Cipher can be nil.
+func NewModelCodec(cipher Cipher) *ModelCodec {
+	return &ModelCodec{
+		models: make(map[string]reflect.Type),
+		cipher: cipher,
+	}
+}
+
+// Register registers the model so that the values can be decoded without knowing the type.
+func (c *ModelCodec) Register(model Model) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tp := getTypeIndirect(model.Type())
+	c.models[tp.String()] = tp
+}
+
+// Encrypted returns true if the values are sealed.
+func (c *ModelCodec) Encrypted() bool {
+	return c.cipher != nil
+}
+
+// getModel returns a new instance of the type mt.
+func (c *ModelCodec) getModel(mt string) (Model, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	tp, ok := c.models[mt]
+	if !ok {
+		return nil, errors.NewTypedError(ErrModelTypeNotRegistered, errors.New("%s", mt))
+	}
+
+	return reflect.New(tp).Interface().(Model), nil
+}
+
+// Encode returns the model of the key wrapped in the value with its type, sealed if the cipher is set.
+func (c *ModelCodec) Encode(key []byte, model Model) ([]byte, error) {
+	data, err := model.JSON()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
+	}
+
+	tp := getTypeIndirect(model.Type())
+	v := value{
+		Type: tp.String(),
+		Data: json.RawMessage(data),
+	}
+
+	data, err = json.Marshal(v)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall value: %v", err))
+	}
+
+	if c.cipher == nil {
+		return data, nil
+	}
+
+	data, err = c.cipher.Encrypt(key, data)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to encrypt value: %v", err))
+	}
+
+	return data, nil
+}
+
+// Decode returns the model of the value stored against the key.
+// Values stored in plaintext are decoded as is until the DB is marked as sealed.
+func (c *ModelCodec) Decode(key, data []byte) (Model, error) {
+	if c.cipher != nil {
+		var err error
+		data, err = c.cipher.Decrypt(key, data)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrModelRepositorySerialisation, err)
+		}
+	}
+
+	v := new(value)
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to value: %v", err))
+	}
+
+	nm, err := c.getModel(v.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	err = nm.FromJSON([]byte(v.Data))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to model: %v", err))
+	}
+
+	return nm, nil
+}
+
+// Reseal returns the stored value of the key sealed with the current key of the cipher.
+// Returns false if the value is already sealed with the current key or is not a stored model.
+func (c *ModelCodec) Reseal(key, data []byte) ([]byte, bool) {
+	if c.cipher == nil {
+		return nil, false
+	}
+
+	if c.cipher.IsEncrypted(data) {
+		data, ok, err := c.cipher.Rotate(data)
+		if err != nil {
+			log.Warningf("failed to rotate the key of %x: %v", key, err)
+			return nil, false
+		}
+
+		return data, ok
+	}
+
+	// plaintext models written after the DB was sealed are not trusted
+	if atomic.LoadInt32(&c.sealed) == 1 {
+		return nil, false
+	}
+
+	// values other than the models, like the salt and the migrations, are kept in plaintext
+	v := new(value)
+	if err := json.Unmarshal(data, v); err != nil || v.Type == "" {
+		return nil, false
+	}
+
+	data, err := c.cipher.Encrypt(key, data)
+	if err != nil {
+		log.Warningf("failed to encrypt %x: %v", key, err)
+		return nil, false
+	}
+
+	return data, true
+}
+
+// LoadSealed refuses the plaintext values if the DB is marked as sealed.
+// Without the cipher, the DB is refused if it is sealed or its passphrase keys were derived.
+func (c *ModelCodec) LoadSealed(db KeyValueStore) error {
+	if c.cipher != nil {
+		ok, err := db.Has([]byte(sealedKey))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			c.refusePlaintext()
+		}
+
+		return nil
+	}
+
+	for _, key := range []string{sealedKey, SaltKey} {
+		ok, err := db.Has([]byte(key))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return errors.NewTypedError(ErrDBSealed, errors.New("%s is set", key))
+		}
+	}
+
+	return nil
+}
+
+// MarkSealed marks the DB as sealed once all the models are sealed. Plaintext values are refused from now on.
+func (c *ModelCodec) MarkSealed(db KeyValueStore) error {
+	if c.cipher == nil {
+		return nil
+	}
+
+	err := db.Put([]byte(sealedKey), []byte{1})
+	if err != nil {
+		return err
+	}
+
+	c.refusePlaintext()
+	return nil
+}
+
+func (c *ModelCodec) refusePlaintext() {
+	atomic.StoreInt32(&c.sealed, 1)
+	c.cipher.RefusePlaintext()
+}
+
+// getTypeIndirect returns the type of the model without pointers.
+func getTypeIndirect(tp reflect.Type) reflect.Type {
+	if tp.Kind() == reflect.Ptr {
+		return getTypeIndirect(tp.Elem())
+	}
+
+	return tp
+}
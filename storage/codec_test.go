@@ -0,0 +1,204 @@
+// +build unit
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	Index int `json:"index"`
+}
+
+func (d *doc) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+func (d *doc) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *doc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// prefixCipher marks the values as sealed with a prefix. Rotated values get a second prefix.
+type prefixCipher struct {
+	sealedOnly bool
+}
+
+var sealedPrefix = []byte("sealed:")
+
+func (*prefixCipher) Encrypt(key, value []byte) ([]byte, error) {
+	return append(append([]byte{}, sealedPrefix...), value...), nil
+}
+
+func (c *prefixCipher) Decrypt(key, data []byte) ([]byte, error) {
+	if !c.IsEncrypted(data) && c.sealedOnly {
+		return nil, errors.New("not sealed")
+	}
+
+	for c.IsEncrypted(data) {
+		data = data[len(sealedPrefix):]
+	}
+
+	return data, nil
+}
+
+func (c *prefixCipher) RefusePlaintext() {
+	c.sealedOnly = true
+}
+
+func (*prefixCipher) IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, sealedPrefix)
+}
+
+func (c *prefixCipher) Rotate(data []byte) ([]byte, bool, error) {
+	if bytes.HasPrefix(data[len(sealedPrefix):], sealedPrefix) {
+		return data, false, nil
+	}
+
+	data, err := c.Encrypt(nil, data)
+	return data, true, err
+}
+
+func TestModelCodec_Register(t *testing.T) {
+	c := NewModelCodec(nil)
+	assert.Len(t, c.models, 0, "should be empty")
+	c.Register(&doc{})
+	assert.Len(t, c.models, 1, "should be not empty")
+	assert.Contains(t, c.models, "storage.doc")
+}
+
+func TestModelCodec_Encode_Decode(t *testing.T) {
+	plain, sealed := NewModelCodec(nil), NewModelCodec(new(prefixCipher))
+	key := []byte("doc")
+	assert.False(t, plain.Encrypted())
+	assert.True(t, sealed.Encrypted())
+
+	data, err := plain.Encode(key, &doc{Index: 1})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"storage.doc","data":{"index":1}}`, string(data))
+
+	// not registered
+	_, err = plain.Decode(key, data)
+	assert.True(t, errors.IsOfType(ErrModelTypeNotRegistered, err))
+
+	// plaintext values are decoded by the sealing codec
+	plain.Register(&doc{})
+	sealed.Register(&doc{})
+	m, err := sealed.Decode(key, data)
+	assert.NoError(t, err)
+	assert.Equal(t, &doc{Index: 1}, m)
+
+	data, err = sealed.Encode(key, &doc{Index: 2})
+	assert.NoError(t, err)
+	assert.True(t, new(prefixCipher).IsEncrypted(data))
+	m, err = sealed.Decode(key, data)
+	assert.NoError(t, err)
+	assert.Equal(t, &doc{Index: 2}, m)
+
+	// sealed values are not decoded without the cipher
+	_, err = plain.Decode(key, data)
+	assert.True(t, errors.IsOfType(ErrModelRepositorySerialisation, err))
+}
+
+func TestModelCodec_Reseal(t *testing.T) {
+	plain, sealed := NewModelCodec(nil), NewModelCodec(new(prefixCipher))
+	data, err := plain.Encode([]byte("doc"), &doc{Index: 1})
+	assert.NoError(t, err)
+
+	// nothing to do without the cipher
+	_, ok := plain.Reseal([]byte("doc"), data)
+	assert.False(t, ok)
+
+	// plaintext model is sealed
+	resealed, ok := sealed.Reseal([]byte("doc"), data)
+	assert.True(t, ok)
+	assert.True(t, new(prefixCipher).IsEncrypted(resealed))
+
+	// sealed value is rotated once
+	rotated, ok := sealed.Reseal([]byte("doc"), resealed)
+	assert.True(t, ok)
+	_, ok = sealed.Reseal([]byte("doc"), rotated)
+	assert.False(t, ok)
+
+	// values other than the models are kept
+	_, ok = sealed.Reseal([]byte("migration"), []byte(`{"id":"1"}`))
+	assert.False(t, ok)
+}
+
+type mapStore map[string][]byte
+
+func (m mapStore) Has(key []byte) (bool, error) {
+	_, ok := m[string(key)]
+	return ok, nil
+}
+
+func (m mapStore) Get(key []byte) ([]byte, error) {
+	return m[string(key)], nil
+}
+
+func (m mapStore) Put(key, value []byte) error {
+	m[string(key)] = value
+	return nil
+}
+
+func (m mapStore) Delete(key []byte) error {
+	delete(m, string(key))
+	return nil
+}
+
+func (m mapStore) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	return nil
+}
+
+func (m mapStore) Close() error {
+	return nil
+}
+
+func TestModelCodec_Sealed(t *testing.T) {
+	db := make(mapStore)
+	plain := NewModelCodec(nil)
+	plain.Register(&doc{})
+	key := []byte("doc")
+	data, err := plain.Encode(key, &doc{Index: 1})
+	assert.NoError(t, err)
+
+	// plaintext is read until the DB is marked as sealed
+	sealed := NewModelCodec(new(prefixCipher))
+	sealed.Register(&doc{})
+	assert.NoError(t, sealed.LoadSealed(db))
+	_, err = sealed.Decode(key, data)
+	assert.NoError(t, err)
+	assert.NoError(t, sealed.MarkSealed(db))
+	_, err = sealed.Decode(key, data)
+	assert.True(t, errors.IsOfType(ErrModelRepositorySerialisation, err))
+	_, ok := sealed.Reseal(key, data)
+	assert.False(t, ok)
+
+	// mark is kept across the restarts
+	sealed = NewModelCodec(new(prefixCipher))
+	sealed.Register(&doc{})
+	assert.NoError(t, sealed.LoadSealed(db))
+	_, err = sealed.Decode(key, data)
+	assert.Error(t, err)
+
+	// sealed DB is refused without the cipher, the mark is kept
+	assert.NoError(t, plain.MarkSealed(db))
+	err = plain.LoadSealed(db)
+	assert.True(t, errors.IsOfType(ErrDBSealed, err))
+	assert.Contains(t, db, sealedKey)
+
+	// so is the DB the passphrase keys were derived for
+	db = mapStore{SaltKey: []byte("salt")}
+	err = plain.LoadSealed(db)
+	assert.True(t, errors.IsOfType(ErrDBSealed, err))
+	assert.NoError(t, plain.LoadSealed(make(mapStore)))
+}
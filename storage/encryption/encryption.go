@@ -0,0 +1,247 @@
+// Package encryption implements the envelope encryption of the values stored at rest.
+// Each value is sealed with its own random data key and the data key is sealed with the master key.
+// Values are bound to their DB key so that a sealed value can't be moved to another key.
+// Rotating the master key only re-wraps the data keys, the sealed values are left as is.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync/atomic"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+const (
+	// ErrInvalidKey is a sentinel error when the master key is not a valid key.
+	ErrInvalidKey = errors.Error("invalid storage encryption key")
+
+	// ErrMissingKey is a sentinel error when the encryption is enabled without a master key.
+	ErrMissingKey = errors.Error("storage encryption key is not configured")
+
+	// ErrDecryption is a sentinel error when the stored value cannot be decrypted.
+	ErrDecryption = errors.Error("failed to decrypt the stored value")
+
+	// ErrPlaintext is a sentinel error when a value is not sealed while only sealed values are accepted.
+	ErrPlaintext = errors.Error("stored value is not sealed")
+)
+
+const (
+	// KeySize is the size of the master and data keys. Keys are AES-256 keys.
+	KeySize = 32
+
+	keyIDSize = 4
+
+	// wrappedKeySize is the size of the sealed data key: nonce, key and GCM tag.
+	wrappedKeySize = 12 + KeySize + 16
+
+	headerSize = 2 + keyIDSize + wrappedKeySize
+)
+
+// magic prefixes the sealed values. It is the first byte of neither a JSON value nor the version.
+// Sealed value is magic | master key ID | sealed data key | nonce | sealed value.
+var magic = []byte{0xce, 0x01}
+
+type masterKey struct {
+	id   [keyIDSize]byte
+	aead cipher.AEAD
+}
+
+func newMasterKey(key []byte) (*masterKey, error) {
+	if len(key) != KeySize {
+		return nil, errors.NewTypedError(ErrInvalidKey, errors.New("expected %d bytes, got %d", KeySize, len(key)))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidKey, err)
+	}
+
+	// key ID identifies the master key of a sealed value without revealing the key
+	h := sha256.Sum256(key)
+	mk := &masterKey{aead: aead}
+	copy(mk.id[:], h[:keyIDSize])
+	return mk, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce and prepends the nonce.
+// Additional data is authenticated along with magic.
+func seal(aead cipher.AEAD, plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData(ad)), nil
+}
+
+// open decrypts the data sealed with seal with the same additional data.
+func open(aead cipher.AEAD, data, ad []byte) ([]byte, error) {
+	n := aead.NonceSize()
+	if len(data) < n {
+		return nil, errors.New("sealed data is too short")
+	}
+
+	return aead.Open(nil, data[:n], data[n:], additionalData(ad))
+}
+
+// additionalData returns magic followed by ad.
+func additionalData(ad []byte) []byte {
+	return append(append(make([]byte, 0, len(magic)+len(ad)), magic...), ad...)
+}
+
+// envelope implements storage.Cipher.
+type envelope struct {
+	current *masterKey
+	keys    map[[keyIDSize]byte]*masterKey
+
+	// sealedOnly is set to 1 once the values that are not sealed must be refused.
+	sealedOnly int32
+}
+
+// NewCipher returns the storage.Cipher sealing the values with the current master key.
+// Previous master keys open the values sealed before the rotation.
+func NewCipher(current []byte, previous ...[]byte) (storage.Cipher, error) {
+	e := &envelope{keys: make(map[[keyIDSize]byte]*masterKey)}
+	for i, key := range append([][]byte{current}, previous...) {
+		mk, err := newMasterKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			e.current = mk
+		}
+
+		e.keys[mk.id] = mk
+	}
+
+	return e, nil
+}
+
+// IsEncrypted returns true if the data is sealed.
+func (e *envelope) IsEncrypted(data []byte) bool {
+	return len(data) >= headerSize && bytes.HasPrefix(data, magic)
+}
+
+// Encrypt seals the value of the key with a new data key wrapped by the current master key.
+func (e *envelope) Encrypt(key, value []byte) ([]byte, error) {
+	dk := make([]byte, KeySize)
+	_, err := rand.Read(dk)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(e.current.aead, dk, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dk)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(aead, value, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(e.header(wrapped, len(sealed)), sealed...), nil
+}
+
+// header returns magic, the current master key ID and the wrapped data key with the capacity for n more bytes.
+func (e *envelope) header(wrapped []byte, n int) []byte {
+	data := make([]byte, 0, headerSize+n)
+	data = append(data, magic...)
+	data = append(data, e.current.id[:]...)
+	return append(data, wrapped...)
+}
+
+// unwrap returns the data key of the sealed data.
+func (e *envelope) unwrap(data []byte) ([]byte, error) {
+	var id [keyIDSize]byte
+	copy(id[:], data[len(magic):])
+	mk, ok := e.keys[id]
+	if !ok {
+		return nil, errors.NewTypedError(ErrDecryption, errors.New("unknown master key %x", id))
+	}
+
+	dk, err := open(mk.aead, data[len(magic)+keyIDSize:headerSize], nil)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDecryption, err)
+	}
+
+	return dk, nil
+}
+
+// Decrypt opens the sealed value of the key. Values stored before the encryption was enabled are returned as is
+// until RefusePlaintext is called.
+func (e *envelope) Decrypt(key, data []byte) ([]byte, error) {
+	if !e.IsEncrypted(data) {
+		if atomic.LoadInt32(&e.sealedOnly) == 1 {
+			return nil, ErrPlaintext
+		}
+
+		return data, nil
+	}
+
+	dk, err := e.unwrap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dk)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDecryption, err)
+	}
+
+	value, err := open(aead, data[headerSize:], key)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDecryption, err)
+	}
+
+	return value, nil
+}
+
+// Rotate re-wraps the data key of the sealed data with the current master key.
+func (e *envelope) Rotate(data []byte) ([]byte, bool, error) {
+	if !e.IsEncrypted(data) {
+		return nil, false, errors.NewTypedError(ErrDecryption, errors.New("data is not sealed"))
+	}
+
+	if bytes.Equal(data[len(magic):len(magic)+keyIDSize], e.current.id[:]) {
+		return data, false, nil
+	}
+
+	dk, err := e.unwrap(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	wrapped, err := seal(e.current.aead, dk, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return append(e.header(wrapped, len(data)-headerSize), data[headerSize:]...), true, nil
+}
+
+// RefusePlaintext makes Decrypt refuse the values that are not sealed.
+func (e *envelope) RefusePlaintext() {
+	atomic.StoreInt32(&e.sealedOnly, 1)
+}
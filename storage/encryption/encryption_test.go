@@ -0,0 +1,215 @@
+// +build unit
+
+package encryption
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCipher(t *testing.T) {
+	_, err := NewCipher(utils.RandomSlice(16))
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+
+	_, err = NewCipher(utils.RandomSlice(KeySize), nil)
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+
+	c, err := NewCipher(utils.RandomSlice(KeySize))
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestEnvelope_Encrypt_Decrypt(t *testing.T) {
+	c, err := NewCipher(utils.RandomSlice(KeySize))
+	assert.NoError(t, err)
+	key, value := []byte("document_1"), []byte(`{"type":"documents.Invoice","data":{}}`)
+
+	data, err := c.Encrypt(key, value)
+	assert.NoError(t, err)
+	assert.True(t, c.IsEncrypted(data))
+	assert.False(t, bytes.Contains(data, value))
+	got, err := c.Decrypt(key, data)
+	assert.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	// data keys are not reused
+	other, err := c.Encrypt(key, value)
+	assert.NoError(t, err)
+	assert.NotEqual(t, data, other)
+
+	// plaintext
+	assert.False(t, c.IsEncrypted(value))
+	got, err = c.Decrypt(key, value)
+	assert.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	// value of another key
+	_, err = c.Decrypt([]byte("document_2"), data)
+	assert.True(t, errors.IsOfType(ErrDecryption, err))
+
+	// tampered
+	tampered := append([]byte{}, data...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = c.Decrypt(key, tampered)
+	assert.True(t, errors.IsOfType(ErrDecryption, err))
+
+	// different key
+	wrong, err := NewCipher(utils.RandomSlice(KeySize))
+	assert.NoError(t, err)
+	_, err = wrong.Decrypt(key, data)
+	assert.True(t, errors.IsOfType(ErrDecryption, err))
+
+	// plaintext is refused once all the values are sealed
+	c.RefusePlaintext()
+	_, err = c.Decrypt(key, value)
+	assert.True(t, errors.IsOfType(ErrPlaintext, err))
+	got, err = c.Decrypt(key, data)
+	assert.NoError(t, err)
+	assert.Equal(t, value, got)
+}
+
+func TestEnvelope_Rotate(t *testing.T) {
+	oldKey, newKey := utils.RandomSlice(KeySize), utils.RandomSlice(KeySize)
+	old, err := NewCipher(oldKey)
+	assert.NoError(t, err)
+	key, value := []byte("key"), []byte("value")
+	data, err := old.Encrypt(key, value)
+	assert.NoError(t, err)
+
+	// current key
+	_, ok, err := old.Rotate(data)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// plaintext
+	_, _, err = old.Rotate(value)
+	assert.True(t, errors.IsOfType(ErrDecryption, err))
+
+	c, err := NewCipher(newKey, oldKey)
+	assert.NoError(t, err)
+	rotated, ok, err := c.Rotate(data)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// value is not re-encrypted
+	assert.Equal(t, data[headerSize:], rotated[headerSize:])
+
+	// previous key is no longer needed
+	c, err = NewCipher(newKey)
+	assert.NoError(t, err)
+	got, err := c.Decrypt(key, rotated)
+	assert.NoError(t, err)
+	assert.Equal(t, value, got)
+	_, err = c.Decrypt(key, data)
+	assert.True(t, errors.IsOfType(ErrDecryption, err))
+}
+
+type mockConfig struct {
+	enabled                                      bool
+	keyFile, passphrase, prevKeyFile, prevPhrase string
+}
+
+func (m mockConfig) IsStorageEncryptionEnabled() bool               { return m.enabled }
+func (m mockConfig) GetStorageEncryptionKeyFile() string            { return m.keyFile }
+func (m mockConfig) GetStorageEncryptionPassphrase() string         { return m.passphrase }
+func (m mockConfig) GetStorageEncryptionPreviousKeyFile() string    { return m.prevKeyFile }
+func (m mockConfig) GetStorageEncryptionPreviousPassphrase() string { return m.prevPhrase }
+
+// mapStore is an in-memory storage.KeyValueStore.
+type mapStore map[string][]byte
+
+func (m mapStore) Has(key []byte) (bool, error) {
+	_, ok := m[string(key)]
+	return ok, nil
+}
+
+func (m mapStore) Get(key []byte) ([]byte, error) {
+	return m[string(key)], nil
+}
+
+func (m mapStore) Put(key, value []byte) error {
+	m[string(key)] = value
+	return nil
+}
+
+func (m mapStore) Delete(key []byte) error {
+	delete(m, string(key))
+	return nil
+}
+
+func (m mapStore) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	return nil
+}
+
+func (m mapStore) Close() error {
+	return nil
+}
+
+func TestNewCipherFromConfig(t *testing.T) {
+	kv := make(mapStore)
+
+	// disabled
+	c, err := NewCipherFromConfig(mockConfig{}, kv)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	// missing key
+	_, err = NewCipherFromConfig(mockConfig{enabled: true}, kv)
+	assert.Equal(t, ErrMissingKey, err)
+
+	// key file
+	dir, err := ioutil.TempDir("", "storage-encryption")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	key := utils.RandomSlice(KeySize)
+	keyFile := filepath.Join(dir, "key")
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte(hexutil.Encode(key)+"\n"), 0600))
+	fc, err := NewCipherFromConfig(mockConfig{enabled: true, keyFile: keyFile}, kv)
+	assert.NoError(t, err)
+	kc, err := NewCipher(key)
+	assert.NoError(t, err)
+	data, err := fc.Encrypt(nil, []byte("value"))
+	assert.NoError(t, err)
+	got, err := kc.Decrypt(nil, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+
+	invalid := filepath.Join(dir, "invalid")
+	assert.NoError(t, ioutil.WriteFile(invalid, []byte("0x1234"), 0600))
+	_, err = NewCipherFromConfig(mockConfig{enabled: true, keyFile: invalid}, kv)
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+	_, err = NewCipherFromConfig(mockConfig{enabled: true, keyFile: filepath.Join(dir, "missing")}, kv)
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+
+	// passphrase with the salt of the DB
+	assert.Empty(t, kv)
+	pc, err := NewCipherFromConfig(mockConfig{enabled: true, passphrase: "secret"}, kv)
+	assert.NoError(t, err)
+	data, err = pc.Encrypt(nil, []byte("value"))
+	assert.NoError(t, err)
+	assert.Len(t, kv[storage.SaltKey], KeySize)
+	pc, err = NewCipherFromConfig(mockConfig{enabled: true, passphrase: "secret"}, kv)
+	assert.NoError(t, err)
+	got, err = pc.Decrypt(nil, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+
+	// previous passphrase with a new key file
+	rc, err := NewCipherFromConfig(mockConfig{enabled: true, keyFile: keyFile, prevPhrase: "secret"}, kv)
+	assert.NoError(t, err)
+	rotated, ok, err := rc.Rotate(data)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	got, err = kc.Decrypt(nil, rotated)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+}
@@ -0,0 +1,119 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters of the passphrase keys as recommended for interactive logins.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Config holds the storage encryption configuration.
+type Config interface {
+	IsStorageEncryptionEnabled() bool
+	GetStorageEncryptionKeyFile() string
+	GetStorageEncryptionPassphrase() string
+	GetStorageEncryptionPreviousKeyFile() string
+	GetStorageEncryptionPreviousPassphrase() string
+}
+
+// NewCipherFromConfig returns the cipher of the DB with the configured master keys.
+// Returns nil if the encryption is disabled.
+func NewCipherFromConfig(cfg Config, db storage.KeyValueStore) (storage.Cipher, error) {
+	if !cfg.IsStorageEncryptionEnabled() {
+		return nil, nil
+	}
+
+	current, err := readMasterKey(cfg.GetStorageEncryptionKeyFile(), cfg.GetStorageEncryptionPassphrase(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, ErrMissingKey
+	}
+
+	previous, err := readMasterKey(cfg.GetStorageEncryptionPreviousKeyFile(), cfg.GetStorageEncryptionPreviousPassphrase(), db)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous == nil {
+		return NewCipher(current)
+	}
+
+	return NewCipher(current, previous)
+}
+
+// readMasterKey returns the master key read from the key file, or derived from the passphrase.
+// Returns nil if neither is set.
+func readMasterKey(keyFile, passphrase string, db storage.KeyValueStore) ([]byte, error) {
+	switch {
+	case keyFile != "":
+		return ReadKeyFile(keyFile)
+	case passphrase != "":
+		salt, err := getSalt(db)
+		if err != nil {
+			return nil, err
+		}
+
+		return DeriveKey(passphrase, salt)
+	default:
+		return nil, nil
+	}
+}
+
+// ReadKeyFile reads the hex encoded master key from the file.
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidKey, err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidKey, err)
+	}
+
+	if len(key) != KeySize {
+		return nil, errors.NewTypedError(ErrInvalidKey, errors.New("expected %d bytes, got %d", KeySize, len(key)))
+	}
+
+	return key, nil
+}
+
+// DeriveKey derives the master key from the passphrase with scrypt.
+func DeriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, KeySize)
+}
+
+// getSalt returns the salt stored in the DB. Salt is created if missing.
+func getSalt(db storage.KeyValueStore) ([]byte, error) {
+	key := []byte(storage.SaltKey)
+	ok, err := db.Has(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return db.Get(key)
+	}
+
+	salt := make([]byte, KeySize)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return salt, db.Put(key, salt)
+}
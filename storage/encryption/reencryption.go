@@ -0,0 +1,44 @@
+package encryption
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("storage-encryption")
+
+// Reencryption re-encrypts the values of the repositories with the current master key in the background.
+// This seals the values stored before the encryption was enabled and completes the master key rotation.
+// Reencryption implements node.Server.
+type Reencryption struct {
+	repos []storage.Reencrypter
+}
+
+// NewReencryption returns the server re-encrypting the values of the repositories.
+func NewReencryption(repos ...storage.Reencrypter) *Reencryption {
+	return &Reencryption{repos: repos}
+}
+
+// Name returns the name of the server.
+func (*Reencryption) Name() string {
+	return "StorageReencryption"
+}
+
+// Start re-encrypts the values of each repository once.
+func (r *Reencryption) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	for _, repo := range r.repos {
+		n, err := repo.Reencrypt(ctx)
+		if err != nil {
+			log.Errorf("failed to re-encrypt the stored values: %v", err)
+			continue
+		}
+
+		if n > 0 {
+			log.Infof("re-encrypted %d stored values with the current key", n)
+		}
+	}
+}
@@ -20,4 +20,10 @@ const (
 
 	// ErrModelTypeNotRegistered must be used when model hasn't been registered in db
 	ErrModelTypeNotRegistered = errors.Error("type not registered")
+
+	// ErrUnknownBackend must be used when the configured storage backend is not supported
+	ErrUnknownBackend = errors.Error("unknown storage backend")
+
+	// ErrDBSealed must be used when a sealed DB is opened without the encryption key
+	ErrDBSealed = errors.Error("DB is sealed, storage encryption must be enabled")
 )
@@ -0,0 +1,118 @@
+package storage
+
+import "bytes"
+
+// IterateOptions selects the models to iterate over.
+// Bounds are combined, so the iteration covers the keys within the prefix, the key range and after the cursor.
+type IterateOptions struct {
+	// Prefix limits the iteration to the keys with the prefix.
+	Prefix []byte
+
+	// Start is the inclusive lower bound of the keys. Nil means no lower bound.
+	Start []byte
+
+	// End is the exclusive upper bound of the keys. Nil means no upper bound.
+	End []byte
+
+	// Cursor resumes the iteration after the cursor key in the iteration order.
+	Cursor []byte
+
+	// Reverse iterates in the descending key order.
+	Reverse bool
+
+	// Limit is the maximum number of models returned by the iterator. Zero means no limit.
+	Limit int
+}
+
+// Range returns the inclusive start and the exclusive end of the keys to iterate over.
+// Nil start or end means the range is unbounded on that side.
+func (o IterateOptions) Range() (start, end []byte) {
+	start, end = o.Prefix, prefixEnd(o.Prefix)
+	if o.Start != nil && bytes.Compare(o.Start, start) > 0 {
+		start = o.Start
+	}
+
+	if o.End != nil && (end == nil || bytes.Compare(o.End, end) < 0) {
+		end = o.End
+	}
+
+	if o.Cursor == nil {
+		return start, end
+	}
+
+	if o.Reverse {
+		if end == nil || bytes.Compare(o.Cursor, end) < 0 {
+			end = o.Cursor
+		}
+
+		return start, end
+	}
+
+	// smallest key after the cursor
+	next := append(append([]byte{}, o.Cursor...), 0)
+	if bytes.Compare(next, start) > 0 {
+		start = next
+	}
+
+	return start, end
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the prefix.
+// Returns nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			end := make([]byte, i+1)
+			copy(end, prefix)
+			end[i] = c + 1
+			return end
+		}
+	}
+
+	return nil
+}
+
+// Iterator iterates over the models of a repository in the key order.
+// Models that fail to parse are skipped.
+// Iterator must be released after use.
+//
+//	iter := repo.Iterate(opts)
+//	defer iter.Release()
+//	for iter.Next() {
+//		key, model := iter.Key(), iter.Model()
+//	}
+//	return iter.Error()
+type Iterator interface {
+	// Next moves the iterator to the next model. Returns false when the iteration is done or failed.
+	Next() bool
+
+	// Key returns the key of the current model.
+	Key() []byte
+
+	// Model returns the current model.
+	Model() Model
+
+	// Error returns the error of the iteration, if any.
+	Error() error
+
+	// Release releases the resources of the iterator.
+	Release()
+}
+
+// Page returns up to limit models selected by the options along with the cursor of the next page.
+// Cursor is nil when there are no more models. Limit of the options is ignored.
+func Page(repo Repository, opts IterateOptions, limit int) (models []Model, cursor []byte, err error) {
+	opts.Limit = 0
+	iter := repo.Iterate(opts)
+	defer iter.Release()
+	for iter.Next() {
+		if limit > 0 && len(models) >= limit {
+			return models, cursor, nil
+		}
+
+		models = append(models, iter.Model())
+		cursor = iter.Key()
+	}
+
+	return models, nil, iter.Error()
+}
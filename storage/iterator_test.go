@@ -0,0 +1,77 @@
+// +build unit
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Nil(t, prefixEnd(nil))
+	assert.Nil(t, prefixEnd([]byte{0xff, 0xff}))
+	assert.Equal(t, []byte("b"), prefixEnd([]byte("a")))
+	assert.Equal(t, []byte{0x01, 0x01}, prefixEnd([]byte{0x01, 0x00, 0xff}))
+}
+
+func TestIterateOptions_Range(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       IterateOptions
+		start, end []byte
+	}{
+		{
+			name: "all",
+		},
+
+		{
+			name:  "prefix",
+			opts:  IterateOptions{Prefix: []byte("ab")},
+			start: []byte("ab"),
+			end:   []byte("ac"),
+		},
+
+		{
+			name:  "range within prefix",
+			opts:  IterateOptions{Prefix: []byte("ab"), Start: []byte("ab1"), End: []byte("ab5")},
+			start: []byte("ab1"),
+			end:   []byte("ab5"),
+		},
+
+		{
+			name:  "range outside prefix",
+			opts:  IterateOptions{Prefix: []byte("ab"), Start: []byte("a"), End: []byte("b")},
+			start: []byte("ab"),
+			end:   []byte("ac"),
+		},
+
+		{
+			name:  "cursor",
+			opts:  IterateOptions{Prefix: []byte("ab"), Cursor: []byte("ab3")},
+			start: []byte("ab3\x00"),
+			end:   []byte("ac"),
+		},
+
+		{
+			name:  "reverse cursor",
+			opts:  IterateOptions{Prefix: []byte("ab"), Cursor: []byte("ab3"), Reverse: true},
+			start: []byte("ab"),
+			end:   []byte("ab3"),
+		},
+
+		{
+			name:  "cursor before start",
+			opts:  IterateOptions{Start: []byte("b"), Cursor: []byte("a")},
+			start: []byte("b"),
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			start, end := c.opts.Range()
+			assert.Equal(t, c.start, start)
+			assert.Equal(t, c.end, end)
+		})
+	}
+}
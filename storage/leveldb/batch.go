@@ -0,0 +1,82 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// levelDBBatch implements storage.Batch using the LevelDB batch.
+type levelDBBatch struct {
+	l     *levelDBRepo
+	batch *leveldb.Batch
+
+	// keys holds true for the keys written and false for the keys deleted in the batch.
+	keys map[string]bool
+}
+
+// NewBatch returns an empty batch of writes committed atomically with a LevelDB batch.
+func (l *levelDBRepo) NewBatch() storage.Batch {
+	return &levelDBBatch{
+		l:     l,
+		batch: new(leveldb.Batch),
+		keys:  make(map[string]bool),
+	}
+}
+
+// Exists checks whether the key exists in the batch or in the db
+func (b *levelDBBatch) Exists(key []byte) bool {
+	if ok, found := b.keys[string(key)]; found {
+		return ok
+	}
+
+	return b.l.Exists(key)
+}
+
+func (b *levelDBBatch) put(key []byte, model storage.Model) error {
+	data, err := b.l.codec.Encode(key, model)
+	if err != nil {
+		return err
+	}
+
+	b.batch.Put(key, data)
+	b.keys[string(key)] = true
+	return nil
+}
+
+// Create adds the model to the batch
+// errors out if key already exists
+func (b *levelDBBatch) Create(key []byte, model storage.Model) error {
+	if b.Exists(key) {
+		return storage.ErrRepositoryModelCreateKeyExists
+	}
+	return b.put(key, model)
+}
+
+// Update adds the model to the batch
+// errors out if key doesn't exists
+func (b *levelDBBatch) Update(key []byte, model storage.Model) error {
+	if !b.Exists(key) {
+		return storage.ErrRepositoryModelUpdateKeyNotFound
+	}
+	return b.put(key, model)
+}
+
+// Delete adds the deletion of the key to the batch
+func (b *levelDBBatch) Delete(key []byte) error {
+	b.batch.Delete(key)
+	b.keys[string(key)] = false
+	return nil
+}
+
+// Commit writes the batch to the db atomically
+func (b *levelDBBatch) Commit() error {
+	b.l.wmu.Lock()
+	defer b.l.wmu.Unlock()
+	err := b.l.db.Write(b.batch, nil)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+// +build unit
+
+package leveldb
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelDBBatch(t *testing.T) {
+	repo, _, err := getRandomRepository()
+	assert.NoError(t, err)
+	repo.Register(&doc{})
+	id1, id2, id3 := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	assert.NoError(t, repo.Create(id3, &doc{SomeString: "Hello, Repo3!"}))
+
+	b := repo.NewBatch()
+	assert.NoError(t, b.Create(id1, &doc{SomeString: "Hello, Repo1!"}))
+	assert.True(t, b.Exists(id1))
+
+	// writes in the batch are taken into account
+	err = b.Create(id1, &doc{})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+	err = b.Update(id2, &doc{})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelUpdateKeyNotFound, err))
+	assert.NoError(t, b.Update(id1, &doc{SomeString: "Hello, Batch!"}))
+	assert.NoError(t, b.Delete(id3))
+	assert.False(t, b.Exists(id3))
+
+	// nothing is written before commit
+	assert.False(t, repo.Exists(id1))
+	assert.True(t, repo.Exists(id3))
+
+	assert.NoError(t, b.Commit())
+	m, err := repo.Get(id1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Batch!", m.(*doc).SomeString)
+	assert.False(t, repo.Exists(id3))
+}
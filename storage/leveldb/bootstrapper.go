@@ -4,10 +4,12 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 )
 
 // Config holds configuration data for storage package
 type Config interface {
+	encryption.Config
 	GetStoragePath() string
 	GetConfigStoragePath() string
 	SetDefault(key string, value interface{})
@@ -27,12 +29,28 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	if err != nil {
 		return errors.New("failed to init config level db: %v", err)
 	}
-	context[storage.BootstrappedConfigDB] = NewLevelDBRepository(configLevelDB)
+	configCipher, err := encryption.NewCipherFromConfig(cfg, NewKeyValueStore(configLevelDB))
+	if err != nil {
+		return errors.New("failed to init config level db encryption: %v", err)
+	}
+	configRepo, err := NewEncryptedLevelDBRepository(configLevelDB, configCipher)
+	if err != nil {
+		return errors.New("failed to init config level db: %v", err)
+	}
+	context[storage.BootstrappedConfigDB] = configRepo
 
 	levelDB, err := NewLevelDBStorage(cfg.GetStoragePath())
 	if err != nil {
 		return errors.New("failed to init level db: %v", err)
 	}
-	context[storage.BootstrappedDB] = NewLevelDBRepository(levelDB)
+	cipher, err := encryption.NewCipherFromConfig(cfg, NewKeyValueStore(levelDB))
+	if err != nil {
+		return errors.New("failed to init level db encryption: %v", err)
+	}
+	repo, err := NewEncryptedLevelDBRepository(levelDB, cipher)
+	if err != nil {
+		return errors.New("failed to init level db: %v", err)
+	}
+	context[storage.BootstrappedDB] = repo
 	return nil
 }
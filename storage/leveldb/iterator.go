@@ -0,0 +1,86 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// levelDBIterator implements storage.Iterator over a LevelDB iterator.
+// LevelDB iterators read from a snapshot of the DB, so the repository may be modified while iterating.
+type levelDBIterator struct {
+	l       *levelDBRepo
+	iter    iterator.Iterator
+	reverse bool
+	limit   int
+	count   int
+	started bool
+	key     []byte
+	model   storage.Model
+}
+
+// Iterate returns an iterator over the models selected by the options.
+func (l *levelDBRepo) Iterate(opts storage.IterateOptions) storage.Iterator {
+	start, end := opts.Range()
+	return &levelDBIterator{
+		l:       l,
+		iter:    l.db.NewIterator(&util.Range{Start: start, Limit: end}, nil),
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}
+}
+
+// move moves the underlying iterator in the iteration order.
+func (i *levelDBIterator) move() bool {
+	if !i.started {
+		i.started = true
+		if i.reverse {
+			return i.iter.Last()
+		}
+
+		return i.iter.First()
+	}
+
+	if i.reverse {
+		return i.iter.Prev()
+	}
+
+	return i.iter.Next()
+}
+
+func (i *levelDBIterator) Next() bool {
+	if i.limit > 0 && i.count >= i.limit {
+		return false
+	}
+
+	for i.move() {
+		model, err := i.l.codec.Decode(i.iter.Key(), i.iter.Value())
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			continue
+		}
+
+		i.key = append([]byte{}, i.iter.Key()...)
+		i.model = model
+		i.count++
+		return true
+	}
+
+	return false
+}
+
+func (i *levelDBIterator) Key() []byte {
+	return i.key
+}
+
+func (i *levelDBIterator) Model() storage.Model {
+	return i.model
+}
+
+func (i *levelDBIterator) Error() error {
+	return i.iter.Error()
+}
+
+func (i *levelDBIterator) Release() {
+	i.iter.Release()
+}
@@ -0,0 +1,51 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// keyValueStore implements storage.KeyValueStore using LevelDB.
+type keyValueStore struct {
+	db *leveldb.DB
+}
+
+// NewKeyValueStore returns the levelDB implementation of the KeyValueStore.
+func NewKeyValueStore(db *leveldb.DB) storage.KeyValueStore {
+	return keyValueStore{db: db}
+}
+
+func (k keyValueStore) Has(key []byte) (bool, error) {
+	return k.db.Has(key, nil)
+}
+
+func (k keyValueStore) Get(key []byte) ([]byte, error) {
+	return k.db.Get(key, nil)
+}
+
+func (k keyValueStore) Put(key, value []byte) error {
+	return k.db.Put(key, value, nil)
+}
+
+func (k keyValueStore) Delete(key []byte) error {
+	return k.db.Delete(key, nil)
+}
+
+// Iterate iterates over the keys with the prefix. LevelDB iterators read from a snapshot of the DB.
+func (k keyValueStore) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	iter := k.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		err := fn(iter.Key(), iter.Value())
+		if err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (k keyValueStore) Close() error {
+	return k.db.Close()
+}
@@ -0,0 +1,37 @@
+// +build unit
+
+package leveldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyValueStore(t *testing.T) {
+	db, err := NewLevelDBStorage(GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	kv := NewKeyValueStore(db)
+	defer kv.Close()
+
+	for _, k := range []string{"b2", "a", "b1"} {
+		assert.NoError(t, kv.Put([]byte(k), []byte("v"+k)))
+	}
+
+	has, err := kv.Has([]byte("a"))
+	assert.NoError(t, err)
+	assert.True(t, has)
+	v, err := kv.Get([]byte("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("va"), v)
+
+	var keys []string
+	assert.NoError(t, kv.Iterate([]byte("b"), func(key, value []byte) error {
+		keys = append(keys, string(key))
+		return kv.Delete(key)
+	}))
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+	has, err = kv.Has([]byte("b1"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
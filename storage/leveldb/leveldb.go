@@ -1,8 +1,6 @@
 package leveldb
 
 import (
-	"encoding/json"
-	"reflect"
 	"sync"
 
 	"github.com/centrifuge/go-centrifuge/storage"
@@ -26,31 +24,38 @@ func NewLevelDBStorage(path string) (*leveldb.DB, error) {
 
 // levelDBRepo implements Repository using LevelDB as storage layer
 type levelDBRepo struct {
-	db     *leveldb.DB
-	models map[string]reflect.Type
-	mu     sync.RWMutex // to protect the models
+	db    *leveldb.DB
+	codec *storage.ModelCodec
+	wmu   sync.Mutex // to serialise the writes with the re-encryption
 }
 
-// value is an internal representation of how levelDb stores the model.
-type value struct {
-	Type string          `json:"type"`
-	Data json.RawMessage `json:"data"`
-}
-
-// NewLevelDBRepository returns levelDb implementation of Repository
+// NewLevelDBRepository returns levelDb implementation of Repository storing the values in plaintext.
+// The encryption state of the DB is not checked, use NewEncryptedLevelDBRepository to open the DB of a node.
 func NewLevelDBRepository(db *leveldb.DB) storage.Repository {
 	return &levelDBRepo{
-		db:     db,
-		models: make(map[string]reflect.Type),
+		db:    db,
+		codec: storage.NewModelCodec(nil),
 	}
 }
 
+// NewEncryptedLevelDBRepository returns levelDb implementation of Repository sealing the values with the cipher.
+// Cipher can be nil, in which case a sealed DB is refused.
+func NewEncryptedLevelDBRepository(db *leveldb.DB, cipher storage.Cipher) (storage.Repository, error) {
+	codec := storage.NewModelCodec(cipher)
+	err := codec.LoadSealed(NewKeyValueStore(db))
+	if err != nil {
+		return nil, err
+	}
+
+	return &levelDBRepo{
+		db:    db,
+		codec: codec,
+	}, nil
+}
+
 // Register registers the model so that the DB can return the model without knowing the type
 func (l *levelDBRepo) Register(model storage.Model) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	tp := getTypeIndirect(model.Type())
-	l.models[tp.String()] = tp
+	l.codec.Register(model)
 }
 
 // Exists checks whether the key exists in db
@@ -62,58 +67,24 @@ func (l *levelDBRepo) Exists(key []byte) bool {
 	return res
 }
 
-// getModel returns a new instance of the type mt.
-func (l *levelDBRepo) getModel(mt string) (storage.Model, error) {
-	tp, ok := l.models[mt]
-	if !ok {
-		return nil, errors.NewTypedError(storage.ErrModelTypeNotRegistered, errors.New("%s", mt))
-	}
-
-	return reflect.New(tp).Interface().(storage.Model), nil
-}
-
-func (l *levelDBRepo) parseModel(data []byte) (storage.Model, error) {
-	v := new(value)
-	err := json.Unmarshal(data, v)
-	if err != nil {
-		return nil, errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to unmarshal to value: %v", err))
-	}
-
-	nm, err := l.getModel(v.Type)
-	if err != nil {
-		return nil, err
-	}
-
-	err = nm.FromJSON([]byte(v.Data))
-	if err != nil {
-		return nil, errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to unmarshal to model: %v", err))
-	}
-
-	return nm, nil
-}
-
 // Get retrieves model by key, otherwise returns error
 func (l *levelDBRepo) Get(key []byte) (storage.Model, error) {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
 	data, err := l.db.Get(key, nil)
 	if err != nil {
 		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
 	}
 
-	return l.parseModel(data)
+	return l.codec.Decode(key, data)
 }
 
 // GetAllByPrefix returns all models which keys match the provided prefix
 // If an error is found parsing one of the matched models, logs warning and continues
 func (l *levelDBRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
 	var models []storage.Model
-	l.mu.RLock()
-	defer l.mu.RUnlock()
 	iter := l.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
 	for iter.Next() {
 		data := iter.Value()
-		model, err := l.parseModel(data)
+		model, err := l.codec.Decode(iter.Key(), data)
 		if err != nil {
 			log.Warningf("Error parsing model: %v", err)
 			continue
@@ -125,22 +96,13 @@ func (l *levelDBRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
 }
 
 func (l *levelDBRepo) save(key []byte, model storage.Model) error {
-	data, err := model.JSON()
+	data, err := l.codec.Encode(key, model)
 	if err != nil {
-		return errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
-	}
-
-	tp := getTypeIndirect(model.Type())
-	v := value{
-		Type: tp.String(),
-		Data: json.RawMessage(data),
-	}
-
-	data, err = json.Marshal(v)
-	if err != nil {
-		return errors.NewTypedError(storage.ErrModelRepositorySerialisation, errors.New("failed to marshall value: %v", err))
+		return err
 	}
 
+	l.wmu.Lock()
+	defer l.wmu.Unlock()
 	err = l.db.Put(key, data, nil)
 	if err != nil {
 		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
@@ -169,6 +131,8 @@ func (l *levelDBRepo) Update(key []byte, model storage.Model) error {
 
 // Delete deletes a model by the key provided
 func (l *levelDBRepo) Delete(key []byte) error {
+	l.wmu.Lock()
+	defer l.wmu.Unlock()
 	return l.db.Delete(key, nil)
 }
 
@@ -176,12 +140,3 @@ func (l *levelDBRepo) Delete(key []byte) error {
 func (l *levelDBRepo) Close() error {
 	return l.db.Close()
 }
-
-// getTypeIndirect returns the type of the model without pointers.
-func getTypeIndirect(tp reflect.Type) reflect.Type {
-	if tp.Kind() == reflect.Ptr {
-		return getTypeIndirect(tp.Elem())
-	}
-
-	return tp
-}
@@ -57,11 +57,15 @@ func TestNewLevelDBRepository(t *testing.T) {
 func TestLevelDBRepo_Register(t *testing.T) {
 	repo, _, err := getRandomRepository()
 	assert.Nil(t, err)
-	assert.Len(t, repo.(*levelDBRepo).models, 0, "should be empty")
+	id := utils.RandomSlice(32)
 	d := &doc{SomeString: "Hello, Repo!"}
+	assert.NoError(t, repo.Create(id, d))
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
 	repo.Register(d)
-	assert.Len(t, repo.(*levelDBRepo).models, 1, "should be not empty")
-	assert.Contains(t, repo.(*levelDBRepo).models, "leveldb.doc")
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
 }
 
 func TestLevelDBRepo_Exists(t *testing.T) {
@@ -0,0 +1,65 @@
+package leveldb
+
+import (
+	"context"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Reencrypt seals the values stored in plaintext or with a previous key with the current key of the cipher.
+// Keys are read from a snapshot and each value is re-read before it is sealed so that concurrent writes are kept.
+// Once all the values are sealed, the DB is marked as sealed and plaintext values are refused.
+func (l *levelDBRepo) Reencrypt(ctx context.Context) (int, error) {
+	if !l.codec.Encrypted() {
+		return 0, nil
+	}
+
+	var n int
+	iter := l.db.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		select {
+		case <-ctx.Done():
+			return n, ctx.Err()
+		default:
+		}
+
+		ok, err := l.reencrypt(iter.Key())
+		if err != nil {
+			return n, err
+		}
+
+		if ok {
+			n++
+		}
+	}
+
+	err := iter.Error()
+	if err != nil {
+		return n, err
+	}
+
+	// models written from now on are sealed
+	return n, l.codec.MarkSealed(NewKeyValueStore(l.db))
+}
+
+// reencrypt seals the value of the key with the current key. Returns true if the value is re-encrypted.
+func (l *levelDBRepo) reencrypt(key []byte) (bool, error) {
+	l.wmu.Lock()
+	defer l.wmu.Unlock()
+	data, err := l.db.Get(key, nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	data, ok := l.codec.Reseal(key, data)
+	if !ok {
+		return false, nil
+	}
+
+	return true, l.db.Put(key, data, nil)
+}
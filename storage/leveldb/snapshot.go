@@ -0,0 +1,124 @@
+package leveldb
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// snapshotAttempts is the number of times the copy is retried if the DB is compacted while it is copied.
+const snapshotAttempts = 5
+
+// Snapshot copies the LevelDB at src to dst while the DB may be open in another process.
+// Tables are immutable and the journal is copied last, so the copy is consistent as long as the manifest
+// does not change while copying. The copy is then equivalent to the DB after a crash and recovers as such on open.
+func Snapshot(src, dst string) error {
+	for i := 0; i < snapshotAttempts; i++ {
+		before, err := manifestState(src)
+		if err != nil {
+			return err
+		}
+
+		err = copyFiles(src, dst)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		after, serr := manifestState(src)
+		if serr != nil {
+			return serr
+		}
+
+		// tables removed by a compaction change the manifest as well
+		if err == nil && before == after {
+			return nil
+		}
+
+		log.Infof("level db %s changed while copying, retrying", src)
+		err = os.RemoveAll(dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.New("level db %s kept changing while copying", src)
+}
+
+// manifestState returns the current manifest along with its size and modification time.
+func manifestState(dir string) (string, error) {
+	current, err := ioutil.ReadFile(filepath.Join(dir, "CURRENT"))
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(string(current))
+	info, err := os.Stat(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%d:%d", name, info.Size(), info.ModTime().UnixNano()), nil
+}
+
+// copyFiles copies the tables, then the manifest and then the journals. Lock and log files are skipped.
+func copyFiles(src, dst string) error {
+	err := os.MkdirAll(dst, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	fis, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	var tables, manifests, journals []string
+	for _, fi := range fis {
+		name := fi.Name()
+		switch {
+		case fi.IsDir(), name == "LOCK", strings.HasPrefix(name, "LOG"):
+			continue
+		case strings.HasSuffix(name, ".log"):
+			journals = append(journals, name)
+		case strings.HasSuffix(name, ".ldb"), strings.HasSuffix(name, ".sst"):
+			tables = append(tables, name)
+		default:
+			manifests = append(manifests, name)
+		}
+	}
+
+	for _, name := range append(append(tables, manifests...), journals...) {
+		err = copyFile(filepath.Join(src, name), filepath.Join(dst, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -9,6 +10,12 @@ const (
 	BootstrappedDB string = "BootstrappedDB"
 	// BootstrappedConfigDB is a key mapped to DB for configs at boot
 	BootstrappedConfigDB string = "BootstrappedConfigDB"
+
+	// LevelDBBackend is the default storage backend
+	LevelDBBackend = "leveldb"
+
+	// SQLiteBackend is the embedded SQL storage backend
+	SQLiteBackend = "sqlite"
 )
 
 // Model is an interface to abstract away storage model specificness
@@ -28,9 +35,73 @@ type Repository interface {
 	Register(model Model)
 	Exists(key []byte) bool
 	Get(key []byte) (Model, error)
+
+	// GetAllByPrefix loads all the models with the prefix at once. Iterate is preferred for the prefixes that grow.
 	GetAllByPrefix(prefix string) ([]Model, error)
+
+	// Iterate returns an iterator over the models selected by the options.
+	Iterate(opts IterateOptions) Iterator
 	Create(key []byte, model Model) error
 	Update(key []byte, model Model) error
 	Delete(key []byte) error
+
+	// NewBatch returns an empty batch of writes to the repository.
+	NewBatch() Batch
+	Close() error
+}
+
+// Batch collects writes to the repository and commits them atomically.
+// Exists, Create and Update take the writes already collected in the batch into account.
+type Batch interface {
+	Exists(key []byte) bool
+	Create(key []byte, model Model) error
+	Update(key []byte, model Model) error
+	Delete(key []byte) error
+
+	// Commit writes all the collected writes atomically. Batch must not be used after Commit.
+	Commit() error
+}
+
+// Cipher encrypts the values of a repository at rest.
+// Values are sealed along with their DB key so that they can only be opened with the same key.
+type Cipher interface {
+	// Encrypt seals the value of the key with the current key.
+	Encrypt(key, value []byte) ([]byte, error)
+
+	// Decrypt opens the sealed value of the key. Values that are not sealed are returned as is
+	// unless RefusePlaintext was called.
+	Decrypt(key, data []byte) ([]byte, error)
+
+	// RefusePlaintext makes Decrypt fail on the values that are not sealed.
+	// Called once all the values are sealed so that plaintext values written to the DB are not read.
+	RefusePlaintext()
+
+	// IsEncrypted returns true if the data is sealed.
+	IsEncrypted(data []byte) bool
+
+	// Rotate returns the sealed data with its data key wrapped by the current key.
+	// Returns false if the data is already sealed with the current key.
+	Rotate(data []byte) ([]byte, bool, error)
+}
+
+// Reencrypter is implemented by the repositories encrypting the values at rest.
+type Reencrypter interface {
+	// Reencrypt seals the values stored in plaintext or with a previous key with the current key.
+	// Returns the number of values re-encrypted.
+	Reencrypt(ctx context.Context) (int, error)
+}
+
+// KeyValueStore is the raw key value store of a storage backend.
+// Migrations operate on it directly so that they run on any of the backends.
+type KeyValueStore interface {
+	Has(key []byte) (bool, error)
+	Get(key []byte) ([]byte, error)
+	Put(key, value []byte) error
+	Delete(key []byte) error
+
+	// Iterate calls fn for every key with the prefix in the key order until fn returns an error.
+	// fn is called with a snapshot of the entries so it may modify the store.
+	// Key and value must not be retained after fn returns.
+	Iterate(prefix []byte, fn func(key, value []byte) error) error
 	Close() error
 }
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// write is a put, or a delete if the value is nil, of a key.
+type write struct {
+	key, value []byte
+}
+
+// sqliteBatch implements storage.Batch by applying the writes in a single SQLite transaction.
+type sqliteBatch struct {
+	s      *sqliteRepo
+	writes []write
+
+	// keys holds true for the keys written and false for the keys deleted in the batch.
+	keys map[string]bool
+}
+
+// NewBatch returns an empty batch of writes committed atomically in a SQLite transaction.
+func (s *sqliteRepo) NewBatch() storage.Batch {
+	return &sqliteBatch{
+		s:    s,
+		keys: make(map[string]bool),
+	}
+}
+
+// Exists checks whether the key exists in the batch or in the db
+func (b *sqliteBatch) Exists(key []byte) bool {
+	if ok, found := b.keys[string(key)]; found {
+		return ok
+	}
+
+	return b.s.Exists(key)
+}
+
+func (b *sqliteBatch) put(key []byte, model storage.Model) error {
+	data, err := b.s.codec.Encode(key, model)
+	if err != nil {
+		return err
+	}
+
+	b.writes = append(b.writes, write{key: key, value: data})
+	b.keys[string(key)] = true
+	return nil
+}
+
+// Create adds the model to the batch
+// errors out if key already exists
+func (b *sqliteBatch) Create(key []byte, model storage.Model) error {
+	if b.Exists(key) {
+		return storage.ErrRepositoryModelCreateKeyExists
+	}
+	return b.put(key, model)
+}
+
+// Update adds the model to the batch
+// errors out if key doesn't exists
+func (b *sqliteBatch) Update(key []byte, model storage.Model) error {
+	if !b.Exists(key) {
+		return storage.ErrRepositoryModelUpdateKeyNotFound
+	}
+	return b.put(key, model)
+}
+
+// Delete adds the deletion of the key to the batch
+func (b *sqliteBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, write{key: key})
+	b.keys[string(key)] = false
+	return nil
+}
+
+// Commit applies the writes in a transaction
+func (b *sqliteBatch) Commit() error {
+	b.s.wmu.Lock()
+	defer b.s.wmu.Unlock()
+	err := b.s.kv.write(b.writes)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
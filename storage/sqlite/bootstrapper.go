@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+)
+
+// Config holds configuration data for storage package
+type Config interface {
+	encryption.Config
+	GetStoragePath() string
+	GetConfigStoragePath() string
+}
+
+// Bootstrapper implements bootstrapper.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap initialises the SQLite DBs.
+func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
+	if _, ok := context[bootstrap.BootstrappedConfig]; !ok {
+		return errors.New("config not initialised")
+	}
+	cfg := context[bootstrap.BootstrappedConfig].(Config)
+
+	configDB, err := NewSQLiteStorage(cfg.GetConfigStoragePath())
+	if err != nil {
+		return errors.New("failed to init config sqlite db: %v", err)
+	}
+	configCipher, err := encryption.NewCipherFromConfig(cfg, NewKeyValueStore(configDB))
+	if err != nil {
+		return errors.New("failed to init config sqlite db encryption: %v", err)
+	}
+	configRepo, err := NewEncryptedSQLiteRepository(configDB, configCipher)
+	if err != nil {
+		return errors.New("failed to init config sqlite db: %v", err)
+	}
+	context[storage.BootstrappedConfigDB] = configRepo
+
+	db, err := NewSQLiteStorage(cfg.GetStoragePath())
+	if err != nil {
+		return errors.New("failed to init sqlite db: %v", err)
+	}
+	cipher, err := encryption.NewCipherFromConfig(cfg, NewKeyValueStore(db))
+	if err != nil {
+		return errors.New("failed to init sqlite db encryption: %v", err)
+	}
+	repo, err := NewEncryptedSQLiteRepository(db, cipher)
+	if err != nil {
+		return errors.New("failed to init sqlite db: %v", err)
+	}
+	context[storage.BootstrappedDB] = repo
+	return nil
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// sqliteIterator implements storage.Iterator by reading the rows in chunks of scanSize.
+// Reading in chunks releases the single connection between the chunks so the repository can be used while iterating.
+type sqliteIterator struct {
+	s          *sqliteRepo
+	start, end []byte
+	reverse    bool
+	limit      int
+	count      int
+	buf        [][2][]byte
+	done       bool
+	key        []byte
+	model      storage.Model
+	err        error
+}
+
+// Iterate returns an iterator over the models selected by the options.
+func (s *sqliteRepo) Iterate(opts storage.IterateOptions) storage.Iterator {
+	start, end := opts.Range()
+	return &sqliteIterator{
+		s:       s,
+		start:   start,
+		end:     end,
+		reverse: opts.Reverse,
+		limit:   opts.Limit,
+	}
+}
+
+// fill reads the next chunk of rows and moves the bounds past them.
+func (i *sqliteIterator) fill() {
+	kvs, err := i.s.kv.scan(i.start, i.end, i.reverse, scanSize)
+	if err != nil {
+		i.err = err
+		return
+	}
+
+	i.buf = kvs
+	i.done = len(kvs) < scanSize
+	if len(kvs) < 1 {
+		return
+	}
+
+	last := kvs[len(kvs)-1][0]
+	if i.reverse {
+		i.end = last
+		return
+	}
+
+	i.start = append(append([]byte{}, last...), 0)
+}
+
+func (i *sqliteIterator) Next() bool {
+	for i.err == nil && (i.limit < 1 || i.count < i.limit) {
+		if len(i.buf) < 1 {
+			if i.done {
+				return false
+			}
+
+			i.fill()
+			continue
+		}
+
+		kv := i.buf[0]
+		i.buf = i.buf[1:]
+		model, err := i.s.codec.Decode(kv[0], kv[1])
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			continue
+		}
+
+		i.key, i.model = kv[0], model
+		i.count++
+		return true
+	}
+
+	return false
+}
+
+func (i *sqliteIterator) Key() []byte {
+	return i.key
+}
+
+func (i *sqliteIterator) Model() storage.Model {
+	return i.model
+}
+
+func (i *sqliteIterator) Error() error {
+	return i.err
+}
+
+func (i *sqliteIterator) Release() {
+	i.buf = nil
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// scanSize is the number of rows read from the DB at once by the iterators.
+const scanSize = 100
+
+// keyValueStore implements storage.KeyValueStore using the kv table of SQLite.
+// Keys are BLOBs which SQLite compares with memcmp, so the key order is the same as in LevelDB.
+type keyValueStore struct {
+	db *sql.DB
+}
+
+// NewKeyValueStore returns the SQLite implementation of the KeyValueStore.
+func NewKeyValueStore(db *sql.DB) storage.KeyValueStore {
+	return keyValueStore{db: db}
+}
+
+func (k keyValueStore) Has(key []byte) (bool, error) {
+	var one int
+	err := k.db.QueryRow("SELECT 1 FROM kv WHERE key = ?", key).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (k keyValueStore) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := k.db.QueryRow("SELECT value FROM kv WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+
+	return value, err
+}
+
+func (k keyValueStore) Put(key, value []byte) error {
+	_, err := k.db.Exec("INSERT OR REPLACE INTO kv (key, value) VALUES (?, ?)", key, value)
+	return err
+}
+
+func (k keyValueStore) Delete(key []byte) error {
+	_, err := k.db.Exec("DELETE FROM kv WHERE key = ?", key)
+	return err
+}
+
+// Iterate reads all the entries with the prefix before calling fn. This keeps fn from seeing its own writes
+// and frees the single connection for them.
+func (k keyValueStore) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	start, end := storage.IterateOptions{Prefix: prefix}.Range()
+	kvs, err := k.scan(start, end, false, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range kvs {
+		err = fn(kv[0], kv[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scan returns up to n key value pairs with the keys in [start, end) in the key order, or in the reverse key order.
+// Nil start or end means the range is unbounded on that side. Negative n means no limit.
+func (k keyValueStore) scan(start, end []byte, reverse bool, n int) (kvs [][2][]byte, err error) {
+	query, args := "SELECT key, value FROM kv WHERE key >= ?", []interface{}{append([]byte{}, start...)}
+	if end != nil {
+		query, args = query+" AND key < ?", append(args, end)
+	}
+
+	order := " ORDER BY key"
+	if reverse {
+		order += " DESC"
+	}
+
+	rows, err := k.db.Query(query+order+" LIMIT ?", append(args, n)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value []byte
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		kvs = append(kvs, [2][]byte{key, value})
+	}
+
+	return kvs, rows.Err()
+}
+
+// write applies the writes in a single transaction. Writes with nil value delete the key.
+func (k keyValueStore) write(writes []write) error {
+	tx, err := k.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, w := range writes {
+		if w.value == nil {
+			_, err = tx.Exec("DELETE FROM kv WHERE key = ?", w.key)
+		} else {
+			_, err = tx.Exec("INSERT OR REPLACE INTO kv (key, value) VALUES (?, ?)", w.key, w.value)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (k keyValueStore) Close() error {
+	return k.db.Close()
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+)
+
+// Reencrypt seals the values stored in plaintext or with a previous key with the current key of the cipher.
+// Keys are read in chunks and each value is re-read before it is sealed so that concurrent writes are kept.
+// Once all the values are sealed, the DB is marked as sealed and plaintext values are refused.
+func (s *sqliteRepo) Reencrypt(ctx context.Context) (int, error) {
+	if !s.codec.Encrypted() {
+		return 0, nil
+	}
+
+	var n int
+	var start []byte
+	for {
+		kvs, err := s.kv.scan(start, nil, false, scanSize)
+		if err != nil {
+			return n, err
+		}
+
+		for _, kv := range kvs {
+			select {
+			case <-ctx.Done():
+				return n, ctx.Err()
+			default:
+			}
+
+			ok, err := s.reencrypt(kv[0])
+			if err != nil {
+				return n, err
+			}
+
+			if ok {
+				n++
+			}
+		}
+
+		if len(kvs) < scanSize {
+			// models written from now on are sealed
+			return n, s.codec.MarkSealed(s.kv)
+		}
+
+		// smallest key after the last one
+		last := kvs[len(kvs)-1][0]
+		start = append(append([]byte{}, last...), 0)
+	}
+}
+
+// reencrypt seals the value of the key with the current key. Returns true if the value is re-encrypted.
+func (s *sqliteRepo) reencrypt(key []byte) (bool, error) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	data, err := s.kv.Get(key)
+	if err != nil {
+		if err == ErrNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	data, ok := s.codec.Reseal(key, data)
+	if !ok {
+		return false, nil
+	}
+
+	return true, s.kv.Put(key, data)
+}
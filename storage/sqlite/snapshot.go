@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+)
+
+// Snapshot copies the SQLite DB at src to dst while the DB may be open in another process.
+// The copy is made in a single read transaction, writers of the other process wait for it up to their busy timeout.
+func Snapshot(src, dst string) error {
+	// opening a missing DB would create it
+	_, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite3", src)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = db.Exec("VACUUM INTO ?", dst)
+	return err
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+
+	// registers the sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var log = logging.Logger("storage")
+
+// ErrNotFound is a sentinel error when the key is not found in the DB.
+const ErrNotFound = errors.Error("sqlite: not found")
+
+// NewSQLiteStorage opens the SQLite DB at path and creates the key value table if missing.
+func NewSQLiteStorage(path string) (*sql.DB, error) {
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer at a time. Sharing one connection serialises the writes instead of failing them as busy.
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS kv (key BLOB PRIMARY KEY, value BLOB NOT NULL) WITHOUT ROWID")
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// sqliteRepo implements Repository using SQLite as storage layer
+type sqliteRepo struct {
+	kv    keyValueStore
+	codec *storage.ModelCodec
+	wmu   sync.Mutex // to serialise the writes with the re-encryption
+}
+
+// NewSQLiteRepository returns SQLite implementation of Repository storing the values in plaintext.
+// The encryption state of the DB is not checked, use NewEncryptedSQLiteRepository to open the DB of a node.
+func NewSQLiteRepository(db *sql.DB) storage.Repository {
+	return &sqliteRepo{
+		kv:    keyValueStore{db: db},
+		codec: storage.NewModelCodec(nil),
+	}
+}
+
+// NewEncryptedSQLiteRepository returns SQLite implementation of Repository sealing the values with the cipher.
+// Cipher can be nil, in which case a sealed DB is refused.
+func NewEncryptedSQLiteRepository(db *sql.DB, cipher storage.Cipher) (storage.Repository, error) {
+	kv := keyValueStore{db: db}
+	codec := storage.NewModelCodec(cipher)
+	err := codec.LoadSealed(kv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteRepo{
+		kv:    kv,
+		codec: codec,
+	}, nil
+}
+
+// Register registers the model so that the DB can return the model without knowing the type
+func (s *sqliteRepo) Register(model storage.Model) {
+	s.codec.Register(model)
+}
+
+// Exists checks whether the key exists in db
+func (s *sqliteRepo) Exists(key []byte) bool {
+	res, err := s.kv.Has(key)
+	if err != nil {
+		return false
+	}
+	return res
+}
+
+// Get retrieves model by key, otherwise returns error
+func (s *sqliteRepo) Get(key []byte) (storage.Model, error) {
+	data, err := s.kv.Get(key)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	return s.codec.Decode(key, data)
+}
+
+// GetAllByPrefix returns all models which keys match the provided prefix
+// If an error is found parsing one of the matched models, logs warning and continues
+func (s *sqliteRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
+	var models []storage.Model
+	err := s.kv.Iterate([]byte(prefix), func(key, data []byte) error {
+		model, err := s.codec.Decode(key, data)
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			return nil
+		}
+
+		models = append(models, model)
+		return nil
+	})
+	return models, err
+}
+
+func (s *sqliteRepo) save(key []byte, model storage.Model) error {
+	data, err := s.codec.Encode(key, model)
+	if err != nil {
+		return err
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	err = s.kv.Put(key, data)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
+
+// Create creates a model indexed by the key provided
+// errors out if key already exists
+func (s *sqliteRepo) Create(key []byte, model storage.Model) error {
+	if s.Exists(key) {
+		return storage.ErrRepositoryModelCreateKeyExists
+	}
+	return s.save(key, model)
+}
+
+// Update updates a model indexed by the key provided
+// errors out if key doesn't exists
+func (s *sqliteRepo) Update(key []byte, model storage.Model) error {
+	if !s.Exists(key) {
+		return storage.ErrRepositoryModelUpdateKeyNotFound
+	}
+	return s.save(key, model)
+}
+
+// Delete deletes a model by the key provided
+func (s *sqliteRepo) Delete(key []byte) error {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	return s.kv.Delete(key)
+}
+
+// Close closes the database
+func (s *sqliteRepo) Close() error {
+	return s.kv.Close()
+}
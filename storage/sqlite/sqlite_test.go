@@ -0,0 +1,211 @@
+// +build unit
+
+package sqlite
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	Id         []byte `json:"id"`
+	SomeString string `json:"some_string"`
+}
+
+func (m *doc) ID() ([]byte, error) {
+	return m.Id, nil
+}
+
+func (m *doc) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *doc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *doc) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func getRandomRepository(t *testing.T) storage.Repository {
+	db, err := NewSQLiteStorage(GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return NewSQLiteRepository(db)
+}
+
+func TestSQLiteRepo_Register(t *testing.T) {
+	repo := getRandomRepository(t)
+	id := utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!"}
+	assert.NoError(t, repo.Create(id, d))
+	_, err := repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
+	repo.Register(d)
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+}
+
+func TestSQLiteRepo_Get(t *testing.T) {
+	repo := getRandomRepository(t)
+	id := utils.RandomSlice(32)
+
+	// Key doesnt exist
+	assert.False(t, repo.Exists(id))
+	_, err := repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
+
+	d := &doc{SomeString: "Hello, Repo!"}
+	err = repo.Create(id, d)
+	assert.NoError(t, err)
+	assert.True(t, repo.Exists(id))
+
+	// Model not registered
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
+
+	// Success
+	repo.Register(&doc{})
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d.SomeString, m.(*doc).SomeString)
+}
+
+func TestSQLiteRepo_GetAllByPrefix(t *testing.T) {
+	prefix := "prefix-"
+	repo := getRandomRepository(t)
+	repo.Register(&doc{})
+
+	// No match
+	models, err := repo.GetAllByPrefix(prefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+
+	id1 := append([]byte(prefix), utils.RandomSlice(32)...)
+	id2 := append([]byte(prefix), utils.RandomSlice(32)...)
+	assert.NoError(t, repo.Create(id1, &doc{SomeString: "Hello, Repo1!"}))
+	assert.NoError(t, repo.Create(id2, &doc{SomeString: "Hello, Repo2!"}))
+	assert.NoError(t, repo.Create([]byte("prefiy-"), &doc{SomeString: "Hello, Repo3!"}))
+
+	models, err = repo.GetAllByPrefix(prefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+}
+
+func TestSQLiteRepo_Create_Update_Delete(t *testing.T) {
+	repo := getRandomRepository(t)
+	repo.Register(&doc{})
+	id := utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!"}
+
+	// Doesn't exist
+	err := repo.Update(id, d)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelUpdateKeyNotFound, err))
+
+	// Doesnt fail on key that doesnt exist
+	assert.NoError(t, repo.Delete(id))
+
+	assert.NoError(t, repo.Create(id, d))
+
+	// Already exists
+	err = repo.Create(id, d)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+
+	d.SomeString = "Hello, Update!"
+	assert.NoError(t, repo.Update(id, d))
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d.SomeString, m.(*doc).SomeString)
+
+	assert.NoError(t, repo.Delete(id))
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
+}
+
+func TestSQLiteBatch(t *testing.T) {
+	repo := getRandomRepository(t)
+	repo.Register(&doc{})
+	id1, id2, id3 := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	assert.NoError(t, repo.Create(id3, &doc{SomeString: "Hello, Repo3!"}))
+
+	b := repo.NewBatch()
+	assert.NoError(t, b.Create(id1, &doc{SomeString: "Hello, Repo1!"}))
+	assert.True(t, b.Exists(id1))
+
+	// writes in the batch are taken into account
+	err := b.Create(id1, &doc{})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+	err = b.Update(id2, &doc{})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelUpdateKeyNotFound, err))
+	assert.NoError(t, b.Update(id1, &doc{SomeString: "Hello, Batch!"}))
+	assert.NoError(t, b.Delete(id3))
+	assert.False(t, b.Exists(id3))
+
+	// nothing is written before commit
+	assert.False(t, repo.Exists(id1))
+	assert.True(t, repo.Exists(id3))
+
+	assert.NoError(t, b.Commit())
+	m, err := repo.Get(id1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Batch!", m.(*doc).SomeString)
+	assert.False(t, repo.Exists(id3))
+
+	// commit fails on a closed DB
+	b = repo.NewBatch()
+	assert.NoError(t, b.Create(id2, &doc{SomeString: "Hello, Repo2!"}))
+	assert.NoError(t, repo.Close())
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelSave, b.Commit()))
+}
+
+func TestKeyValueStore(t *testing.T) {
+	db, err := NewSQLiteStorage(GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	kv := NewKeyValueStore(db)
+	defer kv.Close()
+
+	_, err = kv.Get([]byte("a"))
+	assert.Equal(t, ErrNotFound, err)
+	for _, k := range []string{"b2", "a", "b1", "c", "\xff\xff"} {
+		assert.NoError(t, kv.Put([]byte(k), []byte("v"+k)))
+	}
+
+	has, err := kv.Has([]byte("a"))
+	assert.NoError(t, err)
+	assert.True(t, has)
+	v, err := kv.Get([]byte("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("va"), v)
+
+	keys := func(prefix string) (keys []string) {
+		assert.NoError(t, kv.Iterate([]byte(prefix), func(key, value []byte) error {
+			assert.Equal(t, "v"+string(key), string(value))
+			keys = append(keys, string(key))
+			return nil
+		}))
+		return keys
+	}
+
+	assert.Equal(t, []string{"a", "b1", "b2", "c", "\xff\xff"}, keys(""))
+	assert.Equal(t, []string{"b1", "b2"}, keys("b"))
+	assert.Equal(t, []string{"\xff\xff"}, keys("\xff"))
+
+	// store can be modified while iterating
+	assert.NoError(t, kv.Iterate([]byte("b"), func(key, value []byte) error {
+		return kv.Delete(key)
+	}))
+	assert.Equal(t, []string{"a", "c", "\xff\xff"}, keys(""))
+
+	// iteration stops on error
+	err = kv.Iterate(nil, func(key, value []byte) error {
+		return ErrNotFound
+	})
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,14 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/utils"
+)
+
+const testStoragePath = "/tmp/centrifuge_data.sqlite_TESTING"
+
+// GetRandomTestStoragePath generates a random path for DB storage
+func GetRandomTestStoragePath() string {
+	return fmt.Sprintf("%s_%x.db", testStoragePath, utils.RandomByte32())
+}
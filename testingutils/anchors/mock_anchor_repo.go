@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -14,8 +15,24 @@ type MockAnchorService struct {
 	anchors.Service
 }
 
-func (r *MockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, err error) {
+func (r *MockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, blockNumber uint32, err error) {
 	args := r.Called(anchorID)
 	docRoot, _ = args.Get(0).(anchors.DocumentRoot)
-	return docRoot, anchoredTime, args.Error(1)
+	return docRoot, anchoredTime, blockNumber, args.Error(1)
+}
+
+func (r *MockAnchorService) RepositoryAddress() common.Address {
+	args := r.Called()
+	return args.Get(0).(common.Address)
+}
+
+func (r *MockAnchorService) GetBatchProof(anchorID anchors.AnchorID) (*anchors.BatchProof, error) {
+	args := r.Called(anchorID)
+	proof, _ := args.Get(0).(*anchors.BatchProof)
+	return proof, args.Error(1)
+}
+
+func (r *MockAnchorService) StoreBatchProof(proof *anchors.BatchProof) error {
+	args := r.Called(proof)
+	return args.Error(0)
 }
@@ -20,3 +20,9 @@ func (m *MockRequestProcessor) RequestDocumentWithAccessToken(ctx context.Contex
 	resp, _ := args.Get(0).(*p2ppb.GetDocumentResponse)
 	return resp, args.Error(1)
 }
+
+func (m *MockRequestProcessor) RequestDocumentVersion(ctx context.Context, collaborator identity.DID, identifier []byte) (*p2ppb.GetDocumentResponse, error) {
+	args := m.Called(collaborator, identifier)
+	resp, _ := args.Get(0).(*p2ppb.GetDocumentResponse)
+	return resp, args.Error(1)
+}
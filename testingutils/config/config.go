@@ -142,6 +142,11 @@ func (m *MockConfig) GetBootstrapPeers() []string {
 	return args.Get(0).([]string)
 }
 
+func (m *MockConfig) GetAnchorBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *MockConfig) GetNetworkID() uint32 {
 	args := m.Called()
 	return args.Get(0).(uint32)
@@ -167,6 +172,42 @@ func (m *MockConfig) GetPrecommitEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *MockConfig) GetCentChainAnchorLifespan() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetAnchorBatchEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *MockConfig) GetAnchorBatchSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetAnchorBatchInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetAnchorExpiryWarning() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetAnchorRenewalEnabled() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
+func (m *MockConfig) GetSignaturePolicies() map[string]string {
+	args := m.Called()
+	policies, _ := args.Get(0).(map[string]string)
+	return policies
+}
+
 func (m *MockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
@@ -197,6 +238,11 @@ func (m *MockConfig) GetCentChainNodeURL() string {
 	return args.Get(0).(string)
 }
 
+func (m *MockConfig) GetCentChainInProcess() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func CreateAccountContext(t *testing.T, cfg config.Configuration) context.Context {
 	return CreateTenantContextWithContext(t, context.Background(), cfg)
 }
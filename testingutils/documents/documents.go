@@ -32,6 +32,42 @@ func (m *MockService) GetVersion(ctx context.Context, documentID []byte, version
 	return model, args.Error(1)
 }
 
+func (m *MockService) GetVersionByID(ctx context.Context, version []byte) (documents.Model, error) {
+	args := m.Called(version)
+	model, _ := args.Get(0).(documents.Model)
+	return model, args.Error(1)
+}
+
+func (m *MockService) Query(ctx context.Context, query documents.Query) (documents.QueryResult, error) {
+	args := m.Called(ctx, query)
+	res, _ := args.Get(0).(documents.QueryResult)
+	return res, args.Error(1)
+}
+
+func (m *MockService) GetVersions(ctx context.Context, documentID []byte) ([]documents.VersionInfo, error) {
+	args := m.Called(ctx, documentID)
+	vs, _ := args.Get(0).([]documents.VersionInfo)
+	return vs, args.Error(1)
+}
+
+func (m *MockService) GetVersionsDiff(ctx context.Context, documentID, from, to []byte) (documents.Diff, error) {
+	args := m.Called(ctx, documentID, from, to)
+	diff, _ := args.Get(0).(documents.Diff)
+	return diff, args.Error(1)
+}
+
+func (m *MockService) GetDeliveries(ctx context.Context, documentID, versionID []byte) ([]*documents.Delivery, error) {
+	args := m.Called(ctx, documentID, versionID)
+	ds, _ := args.Get(0).([]*documents.Delivery)
+	return ds, args.Error(1)
+}
+
+func (m *MockService) ResendDocument(ctx context.Context, documentID, versionID []byte, collaborator identity.DID) (*documents.Delivery, error) {
+	args := m.Called(ctx, documentID, versionID, collaborator)
+	d, _ := args.Get(0).(*documents.Delivery)
+	return d, args.Error(1)
+}
+
 func (m *MockService) CreateProofs(ctx context.Context, documentID []byte, fields []string) (*documents.DocumentProof, error) {
 	args := m.Called(ctx, documentID, fields)
 	resp, _ := args.Get(0).(*documents.DocumentProof)
@@ -44,6 +80,18 @@ func (m *MockService) CreateProofsForVersion(ctx context.Context, documentID, ve
 	return resp, args.Error(1)
 }
 
+func (m *MockService) CreateProofsFromZKTree(ctx context.Context, documentID, version []byte, fields []string) (*documents.DocumentProof, error) {
+	args := m.Called(ctx, documentID, version, fields)
+	resp, _ := args.Get(0).(*documents.DocumentProof)
+	return resp, args.Error(1)
+}
+
+func (m *MockService) CreateProofBundle(ctx context.Context, documentID, version []byte, fields []string) (*documents.ProofBundle, error) {
+	args := m.Called(ctx, documentID, version, fields)
+	resp, _ := args.Get(0).(*documents.ProofBundle)
+	return resp, args.Error(1)
+}
+
 func (m *MockService) DeriveFromCoreDocument(cd coredocumentpb.CoreDocument) (documents.Model, error) {
 	args := m.Called(cd)
 	return args.Get(0).(documents.Model), args.Error(1)
@@ -97,6 +145,12 @@ func (m *MockService) Derive(ctx context.Context, payload documents.UpdatePayloa
 	return model, args.Error(1)
 }
 
+func (m *MockService) New(scheme string) (documents.Model, error) {
+	args := m.Called(scheme)
+	model, _ := args.Get(0).(documents.Model)
+	return model, args.Error(1)
+}
+
 type MockModel struct {
 	documents.Model
 	mock.Mock
@@ -230,3 +284,13 @@ func (m MockRegistry) OwnerOf(registry common.Address, tokenID []byte) (common.A
 	addr, _ := args.Get(0).(common.Address)
 	return addr, args.Error(1)
 }
+
+type MockSyncer struct {
+	mock.Mock
+}
+
+func (m *MockSyncer) SyncDocument(ctx context.Context, documentID []byte, collaborator identity.DID) ([]documents.VersionInfo, error) {
+	args := m.Called(ctx, documentID, collaborator)
+	vis, _ := args.Get(0).([]documents.VersionInfo)
+	return vis, args.Error(1)
+}
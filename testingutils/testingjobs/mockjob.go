@@ -30,3 +30,19 @@ func (m MockJobManager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID,
 	args := m.Called(accountID, id, status, taskName, message)
 	return args.Error(0)
 }
+
+func (m MockJobManager) UpdateJobStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, message string) error {
+	args := m.Called(accountID, id, status, message)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	args := m.Called(accountID, id)
+	job, _ := args.Get(0).(*jobs.Job)
+	return job, args.Error(1)
+}
+
+func (m MockJobManager) WaitForJob(accountID identity.DID, id jobs.JobID) error {
+	args := m.Called(accountID, id)
+	return args.Error(0)
+}
@@ -31,14 +31,14 @@ func TestIncorrectProto_ValidMessage(t *testing.T) {
 	p := p2p.AccessPeer(eve.host.p2pClient)
 
 	// send a signature request message with incorect protocol version
-	signatures, signatureErrors, err := p.GetSignaturesForDocument(ctxh, dm)
+	signatures, signatureErrors, err := p.GetSignaturesForDocument(ctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Nil(t, signatureErrors)
 	assert.Equal(t, 1, len(signatures))
 
 }
 
-//send a signature request message with an incorrect node version
+// send a signature request message with an incorrect node version
 func TestIncorrectProto_DifferentVersion(t *testing.T) {
 	errors.MaskErrs = false
 	// Hosts
@@ -64,7 +64,7 @@ func TestIncorrectProto_DifferentVersion(t *testing.T) {
 
 }
 
-//send a signature request message with an invalid body
+// send a signature request message with an invalid body
 func TestIncorrectProto_InvalidBody(t *testing.T) {
 	errors.MaskErrs = false
 	// Hosts
@@ -90,7 +90,7 @@ func TestIncorrectProto_InvalidBody(t *testing.T) {
 
 }
 
-//send a signature request message with an invalid header
+// send a signature request message with an invalid header
 func TestIncorrectProto_InvalidHeader(t *testing.T) {
 	errors.MaskErrs = false
 	// Hosts
@@ -116,7 +116,7 @@ func TestIncorrectProto_InvalidHeader(t *testing.T) {
 
 }
 
-//send a signature request message with a message which is larger than the max allowed size
+// send a signature request message with a message which is larger than the max allowed size
 func TestIncorrectProto_AboveMaxSize(t *testing.T) {
 	// Hosts
 	bob := doctorFord.getHostTestSuite(t, "Bob")
@@ -33,7 +33,7 @@ func TestHost_GetSignatureFromCollaboratorBasedOnWrongSignature(t *testing.T) {
 	collaborators := [][]byte{alice.id[:]}
 	dm := createCDWithEmbeddedDocumentWithWrongSignature(t, collaborators, alice.id, publicKey, privateKey, mallory.host.config.GetContractAddress(config.AnchorRepo))
 
-	signatures, signatureErrors, err := mallory.host.p2pClient.GetSignaturesForDocument(mctxh, dm)
+	signatures, signatureErrors, err := mallory.host.p2pClient.GetSignaturesForDocument(mctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "Signature verification failed error")
 	assert.Equal(t, 0, len(signatures))
@@ -74,7 +74,7 @@ func TestHost_ReturnSignatureComputedBaseOnAnotherSigningRoot(t *testing.T) {
 
 	malloryDocMockSrv.On("DeriveFromCoreDocument", mock.Anything).Return(dm, nil).Once()
 
-	signatures, signatureErrors, err := alice.host.p2pClient.GetSignaturesForDocument(actxh, dm)
+	signatures, signatureErrors, err := alice.host.p2pClient.GetSignaturesForDocument(actxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "Signature verification failed error")
 	assert.Equal(t, 0, len(signatures))
@@ -114,7 +114,7 @@ func TestHost_SignKeyNotInCollaboration(t *testing.T) {
 	malloryDocMockSrv.On("DeriveFromCoreDocument", mock.Anything).Return(dm, nil).Once()
 
 	//Signature verification should success
-	signatures, signatureErrors, err := alice.host.p2pClient.GetSignaturesForDocument(actxh, dm)
+	signatures, signatureErrors, err := alice.host.p2pClient.GetSignaturesForDocument(actxh, dm, nil)
 
 	assert.NoError(t, err)
 	assert.Nil(t, signatureErrors)
@@ -138,7 +138,7 @@ func TestHost_SignKeyNotInCollaboration(t *testing.T) {
 
 	malloryDocMockSrv.On("DeriveFromCoreDocument", mock.Anything).Return(dm, nil).Once()
 
-	signatures, signatureErrors, err = alice.host.p2pClient.GetSignaturesForDocument(actxh, dm)
+	signatures, signatureErrors, err = alice.host.p2pClient.GetSignaturesForDocument(actxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "Signature verification failed error")
 	assert.Equal(t, 0, len(signatures))
@@ -156,7 +156,7 @@ func TestHost_ValidSignature(t *testing.T) {
 	collaborators := [][]byte{bob.id[:]}
 	dm := createCDWithEmbeddedDocument(t, collaborators, eve.id, publicKey, privateKey, eve.host.config.GetContractAddress(config.AnchorRepo))
 
-	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ctxh, dm)
+	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Nil(t, signatureErrors)
 	assert.Equal(t, 1, len(signatures))
@@ -177,7 +177,7 @@ func TestHost_FakedSignature(t *testing.T) {
 	collaborators := [][]byte{bob.id[:]}
 	dm := createCDWithEmbeddedDocument(t, collaborators, eve.id, publicKey, privateKey, eve.host.config.GetContractAddress(config.AnchorRepo))
 
-	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ectxh, dm)
+	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ectxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "Signature verification failed error")
 	assert.Equal(t, 0, len(signatures))
@@ -202,7 +202,7 @@ func TestHost_RevokedSigningKey(t *testing.T) {
 	collaborators := [][]byte{bob.id[:]}
 	dm := createCDWithEmbeddedDocument(t, collaborators, eve.id, publicKey, privateKey, eve.host.config.GetContractAddress(config.AnchorRepo))
 
-	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ctxh, dm)
+	signatures, signatureErrors, err := eve.host.p2pClient.GetSignaturesForDocument(ctxh, dm, nil)
 	assert.NoError(t, err)
 	assert.Error(t, signatureErrors[0], "Signature verification failed error")
 	assert.Equal(t, 0, len(signatures))
@@ -0,0 +1,155 @@
+package verifier
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/proofwire"
+)
+
+const (
+	// ErrUnsupportedBundleVersion is a sentinel error when the bundle format version is not supported.
+	ErrUnsupportedBundleVersion = errors.Error("unsupported proof bundle version")
+
+	// ErrAnchorMismatch is a sentinel error when the anchor of the bundle doesn't belong to the proven version.
+	ErrAnchorMismatch = errors.Error("anchor mismatch")
+
+	// ErrMissingSignatures is a sentinel error when the bundle doesn't carry any signatures.
+	ErrMissingSignatures = errors.Error("proof bundle has no signatures")
+
+	// ErrInvalidSignature is a sentinel error when a signature of the bundle is not valid.
+	ErrInvalidSignature = errors.Error("invalid signature")
+)
+
+// KeyChecker checks that the key was a valid signing key of the signer at the anchoring time.
+type KeyChecker interface {
+	CheckSigningKey(signerID, publicKey []byte, anchoredAt time.Time) error
+}
+
+// KeyCheckerFunc is an adapter to use a function as a KeyChecker.
+type KeyCheckerFunc func(signerID, publicKey []byte, anchoredAt time.Time) error
+
+// CheckSigningKey calls f.
+func (f KeyCheckerFunc) CheckSigningKey(signerID, publicKey []byte, anchoredAt time.Time) error {
+	return f(signerID, publicKey, anchoredAt)
+}
+
+// BundleResult tells which parts of a valid proof bundle were verified against external sources.
+// The bundle is verified end to end only if both are true.
+type BundleResult struct {
+	// Anchored is true if the document root of the bundle was checked against the anchored document root.
+	Anchored bool
+
+	// KeysChecked is true if the signing keys were checked against the signers' identities.
+	// Key validity carried by the bundle is never trusted.
+	KeysChecked bool
+}
+
+// EndToEnd returns true if the bundle was verified against both the anchor and the signers' identities.
+func (r BundleResult) EndToEnd() bool {
+	return r.Anchored && r.KeysChecked
+}
+
+// VerifyBundle verifies the proof bundle.
+// If the reader is nil, the proofs are verified against the document root in the bundle and the result is not Anchored.
+// Otherwise the document root anchored for the version must match the one in the bundle.
+// Each signature must be proven in the signatures tree and sign the signing root. If the checker is not nil, each
+// signing key must have been valid at the anchoring time. Otherwise the keys are left unverified.
+func VerifyBundle(bundle proofwire.ProofBundle, reader AnchorReader, checker KeyChecker) (res BundleResult, err error) {
+	if bundle.Version != proofwire.BundleVersion {
+		return res, errors.NewTypedError(ErrUnsupportedBundleVersion, errors.New("version %d", bundle.Version))
+	}
+
+	h := bundle.Proofs.Header
+	if len(h.VersionID) != 32 || !bytes.Equal(h.VersionID, bundle.AnchorID) {
+		return res, ErrAnchorMismatch
+	}
+
+	if reader != nil {
+		docRoot, err := anchoredRoot(h.VersionID, reader)
+		if err != nil {
+			return res, err
+		}
+
+		if !bytes.Equal(docRoot[:], h.DocumentRoot) {
+			return res, ErrDocumentRootMismatch
+		}
+	}
+
+	err = Verify(bundle.Proofs, h.DocumentRoot)
+	if err != nil {
+		return res, err
+	}
+
+	if len(bundle.Signatures) < 1 {
+		return res, ErrMissingSignatures
+	}
+
+	var anchoredAt time.Time
+	if checker != nil {
+		anchoredAt, err = time.Parse(time.RFC3339, h.AnchoredAt)
+		if err != nil {
+			return res, errors.New("invalid anchoring time: %v", err)
+		}
+	}
+
+	var errs error
+	for _, sig := range bundle.Signatures {
+		err := verifySignature(bundle.Proofs, sig, checker, anchoredAt)
+		if err != nil {
+			errs = errors.AppendError(errs, errors.New("signature %s: %v", sig.SignatureID.String(), err))
+		}
+	}
+
+	if errs != nil {
+		return res, errors.NewTypedError(ErrInvalidSignature, errs)
+	}
+
+	return BundleResult{Anchored: reader != nil, KeysChecked: checker != nil}, nil
+}
+
+// verifySignature verifies that the signature is part of the signatures tree and signs the signing root.
+// If the checker is not nil, the key must have been a valid signing key of the signer at the anchoring time.
+func verifySignature(proof proofwire.ProofsResponse, sig proofwire.ProofBundleSignature, checker KeyChecker, anchoredAt time.Time) error {
+	id, signer := sig.SignatureID, sig.SignerID
+	if !bytes.HasPrefix(id, signer) || !bytes.Equal(id[len(signer):], sig.PublicKey) {
+		return errors.New("signature ID is not derived from the signer and public key")
+	}
+
+	if !isSignatureProven(proof, sig) {
+		return errors.New("signature is not proven in the signatures tree")
+	}
+
+	payload := proofwire.SigningPayload(proof.Header.SigningRoot, sig.TransitionValidated)
+	if !crypto.VerifyMessage(sig.PublicKey, payload, sig.Signature, crypto.CurveSecp256K1) {
+		return errors.New("signature doesn't sign the signing root")
+	}
+
+	if checker == nil {
+		return nil
+	}
+
+	err := checker.CheckSigningKey(sig.SignerID, sig.PublicKey, anchoredAt)
+	if err != nil {
+		return errors.New("key %s is not a valid signing key of %s: %v", sig.PublicKey.String(), sig.SignerID.String(), err)
+	}
+
+	return nil
+}
+
+// isSignatureProven returns true if there is a field proof of the signature.
+// Field proofs are verified against the signatures root separately.
+func isSignatureProven(proof proofwire.ProofsResponse, sig proofwire.ProofBundleSignature) bool {
+	prefix := proofwire.SignaturesTreeProperty()
+	value := proofwire.SignatureLeafValue(sig.Signature, sig.TransitionValidated)
+	for _, fp := range proof.FieldProofs {
+		if bytes.HasPrefix(fp.Property, prefix) && bytes.HasSuffix(fp.Property, sig.SignatureID) &&
+			bytes.Equal(fp.Value, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+// +build unit
+
+package verifier
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/crypto/secp256k1"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockKeyChecker struct {
+	mock.Mock
+}
+
+func (m *mockKeyChecker) CheckSigningKey(signerID, publicKey []byte, anchoredAt time.Time) error {
+	args := m.Called(signerID, publicKey, anchoredAt)
+	return args.Error(0)
+}
+
+func createBundle(t *testing.T) coreapi.ProofBundle {
+	did := testingidentity.GenerateRandomDID()
+	g := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	sr, err := g.CalculateSigningRoot()
+	assert.NoError(t, err)
+	pk, sk, err := secp256k1.GenerateSigningKeyPair()
+	assert.NoError(t, err)
+	pub := utils.AddressTo32Bytes(common.HexToAddress(secp256k1.GetAddress(pk)))
+	s, err := crypto.SignMessage(sk, documents.ConsensusSignaturePayload(sr, true), crypto.CurveSecp256K1)
+	assert.NoError(t, err)
+	sig := &coredocumentpb.Signature{
+		SignatureId:         append(did[:], pub[:]...),
+		SignerId:            did[:],
+		PublicKey:           pub[:],
+		Signature:           s,
+		TransitionValidated: true,
+	}
+	g.AppendSignatures(sig)
+	dr, err := g.CalculateDocumentRoot()
+	assert.NoError(t, err)
+
+	proof, err := g.CreateProofs([]string{documents.CDTreePrefix + ".document_type", documents.SignatureField(sig.SignatureId)})
+	assert.NoError(t, err)
+	proof.DocumentID = g.ID()
+	proof.VersionID = g.CurrentVersion()
+	anchorID, err := anchors.ToAnchorID(g.CurrentVersion())
+	assert.NoError(t, err)
+	docRoot, err := anchors.ToDocumentRoot(dr)
+	assert.NoError(t, err)
+	proof.Anchor = &documents.AnchorInfo{AnchorID: anchorID, DocumentRoot: docRoot, BlockNumber: 5, AnchoredAt: time.Now()}
+	bundle := &documents.ProofBundle{
+		Version: documents.ProofBundleVersion,
+		Proof:   proof,
+		Signatures: []documents.SignatureInfo{
+			{
+				SignatureID:         sig.SignatureId,
+				SignerID:            did,
+				PublicKey:           sig.PublicKey,
+				Signature:           sig.Signature,
+				TransitionValidated: true,
+				KeyValid:            true,
+			},
+		},
+	}
+
+	// bundles are handed out as JSON
+	data, err := json.Marshal(coreapi.ToProofBundle(bundle))
+	assert.NoError(t, err)
+	var resp coreapi.ProofBundle
+	assert.NoError(t, json.Unmarshal(data, &resp))
+	return resp
+}
+
+func TestVerifyBundle(t *testing.T) {
+	bundle := createBundle(t)
+
+	// valid offline
+	res, err := VerifyBundle(bundle, nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, res.Anchored)
+	assert.False(t, res.KeysChecked)
+	assert.False(t, res.EndToEnd())
+
+	// key validity from the bundle is not trusted
+	bundle.Signatures[0].KeyValid = false
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.NoError(t, err)
+
+	// valid against the anchors
+	anchorID, err := anchors.ToAnchorID(bundle.AnchorID)
+	assert.NoError(t, err)
+	docRoot, err := anchors.ToDocumentRoot(bundle.Proofs.Header.DocumentRoot)
+	assert.NoError(t, err)
+	reader := new(mockAnchorReader)
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return([32]byte(docRoot), nil).Once()
+	res, err = VerifyBundle(bundle, reader, nil)
+	assert.NoError(t, err)
+	assert.True(t, res.Anchored)
+	assert.False(t, res.EndToEnd())
+
+	// valid end to end
+	signer := bundle.Signatures[0]
+	checker := new(mockKeyChecker)
+	checker.On("CheckSigningKey", signer.SignerID.Bytes(), signer.PublicKey.Bytes(), mock.Anything).Return(nil).Once()
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return([32]byte(docRoot), nil).Once()
+	res, err = VerifyBundle(bundle, reader, checker)
+	assert.NoError(t, err)
+	assert.True(t, res.EndToEnd())
+
+	// different root anchored
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return(utils.RandomByte32(), nil).Once()
+	_, err = VerifyBundle(bundle, reader, nil)
+	assert.True(t, errors.IsOfType(ErrDocumentRootMismatch, err))
+	reader.AssertExpectations(t)
+
+	// invalid signing key
+	checker.On("CheckSigningKey", signer.SignerID.Bytes(), signer.PublicKey.Bytes(), mock.Anything).Return(errors.New("revoked")).Once()
+	_, err = VerifyBundle(bundle, nil, checker)
+	assert.True(t, errors.IsOfType(ErrInvalidSignature, err))
+	assert.Contains(t, err.Error(), "not a valid signing key")
+	checker.AssertExpectations(t)
+
+	// invalid anchoring time
+	bundle.Proofs.Header.AnchoredAt = "yesterday"
+	_, err = VerifyBundle(bundle, nil, checker)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "anchoring time")
+
+	// signature on a different transition flag
+	bundle.Signatures[0].TransitionValidated = false
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrInvalidSignature, err))
+	assert.Contains(t, err.Error(), "not proven")
+	bundle.Signatures[0].TransitionValidated = true
+
+	// signature of another key
+	sig := bundle.Signatures[0]
+	bundle.Signatures[0].PublicKey = utils.RandomSlice(32)
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrInvalidSignature, err))
+	assert.Contains(t, err.Error(), "signature ID")
+	bundle.Signatures[0] = sig
+
+	// missing signatures
+	bundle.Signatures = nil
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrMissingSignatures, err))
+
+	// tampered field
+	bundle.Proofs.FieldProofs[0].Value = utils.RandomSlice(32)
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+
+	// different anchor
+	bundle.AnchorID = utils.RandomSlice(32)
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrAnchorMismatch, err))
+
+	// unsupported version
+	bundle.Version = 2
+	_, err = VerifyBundle(bundle, nil, nil)
+	assert.True(t, errors.IsOfType(ErrUnsupportedBundleVersion, err))
+}
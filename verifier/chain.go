@@ -0,0 +1,56 @@
+package verifier
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/client"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// getAnchorByIDMethod is the rpc method of the Centrifuge Chain that returns an anchor.
+const getAnchorByIDMethod = "anchor_getAnchorById"
+
+// caller makes the rpc calls to the chain.
+type caller interface {
+	Call(result interface{}, method string, args ...interface{}) error
+}
+
+// chainAnchor is the anchor as returned by the chain.
+type chainAnchor struct {
+	AnchorID     types.Hash `json:"id"`
+	DocumentRoot types.Hash `json:"doc_root"`
+	BlockNumber  uint32     `json:"anchored_block"`
+}
+
+// chainReader reads the anchors directly from a Centrifuge Chain node.
+// Roots anchored as part of a batch are not found since the batch proofs are kept by the nodes.
+type chainReader struct {
+	client caller
+}
+
+// NewChainReader connects to the Centrifuge Chain node at the url and returns an AnchorReader
+// that reads the anchors from the chain without a running Centrifuge node.
+func NewChainReader(url string) (AnchorReader, error) {
+	cl, err := client.Connect(url)
+	if err != nil {
+		return nil, errors.New("failed to connect to the chain at %s: %v", url, err)
+	}
+
+	return chainReader{client: cl}, nil
+}
+
+// GetAnchoredRoot returns the document root anchored against the anchor ID along with the number of the anchoring block.
+func (r chainReader) GetAnchoredRoot(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error) {
+	var ca chainAnchor
+	err = r.client.Call(&ca, getAnchorByIDMethod, types.NewHash(anchorID[:]))
+	if err != nil {
+		return docRoot, 0, err
+	}
+
+	if utils.IsEmptyByte32(ca.DocumentRoot) {
+		return docRoot, 0, errors.New("anchor %s not found", hexutil.Encode(anchorID[:]))
+	}
+
+	return ca.DocumentRoot, ca.BlockNumber, nil
+}
@@ -0,0 +1,55 @@
+// +build unit
+
+package verifier
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockCaller struct {
+	mock.Mock
+}
+
+func (m *mockCaller) Call(result interface{}, method string, args ...interface{}) error {
+	callArgs := m.Called(method, args[0])
+	if ca, ok := callArgs.Get(0).(chainAnchor); ok {
+		*(result.(*chainAnchor)) = ca
+	}
+
+	return callArgs.Error(1)
+}
+
+func TestChainReader_GetAnchoredRoot(t *testing.T) {
+	c := new(mockCaller)
+	r := chainReader{client: c}
+	anchorID, docRoot := utils.RandomByte32(), utils.RandomByte32()
+
+	// rpc failed
+	c.On("Call", getAnchorByIDMethod, types.NewHash(anchorID[:])).Return(nil, errors.New("connection closed")).Once()
+	_, _, err := r.GetAnchoredRoot(anchorID)
+	assert.Error(t, err)
+
+	// not anchored
+	c.On("Call", getAnchorByIDMethod, types.NewHash(anchorID[:])).Return(chainAnchor{}, nil).Once()
+	_, _, err = r.GetAnchoredRoot(anchorID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	// anchored
+	c.On("Call", getAnchorByIDMethod, types.NewHash(anchorID[:])).Return(chainAnchor{
+		AnchorID:     types.NewHash(anchorID[:]),
+		DocumentRoot: types.NewHash(docRoot[:]),
+		BlockNumber:  12,
+	}, nil).Once()
+	root, bn, err := r.GetAnchoredRoot(anchorID)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, root)
+	assert.Equal(t, uint32(12), bn)
+	c.AssertExpectations(t)
+}
@@ -0,0 +1,215 @@
+// Package verifier verifies the precise proofs of document fields against the anchored document root.
+// Verification doesn't need a running node so that the proofs can be checked by anyone they are handed to.
+package verifier
+
+import (
+	"bytes"
+	"hash"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/proofwire"
+	"github.com/centrifuge/precise-proofs/proofs"
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// ErrMissingRoots is a sentinel error when the proofs don't carry the roots they lead to.
+	ErrMissingRoots = errors.Error("proofs are missing the signing or signatures root")
+
+	// ErrSigningRootMismatch is a sentinel error when the signing root is not derived from the data roots.
+	ErrSigningRootMismatch = errors.Error("signing root mismatch")
+
+	// ErrDocumentRootMismatch is a sentinel error when the document root is not derived from the signing and signatures roots.
+	ErrDocumentRootMismatch = errors.Error("document root mismatch")
+
+	// ErrInvalidFieldProof is a sentinel error when a field proof doesn't lead to the root of its tree.
+	ErrInvalidFieldProof = errors.Error("invalid field proof")
+
+	// ErrUnsupportedTree is a sentinel error when the proofs are generated from an unknown data tree.
+	ErrUnsupportedTree = errors.Error("unsupported proof tree")
+
+	// ErrInvalidCircuitProof is a sentinel error when a circuit proof doesn't lead to the zk data root.
+	ErrInvalidCircuitProof = errors.Error("invalid circuit proof")
+)
+
+// AnchorReader returns the document root anchored against the anchor ID along with the number of the anchoring block.
+type AnchorReader interface {
+	GetAnchoredRoot(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error)
+}
+
+// AnchorReaderFunc is an adapter to use a function as an AnchorReader.
+type AnchorReaderFunc func(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error)
+
+// GetAnchoredRoot calls f.
+func (f AnchorReaderFunc) GetAnchoredRoot(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error) {
+	return f(anchorID)
+}
+
+// VerifyAnchored verifies the proofs against the document root anchored for the version of the proofs.
+func VerifyAnchored(proof proofwire.ProofsResponse, reader AnchorReader) error {
+	docRoot, err := anchoredRoot(proof.Header.VersionID, reader)
+	if err != nil {
+		return err
+	}
+
+	return Verify(proof, docRoot[:])
+}
+
+// anchoredRoot returns the document root anchored for the version.
+func anchoredRoot(versionID []byte, reader AnchorReader) (docRoot [32]byte, err error) {
+	if len(versionID) != len(docRoot) {
+		return docRoot, errors.New("invalid version ID %s", hexutil.Encode(versionID))
+	}
+
+	var anchorID [32]byte
+	copy(anchorID[:], versionID)
+	docRoot, _, err = reader.GetAnchoredRoot(anchorID)
+	if err != nil {
+		return docRoot, errors.New("failed to get the anchored document root of version %s: %v", hexutil.Encode(versionID), err)
+	}
+
+	return docRoot, nil
+}
+
+// Verify verifies the proofs against the document root.
+// Signing root must be derived from the basic and zk data roots and the document root from the signing and signatures roots.
+// Each field proof must lead to the root of its tree. Failures of all the field proofs are returned as ErrInvalidFieldProof.
+// Circuit proofs, if any, must lead to the zk data root.
+func Verify(proof proofwire.ProofsResponse, documentRoot []byte) error {
+	h := proof.Header
+	if len(h.SigningRoot) == 0 || len(h.SignaturesRoot) == 0 {
+		return ErrMissingRoots
+	}
+
+	switch h.Tree {
+	case "", proofwire.BasicTree:
+		if len(proof.CircuitProofs) > 0 {
+			return errors.NewTypedError(ErrUnsupportedTree, errors.New("circuit proofs are only supported by the %s tree", proofwire.ZKTree))
+		}
+	case proofwire.ZKTree:
+	default:
+		return errors.NewTypedError(ErrUnsupportedTree, errors.New("tree %s", h.Tree))
+	}
+
+	hashFunc, err := blake2b.New256(nil)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(proofs.HashTwoValues(h.BasicDataRoot, h.ZKDataRoot, hashFunc), h.SigningRoot) {
+		return ErrSigningRootMismatch
+	}
+
+	if !bytes.Equal(proofs.HashTwoValues(h.SigningRoot, h.SignaturesRoot, hashFunc), documentRoot) {
+		return ErrDocumentRootMismatch
+	}
+
+	var errs error
+	for _, fp := range proof.FieldProofs {
+		valid, err := validateProof(toProto(fp), fieldRoot(h, fp.Property, documentRoot), hashFunc, sha3.NewLegacyKeccak256())
+		if !valid {
+			errs = errors.AppendError(errs, errors.New("property %s: %v", hexutil.Encode(fp.Property), err))
+		}
+	}
+
+	if errs != nil {
+		return errors.NewTypedError(ErrInvalidFieldProof, errs)
+	}
+
+	for _, cp := range proof.CircuitProofs {
+		err := VerifyCircuitProof(cp, h.ZKDataRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyCircuitProof verifies the circuit proof of a zk tree field against the zk data root.
+// Path must be of the zk tree depth and the path indices must be the bits of the leaf index.
+func VerifyCircuitProof(proof proofwire.CircuitProof, zkDataRoot []byte) error {
+	if len(proof.Siblings) != proofwire.ZKTreeDepth || len(proof.PathIndices) != proofwire.ZKTreeDepth {
+		return errors.NewTypedError(ErrInvalidCircuitProof, errors.New("property %s: path length must be %d",
+			hexutil.Encode(proof.Property), proofwire.ZKTreeDepth))
+	}
+
+	hashFunc, err := blake2b.New256(nil)
+	if err != nil {
+		return err
+	}
+
+	node := []byte(proof.Leaf)
+	for i, sibling := range proof.Siblings {
+		bit := int((proof.LeafIndex >> uint(i)) & 1)
+		if proof.PathIndices[i] != bit {
+			return errors.NewTypedError(ErrInvalidCircuitProof, errors.New("property %s: path index %d doesn't match the leaf index",
+				hexutil.Encode(proof.Property), i))
+		}
+
+		if bit == 0 {
+			node = proofs.HashTwoValues(node, sibling, hashFunc)
+		} else {
+			node = proofs.HashTwoValues(sibling, node, hashFunc)
+		}
+	}
+
+	if !bytes.Equal(node, zkDataRoot) {
+		return errors.NewTypedError(ErrInvalidCircuitProof, errors.New("property %s: hash does not match", hexutil.Encode(proof.Property)))
+	}
+
+	return nil
+}
+
+// fieldRoot returns the root of the tree the field belongs to.
+func fieldRoot(h proofwire.ProofResponseHeader, property, documentRoot []byte) []byte {
+	switch {
+	case bytes.HasPrefix(property, proofwire.DRTreeProperty()):
+		return documentRoot
+	case bytes.HasPrefix(property, proofwire.SignaturesTreeProperty()):
+		return h.SignaturesRoot
+	case h.Tree == proofwire.ZKTree:
+		return h.ZKDataRoot
+	default:
+		return h.BasicDataRoot
+	}
+}
+
+// validateProof returns true if the field proof leads to the root hash.
+func validateProof(proof *proofspb.Proof, rootHash []byte, hashFunc hash.Hash, leafHashFunc hash.Hash) (valid bool, err error) {
+	fieldHash := proof.Hash
+	if len(fieldHash) == 0 {
+		fieldHash, err = proofs.CalculateHashForProofField(proof, leafHashFunc)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(proof.SortedHashes) > 0 {
+		return proofs.ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, hashFunc)
+	}
+
+	return proofs.ValidateProofHashes(fieldHash, proof.Hashes, rootHash, hashFunc)
+}
+
+func toProto(p proofwire.Proof) *proofspb.Proof {
+	pb := &proofspb.Proof{
+		Property: &proofspb.Proof_CompactName{CompactName: p.Property},
+		Value:    p.Value,
+		Salt:     p.Salt,
+		Hash:     p.Hash,
+	}
+
+	for _, h := range p.SortedHashes {
+		pb.SortedHashes = append(pb.SortedHashes, h)
+	}
+
+	for _, h := range p.Hashes {
+		pb.Hashes = append(pb.Hashes, &proofspb.MerkleHash{Left: h.Left, Right: h.Right})
+	}
+
+	return pb
+}
@@ -0,0 +1,182 @@
+// +build unit
+
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAnchorReader struct {
+	mock.Mock
+}
+
+func (m *mockAnchorReader) GetAnchoredRoot(anchorID [32]byte) (docRoot [32]byte, blockNumber uint32, err error) {
+	args := m.Called(anchorID)
+	docRoot, _ = args.Get(0).([32]byte)
+	return docRoot, blockNumber, args.Error(1)
+}
+
+// createProofs returns the proofs of a data field, the signing root and a signature along with the document root.
+func createProofs(t *testing.T, fromZKTree bool) (coreapi.ProofsResponse, []byte) {
+	did := testingidentity.GenerateRandomDID()
+	g := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	sig := &coredocumentpb.Signature{
+		SignatureId: append(did[:], utils.RandomSlice(32)...),
+		SignerId:    did[:],
+		PublicKey:   utils.RandomSlice(32),
+		Signature:   utils.RandomSlice(65),
+	}
+	g.AppendSignatures(sig)
+	docRoot, err := g.CalculateDocumentRoot()
+	assert.NoError(t, err)
+
+	fields := []string{
+		documents.CDTreePrefix + ".document_type",
+		fmt.Sprintf("%s.%s", documents.DRTreePrefix, documents.SigningRootField),
+		fmt.Sprintf("%s.signatures[%s]", documents.SignaturesTreePrefix, hexutil.Encode(sig.SignatureId)),
+	}
+	createProofs := g.CreateProofs
+	if fromZKTree {
+		createProofs = g.CreateProofsFromZKTree
+	}
+	proof, err := createProofs(fields)
+	assert.NoError(t, err)
+	proof.DocumentID = g.ID()
+	proof.VersionID = g.CurrentVersion()
+
+	// proofs are handed out as JSON
+	data, err := json.Marshal(coreapi.ToProofsResponse(proof))
+	assert.NoError(t, err)
+	var resp coreapi.ProofsResponse
+	assert.NoError(t, json.Unmarshal(data, &resp))
+	return resp, docRoot
+}
+
+func TestVerify(t *testing.T) {
+	proof, docRoot := createProofs(t, false)
+	assert.Len(t, proof.FieldProofs[1].Hashes, 1)
+
+	// valid
+	assert.NoError(t, Verify(proof, docRoot))
+
+	// different document root
+	err := Verify(proof, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(ErrDocumentRootMismatch, err))
+
+	// tampered field values
+	value := proof.FieldProofs[0].Value
+	proof.FieldProofs[0].Value = utils.RandomSlice(32)
+	proof.FieldProofs[2].Value = utils.RandomSlice(32)
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+	assert.Contains(t, err.Error(), hexutil.Encode(proof.FieldProofs[0].Property))
+	assert.Contains(t, err.Error(), hexutil.Encode(proof.FieldProofs[2].Property))
+	assert.NotContains(t, err.Error(), hexutil.Encode(proof.FieldProofs[1].Property))
+	proof.FieldProofs[0].Value = value
+
+	// tampered data root
+	proof.Header.BasicDataRoot = utils.RandomSlice(32)
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrSigningRootMismatch, err))
+
+	// missing roots
+	proof.Header.SigningRoot = nil
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrMissingRoots, err))
+}
+
+func TestVerify_ZKTree(t *testing.T) {
+	proof, docRoot := createProofs(t, true)
+	assert.Equal(t, documents.ZKTree, proof.Header.Tree)
+	assert.Len(t, proof.FieldProofs[0].Hashes, documents.ZKTreeDepth)
+	assert.Len(t, proof.CircuitProofs, 1)
+
+	// valid
+	assert.NoError(t, Verify(proof, docRoot))
+	assert.NoError(t, VerifyCircuitProof(proof.CircuitProofs[0], proof.Header.ZKDataRoot))
+
+	// zk proofs don't lead to the basic data root
+	proof.Header.Tree = documents.BasicTree
+	err := Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrUnsupportedTree, err))
+	proof.Header.Tree = ""
+	cps := proof.CircuitProofs
+	proof.CircuitProofs = nil
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+	proof.CircuitProofs = cps
+
+	// unknown tree
+	proof.Header.Tree = "poseidon"
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrUnsupportedTree, err))
+	proof.Header.Tree = documents.ZKTree
+
+	// path indices not matching the leaf index
+	cp := proof.CircuitProofs[0]
+	proof.CircuitProofs[0].LeafIndex = cp.LeafIndex ^ 1
+	err = Verify(proof, docRoot)
+	assert.True(t, errors.IsOfType(ErrInvalidCircuitProof, err))
+	assert.Contains(t, err.Error(), "leaf index")
+	proof.CircuitProofs[0] = cp
+
+	// tampered leaf
+	err = VerifyCircuitProof(documents.CircuitProof{
+		Property:    cp.Property,
+		Leaf:        utils.RandomSlice(32),
+		LeafIndex:   cp.LeafIndex,
+		Siblings:    cp.Siblings,
+		PathIndices: cp.PathIndices,
+	}, proof.Header.ZKDataRoot)
+	assert.True(t, errors.IsOfType(ErrInvalidCircuitProof, err))
+	assert.Contains(t, err.Error(), "hash does not match")
+
+	// short path
+	cp.Siblings = cp.Siblings[1:]
+	err = VerifyCircuitProof(cp, proof.Header.ZKDataRoot)
+	assert.True(t, errors.IsOfType(ErrInvalidCircuitProof, err))
+	assert.Contains(t, err.Error(), "path length")
+}
+
+func TestVerifyAnchored(t *testing.T) {
+	proof, docRoot := createProofs(t, false)
+	anchorID, err := anchors.ToAnchorID(proof.Header.VersionID)
+	assert.NoError(t, err)
+	root, err := anchors.ToDocumentRoot(docRoot)
+	assert.NoError(t, err)
+
+	// not anchored
+	reader := new(mockAnchorReader)
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return(nil, errors.New("anchor not found")).Once()
+	err = VerifyAnchored(proof, reader)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "anchor not found")
+
+	// anchored
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return([32]byte(root), nil).Once()
+	assert.NoError(t, VerifyAnchored(proof, reader))
+
+	// different root anchored
+	reader.On("GetAnchoredRoot", [32]byte(anchorID)).Return(utils.RandomByte32(), nil).Once()
+	err = VerifyAnchored(proof, reader)
+	assert.True(t, errors.IsOfType(ErrDocumentRootMismatch, err))
+	reader.AssertExpectations(t)
+
+	// invalid version
+	proof.Header.VersionID = utils.RandomSlice(10)
+	assert.Error(t, VerifyAnchored(proof, reader))
+}